@@ -24,11 +24,14 @@ func NewAnteHandler(
 	channelKeeper channelkeeper.Keeper,
 	fk ante.FeegrantKeeper,
 	wasmConfig wasmTypes.WasmConfig,
+	wasmKeeper wasmkeeper.MaxGasPerTxPercentSource,
 ) sdk.AnteHandler {
 	return sdk.ChainAnteDecorators(
 		ante.NewSetUpContextDecorator(),                                          // outermost AnteDecorator. SetUpContext must be called first
 		wasmkeeper.NewLimitSimulationGasDecorator(wasmConfig.SimulationGasLimit), // after setup context to enforce limits early
+		wasmkeeper.NewLimitTxGasDecorator(wasmKeeper),
 		wasmkeeper.NewCountTXDecorator(txCounterStoreKey),
+		wasmkeeper.NewQueryCacheDecorator(),
 		ante.NewRejectExtensionOptionsDecorator(),
 		ante.NewMempoolFeeDecorator(),
 		ante.NewValidateBasicDecorator(),
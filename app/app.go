@@ -420,9 +420,8 @@ func NewWasmApp(
 	)
 	transferModule := transfer.NewAppModule(app.TransferKeeper)
 
-	// Create static IBC router, add transfer route, then set and seal it
+	// Create static IBC router, add transfer and wasm routes, then set and seal it
 	ibcRouter := porttypes.NewRouter()
-	ibcRouter.AddRoute(ibctransfertypes.ModuleName, transferModule)
 
 	// create evidence keeper with router
 	evidenceKeeper := evidencekeeper.NewKeeper(
@@ -439,6 +438,10 @@ func NewWasmApp(
 	// The last arguments can contain custom message handlers, and custom query handlers,
 	// if we want to allow any custom callbacks
 	supportedFeatures := "iterator,staking,stargate"
+	wasmOpts = append(wasmOpts,
+		wasm.WithConnectionKeeper(app.IBCKeeper.ConnectionKeeper),
+		wasm.WithGovParamSource(wasm.NewGovParamSubspaceSource(app.GetSubspace(govtypes.ModuleName))),
+	)
 	app.WasmKeeper = wasm.NewKeeper(
 		appCodec,
 		keys[wasm.StoreKey],
@@ -464,6 +467,8 @@ func NewWasmApp(
 	if len(enabledProposals) != 0 {
 		govRouter.AddRoute(wasm.RouterKey, wasm.NewWasmProposalHandler(app.WasmKeeper, enabledProposals))
 	}
+	// the transfer route tracks the packets of ics20 transfers that contracts send
+	ibcRouter.AddRoute(ibctransfertypes.ModuleName, wasm.NewIBCPacketTrackingMiddleware(transferModule, app.WasmKeeper))
 	ibcRouter.AddRoute(wasm.ModuleName, wasm.NewIBCHandler(app.WasmKeeper, app.IBCKeeper.ChannelKeeper))
 	app.IBCKeeper.SetRouter(ibcRouter)
 
@@ -522,6 +527,7 @@ func NewWasmApp(
 		evidencetypes.ModuleName,
 		stakingtypes.ModuleName,
 		ibchost.ModuleName,
+		wasm.ModuleName,
 	)
 	app.mm.SetOrderEndBlockers(
 		crisistypes.ModuleName,
@@ -603,6 +609,7 @@ func NewWasmApp(
 			app.IBCKeeper.ChannelKeeper,
 			app.FeeGrantKeeper,
 			wasmConfig,
+			app.WasmKeeper,
 		),
 	)
 	app.SetEndBlocker(app.EndBlocker)
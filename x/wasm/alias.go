@@ -74,6 +74,9 @@ var (
 	NewQuerier                = keeper.Querier
 	ContractFromPortID        = keeper.ContractFromPortID
 	WithWasmEngine            = keeper.WithWasmEngine
+	WithConnectionKeeper      = keeper.WithConnectionKeeper
+	WithGovParamSource        = keeper.WithGovParamSource
+	NewGovParamSubspaceSource = keeper.NewGovParamSubspaceSource
 	NewCountTXDecorator       = keeper.NewCountTXDecorator
 
 	// variable aliases
@@ -98,39 +101,51 @@ var (
 )
 
 type (
-	ProposalType                   = types.ProposalType
-	GenesisState                   = types.GenesisState
-	Code                           = types.Code
-	Contract                       = types.Contract
-	MsgStoreCode                   = types.MsgStoreCode
-	MsgStoreCodeResponse           = types.MsgStoreCodeResponse
-	MsgInstantiateContract         = types.MsgInstantiateContract
-	MsgInstantiateContractResponse = types.MsgInstantiateContractResponse
-	MsgExecuteContract             = types.MsgExecuteContract
-	MsgExecuteContractResponse     = types.MsgExecuteContractResponse
-	MsgMigrateContract             = types.MsgMigrateContract
-	MsgMigrateContractResponse     = types.MsgMigrateContractResponse
-	MsgUpdateAdmin                 = types.MsgUpdateAdmin
-	MsgUpdateAdminResponse         = types.MsgUpdateAdminResponse
-	MsgClearAdmin                  = types.MsgClearAdmin
-	MsgWasmIBCCall                 = types.MsgIBCSend
-	MsgClearAdminResponse          = types.MsgClearAdminResponse
-	MsgServer                      = types.MsgServer
-	Model                          = types.Model
-	CodeInfo                       = types.CodeInfo
-	ContractInfo                   = types.ContractInfo
-	CreatedAt                      = types.AbsoluteTxPosition
-	Config                         = types.WasmConfig
-	CodeInfoResponse               = types.CodeInfoResponse
-	MessageHandler                 = keeper.SDKMessageHandler
-	BankEncoder                    = keeper.BankEncoder
-	CustomEncoder                  = keeper.CustomEncoder
-	StakingEncoder                 = keeper.StakingEncoder
-	WasmEncoder                    = keeper.WasmEncoder
-	MessageEncoders                = keeper.MessageEncoders
-	Keeper                         = keeper.Keeper
-	QueryHandler                   = keeper.QueryHandler
-	CustomQuerier                  = keeper.CustomQuerier
-	QueryPlugins                   = keeper.QueryPlugins
-	Option                         = keeper.Option
+	ProposalType                       = types.ProposalType
+	GenesisState                       = types.GenesisState
+	Code                               = types.Code
+	Contract                           = types.Contract
+	MsgStoreCode                       = types.MsgStoreCode
+	MsgStoreCodeResponse               = types.MsgStoreCodeResponse
+	MsgInstantiateContract             = types.MsgInstantiateContract
+	MsgInstantiateContractResponse     = types.MsgInstantiateContractResponse
+	MsgExecuteContract                 = types.MsgExecuteContract
+	MsgExecuteContractResponse         = types.MsgExecuteContractResponse
+	MsgMigrateContract                 = types.MsgMigrateContract
+	MsgMigrateContractResponse         = types.MsgMigrateContractResponse
+	MsgUpdateAdmin                     = types.MsgUpdateAdmin
+	MsgUpdateAdminResponse             = types.MsgUpdateAdminResponse
+	MsgClearAdmin                      = types.MsgClearAdmin
+	MsgWasmIBCCall                     = types.MsgIBCSend
+	MsgClearAdminResponse              = types.MsgClearAdminResponse
+	MsgCommitCodeHash                  = types.MsgCommitCodeHash
+	MsgCommitCodeHashResponse          = types.MsgCommitCodeHashResponse
+	MsgRevealCode                      = types.MsgRevealCode
+	MsgRevealCodeResponse              = types.MsgRevealCodeResponse
+	MsgReleaseTimelockedFunds          = types.MsgReleaseTimelockedFunds
+	MsgReleaseTimelockedFundsResponse  = types.MsgReleaseTimelockedFundsResponse
+	MsgUpdateExecutePermission         = types.MsgUpdateExecutePermission
+	MsgUpdateExecutePermissionResponse = types.MsgUpdateExecutePermissionResponse
+	MsgUpdateMigratePermission         = types.MsgUpdateMigratePermission
+	MsgUpdateMigratePermissionResponse = types.MsgUpdateMigratePermissionResponse
+	MsgUpdateAcceptedDenoms            = types.MsgUpdateAcceptedDenoms
+	MsgUpdateAcceptedDenomsResponse    = types.MsgUpdateAcceptedDenomsResponse
+	MsgServer                          = types.MsgServer
+	Model                              = types.Model
+	CodeInfo                           = types.CodeInfo
+	ContractInfo                       = types.ContractInfo
+	CreatedAt                          = types.AbsoluteTxPosition
+	Config                             = types.WasmConfig
+	CodeInfoResponse                   = types.CodeInfoResponse
+	MessageHandler                     = keeper.SDKMessageHandler
+	BankEncoder                        = keeper.BankEncoder
+	CustomEncoder                      = keeper.CustomEncoder
+	StakingEncoder                     = keeper.StakingEncoder
+	WasmEncoder                        = keeper.WasmEncoder
+	MessageEncoders                    = keeper.MessageEncoders
+	Keeper                             = keeper.Keeper
+	QueryHandler                       = keeper.QueryHandler
+	CustomQuerier                      = keeper.CustomQuerier
+	QueryPlugins                       = keeper.QueryPlugins
+	Option                             = keeper.Option
 )
@@ -1,11 +1,13 @@
 package cli
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/spf13/cobra"
 
@@ -119,3 +121,137 @@ func ClearContractAdminCmd() *cobra.Command {
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
+
+// ReleaseTimelockedFundsCmd sends timelocked funds to the contract
+func ReleaseTimelockedFundsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release-timelocked-funds [contract_addr_bech32] [lock_id_uint64]",
+		Short: "Send timelocked funds to the contract when the unlock height and time are reached",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			lockID, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(err, "lock id")
+			}
+			msg := types.MsgReleaseTimelockedFunds{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				LockID:   lockID,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// UpdateExecutePermissionCmd sets a new execute permission for a contract
+func UpdateExecutePermissionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-execute-permission [contract_addr_bech32] [everybody|nobody|default|address_bech32]",
+		Short: "Set who can execute a contract, default allows everybody",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			perm, err := parseAccessConfigArg(args[1])
+			if err != nil {
+				return sdkerrors.Wrap(err, "execute permission")
+			}
+			msg := types.MsgUpdateExecutePermission{
+				Sender:            clientCtx.GetFromAddress().String(),
+				Contract:          args[0],
+				ExecutePermission: perm,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// UpdateMigratePermissionCmd sets a new migrate permission for a contract
+func UpdateMigratePermissionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-migrate-permission [contract_addr_bech32] [nobody|default|address_bech32]",
+		Short: "Set who can migrate a contract, default allows the admin",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			perm, err := parseAccessConfigArg(args[1])
+			if err != nil {
+				return sdkerrors.Wrap(err, "migrate permission")
+			}
+			msg := types.MsgUpdateMigratePermission{
+				Sender:            clientCtx.GetFromAddress().String(),
+				Contract:          args[0],
+				MigratePermission: perm,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// UpdateAcceptedDenomsCmd sets the denoms a contract accepts as funds
+func UpdateAcceptedDenomsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-accepted-denoms [contract_addr_bech32] [denom]...",
+		Short: "Set the denoms a contract accepts as funds, no denoms accept all",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := types.MsgUpdateAcceptedDenoms{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				Denoms:   args[1:],
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// parseAccessConfigArg parses an access config argument. "default" returns nil to restore the default permission.
+func parseAccessConfigArg(arg string) (*types.AccessConfig, error) {
+	switch arg {
+	case "default":
+		return nil, nil
+	case "everybody":
+		return &types.AllowEverybody, nil
+	case "nobody":
+		return &types.AllowNobody, nil
+	}
+	addr, err := sdk.AccAddressFromBech32(arg)
+	if err != nil {
+		return nil, fmt.Errorf("expected everybody, nobody, default or an address: %s", err)
+	}
+	x := types.AccessTypeOnlyAddress.With(addr)
+	return &x, nil
+}
@@ -1,10 +1,12 @@
 package cli
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"strconv"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -22,10 +24,16 @@ const (
 	flagAmount                 = "amount"
 	flagLabel                  = "label"
 	flagAdmin                  = "admin"
+	flagGovManaged             = "gov-managed"
 	flagRunAs                  = "run-as"
 	flagInstantiateByEverybody = "instantiate-everybody"
 	flagInstantiateByAddress   = "instantiate-only-address"
 	flagProposalType           = "type"
+	flagExecuteMemo            = "execute-memo"
+	flagCodeSource             = "code-source"
+	flagCodeBuilder            = "code-builder"
+	flagUnlockHeight           = "unlock-height"
+	flagUnlockTime             = "unlock-time"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -44,6 +52,12 @@ func GetTxCmd() *cobra.Command {
 		MigrateContractCmd(),
 		UpdateContractAdminCmd(),
 		ClearContractAdminCmd(),
+		CommitCodeCmd(),
+		RevealCodeCmd(),
+		ReleaseTimelockedFundsCmd(),
+		UpdateExecutePermissionCmd(),
+		UpdateMigratePermissionCmd(),
+		UpdateAcceptedDenomsCmd(),
 	)
 	return txCmd
 }
@@ -64,6 +78,12 @@ func StoreCodeCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if msg.Source, err = cmd.Flags().GetString(flagCodeSource); err != nil {
+				return fmt.Errorf("code source: %s", err)
+			}
+			if msg.Builder, err = cmd.Flags().GetString(flagCodeBuilder); err != nil {
+				return fmt.Errorf("code builder: %s", err)
+			}
 			if err = msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -73,6 +93,8 @@ func StoreCodeCmd() *cobra.Command {
 
 	cmd.Flags().String(flagInstantiateByEverybody, "", "Everybody can instantiate a contract from the code, optional")
 	cmd.Flags().String(flagInstantiateByAddress, "", "Only this address can instantiate a contract instance from the code, optional")
+	cmd.Flags().String(flagCodeSource, "", "URL of the source code repository to verify the build, optional")
+	cmd.Flags().String(flagCodeBuilder, "", "Docker image that was used to build the code, for example cosmwasm/rust-optimizer:0.12.4, optional")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -94,40 +116,132 @@ func parseStoreCodeArgs(file string, sender sdk.AccAddress, flags *flag.FlagSet)
 		return types.MsgStoreCode{}, fmt.Errorf("invalid input file. Use wasm binary or gzip")
 	}
 
-	var perm *types.AccessConfig
+	perm, err := parseInstantiatePermissionFlags(flags)
+	if err != nil {
+		return types.MsgStoreCode{}, err
+	}
+
+	msg := types.MsgStoreCode{
+		Sender:                sender.String(),
+		WASMByteCode:          wasm,
+		InstantiatePermission: perm,
+	}
+	return msg, nil
+}
+
+func parseInstantiatePermissionFlags(flags *flag.FlagSet) (*types.AccessConfig, error) {
 	onlyAddrStr, err := flags.GetString(flagInstantiateByAddress)
 	if err != nil {
-		return types.MsgStoreCode{}, fmt.Errorf("instantiate by address: %s", err)
+		return nil, fmt.Errorf("instantiate by address: %s", err)
 	}
 	if onlyAddrStr != "" {
 		allowedAddr, err := sdk.AccAddressFromBech32(onlyAddrStr)
 		if err != nil {
-			return types.MsgStoreCode{}, sdkerrors.Wrap(err, flagInstantiateByAddress)
+			return nil, sdkerrors.Wrap(err, flagInstantiateByAddress)
 		}
 		x := types.AccessTypeOnlyAddress.With(allowedAddr)
-		perm = &x
-	} else {
-		everybodyStr, err := flags.GetString(flagInstantiateByEverybody)
+		return &x, nil
+	}
+	everybodyStr, err := flags.GetString(flagInstantiateByEverybody)
+	if err != nil {
+		return nil, fmt.Errorf("instantiate by everybody: %s", err)
+	}
+	if everybodyStr != "" {
+		ok, err := strconv.ParseBool(everybodyStr)
 		if err != nil {
-			return types.MsgStoreCode{}, fmt.Errorf("instantiate by everybody: %s", err)
+			return nil, fmt.Errorf("boolean value expected for instantiate by everybody: %s", err)
+		}
+		if ok {
+			return &types.AllowEverybody, nil
 		}
-		if everybodyStr != "" {
-			ok, err := strconv.ParseBool(everybodyStr)
+	}
+	return nil, nil
+}
+
+// CommitCodeCmd will commit to the checksum and size of a wasm binary that is revealed later.
+func CommitCodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commit-code [wasm file]",
+		Short: "Commit to the checksum and size of a wasm binary that is revealed later",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
-				return types.MsgStoreCode{}, fmt.Errorf("boolean value expected for instantiate by everybody: %s", err)
+				return err
 			}
-			if ok {
-				perm = &types.AllowEverybody
+			wasm, err := readUncompressedWasm(args[0])
+			if err != nil {
+				return err
 			}
-		}
+			perm, err := parseInstantiatePermissionFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			checksum := sha256.Sum256(wasm)
+			msg := types.MsgCommitCodeHash{
+				Sender:                clientCtx.GetFromAddress().String(),
+				Checksum:              checksum[:],
+				CodeSize:              uint64(len(wasm)),
+				InstantiatePermission: perm,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
 	}
 
-	msg := types.MsgStoreCode{
-		Sender:                sender.String(),
-		WASMByteCode:          wasm,
-		InstantiatePermission: perm,
+	cmd.Flags().String(flagInstantiateByEverybody, "", "Everybody can instantiate a contract from the code, optional")
+	cmd.Flags().String(flagInstantiateByAddress, "", "Only this address can instantiate a contract instance from the code, optional")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// RevealCodeCmd will store a wasm binary that was committed to before.
+func RevealCodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reveal-code [wasm file]",
+		Short: "Upload a wasm binary that matches a code commitment of the sender",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			wasm, err := readUncompressedWasm(args[0])
+			if err != nil {
+				return err
+			}
+			checksum := sha256.Sum256(wasm)
+			zipped, err := wasmUtils.GzipIt(wasm)
+			if err != nil {
+				return err
+			}
+			msg := types.MsgRevealCode{
+				Sender:       clientCtx.GetFromAddress().String(),
+				Checksum:     checksum[:],
+				WASMByteCode: zipped,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
 	}
-	return msg, nil
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// readUncompressedWasm reads a wasm binary that is not compressed so that the checksum can be computed
+func readUncompressedWasm(file string) ([]byte, error) {
+	wasm, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if !wasmUtils.IsWasm(wasm) {
+		return nil, fmt.Errorf("invalid input file. Use uncompressed wasm binary")
+	}
+	return wasm, nil
 }
 
 // InstantiateContractCmd will instantiate a contract from previously uploaded code.
@@ -147,6 +261,10 @@ func InstantiateContractCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			msg.GovManaged, err = cmd.Flags().GetBool(flagGovManaged)
+			if err != nil {
+				return fmt.Errorf("gov managed: %s", err)
+			}
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -157,6 +275,7 @@ func InstantiateContractCmd() *cobra.Command {
 	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
 	cmd.Flags().String(flagAdmin, "", "Address of an admin")
+	cmd.Flags().Bool(flagGovManaged, false, "Set the governance module account as admin so that only governance can migrate the contract")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -217,6 +336,14 @@ func ExecuteContractCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			msg.Memo, err = cmd.Flags().GetString(flagExecuteMemo)
+			if err != nil {
+				return fmt.Errorf("execute memo: %s", err)
+			}
+			msg.Timelock, err = parseFundsTimelockFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -225,10 +352,36 @@ func ExecuteContractCmd() *cobra.Command {
 	}
 
 	cmd.Flags().String(flagAmount, "", "Coins to send to the contract along with command")
+	cmd.Flags().String(flagExecuteMemo, "", "Memo that is recorded in the execute event, not passed to the contract")
+	cmd.Flags().Int64(flagUnlockHeight, 0, "Hold the amount in the wasm module until this block height instead of sending it with the command, optional")
+	cmd.Flags().String(flagUnlockTime, "", "Hold the amount in the wasm module until this RFC3339 block time instead of sending it with the command, optional")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
+func parseFundsTimelockFlags(flags *flag.FlagSet) (*types.FundsTimelock, error) {
+	unlockHeight, err := flags.GetInt64(flagUnlockHeight)
+	if err != nil {
+		return nil, fmt.Errorf("unlock height: %s", err)
+	}
+	unlockTimeStr, err := flags.GetString(flagUnlockTime)
+	if err != nil {
+		return nil, fmt.Errorf("unlock time: %s", err)
+	}
+	var unlockTime uint64
+	if unlockTimeStr != "" {
+		t, err := time.Parse(time.RFC3339, unlockTimeStr)
+		if err != nil {
+			return nil, fmt.Errorf("unlock time: %s", err)
+		}
+		unlockTime = uint64(t.UnixNano())
+	}
+	if unlockHeight == 0 && unlockTime == 0 {
+		return nil, nil
+	}
+	return &types.FundsTimelock{UnlockHeight: unlockHeight, UnlockTime: unlockTime}, nil
+}
+
 func parseExecuteArgs(contractAddr string, execMsg string, sender sdk.AccAddress, flags *flag.FlagSet) (types.MsgExecuteContract, error) {
 	amountStr, err := flags.GetString(flagAmount)
 	if err != nil {
@@ -37,6 +37,18 @@ func NewHandler(k types.ContractOpsKeeper) sdk.Handler {
 			res, err = msgServer.UpdateAdmin(sdk.WrapSDKContext(ctx), msg)
 		case *MsgClearAdmin:
 			res, err = msgServer.ClearAdmin(sdk.WrapSDKContext(ctx), msg)
+		case *MsgCommitCodeHash:
+			res, err = msgServer.CommitCodeHash(sdk.WrapSDKContext(ctx), msg)
+		case *MsgRevealCode:
+			res, err = msgServer.RevealCode(sdk.WrapSDKContext(ctx), msg)
+		case *MsgReleaseTimelockedFunds:
+			res, err = msgServer.ReleaseTimelockedFunds(sdk.WrapSDKContext(ctx), msg)
+		case *MsgUpdateExecutePermission:
+			res, err = msgServer.UpdateExecutePermission(sdk.WrapSDKContext(ctx), msg)
+		case *MsgUpdateMigratePermission:
+			res, err = msgServer.UpdateMigratePermission(sdk.WrapSDKContext(ctx), msg)
+		case *MsgUpdateAcceptedDenoms:
+			res, err = msgServer.UpdateAcceptedDenoms(sdk.WrapSDKContext(ctx), msg)
 		default:
 			errMsg := fmt.Sprintf("unrecognized wasm message type: %T", msg)
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
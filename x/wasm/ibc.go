@@ -2,6 +2,7 @@ package wasm
 
 import (
 	"math"
+	"strings"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -51,6 +52,9 @@ func (i IBCHandler) OnChanOpenInit(
 	if err := ValidateChannelParams(channelID); err != nil {
 		return err
 	}
+	if err := ValidateChannelVersion(version); err != nil {
+		return err
+	}
 	contractAddr, err := ContractFromPortID(portID)
 	if err != nil {
 		return sdkerrors.Wrapf(err, "contract port id")
@@ -92,6 +96,9 @@ func (i IBCHandler) OnChanOpenTry(
 	if err := ValidateChannelParams(channelID); err != nil {
 		return err
 	}
+	if err := ValidateChannelVersion(version); err != nil {
+		return err
+	}
 
 	contractAddr, err := ContractFromPortID(portID)
 	if err != nil {
@@ -183,13 +190,8 @@ func (i IBCHandler) OnChanCloseInit(ctx sdk.Context, portID, channelID string) e
 	msg := wasmvmtypes.IBCChannelCloseMsg{
 		CloseInit: &wasmvmtypes.IBCCloseInit{Channel: toWasmVMChannel(portID, channelID, channelInfo)},
 	}
-	err = i.keeper.OnCloseChannel(ctx, contractAddr, msg)
-	if err != nil {
-		return err
-	}
-	// emit events?
-
-	return err
+	i.closeChannel(ctx, contractAddr, msg)
+	return nil
 }
 
 // OnChanCloseConfirm implements the IBCModule interface
@@ -207,13 +209,23 @@ func (i IBCHandler) OnChanCloseConfirm(ctx sdk.Context, portID, channelID string
 	msg := wasmvmtypes.IBCChannelCloseMsg{
 		CloseConfirm: &wasmvmtypes.IBCCloseConfirm{Channel: toWasmVMChannel(portID, channelID, channelInfo)},
 	}
-	err = i.keeper.OnCloseChannel(ctx, contractAddr, msg)
-	if err != nil {
-		return err
-	}
-	// emit events?
+	i.closeChannel(ctx, contractAddr, msg)
+	return nil
+}
 
-	return err
+// closeChannel calls the contract's ibc_channel_close entrypoint for teardown logic. The channel is closed even
+// when the contract fails: state changes and events of the failed call are discarded and the error is logged.
+// Out of gas panics are not recovered.
+func (i IBCHandler) closeChannel(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelCloseMsg) {
+	em := sdk.NewEventManager()
+	cacheCtx, commit := ctx.CacheContext()
+	if err := i.keeper.OnCloseChannel(cacheCtx.WithEventManager(em), contractAddr, msg); err != nil {
+		ctx.Logger().With("module", "x/"+types.ModuleName).
+			Error("contract failed on channel close", "contract", contractAddr.String(), "error", err.Error())
+		return
+	}
+	commit()
+	ctx.EventManager().EmitEvents(em.Events())
 }
 
 func toWasmVMChannel(portID, channelID string, channelInfo channeltypes.Channel) wasmvmtypes.IBCChannel {
@@ -306,6 +318,19 @@ func newIBCPacket(packet channeltypes.Packet) wasmvmtypes.IBCPacket {
 	}
 }
 
+// ValidateChannelVersion ensures the channel version proposed in the handshake is not empty.
+// It does not check the version content. The version is passed to the contract's `ibc_channel_open` entrypoint,
+// together with the counterparty version in TRY, and the contract decides whether it supports it.
+//
+// Limitation: `ibc_channel_open` can only accept the version or reject the handshake with an error. It can not
+// return a different version, so a contract can not negotiate a version other than the one proposed.
+func ValidateChannelVersion(version string) error {
+	if strings.TrimSpace(version) == "" {
+		return sdkerrors.Wrap(types.ErrEmpty, "channel version")
+	}
+	return nil
+}
+
 func ValidateChannelParams(channelID string) error {
 	// NOTE: for escrow address security only 2^32 channels are allowed to be created
 	// Issue: https://github.com/cosmos/cosmos-sdk/issues/7737
@@ -319,7 +344,9 @@ func ValidateChannelParams(channelID string) error {
 	return nil
 }
 
-// NegotiateAppVersion implements the IBCModule interface
+// NegotiateAppVersion implements the IBCModule interface.
+// The contract can not propose a version of its own (see ValidateChannelVersion), so any non empty
+// proposed version is returned unchanged. The contract accepts or rejects it later in the handshake.
 func (i IBCHandler) NegotiateAppVersion(
 	ctx sdk.Context,
 	order channeltypes.Order,
@@ -328,5 +355,44 @@ func (i IBCHandler) NegotiateAppVersion(
 	counterparty channeltypes.Counterparty,
 	proposedVersion string,
 ) (version string, err error) {
+	if err := ValidateChannelVersion(proposedVersion); err != nil {
+		return "", err
+	}
 	return proposedVersion, nil
 }
+
+var _ porttypes.IBCModule = IBCPacketTrackingMiddleware{}
+
+// IBCPacketTrackingMiddleware wraps the IBC module of a port that contracts send packets on, like the ICS20 transfer
+// module, to remove the packets that contracts sent from the in-flight packets when they are acknowledged or time out.
+type IBCPacketTrackingMiddleware struct {
+	porttypes.IBCModule
+	keeper types.InFlightPacketKeeper
+}
+
+// NewIBCPacketTrackingMiddleware constructor
+func NewIBCPacketTrackingMiddleware(app porttypes.IBCModule, k types.InFlightPacketKeeper) IBCPacketTrackingMiddleware {
+	return IBCPacketTrackingMiddleware{IBCModule: app, keeper: k}
+}
+
+// OnAcknowledgementPacket implements the IBCModule interface
+func (m IBCPacketTrackingMiddleware) OnAcknowledgementPacket(
+	ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte, relayer sdk.AccAddress,
+) error {
+	if err := m.IBCModule.OnAcknowledgementPacket(ctx, packet, acknowledgement, relayer); err != nil {
+		return err
+	}
+	m.keeper.RemoveInFlightPacket(ctx, packet.SourcePort, packet.SourceChannel, packet.Sequence)
+	return nil
+}
+
+// OnTimeoutPacket implements the IBCModule interface
+func (m IBCPacketTrackingMiddleware) OnTimeoutPacket(
+	ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress,
+) error {
+	if err := m.IBCModule.OnTimeoutPacket(ctx, packet, relayer); err != nil {
+		return err
+	}
+	m.keeper.RemoveInFlightPacket(ctx, packet.SourcePort, packet.SourceChannel, packet.Sequence)
+	return nil
+}
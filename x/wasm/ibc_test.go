@@ -1,12 +1,26 @@
 package wasm
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
 	clienttypes "github.com/cosmos/ibc-go/v2/modules/core/02-client/types"
 	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v2/modules/core/05-port/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 func TestMapToWasmVMIBCPacket(t *testing.T) {
@@ -80,3 +94,261 @@ func IBCPacketFixture(mutators ...func(p *channeltypes.Packet)) channeltypes.Pac
 	}
 	return r
 }
+
+func TestOnChanOpenTryVersionNegotiation(t *testing.T) {
+	myContractAddr := keeper.RandomAccountAddress(t)
+	myPortID := keeper.PortIDForContract(myContractAddr)
+	// contract that upgrades a v1 counterparty to v2 and rejects any other version
+	upgradingContract := func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelOpenMsg) error {
+		if msg.OpenTry == nil {
+			return errors.New("unexpected message")
+		}
+		if msg.OpenTry.CounterpartyVersion != "v1" || msg.OpenTry.Channel.Version != "v2" {
+			return errors.New("incompatible version")
+		}
+		return nil
+	}
+	specs := map[string]struct {
+		srcVersion             string
+		srcCounterpartyVersion string
+		expContractCalled      bool
+		expErr                 bool
+	}{
+		"upgraded version accepted": {
+			srcVersion:             "v2",
+			srcCounterpartyVersion: "v1",
+			expContractCalled:      true,
+		},
+		"incompatible version rejected by contract": {
+			srcVersion:             "v1",
+			srcCounterpartyVersion: "v1",
+			expContractCalled:      true,
+			expErr:                 true,
+		},
+		"empty version rejected": {
+			srcVersion:             "",
+			srcCounterpartyVersion: "v1",
+			expErr:                 true,
+		},
+		"blank version rejected": {
+			srcVersion:             " ",
+			srcCounterpartyVersion: "v1",
+			expErr:                 true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var contractCalled bool
+			mock := ibcContractKeeperMock{OnOpenChannelFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelOpenMsg) error {
+				contractCalled = true
+				assert.Equal(t, myContractAddr, contractAddr)
+				return upgradingContract(ctx, contractAddr, msg)
+			}}
+			h := NewIBCHandler(mock, nil)
+			counterparty := channeltypes.NewCounterparty("otherPort", "channel-2")
+			gotErr := h.OnChanOpenTry(sdk.Context{}, channeltypes.UNORDERED, []string{"connection-1"}, myPortID, "channel-1", &capabilitytypes.Capability{}, counterparty, spec.srcVersion, spec.srcCounterpartyVersion)
+			assert.Equal(t, spec.expContractCalled, contractCalled)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestOnChanOpenInitRejectsEmptyVersion(t *testing.T) {
+	myPortID := keeper.PortIDForContract(keeper.RandomAccountAddress(t))
+	mock := ibcContractKeeperMock{OnOpenChannelFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelOpenMsg) error {
+		t.Fatal("contract must not be called")
+		return nil
+	}}
+	h := NewIBCHandler(mock, nil)
+	counterparty := channeltypes.NewCounterparty("otherPort", "")
+	gotErr := h.OnChanOpenInit(sdk.Context{}, channeltypes.UNORDERED, []string{"connection-1"}, myPortID, "channel-1", &capabilitytypes.Capability{}, counterparty, "")
+	assert.True(t, types.ErrEmpty.Is(gotErr), "got %+v", gotErr)
+}
+
+func TestOnChanCloseCallsContract(t *testing.T) {
+	myContractAddr := keeper.RandomAccountAddress(t)
+	myPortID := keeper.PortIDForContract(myContractAddr)
+	channelKeeper := &wasmtesting.MockChannelKeeper{
+		GetChannelFn: func(ctx sdk.Context, srcPort, srcChan string) (channeltypes.Channel, bool) {
+			return channeltypes.NewChannel(channeltypes.OPEN, channeltypes.UNORDERED, channeltypes.NewCounterparty("otherPort", "channel-2"), []string{"connection-1"}, "v1"), true
+		},
+	}
+	myKey := []byte("my-key")
+	specs := map[string]struct {
+		call        func(h IBCHandler, ctx sdk.Context) error
+		contractErr error
+		expInit     bool
+	}{
+		"close init": {
+			call: func(h IBCHandler, ctx sdk.Context) error {
+				return h.OnChanCloseInit(ctx, myPortID, "channel-1")
+			},
+			expInit: true,
+		},
+		"close confirm": {
+			call: func(h IBCHandler, ctx sdk.Context) error {
+				return h.OnChanCloseConfirm(ctx, myPortID, "channel-1")
+			},
+		},
+		"close init with contract failure": {
+			call: func(h IBCHandler, ctx sdk.Context) error {
+				return h.OnChanCloseInit(ctx, myPortID, "channel-1")
+			},
+			contractErr: errors.New("testing"),
+			expInit:     true,
+		},
+		"close confirm with contract failure": {
+			call: func(h IBCHandler, ctx sdk.Context) error {
+				return h.OnChanCloseConfirm(ctx, myPortID, "channel-1")
+			},
+			contractErr: errors.New("testing"),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			storeKey := sdk.NewKVStoreKey("testing")
+			ms := store.NewCommitMultiStore(dbm.NewMemDB())
+			ms.MountStoreWithDB(storeKey, sdk.StoreTypeIAVL, nil)
+			require.NoError(t, ms.LoadLatestVersion())
+			ctx := sdk.NewContext(ms, tmproto.Header{}, false, log.NewNopLogger())
+
+			var gotMsg *wasmvmtypes.IBCChannelCloseMsg
+			mock := ibcContractKeeperMock{OnCloseChannelFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelCloseMsg) error {
+				gotMsg = &msg
+				assert.Equal(t, myContractAddr, contractAddr)
+				// teardown logic of the contract
+				ctx.KVStore(storeKey).Set(myKey, []byte("my-value"))
+				ctx.EventManager().EmitEvent(sdk.NewEvent("my-event"))
+				return spec.contractErr
+			}}
+			h := NewIBCHandler(mock, channelKeeper)
+
+			// when
+			err := spec.call(h, ctx)
+
+			// then the channel is closed
+			require.NoError(t, err)
+			// and the contract was called with the channel details
+			require.NotNil(t, gotMsg)
+			expChannel := wasmvmtypes.IBCChannel{
+				Endpoint:             wasmvmtypes.IBCEndpoint{PortID: myPortID, ChannelID: "channel-1"},
+				CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: "otherPort", ChannelID: "channel-2"},
+				Order:                channeltypes.UNORDERED.String(),
+				Version:              "v1",
+				ConnectionID:         "connection-1",
+			}
+			if spec.expInit {
+				require.NotNil(t, gotMsg.CloseInit)
+				assert.Equal(t, expChannel, gotMsg.CloseInit.Channel)
+			} else {
+				require.NotNil(t, gotMsg.CloseConfirm)
+				assert.Equal(t, expChannel, gotMsg.CloseConfirm.Channel)
+			}
+			// and the changes of a failed contract are discarded
+			if spec.contractErr != nil {
+				assert.Nil(t, ctx.KVStore(storeKey).Get(myKey))
+				assert.Empty(t, ctx.EventManager().Events())
+				return
+			}
+			assert.Equal(t, []byte("my-value"), ctx.KVStore(storeKey).Get(myKey))
+			assert.Equal(t, sdk.Events{sdk.NewEvent("my-event")}, ctx.EventManager().Events())
+		})
+	}
+}
+
+func TestIBCPacketTrackingMiddleware(t *testing.T) {
+	packet := IBCPacketFixture()
+	specs := map[string]struct {
+		srcErr    error
+		call      func(m IBCPacketTrackingMiddleware) error
+		expRemove bool
+	}{
+		"acknowledged": {
+			call: func(m IBCPacketTrackingMiddleware) error {
+				return m.OnAcknowledgementPacket(sdk.Context{}, packet, []byte("myAck"), nil)
+			},
+			expRemove: true,
+		},
+		"timed out": {
+			call: func(m IBCPacketTrackingMiddleware) error {
+				return m.OnTimeoutPacket(sdk.Context{}, packet, nil)
+			},
+			expRemove: true,
+		},
+		"acknowledgement failed": {
+			srcErr: errors.New("testing"),
+			call: func(m IBCPacketTrackingMiddleware) error {
+				return m.OnAcknowledgementPacket(sdk.Context{}, packet, []byte("myAck"), nil)
+			},
+		},
+		"timeout failed": {
+			srcErr: errors.New("testing"),
+			call: func(m IBCPacketTrackingMiddleware) error {
+				return m.OnTimeoutPacket(sdk.Context{}, packet, nil)
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var removed []string
+			k := inFlightPacketKeeperMock(func(ctx sdk.Context, portID, channelID string, sequence uint64) {
+				removed = append(removed, fmt.Sprintf("%s/%s/%d", portID, channelID, sequence))
+			})
+			m := NewIBCPacketTrackingMiddleware(ibcModuleMock{err: spec.srcErr}, k)
+
+			gotErr := spec.call(m)
+			if spec.srcErr != nil {
+				assert.ErrorIs(t, gotErr, spec.srcErr)
+				assert.Empty(t, removed)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, []string{"srcPort/channel-1/1"}, removed)
+		})
+	}
+}
+
+type inFlightPacketKeeperMock func(ctx sdk.Context, portID, channelID string, sequence uint64)
+
+func (m inFlightPacketKeeperMock) RemoveInFlightPacket(ctx sdk.Context, portID, channelID string, sequence uint64) {
+	m(ctx, portID, channelID, sequence)
+}
+
+type ibcModuleMock struct {
+	porttypes.IBCModule
+	err error
+}
+
+func (m ibcModuleMock) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte, relayer sdk.AccAddress) error {
+	return m.err
+}
+
+func (m ibcModuleMock) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet, relayer sdk.AccAddress) error {
+	return m.err
+}
+
+type ibcContractKeeperMock struct {
+	types.IBCContractKeeper
+	OnOpenChannelFn  func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelOpenMsg) error
+	OnCloseChannelFn func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelCloseMsg) error
+}
+
+func (m ibcContractKeeperMock) OnOpenChannel(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelOpenMsg) error {
+	return m.OnOpenChannelFn(ctx, contractAddr, msg)
+}
+
+func (m ibcContractKeeperMock) OnCloseChannel(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IBCChannelCloseMsg) error {
+	return m.OnCloseChannelFn(ctx, contractAddr, msg)
+}
+
+func (m ibcContractKeeperMock) AuthenticateCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) bool {
+	return true
+}
+
+func (m ibcContractKeeperMock) ClaimCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error {
+	return nil
+}
@@ -54,6 +54,21 @@ func decodeHeightCounter(bz []byte) (int64, uint32) {
 	return int64(sdk.BigEndianToUint64(bz[0:8])), binary.BigEndian.Uint32(bz[8:])
 }
 
+// QueryCacheDecorator ante handler to attach an empty smart query result cache to the context of a tx.
+type QueryCacheDecorator struct{}
+
+// NewQueryCacheDecorator constructor
+func NewQueryCacheDecorator() *QueryCacheDecorator {
+	return &QueryCacheDecorator{}
+}
+
+// AnteHandle handler passes a new query cache via sdk.Context upstream so that contract to contract smart
+// query results are cached for the lifetime of the tx. The cache is only used when the `query_cache_enabled`
+// param is set. See `types.QueryCacheFromContext(ctx)` to read the value.
+func (d QueryCacheDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	return next(types.WithQueryCache(ctx, types.NewQueryCache()), tx, simulate)
+}
+
 // LimitSimulationGasDecorator ante decorator to limit gas in simulation calls
 type LimitSimulationGasDecorator struct {
 	gasLimit *sdk.Gas
@@ -94,3 +109,43 @@ func (d LimitSimulationGasDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simu
 	}
 	return next(ctx, tx, simulate)
 }
+
+// MaxGasPerTxPercentSource provides the max share of the block gas limit in percent that a tx can consume
+type MaxGasPerTxPercentSource interface {
+	GetMaxGasPerTxPercent(ctx sdk.Context) uint32
+}
+
+// LimitTxGasDecorator ante decorator to limit the gas of a tx to a share of the block gas limit
+type LimitTxGasDecorator struct {
+	source MaxGasPerTxPercentSource
+}
+
+// NewLimitTxGasDecorator constructor
+func NewLimitTxGasDecorator(source MaxGasPerTxPercentSource) *LimitTxGasDecorator {
+	return &LimitTxGasDecorator{source: source}
+}
+
+// AnteHandle replaces the tx gas meter with a tighter one when the `max_gas_per_tx_percent` param restricts txs to
+// a share of the block gas limit that is lower than the tx gas limit. The gas consumed so far is carried over.
+// It applies to all txs and not only to wasm messages, as contracts are also called via submessages, replies
+// and messages of other modules, like IBC packets. Must run after the SetUpContextDecorator.
+func (d LimitTxGasDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	maxBlockGas := ctx.ConsensusParams().GetBlock().GetMaxGas()
+	if maxBlockGas <= 0 {
+		return next(ctx, tx, simulate)
+	}
+	percent := d.source.GetMaxGasPerTxPercent(ctx)
+	if percent == 0 {
+		return next(ctx, tx, simulate)
+	}
+	// calculated with big integers so that the multiplication can not overflow; the result is not greater than the
+	// block gas limit as the percent is validated to be at most 100
+	maxTxGas := sdk.NewUint(uint64(maxBlockGas)).MulUint64(uint64(percent)).QuoUint64(100).Uint64()
+	// the infinite gas meter of genesis txs has no limit
+	if txGasLimit := ctx.GasMeter().Limit(); txGasLimit == 0 || txGasLimit <= maxTxGas {
+		return next(ctx, tx, simulate)
+	}
+	gasMeter := sdk.NewGasMeter(maxTxGas)
+	gasMeter.ConsumeGas(ctx.GasMeter().GasConsumed(), "ante handler")
+	return next(ctx.WithGasMeter(gasMeter), tx, simulate)
+}
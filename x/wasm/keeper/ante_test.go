@@ -1,6 +1,7 @@
 package keeper_test
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -179,6 +180,79 @@ func TestLimitSimulationGasDecorator(t *testing.T) {
 	}
 }
 
+func TestLimitTxGasDecorator(t *testing.T) {
+	specs := map[string]struct {
+		srcMaxBlockGas int64
+		srcPercent     uint32
+		srcTxGasLimit  sdk.Gas
+		expGasLimit    sdk.Gas
+	}{
+		"share of block gas": {
+			srcMaxBlockGas: 4_000_000,
+			srcPercent:     10,
+			srcTxGasLimit:  1_000_000,
+			expGasLimit:    400_000,
+		},
+		"block gas below 100": {
+			srcMaxBlockGas: 50,
+			srcPercent:     50,
+			srcTxGasLimit:  50,
+			expGasLimit:    25,
+		},
+		"max block gas": {
+			srcMaxBlockGas: math.MaxInt64,
+			srcPercent:     100,
+			srcTxGasLimit:  math.MaxUint64,
+			expGasLimit:    math.MaxInt64,
+		},
+		"tx gas limit below share": {
+			srcMaxBlockGas: 4_000_000,
+			srcPercent:     10,
+			srcTxGasLimit:  200_000,
+			expGasLimit:    200_000,
+		},
+		"disabled": {
+			srcMaxBlockGas: 4_000_000,
+			srcPercent:     0,
+			srcTxGasLimit:  1_000_000,
+			expGasLimit:    1_000_000,
+		},
+		"no block gas limit": {
+			srcMaxBlockGas: -1,
+			srcPercent:     10,
+			srcTxGasLimit:  1_000_000,
+			expGasLimit:    1_000_000,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.Context{}.
+				WithGasMeter(sdk.NewGasMeter(spec.srcTxGasLimit)).
+				WithConsensusParams(&abci.ConsensusParams{
+					Block: &abci.BlockParams{MaxGas: spec.srcMaxBlockGas}})
+			ctx.GasMeter().ConsumeGas(10, "testing")
+			var gotCtx sdk.Context
+			nextAnte := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				gotCtx = ctx
+				return ctx, nil
+			}
+			ante := keeper.NewLimitTxGasDecorator(maxGasPerTxPercentFn(func(sdk.Context) uint32 { return spec.srcPercent }))
+			// when
+			_, err := ante.AnteHandle(ctx, nil, false, nextAnte)
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, spec.expGasLimit, gotCtx.GasMeter().Limit())
+			assert.Equal(t, sdk.Gas(10), gotCtx.GasMeter().GasConsumed())
+		})
+	}
+}
+
+type maxGasPerTxPercentFn func(ctx sdk.Context) uint32
+
+func (f maxGasPerTxPercentFn) GetMaxGasPerTxPercent(ctx sdk.Context) uint32 {
+	return f(ctx)
+}
+
 func consumeGasAnteHandler(gasToConsume sdk.Gas) sdk.AnteHandler {
 	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
 		ctx.GasMeter().ConsumeGas(gasToConsume, "testing")
@@ -10,6 +10,9 @@ type AuthorizationPolicy interface {
 	CanCreateCode(c types.AccessConfig, creator sdk.AccAddress) bool
 	CanInstantiateContract(c types.AccessConfig, actor sdk.AccAddress) bool
 	CanModifyContract(admin, actor sdk.AccAddress) bool
+	CanMigrateContract(migratePermission *types.AccessConfig, admin, actor sdk.AccAddress) bool
+	// CanModifyGovManagedContract returns true when admin operations on contracts managed by governance are allowed
+	CanModifyGovManagedContract() bool
 }
 
 type DefaultAuthorizationPolicy struct {
@@ -27,6 +30,17 @@ func (p DefaultAuthorizationPolicy) CanModifyContract(admin, actor sdk.AccAddres
 	return admin != nil && admin.Equals(actor)
 }
 
+func (p DefaultAuthorizationPolicy) CanMigrateContract(migratePermission *types.AccessConfig, admin, actor sdk.AccAddress) bool {
+	if migratePermission == nil {
+		return p.CanModifyContract(admin, actor)
+	}
+	return migratePermission.Allowed(actor)
+}
+
+func (p DefaultAuthorizationPolicy) CanModifyGovManagedContract() bool {
+	return false
+}
+
 type GovAuthorizationPolicy struct {
 }
 
@@ -41,3 +55,11 @@ func (p GovAuthorizationPolicy) CanInstantiateContract(types.AccessConfig, sdk.A
 func (p GovAuthorizationPolicy) CanModifyContract(sdk.AccAddress, sdk.AccAddress) bool {
 	return true
 }
+
+func (p GovAuthorizationPolicy) CanMigrateContract(*types.AccessConfig, sdk.AccAddress, sdk.AccAddress) bool {
+	return true
+}
+
+func (p GovAuthorizationPolicy) CanModifyGovManagedContract() bool {
+	return true
+}
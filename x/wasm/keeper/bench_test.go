@@ -50,7 +50,8 @@ func BenchmarkInstantiationOverhead(b *testing.B) {
 	}
 	for name, spec := range specs {
 		b.Run(name, func(b *testing.B) {
-			wasmConfig := types.WasmConfig{MemoryCacheSize: 0}
+			wasmConfig := types.DefaultWasmConfig()
+			wasmConfig.MemoryCacheSize = 0
 			ctx, keepers := createTestInput(b, false, SupportedFeatures, wasmConfig, spec.db())
 			example := InstantiateHackatomExampleContract(b, ctx, keepers)
 			if spec.pinned {
@@ -84,7 +85,8 @@ func BenchmarkCompilation(b *testing.B) {
 
 	for name, spec := range specs {
 		b.Run(name, func(b *testing.B) {
-			wasmConfig := types.WasmConfig{MemoryCacheSize: 0}
+			wasmConfig := types.DefaultWasmConfig()
+			wasmConfig.MemoryCacheSize = 0
 			db := dbm.NewMemDB()
 			ctx, keepers := createTestInput(b, false, SupportedFeatures, wasmConfig, db)
 
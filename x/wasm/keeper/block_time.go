@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// UpdateBlockTimeAverage updates the moving average of the block time with the time of the current block. It is
+// called by the begin blocker. The average is an exponential moving average over the number of blocks that is set by
+// the block time average window param so that a single block with an unusual timestamp has a limited impact.
+func (k Keeper) UpdateBlockTimeAverage(ctx sdk.Context) {
+	window := k.GetBlockTimeAverageWindow(ctx)
+	store := ctx.KVStore(k.storeKey)
+	if window == 0 {
+		// forget the time of the last tracked block so that the gap is not tracked when the window is re-enabled
+		if bz := store.Get(types.BlockTimeAveragePrefix); bz != nil {
+			if last, average := decodeBlockTimeAverage(bz); last != 0 {
+				store.Set(types.BlockTimeAveragePrefix, encodeBlockTimeAverage(0, average))
+			}
+		}
+		return
+	}
+	now := ctx.BlockTime().UnixNano()
+	var average int64
+	if bz := store.Get(types.BlockTimeAveragePrefix); bz != nil {
+		last, lastAverage := decodeBlockTimeAverage(bz)
+		average = lastAverage
+		if blockTime := now - last; last != 0 && blockTime > 0 {
+			if average == 0 {
+				average = blockTime
+			} else {
+				average += (blockTime - average) / int64(window)
+			}
+		}
+	}
+	store.Set(types.BlockTimeAveragePrefix, encodeBlockTimeAverage(now, average))
+}
+
+// GetAverageBlockTime returns the moving average of the block time. Zero when less than two blocks were tracked.
+func (k Keeper) GetAverageBlockTime(ctx sdk.Context) time.Duration {
+	bz := ctx.KVStore(k.storeKey).Get(types.BlockTimeAveragePrefix)
+	if bz == nil {
+		return 0
+	}
+	_, average := decodeBlockTimeAverage(bz)
+	return time.Duration(average)
+}
+
+// importAverageBlockTime restores the moving average of the block time. The time of the last block is unknown so
+// that the next block only starts the tracking again.
+func (k Keeper) importAverageBlockTime(ctx sdk.Context, average time.Duration) {
+	if average == 0 {
+		return
+	}
+	ctx.KVStore(k.storeKey).Set(types.BlockTimeAveragePrefix, encodeBlockTimeAverage(0, int64(average)))
+}
+
+func encodeBlockTimeAverage(blockTime, average int64) []byte {
+	return append(sdk.Uint64ToBigEndian(uint64(blockTime)), sdk.Uint64ToBigEndian(uint64(average))...)
+}
+
+func decodeBlockTimeAverage(bz []byte) (int64, int64) {
+	return int64(sdk.BigEndianToUint64(bz[0:8])), int64(sdk.BigEndianToUint64(bz[8:]))
+}
@@ -0,0 +1,63 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestBlockTimeAverage(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	blockTime := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	height := ctx.BlockHeight()
+	produceBlocks := func(n int, interval time.Duration) {
+		for i := 0; i < n; i++ {
+			height++
+			blockTime = blockTime.Add(interval)
+			k.UpdateBlockTimeAverage(ctx.WithBlockHeight(height).WithBlockTime(blockTime))
+		}
+	}
+
+	// no average before the second block
+	produceBlocks(1, time.Second)
+	assert.Equal(t, time.Duration(0), k.GetAverageBlockTime(ctx))
+
+	// when blocks are produced in a new interval
+	produceBlocks(50, 2*time.Second)
+	produceBlocks(500, 5*time.Second)
+
+	// then the average converges to the interval
+	assert.InDelta(t, float64(5*time.Second), float64(k.GetAverageBlockTime(ctx)), float64(50*time.Millisecond))
+
+	// and contracts can query it
+	raw, err := ChainQuerier(k, nil)(ctx, RandomAccountAddress(t), &types.ChainQuery{AverageBlockTime: &types.AverageBlockTimeQuery{}})
+	require.NoError(t, err)
+	var res types.AverageBlockTimeResponse
+	mustParse(t, raw, &res)
+	assert.Equal(t, uint64(k.GetAverageBlockTime(ctx)), res.Nanos)
+
+	// when the tracking is disabled
+	params := types.DefaultParams()
+	params.BlockTimeAverageWindow = 0
+	k.setParams(ctx, params)
+	before := k.GetAverageBlockTime(ctx)
+	produceBlocks(10, time.Minute)
+
+	// then the average is not updated
+	assert.Equal(t, before, k.GetAverageBlockTime(ctx))
+
+	// when the tracking is re-enabled
+	k.setParams(ctx, types.DefaultParams())
+	produceBlocks(1, time.Minute)
+
+	// then the gap while disabled is not tracked
+	assert.Equal(t, before, k.GetAverageBlockTime(ctx))
+	// but the following blocks are
+	produceBlocks(1, time.Minute)
+	assert.Greater(t, int64(k.GetAverageBlockTime(ctx)), int64(before))
+}
@@ -0,0 +1,156 @@
+package keeper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// commitCodeHash records the checksum and size of a wasm code so that the code can be distributed off chain and
+// stored by the creator with revealCode within the code commitment window. Commitments are bound to the creator, so
+// other actors can not take over or block a checksum. An unexpired commitment of the creator for the same checksum
+// can not be replaced. Returns the last height in which the code can be revealed.
+func (k Keeper) commitCodeHash(ctx sdk.Context, creator sdk.AccAddress, checksum []byte, codeSize uint64, instantiateAccess *types.AccessConfig, authZ AuthorizationPolicy) (int64, error) {
+	if creator == nil {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "cannot be nil")
+	}
+	window := k.GetCodeCommitmentWindow(ctx)
+	if window == 0 {
+		return 0, sdkerrors.Wrap(types.ErrInvalid, "code commitments disabled")
+	}
+	if !authZ.CanCreateCode(k.getUploadAccessConfig(ctx), creator) {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not create code")
+	}
+	if maxSize := k.GetMaxWasmCodeSize(ctx); codeSize > maxSize {
+		return 0, sdkerrors.Wrapf(types.ErrLimit, "code size %d exceeds max %d", codeSize, maxSize)
+	}
+	if existing, found := k.GetCodeCommitment(ctx, creator, checksum); found {
+		if ctx.BlockHeight() <= existing.ExpiryHeight {
+			return 0, sdkerrors.Wrap(types.ErrDuplicate, "code commitment")
+		}
+		k.deleteCodeCommitment(ctx, creator, existing)
+	}
+	commitment := types.CodeCommitment{
+		Creator:               creator.String(),
+		Checksum:              checksum,
+		CodeSize:              codeSize,
+		ExpiryHeight:          ctx.BlockHeight() + int64(window),
+		InstantiatePermission: instantiateAccess,
+	}
+	k.storeCodeCommitment(ctx, creator, commitment)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeCommitCodeHash,
+		sdk.NewAttribute(types.AttributeKeyCreator, commitment.Creator),
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(checksum)),
+		sdk.NewAttribute(types.AttributeKeyExpiryHeight, strconv.FormatInt(commitment.ExpiryHeight, 10)),
+	))
+	return commitment.ExpiryHeight, nil
+}
+
+// revealCode stores the wasm code of a commitment with the committer as creator. Only the committer can reveal the
+// code. The uncompressed code must match the committed checksum and size and be revealed before the commitment
+// expires.
+func (k Keeper) revealCode(ctx sdk.Context, creator sdk.AccAddress, checksum []byte, wasmCode []byte, authZ AuthorizationPolicy) (uint64, error) {
+	if creator == nil {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "cannot be nil")
+	}
+	commitment, found := k.GetCodeCommitment(ctx, creator, checksum)
+	if !found {
+		return 0, sdkerrors.Wrap(types.ErrNotFound, "code commitment")
+	}
+	if ctx.BlockHeight() > commitment.ExpiryHeight {
+		return 0, sdkerrors.Wrapf(types.ErrInvalid, "code commitment expired at height %d", commitment.ExpiryHeight)
+	}
+	wasmCode, err := uncompress(wasmCode, k.GetMaxWasmCodeSize(ctx))
+	if err != nil {
+		return 0, sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
+	}
+	if uint64(len(wasmCode)) != commitment.CodeSize {
+		return 0, sdkerrors.Wrapf(types.ErrInvalid, "code size %d does not match commitment %d", len(wasmCode), commitment.CodeSize)
+	}
+	if hash := sha256.Sum256(wasmCode); !bytes.Equal(hash[:], commitment.Checksum) {
+		return 0, sdkerrors.Wrap(types.ErrInvalid, "checksum does not match commitment")
+	}
+	k.deleteCodeCommitment(ctx, creator, commitment)
+	return k.create(ctx, creator, wasmCode, commitment.InstantiatePermission, authZ)
+}
+
+// PruneExpiredCodeCommitments deletes the code hash commitments that expired before the current block height
+func (k Keeper) PruneExpiredCodeCommitments(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	prefixStore := prefix.NewStore(store, types.CodeCommitmentExpiryPrefix)
+	// the queue is ordered by expiry height, the end is exclusive
+	iter := prefixStore.Iterator(nil, sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())))
+	// collect first to not write to the store while iterating it
+	var queueKeys, commitmentKeys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		queueKeys = append(queueKeys, iter.Key())
+		// the value is the commitment key without store prefix
+		commitmentKeys = append(commitmentKeys, append(types.CodeCommitmentPrefix, iter.Value()...))
+	}
+	iter.Close()
+	for i := range queueKeys {
+		prefixStore.Delete(queueKeys[i])
+		store.Delete(commitmentKeys[i])
+	}
+}
+
+// GetCodeCommitment returns the code hash commitment of the creator for the checksum
+func (k Keeper) GetCodeCommitment(ctx sdk.Context, creator sdk.AccAddress, checksum []byte) (types.CodeCommitment, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetCodeCommitmentKey(creator, checksum))
+	if bz == nil {
+		return types.CodeCommitment{}, false
+	}
+	var commitment types.CodeCommitment
+	k.cdc.MustUnmarshal(bz, &commitment)
+	return commitment, true
+}
+
+// IterateCodeCommitments iterates over the code hash commitments that were not revealed, including expired ones
+func (k Keeper) IterateCodeCommitments(ctx sdk.Context, cb func(types.CodeCommitment) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.CodeCommitmentPrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var commitment types.CodeCommitment
+		k.cdc.MustUnmarshal(iter.Value(), &commitment)
+		if cb(commitment) {
+			return
+		}
+	}
+}
+
+// storeCodeCommitment stores the commitment and adds it to the expiry queue
+func (k Keeper) storeCodeCommitment(ctx sdk.Context, creator sdk.AccAddress, commitment types.CodeCommitment) {
+	key := types.GetCodeCommitmentKey(creator, commitment.Checksum)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(key, k.cdc.MustMarshal(&commitment))
+	store.Set(types.GetCodeCommitmentExpiryKey(commitment.ExpiryHeight, creator, commitment.Checksum), key[len(types.CodeCommitmentPrefix):])
+}
+
+// deleteCodeCommitment deletes the commitment and its expiry queue entry
+func (k Keeper) deleteCodeCommitment(ctx sdk.Context, creator sdk.AccAddress, commitment types.CodeCommitment) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetCodeCommitmentKey(creator, commitment.Checksum))
+	store.Delete(types.GetCodeCommitmentExpiryKey(commitment.ExpiryHeight, creator, commitment.Checksum))
+}
+
+func (k Keeper) importCodeCommitment(ctx sdk.Context, commitment types.CodeCommitment) error {
+	creator, err := sdk.AccAddressFromBech32(commitment.Creator)
+	if err != nil {
+		return sdkerrors.Wrap(err, "creator")
+	}
+	if ctx.KVStore(k.storeKey).Has(types.GetCodeCommitmentKey(creator, commitment.Checksum)) {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "code commitment: %s %X", commitment.Creator, commitment.Checksum)
+	}
+	k.storeCodeCommitment(ctx, creator, commitment)
+	return nil
+}
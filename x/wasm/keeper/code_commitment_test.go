@@ -0,0 +1,150 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/rand"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestCommitAndRevealCode(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	k := keepers.WasmKeeper
+	k.wasmVM = &mock
+	params := types.DefaultParams()
+	params.CodeCommitmentWindow = 10
+	k.setParams(ctx, params)
+
+	creator := RandomAccountAddress(t)
+	wasmCode := append(wasmIdent, rand.Bytes(10)...) //nolint:gocritic
+	checksum := sha256.Sum256(wasmCode)
+	ctx = ctx.WithBlockHeight(100)
+
+	// when
+	expiry, err := keepers.ContractKeeper.CommitCodeHash(ctx, creator, checksum[:], uint64(len(wasmCode)), &types.AllowNobody)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, int64(110), expiry)
+	commitment, found := k.GetCodeCommitment(ctx, creator, checksum[:])
+	require.True(t, found)
+	assert.Equal(t, creator.String(), commitment.Creator)
+
+	// and an unexpired commitment can not be replaced
+	_, err = keepers.ContractKeeper.CommitCodeHash(ctx, creator, checksum[:], uint64(len(wasmCode)), nil)
+	assert.True(t, types.ErrDuplicate.Is(err), err)
+	// while others can not block the checksum
+	other := RandomAccountAddress(t)
+	_, err = keepers.ContractKeeper.CommitCodeHash(ctx, other, checksum[:], uint64(len(wasmCode)), nil)
+	require.NoError(t, err)
+
+	// when revealed by an actor without commitment
+	_, err = keepers.ContractKeeper.RevealCode(ctx, RandomAccountAddress(t), checksum[:], wasmCode)
+	assert.True(t, types.ErrNotFound.Is(err), err)
+
+	// when revealed with other code
+	otherCode := append(wasmIdent, rand.Bytes(10)...) //nolint:gocritic
+	_, err = keepers.ContractKeeper.RevealCode(ctx, creator, checksum[:], otherCode)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+
+	// when revealed with the committed code
+	codeID, err := keepers.ContractKeeper.RevealCode(ctx.WithBlockHeight(110), creator, checksum[:], wasmCode)
+	require.NoError(t, err)
+
+	// then the code is stored for the committer
+	codeInfo := k.GetCodeInfo(ctx, codeID)
+	require.NotNil(t, codeInfo)
+	assert.Equal(t, creator.String(), codeInfo.Creator)
+	assert.Equal(t, checksum[:], codeInfo.CodeHash)
+	assert.Equal(t, types.AllowNobody, codeInfo.InstantiateConfig)
+	_, found = k.GetCodeCommitment(ctx, creator, checksum[:])
+	assert.False(t, found)
+	// and the commitment of the other actor is kept
+	_, found = k.GetCodeCommitment(ctx, other, checksum[:])
+	assert.True(t, found)
+
+	// and can not be revealed twice
+	_, err = keepers.ContractKeeper.RevealCode(ctx, creator, checksum[:], wasmCode)
+	assert.True(t, types.ErrNotFound.Is(err), err)
+}
+
+func TestRevealCodeAfterExpiry(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	keepers.WasmKeeper.wasmVM = &mock
+	params := types.DefaultParams()
+	params.CodeCommitmentWindow = 10
+	keepers.WasmKeeper.setParams(ctx, params)
+
+	creator := RandomAccountAddress(t)
+	wasmCode := append(wasmIdent, rand.Bytes(10)...) //nolint:gocritic
+	checksum := sha256.Sum256(wasmCode)
+	ctx = ctx.WithBlockHeight(100)
+	_, err := keepers.ContractKeeper.CommitCodeHash(ctx, creator, checksum[:], uint64(len(wasmCode)), nil)
+	require.NoError(t, err)
+
+	// when
+	_, err = keepers.ContractKeeper.RevealCode(ctx.WithBlockHeight(111), creator, checksum[:], wasmCode)
+
+	// then
+	assert.True(t, types.ErrInvalid.Is(err), err)
+
+	// and an expired commitment can be replaced
+	expiry, err := keepers.ContractKeeper.CommitCodeHash(ctx.WithBlockHeight(111), creator, checksum[:], uint64(len(wasmCode)), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(121), expiry)
+}
+
+func TestPruneExpiredCodeCommitments(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	params := types.DefaultParams()
+	params.CodeCommitmentWindow = 10
+	k.setParams(ctx, params)
+
+	creator := RandomAccountAddress(t)
+	expiringChecksum, otherChecksum := sha256.Sum256([]byte("code1")), sha256.Sum256([]byte("code2"))
+	_, err := keepers.ContractKeeper.CommitCodeHash(ctx.WithBlockHeight(100), creator, expiringChecksum[:], 5, nil)
+	require.NoError(t, err)
+	_, err = keepers.ContractKeeper.CommitCodeHash(ctx.WithBlockHeight(101), creator, otherChecksum[:], 5, nil)
+	require.NoError(t, err)
+
+	// when pruned in the last block of the window
+	k.PruneExpiredCodeCommitments(ctx.WithBlockHeight(110))
+
+	// then nothing is deleted
+	_, found := k.GetCodeCommitment(ctx, creator, expiringChecksum[:])
+	assert.True(t, found)
+
+	// when pruned after the window
+	k.PruneExpiredCodeCommitments(ctx.WithBlockHeight(111))
+
+	// then the expired commitment is deleted with its queue entry
+	_, found = k.GetCodeCommitment(ctx, creator, expiringChecksum[:])
+	assert.False(t, found)
+	assert.False(t, ctx.KVStore(k.storeKey).Has(types.GetCodeCommitmentExpiryKey(110, creator, expiringChecksum[:])))
+	_, found = k.GetCodeCommitment(ctx, creator, otherChecksum[:])
+	assert.True(t, found)
+	var got []types.CodeCommitment
+	k.IterateCodeCommitments(ctx, func(c types.CodeCommitment) bool {
+		got = append(got, c)
+		return false
+	})
+	assert.Len(t, got, 1)
+}
+
+func TestCommitCodeHashDisabled(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	checksum := sha256.Sum256([]byte("code"))
+
+	_, err := keepers.ContractKeeper.CommitCodeHash(ctx, RandomAccountAddress(t), checksum[:], 4, nil)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+}
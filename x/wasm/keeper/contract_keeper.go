@@ -11,12 +11,23 @@ var _ types.ContractOpsKeeper = PermissionedKeeper{}
 // decoratedKeeper contains a subset of the wasm keeper that are already or can be guarded by an authorization policy in the future
 type decoratedKeeper interface {
 	create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ AuthorizationPolicy) (codeID uint64, err error)
+	commitCodeHash(ctx sdk.Context, creator sdk.AccAddress, checksum []byte, codeSize uint64, instantiateAccess *types.AccessConfig, authZ AuthorizationPolicy) (int64, error)
+	revealCode(ctx sdk.Context, creator sdk.AccAddress, checksum []byte, wasmCode []byte, authZ AuthorizationPolicy) (uint64, error)
 	instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error)
+	instantiateGovManaged(ctx sdk.Context, codeID uint64, creator sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error)
 	migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) ([]byte, error)
 	setContractAdmin(ctx sdk.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ AuthorizationPolicy) error
+	setContractPaused(ctx sdk.Context, contractAddress, caller sdk.AccAddress, paused bool, authZ AuthorizationPolicy) error
+	setContractMigratePermission(ctx sdk.Context, contractAddress, caller sdk.AccAddress, permission *types.AccessConfig, authZ AuthorizationPolicy) error
+	setContractExecutePermission(ctx sdk.Context, contractAddress, caller sdk.AccAddress, permission *types.AccessConfig, authZ AuthorizationPolicy) error
+	setContractAcceptedDenoms(ctx sdk.Context, contractAddress, caller sdk.AccAddress, denoms []string, authZ AuthorizationPolicy) error
 	pinCode(ctx sdk.Context, codeID uint64) error
 	unpinCode(ctx sdk.Context, codeID uint64) error
+	removeCode(ctx sdk.Context, codeID uint64) error
+	reserveContractAddress(ctx sdk.Context, contractAddress, creator sdk.AccAddress, codeHash []byte) error
 	execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error)
+	setFundsTimelock(ctx sdk.Context, contractAddr, depositor sdk.AccAddress, amount sdk.Coins, unlockHeight int64, unlockTime uint64) (uint64, error)
+	releaseTimelockedFunds(ctx sdk.Context, contractAddr sdk.AccAddress, lockID uint64) error
 	setContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra types.ContractInfoExtension) error
 }
 
@@ -41,14 +52,34 @@ func (p PermissionedKeeper) Create(ctx sdk.Context, creator sdk.AccAddress, wasm
 	return p.nested.create(ctx, creator, wasmCode, instantiateAccess, p.authZPolicy)
 }
 
+func (p PermissionedKeeper) CommitCodeHash(ctx sdk.Context, creator sdk.AccAddress, checksum []byte, codeSize uint64, instantiateAccess *types.AccessConfig) (int64, error) {
+	return p.nested.commitCodeHash(ctx, creator, checksum, codeSize, instantiateAccess, p.authZPolicy)
+}
+
+func (p PermissionedKeeper) RevealCode(ctx sdk.Context, creator sdk.AccAddress, checksum []byte, wasmCode []byte) (uint64, error) {
+	return p.nested.revealCode(ctx, creator, checksum, wasmCode, p.authZPolicy)
+}
+
 func (p PermissionedKeeper) Instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins) (sdk.AccAddress, []byte, error) {
 	return p.nested.instantiate(ctx, codeID, creator, admin, initMsg, label, deposit, p.authZPolicy)
 }
 
+func (p PermissionedKeeper) InstantiateGovManaged(ctx sdk.Context, codeID uint64, creator sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins) (sdk.AccAddress, []byte, error) {
+	return p.nested.instantiateGovManaged(ctx, codeID, creator, initMsg, label, deposit, p.authZPolicy)
+}
+
 func (p PermissionedKeeper) Execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
 	return p.nested.execute(ctx, contractAddress, caller, msg, coins)
 }
 
+func (p PermissionedKeeper) SetFundsTimelock(ctx sdk.Context, contractAddress, depositor sdk.AccAddress, amount sdk.Coins, unlockHeight int64, unlockTime uint64) (uint64, error) {
+	return p.nested.setFundsTimelock(ctx, contractAddress, depositor, amount, unlockHeight, unlockTime)
+}
+
+func (p PermissionedKeeper) ReleaseTimelockedFunds(ctx sdk.Context, contractAddress sdk.AccAddress, lockID uint64) error {
+	return p.nested.releaseTimelockedFunds(ctx, contractAddress, lockID)
+}
+
 func (p PermissionedKeeper) Migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte) ([]byte, error) {
 	return p.nested.migrate(ctx, contractAddress, caller, newCodeID, msg, p.authZPolicy)
 }
@@ -61,6 +92,22 @@ func (p PermissionedKeeper) ClearContractAdmin(ctx sdk.Context, contractAddress
 	return p.nested.setContractAdmin(ctx, contractAddress, caller, nil, p.authZPolicy)
 }
 
+func (p PermissionedKeeper) SetContractPaused(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, paused bool) error {
+	return p.nested.setContractPaused(ctx, contractAddress, caller, paused, p.authZPolicy)
+}
+
+func (p PermissionedKeeper) SetContractMigratePermission(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, permission *types.AccessConfig) error {
+	return p.nested.setContractMigratePermission(ctx, contractAddress, caller, permission, p.authZPolicy)
+}
+
+func (p PermissionedKeeper) SetContractExecutePermission(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, permission *types.AccessConfig) error {
+	return p.nested.setContractExecutePermission(ctx, contractAddress, caller, permission, p.authZPolicy)
+}
+
+func (p PermissionedKeeper) SetContractAcceptedDenoms(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, denoms []string) error {
+	return p.nested.setContractAcceptedDenoms(ctx, contractAddress, caller, denoms, p.authZPolicy)
+}
+
 func (p PermissionedKeeper) PinCode(ctx sdk.Context, codeID uint64) error {
 	return p.nested.pinCode(ctx, codeID)
 }
@@ -69,6 +116,14 @@ func (p PermissionedKeeper) UnpinCode(ctx sdk.Context, codeID uint64) error {
 	return p.nested.unpinCode(ctx, codeID)
 }
 
+func (p PermissionedKeeper) RemoveCode(ctx sdk.Context, codeID uint64) error {
+	return p.nested.removeCode(ctx, codeID)
+}
+
+func (p PermissionedKeeper) ReserveContractAddress(ctx sdk.Context, contractAddress, creator sdk.AccAddress, codeHash []byte) error {
+	return p.nested.reserveContractAddress(ctx, contractAddress, creator, codeHash)
+}
+
 // SetExtraContractAttributes updates the extra attributes that can be stored with the contract info
 func (p PermissionedKeeper) SetContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra types.ContractInfoExtension) error {
 	return p.nested.setContractInfoExtension(ctx, contract, extra)
@@ -4,10 +4,13 @@ import (
 	"context"
 	"testing"
 
+	wasmvm "github.com/CosmWasm/wasmvm"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
@@ -96,6 +99,15 @@ func TestNewCustomEvents(t *testing.T) {
 					sdk.NewAttribute("otherKey", "otherVal")),
 			},
 		},
+		"native module event type": {
+			src: wasmvmtypes.Events{{
+				Type:       "transfer",
+				Attributes: []wasmvmtypes.EventAttribute{{Key: "recipient", Value: "myVal"}},
+			}},
+			exp: sdk.Events{sdk.NewEvent("wasm-transfer",
+				sdk.NewAttribute("_contract_address", myContract.String()),
+				sdk.NewAttribute("recipient", "myVal"))},
+		},
 		"without attributes": {
 			src: wasmvmtypes.Events{{
 				Type: "foo",
@@ -197,6 +209,37 @@ func TestNewCustomEvents(t *testing.T) {
 	}
 }
 
+func TestContractCanNotEmitNativeModuleEvents(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{Events: wasmvmtypes.Events{
+			{Type: "transfer", Attributes: []wasmvmtypes.EventAttribute{{Key: "recipient", Value: RandomBech32AccountAddress(t)}}},
+			{Type: "message", Attributes: []wasmvmtypes.EventAttribute{{Key: "sender", Value: RandomBech32AccountAddress(t)}}},
+		}}, 0, nil
+	}
+	em := sdk.NewEventManager()
+
+	// when
+	_, err := keepers.ContractKeeper.Execute(ctx.WithEventManager(em), example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+	// then
+	require.NoError(t, err)
+	var gotTypes []string
+	for _, e := range em.Events() {
+		gotTypes = append(gotTypes, e.Type)
+		if e.Type == "wasm-transfer" || e.Type == "wasm-message" {
+			assert.Equal(t, types.AttributeKeyContractAddr, string(e.Attributes[0].Key))
+			assert.Equal(t, example.Contract.String(), string(e.Attributes[0].Value))
+		}
+	}
+	assert.Contains(t, gotTypes, "wasm-transfer")
+	assert.Contains(t, gotTypes, "wasm-message")
+	assert.NotContains(t, gotTypes, "transfer")
+}
+
 func TestNewWasmModuleEvent(t *testing.T) {
 	myContract := RandomAccountAddress(t)
 	specs := map[string]struct {
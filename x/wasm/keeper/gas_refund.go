@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// deletionTrackingStore counts deletions of existing entries with the gas refund tracker. The existence check is
+// done on the parent store without gas metering so that the tracking does not change the gas consumed.
+type deletionTrackingStore struct {
+	sdk.KVStore
+	parent  sdk.KVStore
+	tracker *types.GasRefundTracker
+}
+
+// Delete deletes the key and counts it for the gas refund when an entry existed
+func (s deletionTrackingStore) Delete(key []byte) {
+	if s.parent.Has(key) {
+		s.tracker.AddDeletedKey()
+	}
+	s.KVStore.Delete(key)
+}
+
+// contractStore returns the prefixed state store of the given contract. When gas refunds are tracked for the
+// current message, deletions in the store are counted. Iterators are limited by the max iterator items param.
+// Writes reset the query cache of the transaction.
+func (k Keeper) contractStore(ctx sdk.Context, contractAddress sdk.AccAddress) prefix.Store {
+	var store sdk.KVStore = ctx.KVStore(k.storeKey)
+	if limit := k.GetMaxIteratorItems(ctx); limit != 0 {
+		store = iteratorLimitStore{KVStore: store, limit: limit}
+	}
+	if tracker := types.GasRefundTrackerFromContext(ctx); tracker != nil {
+		store = deletionTrackingStore{KVStore: store, parent: ctx.MultiStore().GetKVStore(k.storeKey), tracker: tracker}
+	}
+	if cache := types.QueryCacheFromContext(ctx); cache != nil {
+		store = queryCacheResettingStore{KVStore: store, cache: cache}
+	}
+	return prefix.NewStore(store, types.GetContractStorePrefix(contractAddress))
+}
+
+// trackGasRefund starts the gas refund tracking for a contract message when enabled by the max gas refund percent
+// param. The returned function must be called when the message succeeded to refund the gas for the contract state
+// deletions. The refund is capped to the max gas refund percent of the gas used by the message.
+// Nested contract calls are tracked with the message they belong to. No gas is refunded when the gas register does not
+// implement DeletionRefundGasRegister.
+func (k Keeper) trackGasRefund(ctx sdk.Context) (sdk.Context, func()) {
+	if types.GasRefundTrackerFromContext(ctx) != nil {
+		return ctx, func() {}
+	}
+	gasRegister, ok := k.gasRegister.(DeletionRefundGasRegister)
+	if !ok {
+		return ctx, func() {}
+	}
+	percent := k.GetMaxGasRefundPercent(ctx)
+	if percent == 0 {
+		return ctx, func() {}
+	}
+	tracker := types.NewGasRefundTracker()
+	gasBefore := ctx.GasMeter().GasConsumed()
+	return types.WithGasRefundTracker(ctx, tracker), func() {
+		refund := gasRegister.DeletionRefund(tracker.DeletedKeys())
+		if maxRefund := (ctx.GasMeter().GasConsumed() - gasBefore) / 100 * uint64(percent); refund > maxRefund {
+			refund = maxRefund
+		}
+		if refund != 0 {
+			ctx.GasMeter().RefundGas(refund, "wasm contract state deletion refund")
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package keeper
 
 import (
+	"bytes"
+	"encoding/binary"
+
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -33,10 +36,15 @@ const (
 	// DefaultInstanceCost is how much SDK gas we charge each time we load a WASM instance.
 	// Creating a new instance is costly, and this helps put a recursion limit to contracts calling contracts.
 	// Benchmarks and numbers were discussed in: https://github.com/CosmWasm/wasmd/pull/634#issuecomment-938056803
-	DefaultInstanceCost uint64 = 60_000
+	// The value can be tuned with the `instance_cost` module param.
+	DefaultInstanceCost = types.DefaultInstanceCost
 	// DefaultCompileCost is how much SDK gas is charged *per byte* for compiling WASM code.
 	// Benchmarks and numbers were discussed in: https://github.com/CosmWasm/wasmd/pull/634#issuecomment-938056803
-	DefaultCompileCost uint64 = 3
+	// The value can be tuned with the `compile_cost` module param.
+	DefaultCompileCost = types.DefaultCompileCost
+	// DefaultCompileComplexityCost is how much SDK gas is charged per unit of static code complexity for compiling
+	// WASM code. See wasmComplexity for how the complexity is measured.
+	DefaultCompileComplexityCost uint64 = 50
 	// DefaultEventAttributeDataCost is how much SDK gas is charged *per byte* for attribute data in events.
 	// This is used with len(key) + len(value)
 	DefaultEventAttributeDataCost uint64 = 1
@@ -52,6 +60,27 @@ const (
 	DefaultPerCustomEventCost uint64 = 20
 	// DefaultEventAttributeDataFreeTier number of bytes of total attribute data we do not charge.
 	DefaultEventAttributeDataFreeTier = 100
+	// DefaultSecp256k1VerifyCost is how much SDK gas is charged for a secp256k1 signature verification requested by a
+	// contract. The value matches the x/auth default for tx signatures.
+	DefaultSecp256k1VerifyCost uint64 = 1000
+	// DefaultEd25519VerifyCost is how much SDK gas is charged for an ed25519 signature verification requested by a
+	// contract. The value matches the x/auth default for tx signatures.
+	DefaultEd25519VerifyCost uint64 = 590
+	// DefaultDeletionRefund is how much SDK gas is refunded per deleted contract state entry when gas refunds are
+	// enabled with the `max_gas_refund_percent` module param. The value matches the flat SDK costs for a write.
+	DefaultDeletionRefund uint64 = 2000
+	// DefaultHashFlatCost is how much SDK gas is charged for a hash computation requested by a contract
+	DefaultHashFlatCost uint64 = 100
+	// DefaultHashPerByteCost is how much SDK gas is charged *per byte* of data hashed for a contract.
+	// The value matches the SDK costs to read a byte from the store.
+	DefaultHashPerByteCost uint64 = 3
+	// DefaultContractBalanceFlatCost is how much SDK gas is charged once for a contract balance chain query. The
+	// balances of the contract are read with a single iteration, so the SDK flat costs of an iteration step are
+	// charged once instead of per balance.
+	DefaultContractBalanceFlatCost uint64 = 30
+	// DefaultContractBalancePerByteCost is how much SDK gas is charged *per byte* of the stored denom key and coin
+	// value read by a contract balance chain query.
+	DefaultContractBalancePerByteCost uint64 = 1
 )
 
 // GasRegister abstract source for gas costs
@@ -72,12 +101,51 @@ type GasRegister interface {
 	FromWasmVMGas(source uint64) sdk.Gas
 }
 
+// CostParamsGasRegister is implemented by gas registers that support the instance and compile costs
+// to be set by the module params.
+type CostParamsGasRegister interface {
+	// WithInstanceCost returns a copy of the gas register with the given instance cost
+	WithInstanceCost(cost sdk.Gas) GasRegister
+	// WithCompileCost returns a copy of the gas register with the given compile cost
+	WithCompileCost(cost sdk.Gas) GasRegister
+}
+
+// CompileComplexityGasRegister is implemented by gas registers that charge additional costs for the static code
+// complexity of a new wasm contract. The costs are charged on top of the CompileCosts.
+type CompileComplexityGasRegister interface {
+	// CompileComplexityCosts costs to "compile" the given wasm code by its static complexity
+	CompileComplexityCosts(wasmCode []byte) sdk.Gas
+}
+
+// SignatureVerifyGasRegister is implemented by gas registers that support custom signature verification costs.
+// The default costs are charged for gas registers without support.
+type SignatureVerifyGasRegister interface {
+	// SignatureVerifyCosts costs for a single signature verification with the given algorithm
+	SignatureVerifyCosts(algorithm string) sdk.Gas
+}
+
+// HashGasRegister is implemented by gas registers that support custom hash costs.
+// The default costs are charged for gas registers without support.
+type HashGasRegister interface {
+	// HashCosts costs to hash the given number of bytes
+	HashCosts(dataLen int) sdk.Gas
+}
+
+// DeletionRefundGasRegister is implemented by gas registers that refund gas for deleted contract state entries.
+// No gas is refunded for gas registers without support.
+type DeletionRefundGasRegister interface {
+	// DeletionRefund gas refund for the given number of deleted contract state entries
+	DeletionRefund(deletedKeys uint64) sdk.Gas
+}
+
 // WasmGasRegisterConfig config type
 type WasmGasRegisterConfig struct {
 	// InstanceCost costs when interacting with a wasm contract
 	InstanceCost sdk.Gas
 	// CompileCosts costs to persist and "compile" a new wasm contract
 	CompileCost sdk.Gas
+	// CompileComplexityCost costs per unit of static code complexity to "compile" a new wasm contract
+	CompileComplexityCost sdk.Gas
 	// GasMultiplier is how many cosmwasm gas points = 1 sdk gas point
 	// SDK reference costs can be found here: https://github.com/cosmos/cosmos-sdk/blob/02c6c9fafd58da88550ab4d7d494724a477c8a68/store/types/gas.go#L153-L164
 	GasMultiplier sdk.Gas
@@ -94,6 +162,16 @@ type WasmGasRegisterConfig struct {
 	ContractMessageDataCost sdk.Gas
 	// CustomEventCost cost per custom event
 	CustomEventCost uint64
+	// Secp256k1VerifyCost SDK gas charged per secp256k1 signature verification
+	Secp256k1VerifyCost sdk.Gas
+	// Ed25519VerifyCost SDK gas charged per ed25519 signature verification
+	Ed25519VerifyCost sdk.Gas
+	// DeletionRefund SDK gas refunded per deleted contract state entry
+	DeletionRefund sdk.Gas
+	// HashFlatCost SDK gas charged per hash computation
+	HashFlatCost sdk.Gas
+	// HashPerByteCost SDK gas charged *per byte* of hashed data
+	HashPerByteCost sdk.Gas
 }
 
 // DefaultGasRegisterConfig default values
@@ -101,12 +179,18 @@ func DefaultGasRegisterConfig() WasmGasRegisterConfig {
 	return WasmGasRegisterConfig{
 		InstanceCost:               DefaultInstanceCost,
 		CompileCost:                DefaultCompileCost,
+		CompileComplexityCost:      DefaultCompileComplexityCost,
 		GasMultiplier:              DefaultGasMultiplier,
 		EventPerAttributeCost:      DefaultPerAttributeCost,
 		CustomEventCost:            DefaultPerCustomEventCost,
 		EventAttributeDataCost:     DefaultEventAttributeDataCost,
 		EventAttributeDataFreeTier: DefaultEventAttributeDataFreeTier,
 		ContractMessageDataCost:    DefaultContractMessageDataCost,
+		Secp256k1VerifyCost:        DefaultSecp256k1VerifyCost,
+		Ed25519VerifyCost:          DefaultEd25519VerifyCost,
+		DeletionRefund:             DefaultDeletionRefund,
+		HashFlatCost:               DefaultHashFlatCost,
+		HashPerByteCost:            DefaultHashPerByteCost,
 	}
 }
 
@@ -130,6 +214,18 @@ func NewWasmGasRegister(c WasmGasRegisterConfig) WasmGasRegister {
 	}
 }
 
+// WithInstanceCost returns a copy of the gas register with the given instance cost
+func (g WasmGasRegister) WithInstanceCost(cost sdk.Gas) GasRegister {
+	g.c.InstanceCost = cost
+	return g
+}
+
+// WithCompileCost returns a copy of the gas register with the given compile cost
+func (g WasmGasRegister) WithCompileCost(cost sdk.Gas) GasRegister {
+	g.c.CompileCost = cost
+	return g
+}
+
 // NewContractInstanceCosts costs to crate a new contract instance from code
 func (g WasmGasRegister) NewContractInstanceCosts(pinned bool, msgLen int) storetypes.Gas {
 	return g.InstantiateContractCosts(pinned, msgLen)
@@ -143,6 +239,12 @@ func (g WasmGasRegister) CompileCosts(byteLength int) storetypes.Gas {
 	return g.c.CompileCost * uint64(byteLength)
 }
 
+// CompileComplexityCosts costs per unit of wasmComplexity so that small contracts with many functions pay for the
+// additional compile work.
+func (g WasmGasRegister) CompileComplexityCosts(wasmCode []byte) storetypes.Gas {
+	return g.c.CompileComplexityCost * wasmComplexity(wasmCode)
+}
+
 // InstantiateContractCosts costs when interacting with a wasm contract
 func (g WasmGasRegister) InstantiateContractCosts(pinned bool, msgLen int) sdk.Gas {
 	if msgLen < 0 {
@@ -203,6 +305,33 @@ func (g WasmGasRegister) eventAttributeCosts(attrs []wasmvmtypes.EventAttribute,
 	return r.Uint64(), freeTier
 }
 
+// SignatureVerifyCosts costs for a single signature verification with the given algorithm. The costs are
+// charged per verification independent of the result so that they are deterministic.
+func (g WasmGasRegister) SignatureVerifyCosts(algorithm string) sdk.Gas {
+	switch algorithm {
+	case types.SignatureAlgorithmSecp256k1:
+		return g.c.Secp256k1VerifyCost
+	case types.SignatureAlgorithmEd25519:
+		return g.c.Ed25519VerifyCost
+	default:
+		panic(sdkerrors.Wrapf(types.ErrInvalid, "unsupported signature algorithm: %s", algorithm))
+	}
+}
+
+// DeletionRefund gas refund for the given number of deleted contract state entries. The refund is capped by the
+// keeper to a share of the gas used.
+func (g WasmGasRegister) DeletionRefund(deletedKeys uint64) sdk.Gas {
+	return g.c.DeletionRefund * deletedKeys
+}
+
+// HashCosts costs to hash the given number of bytes
+func (g WasmGasRegister) HashCosts(dataLen int) sdk.Gas {
+	if dataLen < 0 {
+		panic(sdkerrors.Wrap(types.ErrInvalid, "negative length"))
+	}
+	return g.c.HashFlatCost + g.c.HashPerByteCost*sdk.Gas(dataLen)
+}
+
 // apply free tier
 func calcWithFreeTier(storedBytes uint64, freeTier uint64) (uint64, uint64) {
 	if storedBytes <= freeTier {
@@ -225,3 +354,37 @@ func (g WasmGasRegister) ToWasmVMGas(source storetypes.Gas) uint64 {
 func (g WasmGasRegister) FromWasmVMGas(source uint64) sdk.Gas {
 	return source / g.c.GasMultiplier
 }
+
+const (
+	// wasmHeaderLen is the length of the magic bytes and the version at the start of a wasm binary
+	wasmHeaderLen = 8
+	// wasmFunctionSectionID is the id of the section that declares the functions defined in the module
+	wasmFunctionSectionID = 3
+)
+
+// wasmComplexity returns a cheap static complexity measure of the wasm code: the number of sections plus the number
+// of functions declared in the function section. Only the section headers and the function count are read, the section
+// content is not validated. The result is bounded by the code length as every section and every declared function
+// takes at least one byte. Parsing stops at the first malformed section and 0 is returned for code without wasm header.
+func wasmComplexity(wasmCode []byte) uint64 {
+	if len(wasmCode) < wasmHeaderLen || !bytes.Equal(wasmCode[:4], []byte("\x00\x61\x73\x6D")) {
+		return 0
+	}
+	var sections, functions uint64
+	for pos := wasmHeaderLen; pos < len(wasmCode); {
+		id := wasmCode[pos]
+		size, n := binary.Uvarint(wasmCode[pos+1:])
+		if n <= 0 || size > uint64(len(wasmCode)-pos-1-n) {
+			break
+		}
+		content := wasmCode[pos+1+n : pos+1+n+int(size)]
+		if id == wasmFunctionSectionID {
+			if count, m := binary.Uvarint(content); m > 0 && count <= uint64(len(content)) {
+				functions += count
+			}
+		}
+		sections++
+		pos += 1 + n + int(size)
+	}
+	return sections + functions
+}
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"context"
 	"math"
 	"strings"
 	"testing"
@@ -9,6 +10,10 @@ import (
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 func TestCompileCosts(t *testing.T) {
@@ -48,6 +53,101 @@ func TestCompileCosts(t *testing.T) {
 	}
 }
 
+func TestCompileComplexityCosts(t *testing.T) {
+	noComplexityCost := DefaultGasRegisterConfig()
+	noComplexityCost.CompileComplexityCost = 0
+	specs := map[string]struct {
+		srcCode   []byte
+		srcConfig WasmGasRegisterConfig
+		exp       sdk.Gas
+	}{
+		"wasm code": {
+			srcCode:   wasmWithFunctions(2),
+			srcConfig: DefaultGasRegisterConfig(),
+			exp:       3 * DefaultCompileComplexityCost, // 1 section + 2 functions
+		},
+		"wasm code without complexity cost": {
+			srcCode:   wasmWithFunctions(2),
+			srcConfig: noComplexityCost,
+			exp:       sdk.Gas(0),
+		},
+		"not wasm": {
+			srcCode:   []byte{1},
+			srcConfig: DefaultGasRegisterConfig(),
+			exp:       sdk.Gas(0),
+		},
+		"nil": {
+			srcConfig: DefaultGasRegisterConfig(),
+			exp:       sdk.Gas(0),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotGas := NewWasmGasRegister(spec.srcConfig).CompileComplexityCosts(spec.srcCode)
+			assert.Equal(t, spec.exp, gotGas)
+		})
+	}
+}
+
+func TestCompileComplexityCostsWithSameSize(t *testing.T) {
+	// given two contracts of the same size
+	simpleCode := append(wasmWithFunctions(1), wasmCustomSection(8)...)
+	complexCode := wasmWithFunctions(9)
+	require.Equal(t, len(simpleCode), len(complexCode))
+
+	// when
+	gasRegister := NewDefaultWasmGasRegister()
+	simpleGas, complexGas := gasRegister.CompileComplexityCosts(simpleCode), gasRegister.CompileComplexityCosts(complexCode)
+
+	// then the one with more functions is charged more
+	assert.Equal(t, simpleGas+7*DefaultCompileComplexityCost, complexGas)
+}
+
+func TestKeeperOptionalGasRegisterFallbacks(t *testing.T) {
+	k := Keeper{gasRegister: wasmtesting.MockGasRegister{}}
+	defaultRegister := NewDefaultWasmGasRegister()
+
+	assert.Equal(t, defaultRegister.SignatureVerifyCosts(types.SignatureAlgorithmEd25519), k.signatureVerifyCosts(types.SignatureAlgorithmEd25519))
+	assert.Equal(t, defaultRegister.HashCosts(32), k.hashCosts(32))
+
+	ctx := sdk.Context{}.WithContext(context.Background())
+	gotCtx, refund := k.trackGasRefund(ctx)
+	assert.Nil(t, types.GasRefundTrackerFromContext(gotCtx))
+	assert.NotPanics(t, refund)
+}
+
+func TestWasmComplexity(t *testing.T) {
+	specs := map[string]struct {
+		src []byte
+		exp uint64
+	}{
+		"header only":              {src: wasmWithFunctions(0)[:wasmHeaderLen], exp: 0},
+		"function section":         {src: wasmWithFunctions(3), exp: 4},
+		"multiple sections":        {src: append(wasmWithFunctions(3), wasmCustomSection(2)...), exp: 5},
+		"not wasm":                 {src: []byte("not a wasm binary"), exp: 0},
+		"empty":                    {src: []byte{}, exp: 0},
+		"truncated section":        {src: wasmWithFunctions(3)[:wasmHeaderLen+3], exp: 0},
+		"function count too large": {src: append(wasmWithFunctions(0)[:wasmHeaderLen], wasmFunctionSectionID, 1, 100), exp: 1},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, wasmComplexity(spec.src))
+		})
+	}
+}
+
+// wasmWithFunctions returns a wasm header with a function section that declares n functions of type 0
+func wasmWithFunctions(n int) []byte {
+	r := []byte("\x00\x61\x73\x6D\x01\x00\x00\x00")
+	r = append(r, wasmFunctionSectionID, byte(n+1), byte(n))
+	return append(r, make([]byte, n)...)
+}
+
+// wasmCustomSection returns a custom section of the given total length
+func wasmCustomSection(n int) []byte {
+	return append([]byte{0, byte(n - 2)}, make([]byte, n-2)...)
+}
+
 func TestNewContractInstanceCosts(t *testing.T) {
 	specs := map[string]struct {
 		srcLen    int
@@ -382,3 +482,91 @@ func TestFromWasmVMGasConversion(t *testing.T) {
 		})
 	}
 }
+
+func TestSignatureVerifyCosts(t *testing.T) {
+	specs := map[string]struct {
+		srcConfig    WasmGasRegisterConfig
+		srcAlgorithm string
+		exp          sdk.Gas
+		expPanic     bool
+	}{
+		"secp256k1 default": {
+			srcConfig:    DefaultGasRegisterConfig(),
+			srcAlgorithm: types.SignatureAlgorithmSecp256k1,
+			exp:          DefaultSecp256k1VerifyCost,
+		},
+		"ed25519 default": {
+			srcConfig:    DefaultGasRegisterConfig(),
+			srcAlgorithm: types.SignatureAlgorithmEd25519,
+			exp:          DefaultEd25519VerifyCost,
+		},
+		"secp256k1 custom": {
+			srcConfig:    WasmGasRegisterConfig{GasMultiplier: 1, Secp256k1VerifyCost: 1},
+			srcAlgorithm: types.SignatureAlgorithmSecp256k1,
+			exp:          1,
+		},
+		"ed25519 custom": {
+			srcConfig:    WasmGasRegisterConfig{GasMultiplier: 1, Ed25519VerifyCost: 2},
+			srcAlgorithm: types.SignatureAlgorithmEd25519,
+			exp:          2,
+		},
+		"unknown algorithm": {
+			srcConfig:    DefaultGasRegisterConfig(),
+			srcAlgorithm: "unknown",
+			expPanic:     true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			if spec.expPanic {
+				assert.Panics(t, func() {
+					NewWasmGasRegister(spec.srcConfig).SignatureVerifyCosts(spec.srcAlgorithm)
+				})
+				return
+			}
+			gotGas := NewWasmGasRegister(spec.srcConfig).SignatureVerifyCosts(spec.srcAlgorithm)
+			assert.Equal(t, spec.exp, gotGas)
+		})
+	}
+}
+
+func TestHashCosts(t *testing.T) {
+	specs := map[string]struct {
+		srcConfig WasmGasRegisterConfig
+		srcLen    int
+		exp       sdk.Gas
+		expPanic  bool
+	}{
+		"empty data": {
+			srcConfig: DefaultGasRegisterConfig(),
+			exp:       DefaultHashFlatCost,
+		},
+		"with data": {
+			srcConfig: DefaultGasRegisterConfig(),
+			srcLen:    10,
+			exp:       DefaultHashFlatCost + 10*DefaultHashPerByteCost,
+		},
+		"custom costs": {
+			srcConfig: WasmGasRegisterConfig{GasMultiplier: 1, HashFlatCost: 1, HashPerByteCost: 2},
+			srcLen:    10,
+			exp:       21,
+		},
+		"negative length": {
+			srcConfig: DefaultGasRegisterConfig(),
+			srcLen:    -1,
+			expPanic:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			if spec.expPanic {
+				assert.Panics(t, func() {
+					NewWasmGasRegister(spec.srcConfig).HashCosts(spec.srcLen)
+				})
+				return
+			}
+			gotGas := NewWasmGasRegister(spec.srcConfig).HashCosts(spec.srcLen)
+			assert.Equal(t, spec.exp, gotGas)
+		})
+	}
+}
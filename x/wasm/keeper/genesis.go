@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -36,6 +38,8 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, staki
 	}
 
 	var maxContractID int
+	var maxLockID uint64
+	var reinitContracts []int
 	for i, contract := range data.Contracts {
 		contractAddr, err := sdk.AccAddressFromBech32(contract.ContractAddress)
 		if err != nil {
@@ -45,6 +49,21 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, staki
 		if err != nil {
 			return nil, sdkerrors.Wrapf(err, "contract number %d", i)
 		}
+		if err := keeper.importTimelockedFunds(ctx, contractAddr, contract.TimelockedFunds); err != nil {
+			return nil, sdkerrors.Wrapf(err, "timelocked funds of contract number %d", i)
+		}
+		if err := keeper.importInFlightPackets(ctx, contractAddr, contract.InFlightPackets); err != nil {
+			return nil, sdkerrors.Wrapf(err, "in-flight packets of contract number %d", i)
+		}
+		keeper.setMigrationProgress(ctx, contractAddr, contract.MigrationProgress)
+		for _, lock := range contract.TimelockedFunds {
+			if lock.ID > maxLockID {
+				maxLockID = lock.ID
+			}
+		}
+		if contract.ReinitMsg != nil {
+			reinitContracts = append(reinitContracts, i)
+		}
 		maxContractID = i + 1 // not ideal but max(contractID) is not persisted otherwise
 	}
 
@@ -64,6 +83,31 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, staki
 	if seqVal <= uint64(maxContractID) {
 		return nil, sdkerrors.Wrapf(types.ErrInvalid, "seq %s with value: %d must be greater than: %d ", string(types.KeyLastInstanceID), seqVal, maxContractID)
 	}
+	seqVal = keeper.PeekAutoIncrementID(ctx, types.KeyLastTimelockID)
+	if seqVal <= maxLockID {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "seq %s with value: %d must be greater than: %d ", string(types.KeyLastTimelockID), seqVal, maxLockID)
+	}
+
+	for i, commitment := range data.CodeCommitments {
+		if err := keeper.importCodeCommitment(ctx, commitment); err != nil {
+			return nil, sdkerrors.Wrapf(err, "code commitment number %d", i)
+		}
+	}
+	for i, reservation := range data.ReservedContractAddresses {
+		if err := keeper.importReservedContractAddress(ctx, reservation); err != nil {
+			return nil, sdkerrors.Wrapf(err, "reserved contract address number %d", i)
+		}
+	}
+	keeper.importAverageBlockTime(ctx, time.Duration(data.AverageBlockTime))
+
+	// contracts are re-initialized when all contracts and sequences are imported so that they can call other contracts
+	for _, i := range reinitContracts {
+		contract := data.Contracts[i]
+		contractAddr, _ := sdk.AccAddressFromBech32(contract.ContractAddress) // address was validated on import
+		if err := keeper.reinstantiateContract(ctx, contractAddr, contract.ReinitMsg); err != nil {
+			return nil, sdkerrors.Wrapf(err, "reinit contract number %d", i)
+		}
+	}
 
 	if len(data.GenMsgs) == 0 {
 		return nil, nil
@@ -87,6 +131,12 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 
 	genState.Params = keeper.GetParams(ctx)
 
+	// pinned codes are exported with the pinned flag of the code so that they are re-pinned on import
+	pinnedCodeIDs := keeper.GetPinnedCodeIDs(ctx)
+	pinned := make(map[uint64]bool, len(pinnedCodeIDs))
+	for _, codeID := range pinnedCodeIDs {
+		pinned[codeID] = true
+	}
 	keeper.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
 		bytecode, err := keeper.GetByteCode(ctx, codeID)
 		if err != nil {
@@ -96,10 +146,16 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 			CodeID:    codeID,
 			CodeInfo:  info,
 			CodeBytes: bytecode,
-			Pinned:    keeper.IsPinnedCode(ctx, codeID),
+			Pinned:    pinned[codeID],
 		})
+		delete(pinned, codeID)
 		return false
 	})
+	for _, codeID := range pinnedCodeIDs {
+		if pinned[codeID] {
+			panic(sdkerrors.Wrapf(types.ErrNotFound, "code info for pinned code: %d", codeID))
+		}
+	}
 
 	keeper.IterateContractInfo(ctx, func(addr sdk.AccAddress, contract types.ContractInfo) bool {
 		contractStateIterator := keeper.GetContractState(ctx, addr)
@@ -111,12 +167,25 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 			}
 			state = append(state, m)
 		}
+		var locks []types.TimelockedFunds
+		keeper.IterateTimelockedFunds(ctx, addr, func(lock types.TimelockedFunds) bool {
+			locks = append(locks, lock)
+			return false
+		})
+		var packets []types.InFlightPacket
+		keeper.IterateInFlightPackets(ctx, addr, func(packet types.InFlightPacket) bool {
+			packets = append(packets, packet)
+			return false
+		})
 		// redact contract info
 		contract.Created = nil
 		genState.Contracts = append(genState.Contracts, types.Contract{
-			ContractAddress: addr.String(),
-			ContractInfo:    contract,
-			ContractState:   state,
+			ContractAddress:   addr.String(),
+			ContractInfo:      contract,
+			ContractState:     state,
+			TimelockedFunds:   locks,
+			MigrationProgress: keeper.GetMigrationProgress(ctx, addr),
+			InFlightPackets:   packets,
 		})
 
 		return false
@@ -128,6 +197,23 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 			Value: keeper.PeekAutoIncrementID(ctx, k),
 		})
 	}
+	keeper.IterateCodeCommitments(ctx, func(commitment types.CodeCommitment) bool {
+		genState.CodeCommitments = append(genState.CodeCommitments, commitment)
+		return false
+	})
+	keeper.IterateReservedContractAddresses(ctx, func(reservation types.ReservedContractAddress) bool {
+		genState.ReservedContractAddresses = append(genState.ReservedContractAddresses, reservation)
+		return false
+	})
+	genState.AverageBlockTime = int64(keeper.GetAverageBlockTime(ctx))
+
+	// the timelock sequence is only set once funds were locked
+	if ctx.KVStore(keeper.storeKey).Has(types.KeyLastTimelockID) {
+		genState.Sequences = append(genState.Sequences, types.Sequence{
+			IDKey: types.KeyLastTimelockID,
+			Value: keeper.PeekAutoIncrementID(ctx, types.KeyLastTimelockID),
+		})
+	}
 
 	return &genState
 }
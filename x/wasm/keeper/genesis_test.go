@@ -17,6 +17,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	address "github.com/cosmos/cosmos-sdk/types/address"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	distributionkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
@@ -32,6 +33,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	wasmTypes "github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -58,6 +60,8 @@ func TestGenesisExportImport(t *testing.T) {
 			history           []types.ContractCodeHistoryEntry
 			pinned            bool
 			contractExtension bool
+			migrationProgress []byte
+			inFlightPackets   []types.InFlightPacket
 		)
 		f.Fuzz(&codeInfo)
 		f.Fuzz(&contract)
@@ -65,6 +69,8 @@ func TestGenesisExportImport(t *testing.T) {
 		f.NilChance(0).Fuzz(&history)
 		f.Fuzz(&pinned)
 		f.Fuzz(&contractExtension)
+		f.Fuzz(&migrationProgress)
+		f.Fuzz(&inFlightPackets)
 
 		creatorAddr, err := sdk.AccAddressFromBech32(codeInfo.Creator)
 		require.NoError(t, err)
@@ -85,12 +91,26 @@ func TestGenesisExportImport(t *testing.T) {
 		contract.CodeID = codeID
 		contractAddr := wasmKeeper.generateContractAddress(srcCtx, codeID)
 		wasmKeeper.storeContractInfo(srcCtx, contractAddr, &contract)
+		if !contract.Terminated {
+			wasmKeeper.addCodeInstanceCount(srcCtx, codeID, 1)
+		}
 		wasmKeeper.appendToContractHistory(srcCtx, contractAddr, history...)
-		wasmKeeper.importContractState(srcCtx, contractAddr, stateModels)
+		wasmKeeper.ImportContractState(srcCtx, contractAddr, stateModels)
+		wasmKeeper.setMigrationProgress(srcCtx, contractAddr, migrationProgress)
+		require.NoError(t, wasmKeeper.importInFlightPackets(srcCtx, contractAddr, inFlightPackets))
+
+		var reservedAddr, reservedFor sdk.AccAddress
+		f.Fuzz(&reservedAddr)
+		f.Fuzz(&reservedFor)
+		wasmKeeper.storeReservedContractAddress(srcCtx, reservedAddr, reservedFor, wasmKeeper.GetCodeInfo(srcCtx, codeID).CodeHash)
 	}
 	var wasmParams types.Params
 	f.NilChance(0).Fuzz(&wasmParams)
 	wasmKeeper.setParams(srcCtx, wasmParams)
+	var lastBlockTime, averageBlockTime uint32
+	f.Fuzz(&lastBlockTime)
+	f.Fuzz(&averageBlockTime)
+	srcCtx.KVStore(wasmKeeper.storeKey).Set(types.BlockTimeAveragePrefix, encodeBlockTimeAverage(int64(lastBlockTime)+1, int64(averageBlockTime)+1))
 
 	// export
 	exportedState := ExportGenesis(srcCtx, wasmKeeper)
@@ -121,10 +141,16 @@ func TestGenesisExportImport(t *testing.T) {
 		newHistory := x.ResetFromGenesis(dstCtx)
 		wasmKeeper.storeContractInfo(srcCtx, address, x)
 		wasmKeeper.addToContractCodeSecondaryIndex(srcCtx, address, newHistory)
+		creatorAddress, err := sdk.AccAddressFromBech32(info.Creator)
+		require.NoError(t, err)
+		wasmKeeper.addToContractCreatorSecondaryIndex(srcCtx, creatorAddress, newHistory.Updated, address)
 		wasmKeeper.appendToContractHistory(srcCtx, address, newHistory)
 		return false
 	})
 
+	// reset the time of the last block in source DB as it is not exported
+	wasmKeeper.importAverageBlockTime(srcCtx, time.Duration(averageBlockTime)+1)
+
 	// re-import
 	var importState wasmTypes.GenesisState
 	err = dstKeeper.cdc.UnmarshalJSON(exportedGenesis, &importState)
@@ -149,6 +175,41 @@ func TestGenesisExportImport(t *testing.T) {
 	}
 }
 
+func TestGenesisExportImportPinnedCodes(t *testing.T) {
+	srcKeeper, srcCtx, _ := setupKeeper(t)
+	contractKeeper := NewGovPermissionKeeper(srcKeeper)
+	srcKeeper.setParams(srcCtx, types.DefaultParams())
+
+	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+	creator := RandomAccountAddress(t)
+	var codeIDs []uint64
+	for i := 0; i < 3; i++ {
+		codeID, err := contractKeeper.Create(srcCtx, creator, wasmCode, nil)
+		require.NoError(t, err)
+		codeIDs = append(codeIDs, codeID)
+	}
+	require.NoError(t, contractKeeper.PinCode(srcCtx, codeIDs[0]))
+	require.NoError(t, contractKeeper.PinCode(srcCtx, codeIDs[2]))
+	assert.Equal(t, []uint64{codeIDs[0], codeIDs[2]}, srcKeeper.GetPinnedCodeIDs(srcCtx))
+
+	// when exported
+	exportedState := ExportGenesis(srcCtx, srcKeeper)
+	require.NoError(t, exportedState.ValidateBasic())
+	// then pinned codes are flagged
+	require.Len(t, exportedState.Codes, 3)
+	for _, c := range exportedState.Codes {
+		assert.Equal(t, c.CodeID != codeIDs[1], c.Pinned, "code %d", c.CodeID)
+	}
+
+	// and when imported into a new chain
+	dstKeeper, dstCtx, _ := setupKeeper(t)
+	_, err = InitGenesis(dstCtx, dstKeeper, *exportedState, &StakingKeeperMock{}, TestHandler(contractKeeper))
+	require.NoError(t, err)
+	// then the same codes are pinned
+	assert.Equal(t, []uint64{codeIDs[0], codeIDs[2]}, dstKeeper.GetPinnedCodeIDs(dstCtx))
+}
+
 func TestGenesisInit(t *testing.T) {
 	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
 	require.NoError(t, err)
@@ -450,6 +511,58 @@ func TestGenesisInit(t *testing.T) {
 	}
 }
 
+func TestGenesisInstantiateDefaultPermission(t *testing.T) {
+	specs := map[string]struct {
+		permission    types.AccessType
+		expCreatorErr bool
+		expOtherErr   bool
+	}{
+		"everybody": {
+			permission: types.AccessTypeEverybody,
+		},
+		"only address": {
+			permission:  types.AccessTypeOnlyAddress,
+			expOtherErr: true,
+		},
+		"nobody": {
+			permission:    types.AccessTypeNobody,
+			expCreatorErr: true,
+			expOtherErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			params := types.DefaultParams()
+			params.InstantiateDefaultPermission = spec.permission
+			genesis := types.GenesisState{Params: params}
+			require.NoError(t, genesis.ValidateBasic())
+			_, err := InitGenesis(ctx, keepers.WasmKeeper, genesis, &StakingKeeperMock{}, TestHandler(keepers.ContractKeeper))
+			require.NoError(t, err)
+
+			// when a code is stored in the first block
+			ctx = ctx.WithBlockHeight(1)
+			var mock wasmtesting.MockWasmer
+			wasmtesting.MakeInstantiable(&mock)
+			example := StoreRandomContract(t, ctx, keepers, &mock)
+
+			// then the default permission is enforced
+			_, _, err = keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "creator", nil)
+			if spec.expCreatorErr {
+				assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+			_, _, err = keepers.ContractKeeper.Instantiate(ctx, example.CodeID, RandomAccountAddress(t), nil, []byte(`{}`), "other", nil)
+			if spec.expOtherErr {
+				assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestImportContractWithCodeHistoryReset(t *testing.T) {
 	genesisTemplate := `
 {
@@ -458,7 +571,21 @@ func TestImportContractWithCodeHistoryReset(t *testing.T) {
 			"permission": "Everybody"
 		},
 		"instantiate_default_permission": "Everybody",
-		"max_wasm_code_size": 500000
+		"max_wasm_code_size": 500000,
+		"max_msg_size": 100000,
+		"instance_cost": 60000,
+		"compile_cost": 3,
+		"max_contract_response_data_size": 262144,
+		"query_cache_enabled": false,
+		"max_gas_per_tx_percent": 0,
+		"max_code_count": 0,
+		"max_gas_refund_percent": 0,
+		"allow_contract_instantiation": true,
+		"max_reply_gas": 0,
+		"max_iterator_items": 0,
+		"block_time_average_window": 100,
+		"deduplicate_code_uploads": false,
+		"code_commitment_window": 0
 	},
   "codes": [
     {
@@ -557,6 +684,52 @@ func TestImportContractWithCodeHistoryReset(t *testing.T) {
 	assert.Equal(t, expHistory, keeper.GetContractHistory(ctx, contractAddr))
 }
 
+func TestGenesisInitContractWithStateOrReinit(t *testing.T) {
+	keeper, ctx, _ := setupKeeper(t)
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	verifier, beneficiary := RandomAccountAddress(t), RandomAccountAddress(t)
+	importedAddr, reinitAddr := BuildContractAddress(1, 1), BuildContractAddress(1, 2)
+	src := types.GenesisState{
+		Codes: []types.Code{{
+			CodeID:    firstCodeID,
+			CodeInfo:  wasmTypes.CodeInfoFixture(wasmTypes.WithSHA256CodeHash(wasmCode)),
+			CodeBytes: wasmCode,
+		}},
+		Contracts: []types.Contract{{
+			ContractAddress: importedAddr.String(),
+			ContractInfo:    types.ContractInfoFixture(types.OnlyGenesisFields),
+			ContractState:   []types.Model{{Key: []byte("foo"), Value: []byte("bar")}},
+		}, {
+			ContractAddress: reinitAddr.String(),
+			ContractInfo:    types.ContractInfoFixture(types.OnlyGenesisFields),
+			ReinitMsg:       HackatomExampleInitMsg{Verifier: verifier, Beneficiary: beneficiary}.GetBytes(t),
+		}},
+		Sequences: []types.Sequence{
+			{IDKey: types.KeyLastCodeID, Value: 2},
+			{IDKey: types.KeyLastInstanceID, Value: 3},
+		},
+		Params: types.DefaultParams(),
+	}
+	require.NoError(t, types.ValidateGenesis(src))
+
+	// when
+	var msgHandlerMock MockMsgHandler
+	_, err = InitGenesis(ctx, keeper, src, &StakingKeeperMock{}, msgHandlerMock.Handle)
+
+	// then
+	require.NoError(t, err)
+	// the state of the imported contract is restored
+	assert.Equal(t, []byte("bar"), keeper.QueryRaw(ctx, importedAddr, []byte("foo")))
+	// and the other contract was initialized by its instantiate entrypoint
+	gotRsp, err := keeper.QuerySmart(ctx, reinitAddr, []byte(`{"verifier":{}}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`{"verifier":%q}`, verifier.String()), string(gotRsp))
+	assert.NotNil(t, keeper.GetContractInfo(ctx, reinitAddr))
+}
+
 func TestSupportedGenMsgTypes(t *testing.T) {
 	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
 	require.NoError(t, err)
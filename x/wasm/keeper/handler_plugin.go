@@ -1,8 +1,10 @@
 package keeper
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
@@ -28,6 +30,10 @@ type SDKMessageHandler struct {
 	router    sdk.Router
 	msgRouter *baseapp.MsgServiceRouter
 	encoders  msgEncoder
+	// acceptedMsgTypeURLs restricts the sdk message types that contracts can dispatch. Empty accepts all types.
+	acceptedMsgTypeURLs map[string]struct{}
+	// dispatchedMsgEvents emits an event with the type and the result data length of each routed message
+	dispatchedMsgEvents bool
 }
 
 func NewDefaultMessageHandler(
@@ -48,6 +54,7 @@ func NewDefaultMessageHandler(
 		NewSDKMessageHandler(router, msgRouter, encoders),
 		NewIBCRawPacketHandler(channelKeeper, capabilityKeeper),
 		NewBurnCoinMessageHandler(bankKeeper),
+		NewGasHintMessageHandler(),
 	)
 }
 
@@ -65,23 +72,59 @@ func (h SDKMessageHandler) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddr
 		return nil, nil, err
 	}
 	for _, sdkMsg := range sdkMsgs {
-		res, err := h.handleSdkMessage(ctx, contractAddr, sdkMsg)
+		// collect the events that a handler emits to the context per message so that they are returned
+		// in dispatch order
+		em := sdk.NewEventManager()
+		res, err := h.handleSdkMessage(ctx.WithEventManager(em), contractAddr, sdkMsg)
 		if err != nil {
 			return nil, nil, err
 		}
 		// append data
 		data = append(data, res.Data)
-		// append events
-		sdkEvents := make([]sdk.Event, len(res.Events))
+		// append events. SDK handlers return the events that they emit to the context with the result, so that
+		// the emitted events are only used for handlers that do not return any.
+		if len(res.Events) == 0 {
+			events = append(events, em.Events()...)
+		}
 		for i := range res.Events {
-			sdkEvents[i] = sdk.Event(res.Events[i])
+			events = append(events, sdk.Event(res.Events[i]))
+		}
+		if h.dispatchedMsgEvents {
+			// the data is not added as it may contain sensitive payloads
+			events = append(events, sdk.NewEvent(
+				types.EventTypeDispatchedMsg,
+				sdk.NewAttribute(types.AttributeKeyMsgType, sdk.MsgTypeURL(sdkMsg)),
+				sdk.NewAttribute(types.AttributeKeyDataLength, strconv.Itoa(len(res.Data))),
+			))
 		}
-		events = append(events, sdkEvents...)
 	}
 	return
 }
 
-func (h SDKMessageHandler) handleSdkMessage(ctx sdk.Context, contractAddr sdk.Address, msg sdk.Msg) (*sdk.Result, error) {
+// isAcceptedMsgType returns true when no allowlist is set or the message type url is on it
+func (h SDKMessageHandler) isAcceptedMsgType(msg sdk.Msg) bool {
+	if len(h.acceptedMsgTypeURLs) == 0 {
+		return true
+	}
+	_, ok := h.acceptedMsgTypeURLs[sdk.MsgTypeURL(msg)]
+	return ok
+}
+
+// routing outcomes of messages dispatched by contracts that are reported in debug logs
+const (
+	msgRoutingRejected = "rejected"
+	msgRoutingRouted   = "routed"
+	msgRoutingLegacy   = "legacy"
+	msgRoutingUnknown  = "unknown"
+)
+
+func (h SDKMessageHandler) handleSdkMessage(ctx sdk.Context, contractAddr sdk.Address, msg sdk.Msg) (_ *sdk.Result, err error) {
+	routing := msgRoutingRejected
+	defer func() { logDispatchedMsg(ctx, contractAddr, msg, routing, err) }()
+
+	if !h.isAcceptedMsgType(msg) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "message type %s not accepted", sdk.MsgTypeURL(msg))
+	}
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
@@ -98,6 +141,7 @@ func (h SDKMessageHandler) handleSdkMessage(ctx sdk.Context, contractAddr sdk.Ad
 
 	if handler := h.msgRouter.Handler(msg); handler != nil {
 		// ADR 031 request type routing
+		routing = msgRoutingRouted
 		res, err := handler(ctx, msg)
 		if err != nil {
 			return nil, err
@@ -108,9 +152,11 @@ func (h SDKMessageHandler) handleSdkMessage(ctx sdk.Context, contractAddr sdk.Ad
 		// legacy sdk.Msg routing
 		handler := h.router.Route(ctx, legacyMsg.Route())
 		if handler == nil {
+			routing = msgRoutingUnknown
 			return nil, sdkerrors.Wrapf(
 				sdkerrors.ErrUnknownRequest, "unrecognized message route: %s; message", legacyMsg.Route())
 		}
+		routing = msgRoutingLegacy
 		res, err := handler(ctx, msg)
 		if err != nil {
 			return nil, err
@@ -118,12 +164,84 @@ func (h SDKMessageHandler) handleSdkMessage(ctx sdk.Context, contractAddr sdk.Ad
 		return res, nil
 
 	}
+	routing = msgRoutingUnknown
 	return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "can't route message %+v", msg)
 }
 
+// logDispatchedMsg writes a structured debug log entry for a message dispatched by a contract. Only the type url,
+// signers of successful messages, routing outcome and error code are logged but never the message content as it may contain sensitive data.
+func logDispatchedMsg(ctx sdk.Context, contractAddr sdk.Address, msg sdk.Msg, routing string, err error) {
+	if ctx.Logger() == nil {
+		return
+	}
+	keyvals := []interface{}{
+		"contract", contractAddr.String(),
+		"type_url", sdk.MsgTypeURL(msg),
+		"routing", routing,
+	}
+	if err != nil {
+		// the error message is not logged as it can contain the message content
+		codespace, code, _ := sdkerrors.ABCIInfo(err, false)
+		keyvals = append(keyvals, "error_codespace", codespace, "error_code", code)
+	} else {
+		// signers are only read from valid messages as GetSigners panics on invalid addresses
+		signers := make([]string, 0)
+		for _, s := range msg.GetSigners() {
+			signers = append(signers, s.String())
+		}
+		keyvals = append(keyvals, "signers", signers)
+	}
+	moduleLogger(ctx).Debug("dispatched contract message", keyvals...)
+}
+
+// packetTracker records the IBC packets that contracts send
+type packetTracker interface {
+	addInFlightPacket(ctx sdk.Context, contractAddr sdk.AccAddress, packet types.InFlightPacket)
+}
+
+// packetTrackingMessenger decorates a Messenger to record the packets of IBC transfers that contracts send.
+// The packets are read from the send packet events of the dispatched message.
+type packetTrackingMessenger struct {
+	nested  Messenger
+	tracker packetTracker
+}
+
+func newPacketTrackingMessenger(nested Messenger, tracker packetTracker) Messenger {
+	return packetTrackingMessenger{nested: nested, tracker: tracker}
+}
+
+func (m packetTrackingMessenger) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+	events, data, err := m.nested.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
+	if err != nil || msg.IBC == nil || msg.IBC.Transfer == nil {
+		return events, data, err
+	}
+	for _, e := range events {
+		if e.Type != channeltypes.EventTypeSendPacket {
+			continue
+		}
+		var packet types.InFlightPacket
+		for _, a := range e.Attributes {
+			switch string(a.Key) {
+			case channeltypes.AttributeKeySrcPort:
+				packet.SourcePort = string(a.Value)
+			case channeltypes.AttributeKeySrcChannel:
+				packet.SourceChannel = string(a.Value)
+			case channeltypes.AttributeKeySequence:
+				if packet.Sequence, err = strconv.ParseUint(string(a.Value), 10, 64); err != nil {
+					return nil, nil, sdkerrors.Wrap(err, "packet sequence")
+				}
+			}
+		}
+		m.tracker.addInFlightPacket(ctx, contractAddr, packet)
+	}
+	return events, data, nil
+}
+
 // MessageHandlerChain defines a chain of handlers that are called one by one until it can be handled.
 type MessageHandlerChain struct {
 	handlers []Messenger
+	// middlewares are called around each dispatched message in the order of the slice
+	middlewares []MessageHandlerMiddleware
 }
 
 func NewMessageHandlerChain(first Messenger, others ...Messenger) *MessageHandlerChain {
@@ -140,7 +258,22 @@ func NewMessageHandlerChain(first Messenger, others ...Messenger) *MessageHandle
 // order to find the right one to process given message. If a handler cannot
 // process given message (returns ErrUnknownMsg), its result is ignored and the
 // next handler is executed.
+// The middlewares are called before and after the message is dispatched. An error of a before hook aborts the
+// dispatch without calling the handlers.
 func (m MessageHandlerChain) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+	for _, mw := range m.middlewares {
+		if err := mw.BeforeDispatch(ctx, contractAddr, msg); err != nil {
+			return nil, nil, err
+		}
+	}
+	events, data, err := m.dispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
+	for _, mw := range m.middlewares {
+		mw.AfterDispatch(ctx, contractAddr, msg, events, data, err)
+	}
+	return events, data, err
+}
+
+func (m MessageHandlerChain) dispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
 	for _, h := range m.handlers {
 		events, data, err := h.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
 		switch {
@@ -155,6 +288,183 @@ func (m MessageHandlerChain) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAd
 	return nil, nil, sdkerrors.Wrap(types.ErrUnknownMsg, "no handler found")
 }
 
+// MessageHandlerMiddleware hooks into the dispatching of contract messages by the MessageHandlerChain so that chains
+// can add logging, rate limiting or allow-listing without replacing the handlers.
+type MessageHandlerMiddleware interface {
+	// BeforeDispatch is called before the message is dispatched. A returned error rejects the message.
+	BeforeDispatch(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.CosmosMsg) error
+	// AfterDispatch is called with the result of the dispatched message. It can not modify the result.
+	AfterDispatch(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.CosmosMsg, events []sdk.Event, data [][]byte, err error)
+}
+
+var _ MessageHandlerMiddleware = MessageHandlerHooks{}
+
+// MessageHandlerHooks is a helper to construct a function based middleware. Hooks that are not set are skipped.
+type MessageHandlerHooks struct {
+	Before func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.CosmosMsg) error
+	After  func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.CosmosMsg, events []sdk.Event, data [][]byte, err error)
+}
+
+// BeforeDispatch delegates to the Before hook
+func (m MessageHandlerHooks) BeforeDispatch(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.CosmosMsg) error {
+	if m.Before == nil {
+		return nil
+	}
+	return m.Before(ctx, contractAddr, msg)
+}
+
+// AfterDispatch delegates to the After hook
+func (m MessageHandlerHooks) AfterDispatch(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.CosmosMsg, events []sdk.Event, data [][]byte, err error) {
+	if m.After != nil {
+		m.After(ctx, contractAddr, msg, events, data, err)
+	}
+}
+
+// sdkMessageHandler returns the SDK message handler and its position in the chain.
+// It panics when the chain does not contain one.
+func (m MessageHandlerChain) sdkMessageHandler() (int, SDKMessageHandler) {
+	for i, h := range m.handlers {
+		if s, ok := h.(SDKMessageHandler); ok {
+			return i, s
+		}
+	}
+	panic("no sdk message handler in chain")
+}
+
+// MsgGasSurcharge is the additional gas charged for a message dispatched by a contract
+type MsgGasSurcharge struct {
+	// Flat is charged once per message
+	Flat sdk.Gas
+	// PerByte is charged per byte of the json encoded message
+	PerByte sdk.Gas
+}
+
+// CosmosMsg variant names to set the surcharges of the GasChargingMessageHandler with
+const (
+	MsgVariantBank         = "bank"
+	MsgVariantCustom       = "custom"
+	MsgVariantDistribution = "distribution"
+	MsgVariantGov          = "gov"
+	MsgVariantIBC          = "ibc"
+	MsgVariantStaking      = "staking"
+	MsgVariantStargate     = "stargate"
+	MsgVariantWasm         = "wasm"
+	// Chain message variants are sent as custom message within the chain namespace. See types.ChainMsg
+	MsgVariantMultiSend = "multi_send"
+	MsgVariantTerminate = "terminate"
+	MsgVariantGasHint   = "gas_hint"
+	MsgVariantAuthz     = "authz"
+	MsgVariantFeegrant  = "feegrant"
+)
+
+// msgVariants all variant names supported by the GasChargingMessageHandler
+var msgVariants = map[string]struct{}{
+	MsgVariantBank:         {},
+	MsgVariantCustom:       {},
+	MsgVariantDistribution: {},
+	MsgVariantGov:          {},
+	MsgVariantIBC:          {},
+	MsgVariantStaking:      {},
+	MsgVariantStargate:     {},
+	MsgVariantWasm:         {},
+	MsgVariantMultiSend:    {},
+	MsgVariantTerminate:    {},
+	MsgVariantGasHint:      {},
+	MsgVariantAuthz:        {},
+	MsgVariantFeegrant:     {},
+}
+
+// GasChargingMessageHandler decorates a Messenger and charges a gas surcharge for each dispatched message before it
+// is passed on, so that chains can price expensive message routes higher than cheap ones. The surcharges are set
+// per message variant, see the MsgVariant constants. Chain messages are priced by their own variant and not
+// as custom message. Variants without a surcharge are passed on without additional costs.
+type GasChargingMessageHandler struct {
+	next       Messenger
+	surcharges map[string]MsgGasSurcharge
+}
+
+// NewGasChargingMessageHandler constructor. Use it with the `WithMessageHandlerDecorator` option to wrap the
+// message handler chain of the keeper. It panics for unknown variant names.
+func NewGasChargingMessageHandler(next Messenger, surcharges map[string]MsgGasSurcharge) GasChargingMessageHandler {
+	if next == nil {
+		panic("next handler must not be nil")
+	}
+	for v := range surcharges {
+		if _, ok := msgVariants[v]; !ok {
+			panic(fmt.Sprintf("unknown message variant: %q", v))
+		}
+	}
+	return GasChargingMessageHandler{next: next, surcharges: surcharges}
+}
+
+// DispatchMsg charges the surcharge of the message variant and dispatches the message with the decorated handler
+func (h GasChargingMessageHandler) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+	if s, ok := h.surcharges[cosmosMsgVariant(msg)]; ok {
+		gas := s.Flat
+		if s.PerByte != 0 {
+			bz, err := json.Marshal(msg)
+			if err != nil {
+				return nil, nil, sdkerrors.Wrap(types.ErrInvalidMsg, err.Error())
+			}
+			byteCosts := s.PerByte * uint64(len(bz))
+			if byteCosts/s.PerByte != uint64(len(bz)) || gas+byteCosts < gas {
+				panic(sdk.ErrorGasOverflow{Descriptor: "message surcharge"})
+			}
+			gas += byteCosts
+		}
+		ctx.GasMeter().ConsumeGas(gas, "message surcharge")
+	}
+	return h.next.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
+}
+
+// cosmosMsgVariant returns the name of the variant that is set in the message or empty when none is set.
+// Custom messages in the chain namespace return the name of the chain message variant.
+func cosmosMsgVariant(msg wasmvmtypes.CosmosMsg) string {
+	switch {
+	case msg.Bank != nil:
+		return MsgVariantBank
+	case msg.Custom != nil:
+		return customMsgVariant(msg.Custom)
+	case msg.Distribution != nil:
+		return MsgVariantDistribution
+	case msg.Gov != nil:
+		return MsgVariantGov
+	case msg.IBC != nil:
+		return MsgVariantIBC
+	case msg.Staking != nil:
+		return MsgVariantStaking
+	case msg.Stargate != nil:
+		return MsgVariantStargate
+	case msg.Wasm != nil:
+		return MsgVariantWasm
+	default:
+		return ""
+	}
+}
+
+// customMsgVariant returns the name of the chain message variant or the custom variant for any other message.
+// Invalid chain messages are priced as custom message and rejected by the message handler.
+func customMsgVariant(bz []byte) string {
+	chainMsg, ok, err := types.DecodeChainMsg(bz)
+	if err != nil || !ok {
+		return MsgVariantCustom
+	}
+	switch {
+	case chainMsg.MultiSend != nil:
+		return MsgVariantMultiSend
+	case chainMsg.Terminate != nil:
+		return MsgVariantTerminate
+	case chainMsg.GasHint != nil:
+		return MsgVariantGasHint
+	case chainMsg.Authz != nil:
+		return MsgVariantAuthz
+	case chainMsg.Feegrant != nil:
+		return MsgVariantFeegrant
+	default:
+		return MsgVariantCustom
+	}
+}
+
 // IBCRawPacketHandler handels IBC.SendPacket messages which are published to an IBC channel.
 type IBCRawPacketHandler struct {
 	channelKeeper    types.ChannelKeeper
@@ -165,8 +475,9 @@ func NewIBCRawPacketHandler(chk types.ChannelKeeper, cak types.CapabilityKeeper)
 	return IBCRawPacketHandler{channelKeeper: chk, capabilityKeeper: cak}
 }
 
-// DispatchMsg publishes a raw IBC packet onto the channel.
-func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, _ sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+// DispatchMsg publishes a raw IBC packet onto the channel. An event with the contract address and the packet
+// details is returned in addition to the events emitted by the IBC core module.
+func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
 	if msg.IBC == nil || msg.IBC.SendPacket == nil {
 		return nil, nil, types.ErrUnknownMsg
 	}
@@ -203,7 +514,16 @@ func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, _ sdk.AccAddress, cont
 		convertWasmIBCTimeoutHeightToCosmosHeight(msg.IBC.SendPacket.Timeout.Block),
 		msg.IBC.SendPacket.Timeout.Timestamp,
 	)
-	return nil, nil, h.channelKeeper.SendPacket(ctx, channelCap, packet)
+	if err := h.channelKeeper.SendPacket(ctx, channelCap, packet); err != nil {
+		return nil, nil, err
+	}
+	return []sdk.Event{sdk.NewEvent(
+		types.EventTypeIBCSendPacket,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(channeltypes.AttributeKeySrcPort, contractIBCPortID),
+		sdk.NewAttribute(channeltypes.AttributeKeySrcChannel, contractIBCChannelID),
+		sdk.NewAttribute(channeltypes.AttributeKeySequence, strconv.FormatUint(sequence, 10)),
+	)}, nil, nil
 }
 
 var _ Messenger = MessageHandlerFunc(nil)
@@ -216,6 +536,40 @@ func (m MessageHandlerFunc) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAdd
 	return m(ctx, contractAddr, contractIBCPortID, msg)
 }
 
+type contractTerminator interface {
+	terminateContract(ctx sdk.Context, contractAddress sdk.AccAddress) error
+}
+
+// NewTerminateContractMessageHandler handles the terminate chain message that a contract sends to disable itself
+func NewTerminateContractMessageHandler(k contractTerminator) MessageHandlerFunc {
+	return func(ctx sdk.Context, contractAddr sdk.AccAddress, _ string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+		if msg.Custom != nil {
+			if chainMsg, ok, err := types.DecodeChainMsg(msg.Custom); err == nil && ok && chainMsg.Terminate != nil {
+				return nil, nil, k.terminateContract(ctx, contractAddr)
+			}
+		}
+		return nil, nil, types.ErrUnknownMsg
+	}
+}
+
+// NewGasHintMessageHandler handles the gas hint chain message. The hint is only reported with an event and does not
+// modify any state.
+func NewGasHintMessageHandler() MessageHandlerFunc {
+	return func(ctx sdk.Context, contractAddr sdk.AccAddress, _ string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+		if msg.Custom != nil {
+			if chainMsg, ok, err := types.DecodeChainMsg(msg.Custom); err == nil && ok && chainMsg.GasHint != nil {
+				return []sdk.Event{sdk.NewEvent(
+					types.EventTypeGasHint,
+					sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+					sdk.NewAttribute(types.AttributeKeySubMsgID, strconv.FormatUint(chainMsg.GasHint.MsgID, 10)),
+					sdk.NewAttribute(types.AttributeKeyExpectedGas, strconv.FormatUint(chainMsg.GasHint.ExpectedGas, 10)),
+				)}, nil, nil
+			}
+		}
+		return nil, nil, types.ErrUnknownMsg
+	}
+}
+
 // NewBurnCoinMessageHandler handles wasmvm.BurnMsg messages
 func NewBurnCoinMessageHandler(burner types.Burner) MessageHandlerFunc {
 	return func(ctx sdk.Context, contractAddr sdk.AccAddress, _ string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
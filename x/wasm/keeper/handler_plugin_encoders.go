@@ -3,13 +3,17 @@ package keeper
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"time"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	ibctransfertypes "github.com/cosmos/ibc-go/v2/modules/apps/transfer/types"
@@ -20,6 +24,9 @@ import (
 )
 
 type BankEncoder func(sender sdk.AccAddress, msg *wasmvmtypes.BankMsg) ([]sdk.Msg, error)
+type ChainEncoder func(sender sdk.AccAddress, msg *types.ChainMsg) ([]sdk.Msg, error)
+type AuthzEncoder func(sender sdk.AccAddress, msg *types.AuthzMsg) ([]sdk.Msg, error)
+type FeegrantEncoder func(sender sdk.AccAddress, msg *types.FeegrantMsg) ([]sdk.Msg, error)
 type CustomEncoder func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error)
 type DistributionEncoder func(sender sdk.AccAddress, msg *wasmvmtypes.DistributionMsg) ([]sdk.Msg, error)
 type StakingEncoder func(sender sdk.AccAddress, msg *wasmvmtypes.StakingMsg) ([]sdk.Msg, error)
@@ -29,6 +36,9 @@ type IBCEncoder func(ctx sdk.Context, sender sdk.AccAddress, contractIBCPortID s
 
 type MessageEncoders struct {
 	Bank         func(sender sdk.AccAddress, msg *wasmvmtypes.BankMsg) ([]sdk.Msg, error)
+	Chain        func(sender sdk.AccAddress, msg *types.ChainMsg) ([]sdk.Msg, error)
+	Authz        func(sender sdk.AccAddress, msg *types.AuthzMsg) ([]sdk.Msg, error)
+	Feegrant     func(sender sdk.AccAddress, msg *types.FeegrantMsg) ([]sdk.Msg, error)
 	Custom       func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error)
 	Distribution func(sender sdk.AccAddress, msg *wasmvmtypes.DistributionMsg) ([]sdk.Msg, error)
 	IBC          func(ctx sdk.Context, sender sdk.AccAddress, contractIBCPortID string, msg *wasmvmtypes.IBCMsg) ([]sdk.Msg, error)
@@ -41,6 +51,9 @@ type MessageEncoders struct {
 func DefaultEncoders(unpacker codectypes.AnyUnpacker, portSource types.ICS20TransferPortSource) MessageEncoders {
 	return MessageEncoders{
 		Bank:         EncodeBankMsg,
+		Chain:        EncodeChainMsg,
+		Authz:        EncodeAuthzMsg(unpacker),
+		Feegrant:     EncodeFeegrantMsg(unpacker),
 		Custom:       NoCustomMsg,
 		Distribution: EncodeDistributionMsg,
 		IBC:          EncodeIBCMsg(portSource),
@@ -58,6 +71,15 @@ func (e MessageEncoders) Merge(o *MessageEncoders) MessageEncoders {
 	if o.Bank != nil {
 		e.Bank = o.Bank
 	}
+	if o.Chain != nil {
+		e.Chain = o.Chain
+	}
+	if o.Authz != nil {
+		e.Authz = o.Authz
+	}
+	if o.Feegrant != nil {
+		e.Feegrant = o.Feegrant
+	}
 	if o.Custom != nil {
 		e.Custom = o.Custom
 	}
@@ -87,7 +109,22 @@ func (e MessageEncoders) Encode(ctx sdk.Context, contractAddr sdk.AccAddress, co
 	case msg.Bank != nil:
 		return e.Bank(contractAddr, msg.Bank)
 	case msg.Custom != nil:
-		return e.Custom(contractAddr, msg.Custom)
+		// chain messages are sent via the custom channel in their own namespace so that they never shadow
+		// the messages of the chain's custom encoder
+		chainMsg, ok, err := types.DecodeChainMsg(msg.Custom)
+		switch {
+		case err != nil:
+			return nil, err
+		case !ok:
+			return e.Custom(contractAddr, msg.Custom)
+		case chainMsg.Authz != nil && e.Authz != nil:
+			return e.Authz(contractAddr, chainMsg.Authz)
+		case chainMsg.Feegrant != nil && e.Feegrant != nil:
+			return e.Feegrant(contractAddr, chainMsg.Feegrant)
+		case e.Chain != nil:
+			return e.Chain(contractAddr, chainMsg)
+		}
+		return nil, sdkerrors.Wrap(types.ErrUnknownMsg, "unknown variant of Chain")
 	case msg.Distribution != nil:
 		return e.Distribution(contractAddr, msg.Distribution)
 	case msg.IBC != nil:
@@ -123,6 +160,110 @@ func EncodeBankMsg(sender sdk.AccAddress, msg *wasmvmtypes.BankMsg) ([]sdk.Msg,
 	return []sdk.Msg{&sdkMsg}, nil
 }
 
+// EncodeChainMsg encodes the chain messages that wasmd provides to contracts via the custom message channel.
+func EncodeChainMsg(sender sdk.AccAddress, msg *types.ChainMsg) ([]sdk.Msg, error) {
+	if msg.MultiSend == nil {
+		return nil, sdkerrors.Wrap(types.ErrUnknownMsg, "unknown variant of Chain")
+	}
+	total, err := convertWasmCoinsToSdkCoins(msg.MultiSend.Amount)
+	if err != nil {
+		return nil, err
+	}
+	inputs := []banktypes.Input{{Address: sender.String(), Coins: total}}
+	outputs := make([]banktypes.Output, len(msg.MultiSend.Outputs))
+	for i, o := range msg.MultiSend.Outputs {
+		amount, err := convertWasmCoinsToSdkCoins(o.Amount)
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = banktypes.Output{Address: o.ToAddress, Coins: amount}
+	}
+	// reject unbalanced sends early with the bank error
+	if err := banktypes.ValidateInputsOutputs(inputs, outputs); err != nil {
+		return nil, err
+	}
+	sdkMsg := banktypes.MsgMultiSend{
+		Inputs:  inputs,
+		Outputs: outputs,
+	}
+	return []sdk.Msg{&sdkMsg}, nil
+}
+
+// EncodeAuthzMsg encodes the authz chain messages into x/authz grant and revoke messages with the contract as
+// granter. The authorization is unpacked so that it is validated with the message.
+func EncodeAuthzMsg(unpacker codectypes.AnyUnpacker) AuthzEncoder {
+	return func(sender sdk.AccAddress, msg *types.AuthzMsg) ([]sdk.Msg, error) {
+		switch {
+		case msg.Grant != nil:
+			if msg.Grant.Expiration == 0 || msg.Grant.Expiration > math.MaxInt64 {
+				return nil, sdkerrors.Wrapf(types.ErrInvalidMsg, "invalid expiration: %d", msg.Grant.Expiration)
+			}
+			any := codectypes.Any{
+				TypeUrl: msg.Grant.Authorization.TypeURL,
+				Value:   msg.Grant.Authorization.Value,
+			}
+			var authorization authz.Authorization
+			if err := unpacker.UnpackAny(&any, &authorization); err != nil {
+				return nil, sdkerrors.Wrap(types.ErrInvalidMsg, fmt.Sprintf("Cannot unpack authorization with type URL: %s", msg.Grant.Authorization.TypeURL))
+			}
+			grantee, err := sdk.AccAddressFromBech32(msg.Grant.Grantee)
+			if err != nil {
+				return nil, sdkerrors.Wrap(err, "grantee")
+			}
+			sdkMsg, err := authz.NewMsgGrant(sender, grantee, authorization, time.Unix(0, int64(msg.Grant.Expiration)).UTC())
+			if err != nil {
+				return nil, err
+			}
+			return []sdk.Msg{sdkMsg}, nil
+		case msg.Revoke != nil:
+			grantee, err := sdk.AccAddressFromBech32(msg.Revoke.Grantee)
+			if err != nil {
+				return nil, sdkerrors.Wrap(err, "grantee")
+			}
+			sdkMsg := authz.NewMsgRevoke(sender, grantee, msg.Revoke.MsgTypeURL)
+			return []sdk.Msg{&sdkMsg}, nil
+		default:
+			return nil, sdkerrors.Wrap(types.ErrUnknownMsg, "unknown variant of Authz")
+		}
+	}
+}
+
+// EncodeFeegrantMsg encodes the feegrant chain messages into x/feegrant grant and revoke allowance messages with the
+// contract as granter. The allowance is unpacked so that it is validated with the message.
+func EncodeFeegrantMsg(unpacker codectypes.AnyUnpacker) FeegrantEncoder {
+	return func(sender sdk.AccAddress, msg *types.FeegrantMsg) ([]sdk.Msg, error) {
+		switch {
+		case msg.GrantAllowance != nil:
+			any := codectypes.Any{
+				TypeUrl: msg.GrantAllowance.Allowance.TypeURL,
+				Value:   msg.GrantAllowance.Allowance.Value,
+			}
+			var allowance feegrant.FeeAllowanceI
+			if err := unpacker.UnpackAny(&any, &allowance); err != nil {
+				return nil, sdkerrors.Wrap(types.ErrInvalidMsg, fmt.Sprintf("Cannot unpack allowance with type URL: %s", msg.GrantAllowance.Allowance.TypeURL))
+			}
+			grantee, err := sdk.AccAddressFromBech32(msg.GrantAllowance.Grantee)
+			if err != nil {
+				return nil, sdkerrors.Wrap(err, "grantee")
+			}
+			sdkMsg, err := feegrant.NewMsgGrantAllowance(allowance, sender, grantee)
+			if err != nil {
+				return nil, err
+			}
+			return []sdk.Msg{sdkMsg}, nil
+		case msg.RevokeAllowance != nil:
+			grantee, err := sdk.AccAddressFromBech32(msg.RevokeAllowance.Grantee)
+			if err != nil {
+				return nil, sdkerrors.Wrap(err, "grantee")
+			}
+			sdkMsg := feegrant.NewMsgRevokeAllowance(sender, grantee)
+			return []sdk.Msg{&sdkMsg}, nil
+		default:
+			return nil, sdkerrors.Wrap(types.ErrUnknownMsg, "unknown variant of Feegrant")
+		}
+	}
+}
+
 func NoCustomMsg(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
 	return nil, sdkerrors.Wrap(types.ErrUnknownMsg, "custom variant not supported")
 }
@@ -327,6 +468,11 @@ func convertWasmCoinsToSdkCoins(coins []wasmvmtypes.Coin) (sdk.Coins, error) {
 		}
 		toSend = append(toSend, c)
 	}
+	// contracts may send coins in any order: sort them for the bank and reject zero amounts or duplicate denoms
+	toSend = toSend.Sort()
+	if err := toSend.Validate(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	}
 	return toSend, nil
 }
 
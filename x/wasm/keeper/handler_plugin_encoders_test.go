@@ -1,7 +1,12 @@
 package keeper
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
@@ -14,8 +19,11 @@ import (
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	address "github.com/cosmos/cosmos-sdk/types/address"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/require"
 
@@ -59,6 +67,21 @@ func TestEncoding(t *testing.T) {
 	proposalMsgBin, err := proto.Marshal(proposalMsg)
 	require.NoError(t, err)
 
+	voteAuthorization := authz.NewGenericAuthorization(sdk.MsgTypeURL(&govtypes.MsgVote{}))
+	voteAuthorizationBin, err := proto.Marshal(voteAuthorization)
+	require.NoError(t, err)
+	expiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	grantMsg, err := authz.NewMsgGrant(addr1, addr2, voteAuthorization, expiration)
+	require.NoError(t, err)
+	revokeMsg := authz.NewMsgRevoke(addr1, addr2, sdk.MsgTypeURL(&govtypes.MsgVote{}))
+
+	basicAllowance := &feegrant.BasicAllowance{SpendLimit: sdk.NewCoins(sdk.NewInt64Coin("uatom", 100))}
+	basicAllowanceBin, err := proto.Marshal(basicAllowance)
+	require.NoError(t, err)
+	grantAllowanceMsg, err := feegrant.NewMsgGrantAllowance(basicAllowance, addr1, addr2)
+	require.NoError(t, err)
+	revokeAllowanceMsg := feegrant.NewMsgRevokeAllowance(addr1, addr2)
+
 	cases := map[string]struct {
 		sender             sdk.AccAddress
 		srcMsg             wasmvmtypes.CosmosMsg
@@ -69,6 +92,42 @@ func TestEncoding(t *testing.T) {
 		// set if invalid
 		isError bool
 	}{
+		"chain multi send": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"multi_send":{"amount":[{"denom":"uatom","amount":"3"},{"denom":"usdt","amount":"1"}],"outputs":[{"to_address":%q,"amount":[{"denom":"uatom","amount":"1"}]},{"to_address":%q,"amount":[{"denom":"usdt","amount":"1"},{"denom":"uatom","amount":"2"}]}]}}}`, addr2.String(), addr3.String())),
+			},
+			output: []sdk.Msg{
+				&banktypes.MsgMultiSend{
+					Inputs: []banktypes.Input{{Address: addr1.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("uatom", 3), sdk.NewInt64Coin("usdt", 1))}},
+					Outputs: []banktypes.Output{
+						{Address: addr2.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("uatom", 1))},
+						{Address: addr3.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("uatom", 2), sdk.NewInt64Coin("usdt", 1))},
+					},
+				},
+			},
+		},
+		"chain multi send unbalanced": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"multi_send":{"amount":[{"denom":"uatom","amount":"3"}],"outputs":[{"to_address":%q,"amount":[{"denom":"uatom","amount":"1"}]}]}}}`, addr2.String())),
+			},
+			isError: true,
+		},
+		"chain multi send without outputs": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(`{"wasmd":{"multi_send":{"amount":[{"denom":"uatom","amount":"3"}],"outputs":[]}}}`),
+			},
+			isError: true,
+		},
+		"chain multi send invalid recipient": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"multi_send":{"amount":[{"denom":"uatom","amount":"1"}],"outputs":[{"to_address":%q,"amount":[{"denom":"uatom","amount":"1"}]}]}}}`, invalidAddr)),
+			},
+			isError: true,
+		},
 		"simple send": {
 			sender: addr1,
 			srcMsg: wasmvmtypes.CosmosMsg{
@@ -99,6 +158,60 @@ func TestEncoding(t *testing.T) {
 				},
 			},
 		},
+		"unsorted send amount": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Bank: &wasmvmtypes.BankMsg{
+					Send: &wasmvmtypes.SendMsg{
+						ToAddress: addr2.String(),
+						Amount: []wasmvmtypes.Coin{
+							wasmvmtypes.NewCoin(54321, "usdt"),
+							wasmvmtypes.NewCoin(12345, "uatom"),
+						},
+					},
+				},
+			},
+			output: []sdk.Msg{
+				&banktypes.MsgSend{
+					FromAddress: addr1.String(),
+					ToAddress:   addr2.String(),
+					Amount: sdk.Coins{
+						sdk.NewInt64Coin("uatom", 12345),
+						sdk.NewInt64Coin("usdt", 54321),
+					},
+				},
+			},
+		},
+		"zero send amount": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Bank: &wasmvmtypes.BankMsg{
+					Send: &wasmvmtypes.SendMsg{
+						ToAddress: addr2.String(),
+						Amount: []wasmvmtypes.Coin{
+							wasmvmtypes.NewCoin(12345, "uatom"),
+							wasmvmtypes.NewCoin(0, "usdt"),
+						},
+					},
+				},
+			},
+			isError: true,
+		},
+		"duplicate send denom": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Bank: &wasmvmtypes.BankMsg{
+					Send: &wasmvmtypes.SendMsg{
+						ToAddress: addr2.String(),
+						Amount: []wasmvmtypes.Coin{
+							wasmvmtypes.NewCoin(1, "uatom"),
+							wasmvmtypes.NewCoin(2, "uatom"),
+						},
+					},
+				},
+			},
+			isError: true,
+		},
 		"invalid send amount": {
 			sender: addr1,
 			srcMsg: wasmvmtypes.CosmosMsg{
@@ -533,6 +646,97 @@ func TestEncoding(t *testing.T) {
 				},
 			},
 		},
+		"authz grant": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"authz":{"grant":{"grantee":%q,"authorization":{"type_url":"/cosmos.authz.v1beta1.GenericAuthorization","value":%q},"expiration":%d}}}}`,
+					addr2.String(), base64.StdEncoding.EncodeToString(voteAuthorizationBin), expiration.UnixNano())),
+			},
+			output: []sdk.Msg{grantMsg},
+		},
+		"authz grant with unknown authorization type": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"authz":{"grant":{"grantee":%q,"authorization":{"type_url":"/cosmos.bank.v1beta1.MsgSend","value":%q},"expiration":%d}}}}`,
+					addr2.String(), base64.StdEncoding.EncodeToString(bankMsgBin), expiration.UnixNano())),
+			},
+			isError: true,
+		},
+		"authz grant with invalid grantee": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"authz":{"grant":{"grantee":%q,"authorization":{"type_url":"/cosmos.authz.v1beta1.GenericAuthorization","value":%q},"expiration":%d}}}}`,
+					invalidAddr, base64.StdEncoding.EncodeToString(voteAuthorizationBin), expiration.UnixNano())),
+			},
+			isError: true,
+		},
+		"authz grant without expiration": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"authz":{"grant":{"grantee":%q,"authorization":{"type_url":"/cosmos.authz.v1beta1.GenericAuthorization","value":%q},"expiration":0}}}}`,
+					addr2.String(), base64.StdEncoding.EncodeToString(voteAuthorizationBin))),
+			},
+			isError: true,
+		},
+		"authz grant with expiration exceeding max int64": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"authz":{"grant":{"grantee":%q,"authorization":{"type_url":"/cosmos.authz.v1beta1.GenericAuthorization","value":%q},"expiration":%d}}}}`,
+					addr2.String(), base64.StdEncoding.EncodeToString(voteAuthorizationBin), uint64(math.MaxInt64)+1)),
+			},
+			isError: true,
+		},
+		"authz revoke": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"authz":{"revoke":{"grantee":%q,"msg_type_url":"/cosmos.gov.v1beta1.MsgVote"}}}}`, addr2.String())),
+			},
+			output: []sdk.Msg{&revokeMsg},
+		},
+		"authz without variant": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(`{"wasmd":{"authz":{}}}`),
+			},
+			isError: true,
+		},
+		"feegrant grant allowance": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"feegrant":{"grant_allowance":{"grantee":%q,"allowance":{"type_url":"/cosmos.feegrant.v1beta1.BasicAllowance","value":%q}}}}}`,
+					addr2.String(), base64.StdEncoding.EncodeToString(basicAllowanceBin))),
+			},
+			output: []sdk.Msg{grantAllowanceMsg},
+		},
+		"feegrant grant allowance with unknown allowance type": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"feegrant":{"grant_allowance":{"grantee":%q,"allowance":{"type_url":"/cosmos.authz.v1beta1.GenericAuthorization","value":%q}}}}}`,
+					addr2.String(), base64.StdEncoding.EncodeToString(voteAuthorizationBin))),
+			},
+			isError: true,
+		},
+		"feegrant revoke allowance": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"feegrant":{"revoke_allowance":{"grantee":%q}}}}`, addr2.String())),
+			},
+			output: []sdk.Msg{&revokeAllowanceMsg},
+		},
+		"feegrant revoke allowance with invalid grantee": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(fmt.Sprintf(`{"wasmd":{"feegrant":{"revoke_allowance":{"grantee":%q}}}}`, invalidAddr)),
+			},
+			isError: true,
+		},
+		"feegrant without variant": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Custom: []byte(`{"wasmd":{"feegrant":{}}}`),
+			},
+			isError: true,
+		},
 		"Gov vote: Abstain": {
 			sender:             addr1,
 			srcContractIBCPort: "myIBCPort",
@@ -582,6 +786,73 @@ func TestEncoding(t *testing.T) {
 	}
 }
 
+func TestEncodeChainMsgUnbalanced(t *testing.T) {
+	msg := types.ChainMsg{MultiSend: &types.MultiSendMsg{
+		Amount:  []wasmvmtypes.Coin{wasmvmtypes.NewCoin(2, "uatom")},
+		Outputs: []types.MultiSendOutput{{ToAddress: RandomBech32AccountAddress(t), Amount: []wasmvmtypes.Coin{wasmvmtypes.NewCoin(1, "uatom")}}},
+	}}
+	_, err := EncodeChainMsg(RandomAccountAddress(t), &msg)
+	assert.True(t, banktypes.ErrInputOutputMismatch.Is(err), err)
+}
+
+func TestEncodeChainMsgNamespace(t *testing.T) {
+	var chainCalled, customCalled bool
+	encoder := MessageEncoders{
+		Chain: func(sender sdk.AccAddress, msg *types.ChainMsg) ([]sdk.Msg, error) {
+			chainCalled = true
+			return nil, nil
+		},
+		Custom: func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+			customCalled = true
+			return nil, nil
+		},
+	}
+	specs := map[string]struct {
+		src       json.RawMessage
+		expChain  bool
+		expCustom bool
+		expErr    *sdkerrors.Error
+	}{
+		"chain message": {
+			src:      []byte(`{"wasmd":{"terminate":{}}}`),
+			expChain: true,
+		},
+		"custom message": {
+			src:       []byte(`{"ping":{}}`),
+			expCustom: true,
+		},
+		"custom message with chain message key": {
+			src:       []byte(`{"terminate":{}}`),
+			expCustom: true,
+		},
+		"custom message with namespace in other case": {
+			src:       []byte(`{"Wasmd":{"terminate":{}}}`),
+			expCustom: true,
+		},
+		"custom message with namespace and other keys": {
+			src:       []byte(`{"wasmd":{"terminate":{}},"ping":{}}`),
+			expCustom: true,
+		},
+		"invalid payload in namespace": {
+			src:    []byte(`{"wasmd":"ping"}`),
+			expErr: types.ErrInvalidMsg,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			chainCalled, customCalled = false, false
+			_, gotErr := encoder.Encode(sdk.Context{}, RandomAccountAddress(t), "", wasmvmtypes.CosmosMsg{Custom: spec.src})
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), gotErr)
+			} else {
+				require.NoError(t, gotErr)
+			}
+			assert.Equal(t, spec.expChain, chainCalled)
+			assert.Equal(t, spec.expCustom, customCalled)
+		})
+	}
+}
+
 func TestConvertWasmCoinToSdkCoin(t *testing.T) {
 	specs := map[string]struct {
 		src    wasmvmtypes.Coin
@@ -1,7 +1,11 @@
 package keeper
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	wasmvm "github.com/CosmWasm/wasmvm"
@@ -11,11 +15,15 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	clienttypes "github.com/cosmos/ibc-go/v2/modules/core/02-client/types"
 	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
 	ibcexported "github.com/cosmos/ibc-go/v2/modules/core/exported"
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -76,7 +84,7 @@ func TestMessageHandlerChainDispatch(t *testing.T) {
 			*gotMsgs = make([]wasmvmtypes.CosmosMsg, 0)
 
 			// when
-			h := MessageHandlerChain{spec.handlers}
+			h := MessageHandlerChain{handlers: spec.handlers}
 			gotEvents, gotData, gotErr := h.DispatchMsg(sdk.Context{}, RandomAccountAddress(t), "anyPort", myMsg)
 
 			// then
@@ -91,6 +99,180 @@ func TestMessageHandlerChainDispatch(t *testing.T) {
 	}
 }
 
+func TestMessageHandlerChainMiddleware(t *testing.T) {
+	myContractAddr := RandomAccountAddress(t)
+	myMsg := wasmvmtypes.CosmosMsg{Custom: []byte(`{}`)}
+	myEvents := []sdk.Event{{Type: "myEvent"}}
+	myData := [][]byte{[]byte("myData")}
+
+	specs := map[string]struct {
+		handlerErr    error
+		beforeErr     error
+		expErr        *sdkerrors.Error
+		expDispatched bool
+		expCalls      []string
+	}{
+		"before and after called in order": {
+			expDispatched: true,
+			expCalls:      []string{"before-1", "before-2", "after-1", "after-2"},
+		},
+		"after called with handler error": {
+			handlerErr:    types.ErrInvalidMsg,
+			expErr:        types.ErrInvalidMsg,
+			expDispatched: true,
+			expCalls:      []string{"before-1", "before-2", "after-1", "after-2"},
+		},
+		"before error rejects message": {
+			beforeErr: sdkerrors.ErrUnauthorized,
+			expErr:    sdkerrors.ErrUnauthorized,
+			expCalls:  []string{"before-1"},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotCalls []string
+			hooks := func(name string, beforeErr error) MessageHandlerHooks {
+				return MessageHandlerHooks{
+					Before: func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.CosmosMsg) error {
+						assert.Equal(t, myContractAddr, contractAddr)
+						assert.Equal(t, myMsg, msg)
+						gotCalls = append(gotCalls, "before-"+name)
+						return beforeErr
+					},
+					After: func(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.CosmosMsg, events []sdk.Event, data [][]byte, err error) {
+						assert.Equal(t, myContractAddr, contractAddr)
+						assert.Equal(t, myMsg, msg)
+						if spec.handlerErr != nil {
+							assert.Equal(t, spec.handlerErr, err)
+						} else {
+							assert.Equal(t, myEvents, events)
+							assert.Equal(t, myData, data)
+						}
+						gotCalls = append(gotCalls, "after-"+name)
+					},
+				}
+			}
+			var dispatched bool
+			handler := &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+					dispatched = true
+					if spec.handlerErr != nil {
+						return nil, nil, spec.handlerErr
+					}
+					return myEvents, myData, nil
+				},
+			}
+			chain := NewMessageHandlerChain(handler)
+			chain.middlewares = []MessageHandlerMiddleware{hooks("1", spec.beforeErr), hooks("2", nil)}
+
+			// when
+			gotEvents, gotData, gotErr := chain.DispatchMsg(sdk.Context{}, myContractAddr, "", myMsg)
+
+			// then
+			assert.Equal(t, spec.expCalls, gotCalls)
+			assert.Equal(t, spec.expDispatched, dispatched)
+			if spec.expErr != nil {
+				require.True(t, spec.expErr.Is(gotErr), gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, myEvents, gotEvents)
+			assert.Equal(t, myData, gotData)
+		})
+	}
+}
+
+func TestMessageHandlerInsertedFirstInterceptsBankMsg(t *testing.T) {
+	interceptor, gotMsgs := wasmtesting.NewCapturingMessageHandler()
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithMessageHandlerAt(0, interceptor))
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	_, _, bob := keyPubAddr()
+	sendMsg := wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+		ToAddress: bob.String(),
+		Amount:    wasmvmtypes.Coins{{Denom: "denom", Amount: "100"}},
+	}}}
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{{Msg: sendMsg, ReplyOn: wasmvmtypes.ReplyNever}}}, 0, nil
+	}
+
+	// when
+	_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), deposit)
+
+	// then the message was handled by the interceptor and not sent by the bank module
+	require.NoError(t, err)
+	assert.Equal(t, []wasmvmtypes.CosmosMsg{sendMsg}, *gotMsgs)
+	assert.Equal(t, deposit, keepers.BankKeeper.GetAllBalances(ctx, example.Contract))
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, bob).IsZero())
+}
+
+func TestDispatchedMsgEvents(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithDispatchedMsgEvents())
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	_, _, bob := keyPubAddr()
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{{ReplyOn: wasmvmtypes.ReplyNever, Msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+			ToAddress: bob.String(),
+			Amount:    wasmvmtypes.Coins{{Denom: "denom", Amount: "100"}},
+		}}}}}}, 0, nil
+	}
+	em := sdk.NewEventManager()
+
+	// when
+	_, err := keepers.ContractKeeper.Execute(ctx.WithEventManager(em), example.Contract, example.CreatorAddr, []byte(`{}`), deposit)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, deposit, keepers.BankKeeper.GetAllBalances(ctx, bob))
+	var gotEvents []sdk.Event
+	for _, e := range em.Events() {
+		if e.Type == types.EventTypeDispatchedMsg {
+			gotEvents = append(gotEvents, e)
+		}
+	}
+	// the bank send response has no data
+	exp := sdk.NewEvent(types.EventTypeDispatchedMsg,
+		sdk.NewAttribute(types.AttributeKeyMsgType, "/cosmos.bank.v1beta1.MsgSend"),
+		sdk.NewAttribute(types.AttributeKeyDataLength, "0"),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+	)
+	assert.Equal(t, []sdk.Event{exp}, gotEvents)
+}
+
+func TestSDKMessageHandlerDispatchedMsgEvent(t *testing.T) {
+	router := baseapp.NewRouter()
+	router.AddRoute(sdk.NewRoute(types.RouterKey, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		return &sdk.Result{Data: []byte("myData")}, nil
+	}))
+	myContractAddr := RandomAccountAddress(t)
+	encoder := func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+		return []sdk.Msg{&types.MsgExecuteContract{
+			Sender:   myContractAddr.String(),
+			Contract: RandomBech32AccountAddress(t),
+			Msg:      []byte("{}"),
+		}}, nil
+	}
+	k := Keeper{messenger: NewMessageHandlerChain(NewSDKMessageHandler(router, baseapp.NewMsgServiceRouter(), MessageEncoders{Custom: encoder}))}
+	WithDispatchedMsgEvents().apply(&k)
+
+	// when
+	gotEvents, gotData, gotErr := k.messenger.DispatchMsg(sdk.Context{}, myContractAddr, "", wasmvmtypes.CosmosMsg{Custom: []byte("{}")})
+
+	// then the data length but not the data is in the event
+	require.NoError(t, gotErr)
+	assert.Equal(t, [][]byte{[]byte("myData")}, gotData)
+	exp := []sdk.Event{sdk.NewEvent(types.EventTypeDispatchedMsg,
+		sdk.NewAttribute(types.AttributeKeyMsgType, "/cosmwasm.wasm.v1.MsgExecuteContract"),
+		sdk.NewAttribute(types.AttributeKeyDataLength, "6"),
+	)}
+	assert.Equal(t, exp, gotEvents)
+}
+
 func TestSDKMessageHandlerDispatch(t *testing.T) {
 	myEvent := sdk.NewEvent("myEvent", sdk.NewAttribute("foo", "bar"))
 	const myData = "myData"
@@ -214,6 +396,373 @@ func TestSDKMessageHandlerDispatch(t *testing.T) {
 	}
 }
 
+func TestSDKMessageHandlerDispatchEventOrder(t *testing.T) {
+	var dispatched int
+	routeFn := func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		dispatched++
+		switch dispatched {
+		case 1: // a handler that returns result events only
+			return &sdk.Result{Events: sdk.Events{sdk.NewEvent("returned-1")}.ToABCIEvents()}, nil
+		case 2: // a handler that emits to the context only
+			ctx.EventManager().EmitEvent(sdk.NewEvent("emitted-2"))
+			return &sdk.Result{}, nil
+		default: // a handler that emits to the context and returns the same events, like the sdk handlers do
+			ctx.EventManager().EmitEvent(sdk.NewEvent(fmt.Sprintf("both-%d", dispatched)))
+			return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+		}
+	}
+	router := baseapp.NewRouter()
+	router.AddRoute(sdk.NewRoute(types.RouterKey, routeFn))
+	myContractAddr := RandomAccountAddress(t)
+	encoder := func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+		msgs := make([]sdk.Msg, 3)
+		for i := range msgs {
+			msgs[i] = &types.MsgExecuteContract{
+				Sender:   myContractAddr.String(),
+				Contract: RandomBech32AccountAddress(t),
+				Msg:      []byte("{}"),
+			}
+		}
+		return msgs, nil
+	}
+	h := NewSDKMessageHandler(router, baseapp.NewMsgServiceRouter(), MessageEncoders{Custom: encoder})
+	em := sdk.NewEventManager()
+
+	// when
+	gotEvents, _, gotErr := h.DispatchMsg(sdk.Context{}.WithEventManager(em), myContractAddr, "myPort", wasmvmtypes.CosmosMsg{Custom: []byte("{}")})
+
+	// then
+	require.NoError(t, gotErr)
+	// each event is returned once and in dispatch order
+	exp := []sdk.Event{sdk.NewEvent("returned-1"), sdk.NewEvent("emitted-2"), sdk.NewEvent("both-3")}
+	assert.Equal(t, exp, gotEvents)
+	assert.Empty(t, em.Events())
+}
+
+func TestSDKMessageHandlerAcceptedMsgTypes(t *testing.T) {
+	myContractAddr := RandomAccountAddress(t)
+	myValidatorAddr := sdk.ValAddress(RandomAccountAddress(t)).String()
+	delegateMsg := wasmvmtypes.CosmosMsg{Staking: &wasmvmtypes.StakingMsg{
+		Delegate: &wasmvmtypes.DelegateMsg{Validator: myValidatorAddr, Amount: wasmvmtypes.NewCoin(1, "stake")},
+	}}
+	undelegateMsg := wasmvmtypes.CosmosMsg{Staking: &wasmvmtypes.StakingMsg{
+		Undelegate: &wasmvmtypes.UndelegateMsg{Validator: myValidatorAddr, Amount: wasmvmtypes.NewCoin(1, "stake")},
+	}}
+
+	specs := map[string]struct {
+		srcTypeURLs []string
+		srcMsg      wasmvmtypes.CosmosMsg
+		expErr      *sdkerrors.Error
+	}{
+		"no allowlist accepts all": {
+			srcMsg: undelegateMsg,
+		},
+		"allowed type": {
+			srcTypeURLs: []string{"/cosmos.staking.v1beta1.MsgDelegate"},
+			srcMsg:      delegateMsg,
+		},
+		"not allowed type": {
+			srcTypeURLs: []string{"/cosmos.staking.v1beta1.MsgDelegate"},
+			srcMsg:      undelegateMsg,
+			expErr:      sdkerrors.ErrUnauthorized,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotMsgs []sdk.Msg
+			router := baseapp.NewRouter()
+			router.AddRoute(sdk.NewRoute(stakingtypes.RouterKey, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+				gotMsgs = append(gotMsgs, msg)
+				return &sdk.Result{}, nil
+			}))
+			k := Keeper{messenger: NewMessageHandlerChain(NewSDKMessageHandler(router, baseapp.NewMsgServiceRouter(), DefaultEncoders(nil, nil)))}
+			WithAcceptedMsgTypeURLs(spec.srcTypeURLs...).apply(&k)
+
+			// when
+			_, _, gotErr := k.messenger.DispatchMsg(sdk.Context{}, myContractAddr, "", spec.srcMsg)
+
+			// then
+			require.True(t, spec.expErr.Is(gotErr), "exp %v but got %#+v", spec.expErr, gotErr)
+			if spec.expErr != nil {
+				assert.Contains(t, gotErr.Error(), "/cosmos.staking.v1beta1.MsgUndelegate")
+				assert.Empty(t, gotMsgs)
+				return
+			}
+			assert.Len(t, gotMsgs, 1)
+		})
+	}
+}
+
+func TestSDKMessageHandlerFeegrantGranter(t *testing.T) {
+	myContractAddr := RandomAccountAddress(t)
+	otherAddr := RandomAccountAddress(t)
+	allowance := &feegrant.BasicAllowance{SpendLimit: sdk.NewCoins(sdk.NewInt64Coin("stake", 100))}
+	allowanceBin, err := proto.Marshal(allowance)
+	require.NoError(t, err)
+	foreignGrant, err := feegrant.NewMsgGrantAllowance(allowance, otherAddr, RandomAccountAddress(t))
+	require.NoError(t, err)
+	foreignGrantBin, err := proto.Marshal(foreignGrant)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		srcMsg     wasmvmtypes.CosmosMsg
+		expGranter sdk.AccAddress
+		expErr     *sdkerrors.Error
+	}{
+		"contract as granter": {
+			srcMsg: wasmvmtypes.CosmosMsg{Custom: []byte(fmt.Sprintf(`{"wasmd":{"feegrant":{"grant_allowance":{"grantee":%q,"allowance":{"type_url":"/cosmos.feegrant.v1beta1.BasicAllowance","value":%q}}}}}`,
+				otherAddr.String(), base64.StdEncoding.EncodeToString(allowanceBin)))},
+			expGranter: myContractAddr,
+		},
+		"other granter": {
+			srcMsg: wasmvmtypes.CosmosMsg{Stargate: &wasmvmtypes.StargateMsg{
+				TypeURL: "/cosmos.feegrant.v1beta1.MsgGrantAllowance",
+				Value:   foreignGrantBin,
+			}},
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+	}
+	encodingConfig := MakeEncodingConfig(t)
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotMsgs []sdk.Msg
+			msgRouter := baseapp.NewMsgServiceRouter()
+			msgRouter.SetInterfaceRegistry(encodingConfig.InterfaceRegistry)
+			feegrant.RegisterMsgServer(msgRouter, &capturingFeegrantMsgServer{capture: func(msg sdk.Msg) {
+				gotMsgs = append(gotMsgs, msg)
+			}})
+			h := NewSDKMessageHandler(baseapp.NewRouter(), msgRouter, DefaultEncoders(encodingConfig.Marshaler, nil))
+
+			// when
+			_, _, gotErr := h.DispatchMsg(sdk.Context{}.WithContext(context.Background()), myContractAddr, "", spec.srcMsg)
+
+			// then
+			if spec.expErr != nil {
+				require.True(t, spec.expErr.Is(gotErr), "exp %v but got %#+v", spec.expErr, gotErr)
+				assert.Empty(t, gotMsgs)
+				return
+			}
+			require.NoError(t, gotErr)
+			require.Len(t, gotMsgs, 1)
+			assert.Equal(t, spec.expGranter.String(), gotMsgs[0].(*feegrant.MsgGrantAllowance).Granter)
+		})
+	}
+}
+
+type capturingFeegrantMsgServer struct {
+	feegrant.UnimplementedMsgServer
+	capture func(msg sdk.Msg)
+}
+
+func (m *capturingFeegrantMsgServer) GrantAllowance(_ context.Context, msg *feegrant.MsgGrantAllowance) (*feegrant.MsgGrantAllowanceResponse, error) {
+	m.capture(msg)
+	return &feegrant.MsgGrantAllowanceResponse{}, nil
+}
+
+func TestSDKMessageHandlerDebugLogging(t *testing.T) {
+	myContractAddr := RandomAccountAddress(t)
+	mySecretMsg := &types.MsgExecuteContract{
+		Sender:   myContractAddr.String(),
+		Contract: RandomBech32AccountAddress(t),
+		Msg:      []byte(`{"secret":"my-password"}`),
+	}
+	router := baseapp.NewRouter()
+	router.AddRoute(sdk.NewRoute(types.RouterKey, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		return &sdk.Result{}, nil
+	}))
+	myInvalidMsg := &types.MsgExecuteContract{
+		Sender:   "invalid",
+		Contract: RandomBech32AccountAddress(t),
+		Msg:      []byte(`{"secret":"my-password"}`),
+	}
+
+	specs := map[string]struct {
+		logLevel log.Option
+		msg      sdk.Msg
+		expLog   bool
+		expErr   bool
+	}{
+		"debug level": {
+			logLevel: log.AllowDebug(),
+			msg:      mySecretMsg,
+			expLog:   true,
+		},
+		"info level": {
+			logLevel: log.AllowInfo(),
+			msg:      mySecretMsg,
+		},
+		"debug level - invalid signer": {
+			logLevel: log.AllowDebug(),
+			msg:      myInvalidMsg,
+			expLog:   true,
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			h := NewSDKMessageHandler(router, baseapp.NewMsgServiceRouter(), MessageEncoders{
+				Custom: func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+					return []sdk.Msg{spec.msg}, nil
+				},
+			})
+			var buf bytes.Buffer
+			ctx := sdk.Context{}.WithLogger(log.NewFilter(log.NewTMJSONLogger(&buf), spec.logLevel))
+
+			// when
+			_, _, gotErr := h.DispatchMsg(ctx, myContractAddr, "", wasmvmtypes.CosmosMsg{Custom: []byte("{}")})
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+			} else {
+				require.NoError(t, gotErr)
+			}
+			if !spec.expLog {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.Contains(t, buf.String(), `"type_url":"/cosmwasm.wasm.v1.MsgExecuteContract"`)
+			assert.Contains(t, buf.String(), myContractAddr.String())
+			assert.NotContains(t, buf.String(), "my-password")
+			if spec.expErr {
+				assert.Contains(t, buf.String(), `"routing":"rejected"`)
+				assert.Contains(t, buf.String(), `"error_code"`)
+				return
+			}
+			assert.Contains(t, buf.String(), `"routing":"legacy"`)
+			assert.Contains(t, buf.String(), `"signers"`)
+		})
+	}
+}
+
+func TestGasChargingMessageHandler(t *testing.T) {
+	bankMsg := wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{ToAddress: "foo", Amount: wasmvmtypes.Coins{wasmvmtypes.NewCoin(1, "stake")}}}}
+	ibcMsg := wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &wasmvmtypes.SendPacketMsg{ChannelID: "channel-0", Data: []byte("data")}}}
+	ibcMsgBz, err := json.Marshal(ibcMsg)
+	require.NoError(t, err)
+	surcharges := map[string]MsgGasSurcharge{
+		MsgVariantBank:     {Flat: 100},
+		MsgVariantIBC:      {Flat: 1000, PerByte: 10},
+		MsgVariantCustom:   {Flat: 200},
+		MsgVariantAuthz:    {Flat: 300},
+		MsgVariantFeegrant: {Flat: 400},
+	}
+
+	specs := map[string]struct {
+		srcMsg  wasmvmtypes.CosmosMsg
+		nextErr error
+		expGas  sdk.Gas
+		expErr  *sdkerrors.Error
+	}{
+		"flat surcharge": {
+			srcMsg: bankMsg,
+			expGas: 100,
+		},
+		"flat and per byte surcharge": {
+			srcMsg: ibcMsg,
+			expGas: 1000 + 10*uint64(len(ibcMsgBz)),
+		},
+		"custom message": {
+			srcMsg: wasmvmtypes.CosmosMsg{Custom: []byte(`{"foo":{}}`)},
+			expGas: 200,
+		},
+		"authz chain message": {
+			srcMsg: wasmvmtypes.CosmosMsg{Custom: []byte(`{"wasmd":{"authz":{"revoke":{"grantee":"foo","msg_type_url":"/bar"}}}}`)},
+			expGas: 300,
+		},
+		"feegrant chain message": {
+			srcMsg: wasmvmtypes.CosmosMsg{Custom: []byte(`{"wasmd":{"feegrant":{"revoke_allowance":{"grantee":"foo"}}}}`)},
+			expGas: 400,
+		},
+		"no surcharge for chain message variant": {
+			srcMsg: wasmvmtypes.CosmosMsg{Custom: []byte(`{"wasmd":{"terminate":{}}}`)},
+			expGas: 0,
+		},
+		"invalid chain message charged as custom": {
+			srcMsg: wasmvmtypes.CosmosMsg{Custom: []byte(`{"wasmd":{"authz":1}}`)},
+			expGas: 200,
+		},
+		"no surcharge for variant": {
+			srcMsg: wasmvmtypes.CosmosMsg{Staking: &wasmvmtypes.StakingMsg{Delegate: &wasmvmtypes.DelegateMsg{Validator: "foo", Amount: wasmvmtypes.NewCoin(1, "stake")}}},
+			expGas: 0,
+		},
+		"surcharge charged when next handler fails": {
+			srcMsg:  bankMsg,
+			nextErr: types.ErrInvalidMsg,
+			expGas:  100,
+			expErr:  types.ErrInvalidMsg,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotMsgs []wasmvmtypes.CosmosMsg
+			next := &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+					gotMsgs = append(gotMsgs, msg)
+					return nil, [][]byte{[]byte("myData")}, spec.nextErr
+				},
+			}
+			h := NewGasChargingMessageHandler(next, surcharges)
+			ctx := sdk.Context{}.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+			// when
+			_, gotData, gotErr := h.DispatchMsg(ctx, RandomAccountAddress(t), "", spec.srcMsg)
+
+			// then
+			assert.Equal(t, spec.expGas, ctx.GasMeter().GasConsumed())
+			assert.Equal(t, []wasmvmtypes.CosmosMsg{spec.srcMsg}, gotMsgs)
+			if spec.expErr != nil {
+				require.True(t, spec.expErr.Is(gotErr), gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, [][]byte{[]byte("myData")}, gotData)
+		})
+	}
+}
+
+func TestNewGasChargingMessageHandler(t *testing.T) {
+	next := &wasmtesting.MockMessageHandler{}
+	specs := map[string]struct {
+		src      map[string]MsgGasSurcharge
+		expPanic bool
+	}{
+		"all variants": {
+			src: map[string]MsgGasSurcharge{
+				MsgVariantBank: {}, MsgVariantCustom: {}, MsgVariantDistribution: {}, MsgVariantGov: {},
+				MsgVariantIBC: {}, MsgVariantStaking: {}, MsgVariantStargate: {}, MsgVariantWasm: {},
+				MsgVariantMultiSend: {}, MsgVariantTerminate: {}, MsgVariantGasHint: {}, MsgVariantAuthz: {},
+				MsgVariantFeegrant: {},
+			},
+		},
+		"empty": {
+			src: map[string]MsgGasSurcharge{},
+		},
+		"nil": {},
+		"unknown variant": {
+			src:      map[string]MsgGasSurcharge{"banks": {Flat: 1}},
+			expPanic: true,
+		},
+		"variant in other case": {
+			src:      map[string]MsgGasSurcharge{"Bank": {Flat: 1}},
+			expPanic: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			if spec.expPanic {
+				assert.Panics(t, func() {
+					NewGasChargingMessageHandler(next, spec.src)
+				})
+				return
+			}
+			assert.NotPanics(t, func() {
+				NewGasChargingMessageHandler(next, spec.src)
+			})
+		})
+	}
+}
+
 func TestIBCRawPacketHandler(t *testing.T) {
 	ibcPort := "contractsIBCPort"
 	var ctx sdk.Context
@@ -242,11 +791,13 @@ func TestIBCRawPacketHandler(t *testing.T) {
 		},
 	}
 
+	contractAddr := RandomAccountAddress(t)
 	specs := map[string]struct {
 		srcMsg        wasmvmtypes.SendPacketMsg
 		chanKeeper    types.ChannelKeeper
 		capKeeper     types.CapabilityKeeper
 		expPacketSent channeltypes.Packet
+		expEvents     []sdk.Event
 		expErr        *sdkerrors.Error
 	}{
 		"all good": {
@@ -266,6 +817,13 @@ func TestIBCRawPacketHandler(t *testing.T) {
 				Data:               []byte("myData"),
 				TimeoutHeight:      clienttypes.Height{RevisionNumber: 1, RevisionHeight: 2},
 			},
+			expEvents: []sdk.Event{sdk.NewEvent(
+				"ibc_send_packet",
+				sdk.NewAttribute("_contract_address", contractAddr.String()),
+				sdk.NewAttribute("packet_src_port", ibcPort),
+				sdk.NewAttribute("packet_src_channel", "channel-1"),
+				sdk.NewAttribute("packet_sequence", "1"),
+			)},
 		},
 		"sequence not found returns error": {
 			srcMsg: wasmvmtypes.SendPacketMsg{
@@ -298,14 +856,14 @@ func TestIBCRawPacketHandler(t *testing.T) {
 			capturedPacket = nil
 			// when
 			h := NewIBCRawPacketHandler(spec.chanKeeper, spec.capKeeper)
-			data, evts, gotErr := h.DispatchMsg(ctx, RandomAccountAddress(t), ibcPort, wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &spec.srcMsg}})
+			evts, data, gotErr := h.DispatchMsg(ctx, contractAddr, ibcPort, wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &spec.srcMsg}})
 			// then
 			require.True(t, spec.expErr.Is(gotErr), "exp %v but got %#+v", spec.expErr, gotErr)
 			if spec.expErr != nil {
 				return
 			}
 			assert.Nil(t, data)
-			assert.Nil(t, evts)
+			assert.Equal(t, spec.expEvents, evts)
 			assert.Equal(t, spec.expPacketSent, capturedPacket)
 		})
 	}
@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// iteratorLimitStore limits the number of entries that a single iterator returns. Iterators signal exhaustion
+// after the limit so that contracts must paginate large ranges.
+type iteratorLimitStore struct {
+	sdk.KVStore
+	limit uint64
+}
+
+// Iterator returns an iterator over the domain that is exhausted after the limit
+func (s iteratorLimitStore) Iterator(start, end []byte) sdk.Iterator {
+	return &limitedIterator{Iterator: s.KVStore.Iterator(start, end), limit: s.limit}
+}
+
+// ReverseIterator returns a reverse iterator over the domain that is exhausted after the limit
+func (s iteratorLimitStore) ReverseIterator(start, end []byte) sdk.Iterator {
+	return &limitedIterator{Iterator: s.KVStore.ReverseIterator(start, end), limit: s.limit}
+}
+
+// limitedIterator is valid for up to limit entries of the parent iterator
+type limitedIterator struct {
+	sdk.Iterator
+	limit uint64
+	pos   uint64
+}
+
+// Valid returns false when the parent iterator is exhausted or the limit was reached
+func (i *limitedIterator) Valid() bool {
+	return i.pos < i.limit && i.Iterator.Valid()
+}
+
+// Next moves to the next entry
+func (i *limitedIterator) Next() {
+	if !i.Valid() {
+		panic("iterator is invalid")
+	}
+	i.pos++
+	i.Iterator.Next()
+}
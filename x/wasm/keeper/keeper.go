@@ -3,9 +3,11 @@ package keeper
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"math"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -19,16 +21,16 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkaddress "github.com/cosmos/cosmos-sdk/types/address"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
-// contractMemoryLimit is the memory limit of each contract execution (in MiB)
-// constant value so all nodes run with the same limit.
-const contractMemoryLimit = 32
-
 // Option is an extension point to instantiate keeper with non default values
 type Option interface {
 	apply(*Keeper)
@@ -45,6 +47,29 @@ type CoinTransferrer interface {
 	TransferCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
 }
 
+// GasPriceSource is an extension point to provide the chain's globally agreed gas prices to contracts.
+// The node local minimum gas prices must not be used as they are not deterministic.
+type GasPriceSource interface {
+	// MinGasPrices returns the gas prices that apply to the current block
+	MinGasPrices(ctx sdk.Context) sdk.DecCoins
+}
+
+// GovParamSource provides the governance params that contracts can read with the gov params chain query.
+// The gov keeper implements it.
+type GovParamSource interface {
+	GetDepositParams(ctx sdk.Context) govtypes.DepositParams
+	GetVotingParams(ctx sdk.Context) govtypes.VotingParams
+	GetTallyParams(ctx sdk.Context) govtypes.TallyParams
+}
+
+// ExecuteRateLimiter is an extension point to limit how often a contract can be executed, for example N executes per
+// block. It is called before each execute, including executes by other contracts. Implementations must be
+// deterministic and count in the store or context but never use the wall-clock time.
+type ExecuteRateLimiter interface {
+	// CheckExecute returns an error, preferably wrapping `types.ErrRateLimited`, when the contract must not be executed
+	CheckExecute(ctx sdk.Context, contractAddr sdk.AccAddress) error
+}
+
 // WasmVMResponseHandler is an extension point to handles the response data returned by a contract call.
 type WasmVMResponseHandler interface {
 	// Handle processes the data returned by a contract invocation.
@@ -63,6 +88,9 @@ type Keeper struct {
 	cdc                   codec.Codec
 	accountKeeper         types.AccountKeeper
 	bank                  CoinTransferrer
+	bankView              types.BankViewKeeper
+	stakingKeeper         types.StakingKeeper
+	distKeeper            types.DistributionKeeper
 	portKeeper            types.PortKeeper
 	capabilityKeeper      types.CapabilityKeeper
 	wasmVM                types.WasmerEngine
@@ -71,8 +99,34 @@ type Keeper struct {
 	messenger             Messenger
 	// queryGasLimit is the max wasmvm gas that can be spent on executing a query with a contract
 	queryGasLimit uint64
-	paramSpace    paramtypes.Subspace
-	gasRegister   GasRegister
+	// queryCheckTxGasLimit is the max wasmvm gas that can be spent on executing a query with a contract sent by a client
+	queryCheckTxGasLimit uint64
+	// simulationGasLimit is the max gas that can be spent on simulating a contract execution or instantiation via gRPC
+	simulationGasLimit uint64
+	paramSpace         paramtypes.Subspace
+	gasRegister        GasRegister
+	// reentrancyGuard rejects calls to contracts that are already executing in the current call stack
+	reentrancyGuard bool
+	// addressGenerator builds the address for a new contract instance
+	addressGenerator AddressGenerator
+	// allowPrefundedContractAddress accepts an unused base account at a new contract address instead of failing
+	allowPrefundedContractAddress bool
+	// capabilities are the features supported for contracts that the wasmvm was configured with
+	capabilities []string
+	// gasPriceSource provides the gas prices returned by the min gas prices chain query
+	gasPriceSource GasPriceSource
+	// executeRateLimiter is consulted before each execute. Nil for no limit.
+	executeRateLimiter ExecuteRateLimiter
+	// gasBreakdownEvents emits the gas consumed by contract calls split into VM and host gas
+	gasBreakdownEvents bool
+	// connectionKeeper reads the IBC connections of a client. Nil when not configured.
+	connectionKeeper types.ConnectionKeeper
+	// govParamSource provides the gov params for the gov params chain query. Nil when not configured.
+	govParamSource GovParamSource
+	// pinnedCodes tracks the codes pinned in the wasmvm memory cache within the node local memory budget
+	pinnedCodes *pinnedCodeCache
+	// bankKeeper moves the timelocked funds from and to the module account
+	bankKeeper types.BankKeeper
 }
 
 // NewKeeper creates a new contract Keeper instance
@@ -97,7 +151,10 @@ func NewKeeper(
 	supportedFeatures string,
 	opts ...Option,
 ) Keeper {
-	wasmer, err := wasmvm.NewVM(filepath.Join(homeDir, "wasm"), supportedFeatures, contractMemoryLimit, wasmConfig.ContractDebugMode, wasmConfig.MemoryCacheSize)
+	if err := wasmConfig.ValidateBasic(); err != nil {
+		panic(err)
+	}
+	wasmer, err := wasmvm.NewVM(filepath.Join(homeDir, "wasm"), supportedFeatures, wasmConfig.ContractMemoryLimit, wasmConfig.ContractDebugMode, wasmConfig.MemoryCacheSize)
 	if err != nil {
 		panic(err)
 	}
@@ -107,24 +164,44 @@ func NewKeeper(
 	}
 
 	keeper := &Keeper{
-		storeKey:         storeKey,
-		cdc:              cdc,
-		wasmVM:           wasmer,
-		accountKeeper:    accountKeeper,
-		bank:             NewBankCoinTransferrer(bankKeeper),
-		portKeeper:       portKeeper,
-		capabilityKeeper: capabilityKeeper,
-		messenger:        NewDefaultMessageHandler(router, msgRouter, channelKeeper, capabilityKeeper, bankKeeper, cdc, portSource),
-		queryGasLimit:    wasmConfig.SmartQueryGasLimit,
-		paramSpace:       paramSpace,
-		gasRegister:      NewDefaultWasmGasRegister(),
+		storeKey:             storeKey,
+		cdc:                  cdc,
+		wasmVM:               wasmer,
+		accountKeeper:        accountKeeper,
+		bank:                 NewBankCoinTransferrer(bankKeeper),
+		bankView:             bankKeeper,
+		bankKeeper:           bankKeeper,
+		stakingKeeper:        stakingKeeper,
+		distKeeper:           distKeeper,
+		portKeeper:           portKeeper,
+		capabilityKeeper:     capabilityKeeper,
+		messenger:            NewDefaultMessageHandler(router, msgRouter, channelKeeper, capabilityKeeper, bankKeeper, cdc, portSource),
+		queryGasLimit:        wasmConfig.SmartQueryGasLimit,
+		queryCheckTxGasLimit: wasmConfig.SmartQueryCheckTxGasLimit,
+		simulationGasLimit:   wasmConfig.SmartQueryGasLimit,
+		paramSpace:           paramSpace,
+		gasRegister:          NewDefaultWasmGasRegister(),
+		addressGenerator:     BuildContractAddress,
+		capabilities:         parseCapabilities(supportedFeatures),
+		gasPriceSource:       NewDefaultGasPriceSource(stakingKeeper),
+		pinnedCodes:          newPinnedCodeCache(uint64(wasmConfig.PinnedCodeMemoryBudget) * 1024 * 1024),
+	}
+	if wasmConfig.SimulationGasLimit != nil {
+		keeper.simulationGasLimit = *wasmConfig.SimulationGasLimit
 	}
 	keeper.wasmVMQueryHandler = DefaultQueryPlugins(bankKeeper, stakingKeeper, distKeeper, channelKeeper, queryRouter, keeper)
 	for _, o := range opts {
 		o.apply(keeper)
 	}
+	// the terminate chain message modifies the contract info and is therefore handled by the keeper. It is added
+	// after the options so that custom or decorated message handlers can not drop it.
+	if c, ok := keeper.messenger.(*MessageHandlerChain); ok {
+		c.handlers = append(c.handlers, NewTerminateContractMessageHandler(keeper))
+	} else {
+		keeper.messenger = NewMessageHandlerChain(keeper.messenger, NewTerminateContractMessageHandler(keeper))
+	}
 	// not updateable, yet
-	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(keeper.messenger, keeper))
+	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(newPacketTrackingMessenger(keeper.messenger, keeper), keeper))
 	return *keeper
 }
 
@@ -146,6 +223,308 @@ func (k Keeper) GetMaxWasmCodeSize(ctx sdk.Context) uint64 {
 	return a
 }
 
+// GetMaxMsgSize returns the max size of a json message that can be passed to a contract
+func (k Keeper) GetMaxMsgSize(ctx sdk.Context) uint64 {
+	var a uint64
+	k.paramSpace.Get(ctx, types.ParamStoreKeyMaxMsgSize, &a)
+	return a
+}
+
+// GetInstanceCost returns the SDK gas charged each time a wasm instance is loaded
+func (k Keeper) GetInstanceCost(ctx sdk.Context) uint64 {
+	var a uint64
+	k.paramSpace.Get(ctx, types.ParamStoreKeyInstanceCost, &a)
+	return a
+}
+
+// GetCompileCost returns the SDK gas charged per byte for compiling wasm code
+func (k Keeper) GetCompileCost(ctx sdk.Context) uint64 {
+	var a uint64
+	k.paramSpace.Get(ctx, types.ParamStoreKeyCompileCost, &a)
+	return a
+}
+
+// GetCodeChecksumAllowlist returns the checksums of the codes that can be instantiated or migrated to. All codes
+// can be used when empty.
+func (k Keeper) GetCodeChecksumAllowlist(ctx sdk.Context) [][]byte {
+	var a [][]byte
+	k.paramSpace.Get(ctx, types.ParamStoreKeyCodeChecksumAllowlist, &a)
+	return a
+}
+
+func (k Keeper) isChecksumAllowed(ctx sdk.Context, checksum []byte) bool {
+	return types.Params{CodeChecksumAllowlist: k.GetCodeChecksumAllowlist(ctx)}.IsChecksumAllowed(checksum)
+}
+
+// signatureVerifyCosts returns the signature verification costs of the gas register. The default costs are
+// returned for custom gas registers without support.
+func (k Keeper) signatureVerifyCosts(algorithm string) sdk.Gas {
+	if r, ok := k.gasRegister.(SignatureVerifyGasRegister); ok {
+		return r.SignatureVerifyCosts(algorithm)
+	}
+	return NewDefaultWasmGasRegister().SignatureVerifyCosts(algorithm)
+}
+
+// hashCosts returns the hash costs of the gas register. The default costs are returned for custom gas registers
+// without support.
+func (k Keeper) hashCosts(dataLen int) sdk.Gas {
+	if r, ok := k.gasRegister.(HashGasRegister); ok {
+		return r.HashCosts(dataLen)
+	}
+	return NewDefaultWasmGasRegister().HashCosts(dataLen)
+}
+
+// contractBalance returns all balances of the contract. The balance prefix of the contract is iterated once without
+// gas metering. A flat cost is charged for the iteration plus a cost per byte of the denom keys and coin values read.
+func (k Keeper) contractBalance(ctx sdk.Context, contractAddress sdk.AccAddress) sdk.Coins {
+	balances := sdk.NewCoins()
+	var readBytes uint64
+	k.bankView.IterateAccountBalances(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), contractAddress, func(c sdk.Coin) bool {
+		// the bank stores the coin under its denom in the account balance prefix
+		readBytes += uint64(len(c.Denom) + c.Size())
+		balances = append(balances, c)
+		return false
+	})
+	ctx.GasMeter().ConsumeGas(DefaultContractBalanceFlatCost+DefaultContractBalancePerByteCost*readBytes, "contract balance")
+	return balances
+}
+
+// minGasPrices returns the gas prices of the configured gas price source
+func (k Keeper) minGasPrices(ctx sdk.Context) sdk.DecCoins {
+	return k.gasPriceSource.MinGasPrices(ctx)
+}
+
+// delegationRewards returns the pending rewards of the delegation. The rewards are empty when no delegation exists.
+func (k Keeper) delegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (sdk.DecCoins, error) {
+	if _, found := k.stakingKeeper.GetDelegation(ctx, delAddr, valAddr); !found {
+		return sdk.NewDecCoins(), nil
+	}
+	// the distribution query modifies state to calculate the rewards, so it must not be persisted
+	cache, _ := ctx.CacheContext()
+	res, err := k.distKeeper.DelegationRewards(sdk.WrapSDKContext(cache), &distributiontypes.QueryDelegationRewardsRequest{
+		DelegatorAddress: delAddr.String(),
+		ValidatorAddress: valAddr.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Rewards, nil
+}
+
+// totalSupply returns the total supply of the denom
+func (k Keeper) totalSupply(ctx sdk.Context, denom string) sdk.Coin {
+	return k.bankView.GetSupply(ctx, denom)
+}
+
+// stakingPool returns the bonded and not bonded tokens of the staking module in the bond denom.
+// Like the staking pool query, the amounts are read from the balances of the staking pool module accounts.
+func (k Keeper) stakingPool(ctx sdk.Context) (bonded, notBonded sdk.Coin) {
+	denom := k.stakingKeeper.BondDenom(ctx)
+	bonded = k.bankView.GetBalance(ctx, authtypes.NewModuleAddress(stakingtypes.BondedPoolName), denom)
+	notBonded = k.bankView.GetBalance(ctx, authtypes.NewModuleAddress(stakingtypes.NotBondedPoolName), denom)
+	return bonded, notBonded
+}
+
+// validator returns the validator with the operator address independent of its bond status
+func (k Keeper) validator(ctx sdk.Context, valAddr sdk.ValAddress) (stakingtypes.Validator, bool) {
+	return k.stakingKeeper.GetValidator(ctx, valAddr)
+}
+
+// activeValidatorSet returns the number of validators in the last committed validator set and the max size of the set.
+// The last validator powers index is bounded by the max validators param.
+func (k Keeper) activeValidatorSet(ctx sdk.Context) (bondedCount, maxValidators uint32) {
+	k.stakingKeeper.IterateLastValidatorPowers(ctx, func(sdk.ValAddress, int64) bool {
+		bondedCount++
+		return false
+	})
+	return bondedCount, k.stakingKeeper.MaxValidators(ctx)
+}
+
+// govParams returns the current params of the gov module
+func (k Keeper) govParams(ctx sdk.Context) (govtypes.DepositParams, govtypes.VotingParams, govtypes.TallyParams, error) {
+	if k.govParamSource == nil {
+		return govtypes.DepositParams{}, govtypes.VotingParams{}, govtypes.TallyParams{}, wasmvmtypes.UnsupportedRequest{Kind: "gov params without gov param source"}
+	}
+	return k.govParamSource.GetDepositParams(ctx), k.govParamSource.GetVotingParams(ctx), k.govParamSource.GetTallyParams(ctx), nil
+}
+
+// clientConnections returns up to limit connections of the IBC client, ordered as stored by the connection keeper
+// and starting after the connection id startAfter. The next key is the id to continue with, or empty when all
+// connections were returned. Unknown clients have no connections.
+func (k Keeper) clientConnections(ctx sdk.Context, clientID, startAfter string, limit uint32) ([]types.IBCConnection, string, error) {
+	if k.connectionKeeper == nil {
+		return nil, "", wasmvmtypes.UnsupportedRequest{Kind: "ibc client connections without connection keeper"}
+	}
+	ids, _ := k.connectionKeeper.GetClientConnectionPaths(ctx, clientID)
+	if startAfter != "" {
+		pos := len(ids)
+		for i, id := range ids {
+			if id == startAfter {
+				pos = i + 1
+				break
+			}
+		}
+		ids = ids[pos:]
+	}
+	var next string
+	if uint32(len(ids)) > limit {
+		ids = ids[:limit]
+		next = ids[limit-1]
+	}
+	result := make([]types.IBCConnection, 0, len(ids))
+	for _, id := range ids {
+		conn, found := k.connectionKeeper.GetConnection(ctx, id)
+		if !found {
+			continue
+		}
+		result = append(result, types.IBCConnection{ID: id, State: conn.State.String()})
+	}
+	return result, next, nil
+}
+
+// isModuleAccount returns true when an account exists for the address and is a module account
+func (k Keeper) isModuleAccount(ctx sdk.Context, addr sdk.AccAddress) bool {
+	_, ok := k.accountKeeper.GetAccount(ctx, addr).(authtypes.ModuleAccountI)
+	return ok
+}
+
+// instanceGasRegister returns the gas register with the instance cost of the module params applied.
+// Custom gas registers without support for cost params are returned unmodified.
+func (k Keeper) instanceGasRegister(ctx sdk.Context) GasRegister {
+	if r, ok := k.gasRegister.(CostParamsGasRegister); ok {
+		return r.WithInstanceCost(k.GetInstanceCost(ctx))
+	}
+	return k.gasRegister
+}
+
+// compileGasRegister returns the gas register with the compile cost of the module params applied.
+// Custom gas registers without support for cost params are returned unmodified.
+func (k Keeper) compileGasRegister(ctx sdk.Context) GasRegister {
+	if r, ok := k.gasRegister.(CostParamsGasRegister); ok {
+		return r.WithCompileCost(k.GetCompileCost(ctx))
+	}
+	return k.gasRegister
+}
+
+// assertNoReentrancy returns an error when the re-entrancy guard is enabled for the keeper or the context
+// and the given contract is already executing in the current call stack
+func (k Keeper) assertNoReentrancy(ctx sdk.Context, contractAddress sdk.AccAddress) error {
+	if (k.reentrancyGuard || types.IsReentrancyGuardEnabled(ctx)) && types.IsContractOnCallStack(ctx, contractAddress) {
+		return sdkerrors.Wrap(types.ErrReentrancy, contractAddress.String())
+	}
+	return nil
+}
+
+// assertMsgSize returns an error when the given contract message exceeds the max msg size param
+func (k Keeper) assertMsgSize(ctx sdk.Context, msg []byte) error {
+	if maxSize := k.GetMaxMsgSize(ctx); uint64(len(msg)) > maxSize {
+		return sdkerrors.Wrapf(types.ErrLimit, "msg size %d exceeds max %d", len(msg), maxSize)
+	}
+	return nil
+}
+
+// GetMaxContractResponseDataSize returns the max size of the data field in a contract response
+func (k Keeper) GetMaxContractResponseDataSize(ctx sdk.Context) uint64 {
+	var a uint64
+	k.paramSpace.Get(ctx, types.ParamStoreKeyMaxContractResponseDataSize, &a)
+	return a
+}
+
+// GetMaxGasPerTxPercent returns the max share of the block gas limit in percent that a tx can consume. It is
+// enforced by the LimitTxGasDecorator. Zero means no limit.
+func (k Keeper) GetMaxGasPerTxPercent(ctx sdk.Context) uint32 {
+	var a uint32
+	k.paramSpace.Get(ctx, types.ParamStoreKeyMaxGasPerTxPercent, &a)
+	return a
+}
+
+// GetMaxCodeCount returns the max number of codes that can be uploaded. Zero means unlimited.
+func (k Keeper) GetMaxCodeCount(ctx sdk.Context) uint64 {
+	var a uint64
+	k.paramSpace.Get(ctx, types.ParamStoreKeyMaxCodeCount, &a)
+	return a
+}
+
+// GetMaxGasRefundPercent returns the max share of the gas used by a contract message that is refunded for contract
+// state deletions. Zero means disabled.
+func (k Keeper) GetMaxGasRefundPercent(ctx sdk.Context) uint32 {
+	var a uint32
+	k.paramSpace.Get(ctx, types.ParamStoreKeyMaxGasRefundPercent, &a)
+	return a
+}
+
+// GetMaxReplyGas returns the max gas that a single reply call can consume. Zero means unlimited.
+func (k Keeper) GetMaxReplyGas(ctx sdk.Context) uint64 {
+	var a uint64
+	k.paramSpace.Get(ctx, types.ParamStoreKeyMaxReplyGas, &a)
+	return a
+}
+
+// GetMaxIteratorItems returns the max number of entries that a single contract state iterator returns.
+// Zero means unlimited.
+func (k Keeper) GetMaxIteratorItems(ctx sdk.Context) uint64 {
+	var a uint64
+	k.paramSpace.Get(ctx, types.ParamStoreKeyMaxIteratorItems, &a)
+	return a
+}
+
+// GetBlockTimeAverageWindow returns the number of blocks of the block time moving average. Zero means disabled.
+func (k Keeper) GetBlockTimeAverageWindow(ctx sdk.Context) uint32 {
+	var a uint32
+	k.paramSpace.Get(ctx, types.ParamStoreKeyBlockTimeAverageWindow, &a)
+	return a
+}
+
+// IsContractInstantiationAllowed returns true when contracts can instantiate other contracts
+func (k Keeper) IsContractInstantiationAllowed(ctx sdk.Context) bool {
+	var a bool
+	k.paramSpace.Get(ctx, types.ParamStoreKeyAllowContractInstantiation, &a)
+	return a
+}
+
+// IsCodeUploadDeduplicated returns true when uploads of a stored wasm code with the same instantiate permission and
+// source return the existing code id
+func (k Keeper) IsCodeUploadDeduplicated(ctx sdk.Context) bool {
+	var a bool
+	k.paramSpace.Get(ctx, types.ParamStoreKeyDeduplicateCodeUploads, &a)
+	return a
+}
+
+// GetCodeCommitmentWindow returns the number of blocks in which a committed code can be revealed.
+// Zero disables the commit and reveal upload.
+func (k Keeper) GetCodeCommitmentWindow(ctx sdk.Context) uint32 {
+	var a uint32
+	k.paramSpace.Get(ctx, types.ParamStoreKeyCodeCommitmentWindow, &a)
+	return a
+}
+
+// IsQueryCacheEnabled returns true when smart query results are cached within a transaction
+func (k Keeper) IsQueryCacheEnabled(ctx sdk.Context) bool {
+	var a bool
+	k.paramSpace.Get(ctx, types.ParamStoreKeyQueryCacheEnabled, &a)
+	return a
+}
+
+// assertResponseDataSize returns an error when the data returned by a contract exceeds the max contract response
+// data size param. The param is not read for empty data.
+func (k Keeper) assertResponseDataSize(ctx sdk.Context, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if maxSize := k.GetMaxContractResponseDataSize(ctx); uint64(len(data)) > maxSize {
+		return sdkerrors.Wrapf(types.ErrLimit, "contract response data size %d exceeds max %d", len(data), maxSize)
+	}
+	return nil
+}
+
+// assertValidFunds returns an error when the funds sent to a contract are not sorted, not positive or contain duplicate denoms
+func assertValidFunds(funds sdk.Coins) error {
+	if err := funds.Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	}
+	return nil
+}
+
 // GetParams returns the total set of wasm parameters.
 func (k Keeper) GetParams(ctx sdk.Context) types.Params {
 	var params types.Params
@@ -165,28 +544,52 @@ func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte,
 	if !authZ.CanCreateCode(k.getUploadAccessConfig(ctx), creator) {
 		return 0, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not create code")
 	}
+	if maxCodes := k.GetMaxCodeCount(ctx); maxCodes != 0 {
+		// removed codes free up room for new uploads
+		if stored := k.GetCodeCount(ctx); stored >= maxCodes {
+			return 0, sdkerrors.Wrapf(types.ErrCreateFailed, "max code count reached: %d", maxCodes)
+		}
+	}
 	wasmCode, err = uncompress(wasmCode, k.GetMaxWasmCodeSize(ctx))
 	if err != nil {
 		return 0, sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
 	}
-	ctx.GasMeter().ConsumeGas(k.gasRegister.CompileCosts(len(wasmCode)), "Compiling WASM Bytecode")
+	compileGasRegister := k.compileGasRegister(ctx)
+	ctx.GasMeter().ConsumeGas(compileGasRegister.CompileCosts(len(wasmCode)), "Compiling WASM Bytecode")
+	if r, ok := compileGasRegister.(CompileComplexityGasRegister); ok {
+		ctx.GasMeter().ConsumeGas(r.CompileComplexityCosts(wasmCode), "Compiling WASM Bytecode complexity")
+	}
 
 	checksum, err := k.wasmVM.Create(wasmCode)
 	if err != nil {
 		return 0, sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
 	}
+	if instantiateAccess == nil {
+		defaultAccessConfig := k.getInstantiateAccessConfig(ctx).With(creator)
+		instantiateAccess = &defaultAccessConfig
+	}
+	codeInfo := types.NewCodeInfo(checksum, creator, *instantiateAccess)
+	codeInfo.Source, codeInfo.Builder = types.CodeSource(ctx)
+	if k.IsCodeUploadDeduplicated(ctx) {
+		// the index is written with each upload so that a second upload in the same block finds the first one
+		if existingID, found := k.GetCodeIDByChecksum(ctx, checksum); found && k.isSameCodeSetup(ctx, existingID, codeInfo) {
+			k.Logger(ctx).Debug("code stored already", "code_id", existingID)
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeStoreCode,
+				sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(existingID, 10)),
+			))
+			return existingID, nil
+		}
+	}
 	report, err := k.wasmVM.AnalyzeCode(checksum)
 	if err != nil {
 		return 0, sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
 	}
 	codeID = k.autoIncrementID(ctx, types.KeyLastCodeID)
 	k.Logger(ctx).Debug("storing new contract", "features", report.RequiredFeatures, "code_id", codeID)
-	if instantiateAccess == nil {
-		defaultAccessConfig := k.getInstantiateAccessConfig(ctx).With(creator)
-		instantiateAccess = &defaultAccessConfig
-	}
-	codeInfo := types.NewCodeInfo(checksum, creator, *instantiateAccess)
 	k.storeCodeInfo(ctx, codeID, codeInfo)
+	k.setCodeCount(ctx, k.GetCodeCount(ctx)+1)
+	k.addToCodeChecksumIndex(ctx, checksum, codeID)
 
 	evt := sdk.NewEvent(
 		types.EventTypeStoreCode,
@@ -200,6 +603,16 @@ func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte,
 	return codeID, nil
 }
 
+// isSameCodeSetup returns true when the stored code has the instantiate permission and source metadata of the new
+// upload. Only then can an upload resolve to the existing code id without dropping the uploader's settings.
+func (k Keeper) isSameCodeSetup(ctx sdk.Context, codeID uint64, newInfo types.CodeInfo) bool {
+	existing := k.GetCodeInfo(ctx, codeID)
+	return existing != nil &&
+		existing.InstantiateConfig.Equals(newInfo.InstantiateConfig) &&
+		existing.Source == newInfo.Source &&
+		existing.Builder == newInfo.Builder
+}
+
 func (k Keeper) storeCodeInfo(ctx sdk.Context, codeID uint64, codeInfo types.CodeInfo) {
 	store := ctx.KVStore(k.storeKey)
 	// 0x01 | codeID (uint64) -> ContractInfo
@@ -226,20 +639,99 @@ func (k Keeper) importCode(ctx sdk.Context, codeID uint64, codeInfo types.CodeIn
 	}
 	// 0x01 | codeID (uint64) -> ContractInfo
 	store.Set(key, k.cdc.MustMarshal(&codeInfo))
+	k.setCodeCount(ctx, k.GetCodeCount(ctx)+1)
+	// genesis codes come in any order, keep the index on the lowest code id as create would do
+	if existingID, found := k.GetCodeIDByChecksum(ctx, codeInfo.CodeHash); !found || codeID < existingID {
+		store.Set(types.GetCodeIDByChecksumKey(codeInfo.CodeHash), sdk.Uint64ToBigEndian(codeID))
+	}
 	return nil
 }
 
+// GetCodeIDByChecksum returns the id of the first stored code with the given checksum
+func (k Keeper) GetCodeIDByChecksum(ctx sdk.Context, checksum []byte) (uint64, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetCodeIDByChecksumKey(checksum))
+	if bz == nil {
+		return 0, false
+	}
+	return sdk.BigEndianToUint64(bz), true
+}
+
+// addToCodeChecksumIndex stores the code id for the checksum unless another code with the same checksum is indexed
+// already. The index always points to the first code so that duplicates resolve deterministically.
+func (k Keeper) addToCodeChecksumIndex(ctx sdk.Context, checksum []byte, codeID uint64) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetCodeIDByChecksumKey(checksum)
+	if store.Has(key) {
+		return
+	}
+	store.Set(key, sdk.Uint64ToBigEndian(codeID))
+}
+
+// removeFromCodeChecksumIndex deletes the index entry for the checksum when it points to the given code id. The entry
+// moves to the next code with the same checksum, if any.
+func (k Keeper) removeFromCodeChecksumIndex(ctx sdk.Context, checksum []byte, codeID uint64) {
+	if existingID, found := k.GetCodeIDByChecksum(ctx, checksum); !found || existingID != codeID {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetCodeIDByChecksumKey(checksum))
+	k.IterateCodeInfos(ctx, func(otherID uint64, info types.CodeInfo) bool {
+		if otherID == codeID || !bytes.Equal(info.CodeHash, checksum) {
+			return false
+		}
+		// codes are iterated in id order, so this is the lowest remaining id
+		store.Set(types.GetCodeIDByChecksumKey(checksum), sdk.Uint64ToBigEndian(otherID))
+		return true
+	})
+}
+
 func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "instantiate")
 
-	instanceCosts := k.gasRegister.NewContractInstanceCosts(k.IsPinnedCode(ctx, codeID), len(initMsg))
+	ctx, refundGas := k.trackGasRefund(ctx)
+	if err := k.assertMsgSize(ctx, initMsg); err != nil {
+		return nil, nil, err
+	}
+	if err := assertValidFunds(deposit); err != nil {
+		return nil, nil, err
+	}
+
+	if !k.IsContractInstantiationAllowed(ctx) && k.HasContractInfo(ctx, creator) {
+		return nil, nil, sdkerrors.Wrap(types.ErrUnsupportedForContract, "contracts can not instantiate contracts")
+	}
+
+	instanceCosts := k.instanceGasRegister(ctx).NewContractInstanceCosts(k.IsPinnedCode(ctx, codeID), len(initMsg))
 	ctx.GasMeter().ConsumeGas(instanceCosts, "Loading CosmWasm module: instantiate")
 
+	// get contact info
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetCodeKey(codeID))
+	if bz == nil {
+		return nil, nil, sdkerrors.Wrap(types.ErrNotFound, "code")
+	}
+	var codeInfo types.CodeInfo
+	k.cdc.MustUnmarshal(bz, &codeInfo)
+
 	// create contract address
-	contractAddress := k.generateContractAddress(ctx, codeID)
-	existingAcct := k.accountKeeper.GetAccount(ctx, contractAddress)
+	contractAddress := k.reservedContractAddress(ctx, creator, codeInfo.CodeHash)
+	if contractAddress != nil {
+		k.releaseContractAddress(ctx, contractAddress, creator, codeInfo.CodeHash)
+	} else {
+		contractAddress = k.generateContractAddress(ctx, codeID)
+	}
+	existingAcct, err := k.existingContractAddressAccount(ctx, contractAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx = types.WithContractOnCallStack(ctx, contractAddress)
+
+	// create the contract account so that it can be identified as such
 	if existingAcct != nil {
-		return nil, nil, sdkerrors.Wrap(types.ErrAccountExists, existingAcct.GetAddress().String())
+		// keep the account number of the pre-funded account
+		k.accountKeeper.SetAccount(ctx, types.NewContractAccount(existingAcct, codeID))
+	} else {
+		contractAccount := k.accountKeeper.NewAccount(ctx, types.NewContractAccount(authtypes.NewBaseAccountWithAddress(contractAddress), codeID))
+		k.accountKeeper.SetAccount(ctx, contractAccount)
 	}
 
 	// deposit initial contract funds
@@ -247,45 +739,43 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 		if err := k.bank.TransferCoins(ctx, creator, contractAddress, deposit); err != nil {
 			return nil, nil, err
 		}
-
-	} else {
-		// create an empty account (so we don't have issues later)
-		// TODO: can we remove this?
-		contractAccount := k.accountKeeper.NewAccountWithAddress(ctx, contractAddress)
-		k.accountKeeper.SetAccount(ctx, contractAccount)
 	}
 
-	// get contact info
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(types.GetCodeKey(codeID))
-	if bz == nil {
-		return nil, nil, sdkerrors.Wrap(types.ErrNotFound, "code")
-	}
-	var codeInfo types.CodeInfo
-	k.cdc.MustUnmarshal(bz, &codeInfo)
+	k.pinnedCodes.touch(codeInfo.CodeHash)
 
 	if !authZ.CanInstantiateContract(codeInfo.InstantiateConfig, creator) {
 		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not instantiate")
 	}
+	if !k.isChecksumAllowed(ctx, codeInfo.CodeHash) {
+		return nil, nil, sdkerrors.Wrapf(types.ErrChecksumNotAllowed, "code id %d", codeID)
+	}
 
 	// prepare params for contract instantiate call
 	env := types.NewEnv(ctx, contractAddress)
 	info := types.NewInfo(creator, deposit)
 
+	resetQueryCache(ctx)
+
 	// create prefixed data store
 	// 0x03 | BuildContractAddress (sdk.AccAddress)
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
+	prefixStore := k.contractStore(ctx, contractAddress)
 
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
 
 	// instantiate wasm contract
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, err := k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	var res *wasmvmtypes.Response
+	var gasUsed uint64
+	gasBreakdown := k.startGasBreakdown(ctx, contractAddress)
+	err = callVM(func() (err error) {
+		res, gasUsed, err = k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+		return err
+	})
+	gasBreakdown(gasUsed)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if err != nil {
-		return nil, nil, sdkerrors.Wrap(types.ErrInstantiateFailed, err.Error())
+		return nil, nil, wrapVMError(err, types.ErrInstantiateFailed)
 	}
 
 	// persist instance first
@@ -309,36 +799,78 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 	// store contract before dispatch so that contract could be called back
 	historyEntry := contractInfo.InitialHistory(initMsg)
 	k.addToContractCodeSecondaryIndex(ctx, contractAddress, historyEntry)
+	k.addToContractCreatorSecondaryIndex(ctx, creator, historyEntry.Updated, contractAddress)
 	k.appendToContractHistory(ctx, contractAddress, historyEntry)
 	k.storeContractInfo(ctx, contractAddress, &contractInfo)
+	k.addCodeInstanceCount(ctx, codeID, 1)
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeInstantiate,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
 		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAdmin, contractInfo.Admin),
+		sdk.NewAttribute(types.AttributeKeyLabel, contractInfo.Label),
 	))
 
+	if err := k.assertResponseDataSize(ctx, res.Data); err != nil {
+		return nil, nil, err
+	}
+
 	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Messages, res.Attributes, res.Data, res.Events)
 	if err != nil {
 		return nil, nil, sdkerrors.Wrap(err, "dispatch")
 	}
 
+	refundGas()
 	return contractAddress, data, nil
 }
 
 // Execute executes the contract instance
 func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "execute")
+	ctx, refundGas := k.trackGasRefund(ctx)
+	if err := k.assertMsgSize(ctx, msg); err != nil {
+		return nil, err
+	}
+	if err := k.assertNoReentrancy(ctx, contractAddress); err != nil {
+		return nil, err
+	}
+	if err := assertValidFunds(coins); err != nil {
+		return nil, err
+	}
+	// the memo belongs to this execution only and must not show up in the events of nested calls
+	memo := types.ExecuteMemo(ctx)
+	ctx = types.WithExecuteMemo(ctx, "")
+	// the caller is on the call stack when the execute was dispatched by another contract
+	dispatchedByContract := types.IsContractOnCallStack(ctx, caller)
+	ctx = types.WithContractOnCallStack(ctx, contractAddress)
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
 	}
+	if contractInfo.Terminated {
+		return nil, sdkerrors.Wrap(types.ErrContractTerminated, contractAddress.String())
+	}
+	if contractInfo.Paused {
+		return nil, sdkerrors.Wrap(types.ErrContractPaused, contractAddress.String())
+	}
+	if contractInfo.ExecutePermission != nil && !contractInfo.ExecutePermission.Allowed(caller) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not execute contract")
+	}
+	if k.executeRateLimiter != nil {
+		if err := k.executeRateLimiter.CheckExecute(ctx, contractAddress); err != nil {
+			return nil, err
+		}
+	}
 
-	executeCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
+	executeCosts := k.instanceGasRegister(ctx).InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
 	ctx.GasMeter().ConsumeGas(executeCosts, "Loading CosmWasm module: execute")
 
 	// add more funds
 	if !coins.IsZero() {
+		if err := contractInfo.AssertAcceptsFunds(coins); err != nil {
+			return nil, err
+		}
 		if err := k.bank.TransferCoins(ctx, caller, contractAddress, coins); err != nil {
 			return nil, err
 		}
@@ -347,45 +879,98 @@ func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	env := types.NewEnv(ctx, contractAddress)
 	info := types.NewInfo(caller, coins)
 
+	resetQueryCache(ctx)
+
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	var res *wasmvmtypes.Response
+	var gasUsed uint64
+	gasBreakdown := k.startGasBreakdown(ctx, contractAddress)
+	execErr := callVM(func() (err error) {
+		res, gasUsed, err = k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+		return err
+	})
+	gasBreakdown(gasUsed)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, wrapVMError(execErr, types.ErrExecuteFailed)
 	}
 
-	ctx.EventManager().EmitEvent(sdk.NewEvent(
+	executeEvent := sdk.NewEvent(
 		types.EventTypeExecute,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
-	))
+	)
+	if memo != "" {
+		executeEvent = executeEvent.AppendAttributes(sdk.NewAttribute(types.AttributeKeyMemo, memo))
+	}
+	if dispatchedByContract {
+		executeEvent = executeEvent.AppendAttributes(sdk.NewAttribute(types.AttributeKeyContractOrigin, caller.String()))
+	}
+	ctx.EventManager().EmitEvent(executeEvent)
+
+	if err := k.assertResponseDataSize(ctx, res.Data); err != nil {
+		return nil, err
+	}
 
 	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Messages, res.Attributes, res.Data, res.Events)
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "dispatch")
 	}
 
+	refundGas()
 	return data, nil
 }
 
+// instantiateGovManaged instantiates a contract with the governance module account as admin and marks it as managed
+// by governance so that only governance proposals can migrate or modify it.
+func (k Keeper) instantiateGovManaged(ctx sdk.Context, codeID uint64, creator sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error) {
+	govAddr := authtypes.NewModuleAddress(govtypes.ModuleName)
+	contractAddress, data, err := k.instantiate(ctx, codeID, creator, govAddr, initMsg, label, deposit, authZ)
+	if err != nil {
+		return nil, nil, err
+	}
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	contractInfo.GovManaged = true
+	k.storeContractInfo(ctx, contractAddress, contractInfo)
+	return contractAddress, data, nil
+}
+
 func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "migrate")
-	migrateSetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, newCodeID), len(msg))
+	ctx, refundGas := k.trackGasRefund(ctx)
+	if err := k.assertMsgSize(ctx, msg); err != nil {
+		return nil, err
+	}
+	migrateSetupCosts := k.instanceGasRegister(ctx).InstantiateContractCosts(k.IsPinnedCode(ctx, newCodeID), len(msg))
 	ctx.GasMeter().ConsumeGas(migrateSetupCosts, "Loading CosmWasm module: migrate")
 
 	contractInfo := k.GetContractInfo(ctx, contractAddress)
 	if contractInfo == nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
 	}
-	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+	if contractInfo.Terminated {
+		return nil, sdkerrors.Wrap(types.ErrContractTerminated, contractAddress.String())
+	}
+	if err := assertGovManagedAccess(contractInfo, authZ); err != nil {
+		return nil, err
+	}
+	if !authZ.CanMigrateContract(contractInfo.MigratePermission, contractInfo.AdminAddr(), caller) {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not migrate")
 	}
+	ctx = types.WithContractOnCallStack(ctx, contractAddress)
 
 	newCodeInfo := k.GetCodeInfo(ctx, newCodeID)
 	if newCodeInfo == nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown code")
 	}
+	if !k.isChecksumAllowed(ctx, newCodeInfo.CodeHash) {
+		return nil, sdkerrors.Wrapf(types.ErrChecksumNotAllowed, "code id %d", newCodeID)
+	}
+	oldCodeInfo := k.GetCodeInfo(ctx, contractInfo.CodeID)
+	if oldCodeInfo == nil {
+		return nil, sdkerrors.Wrap(types.ErrNotFound, "code info of current contract code")
+	}
 
 	// check for IBC flag
 	switch report, err := k.wasmVM.AnalyzeCode(newCodeInfo.CodeHash); {
@@ -405,62 +990,153 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 
 	env := types.NewEnv(ctx, contractAddress)
 
+	resetQueryCache(ctx)
+
+	// track state batch iterations of the contract so that an unfinished migration can be resumed
+	cursor := types.NewMigrationCursor(contractAddress)
+
 	// prepare querier
-	querier := k.newQueryHandler(ctx, contractAddress)
+	querier := k.newQueryHandler(types.WithMigrationCursor(ctx, cursor), contractAddress)
 
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
+	prefixStore := k.contractStore(ctx, contractAddress)
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, err := k.wasmVM.Migrate(newCodeInfo.CodeHash, env, msg, &prefixStore, cosmwasmAPI, &querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	var res *wasmvmtypes.Response
+	var gasUsed uint64
+	gasBreakdown := k.startGasBreakdown(ctx, contractAddress)
+	err := callVM(func() (err error) {
+		res, gasUsed, err = k.wasmVM.Migrate(newCodeInfo.CodeHash, env, msg, &prefixStore, cosmwasmAPI, &querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+		return err
+	})
+	gasBreakdown(gasUsed)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if err != nil {
-		return nil, sdkerrors.Wrap(types.ErrMigrationFailed, err.Error())
+		return nil, wrapVMError(err, types.ErrMigrationFailed)
 	}
+	// a migration that does not continue the state iteration completes it
+	position, _ := cursor.Position()
+	k.setMigrationProgress(ctx, contractAddress, position)
 
 	// delete old secondary index entry
 	k.removeFromContractCodeSecondaryIndex(ctx, contractAddress, k.getLastContractHistoryEntry(ctx, contractAddress))
+	k.addCodeInstanceCount(ctx, contractInfo.CodeID, -1)
+	k.addCodeInstanceCount(ctx, newCodeID, 1)
+
 	// persist migration updates
 	historyEntry := contractInfo.AddMigration(ctx, newCodeID, msg)
 	k.appendToContractHistory(ctx, contractAddress, historyEntry)
 	k.addToContractCodeSecondaryIndex(ctx, contractAddress, historyEntry)
 	k.storeContractInfo(ctx, contractAddress, contractInfo)
+	k.setContractAccountCodeID(ctx, contractAddress, newCodeID)
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeMigrate,
 		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(newCodeID, 10)),
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeySrcChecksum, hex.EncodeToString(oldCodeInfo.CodeHash)),
+		sdk.NewAttribute(types.AttributeKeyDestChecksum, hex.EncodeToString(newCodeInfo.CodeHash)),
 	))
+	emitAdminActionEvent(ctx, types.AdminActionMigrate, contractAddress, caller)
+
+	if err := k.assertResponseDataSize(ctx, res.Data); err != nil {
+		return nil, err
+	}
 
 	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Messages, res.Attributes, res.Data, res.Events)
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "dispatch")
 	}
 
+	refundGas()
 	return data, nil
 }
 
+// ExecuteAs executes the contract with the caller address as sender and moves the funds from the caller account to
+// the contract. Like Sudo, it can never be called by governance or an external tx, but only by another native Go
+// module directly, for example a relayer module for meta transactions. The keeper doesn't verify that the caller
+// authorized the execution, that is the responsibility of the module that calls it.
+// Unlike Execute, the caller must be a user account: contracts and module accounts are rejected so that a module
+// can not impersonate them, as contracts and modules may trust messages from each other.
+func (k Keeper) ExecuteAs(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
+	if err := sdk.VerifyAddressFormat(caller); err != nil {
+		return nil, sdkerrors.Wrap(err, "caller")
+	}
+	if k.HasContractInfo(ctx, caller) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not execute as contract")
+	}
+	if k.isModuleAccount(ctx, caller) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not execute as module account")
+	}
+	return k.execute(ctx, contractAddress, caller, msg, coins)
+}
+
+// BootstrapContract stores the wasm code, instantiates it and pins the code in one atomic operation. It is meant for
+// upgrade handlers that deploy system contracts. The gov module account is used as creator and the governance
+// authorization policy applies. On any failure all state changes are rolled back.
+func (k Keeper) BootstrapContract(ctx sdk.Context, wasmCode []byte, initMsg []byte, label string, admin sdk.AccAddress) (sdk.AccAddress, uint64, error) {
+	creator := authtypes.NewModuleAddress(govtypes.ModuleName)
+	authZ := GovAuthorizationPolicy{}
+	cacheCtx, commit := ctx.CacheContext()
+	em := sdk.NewEventManager()
+	cacheCtx = cacheCtx.WithEventManager(em)
+
+	codeID, err := k.create(cacheCtx, creator, wasmCode, nil, authZ)
+	if err != nil {
+		return nil, 0, sdkerrors.Wrap(err, "store code")
+	}
+	contractAddr, _, err := k.instantiate(cacheCtx, codeID, creator, admin, initMsg, label, nil, authZ)
+	if err != nil {
+		return nil, 0, sdkerrors.Wrap(err, "instantiate")
+	}
+	if err := k.pinCode(cacheCtx, codeID); err != nil {
+		return nil, 0, sdkerrors.Wrap(err, "pin code")
+	}
+	commit()
+	ctx.EventManager().EmitEvents(em.Events())
+	return contractAddr, codeID, nil
+}
+
 // Sudo allows priviledged access to a contract. This can never be called by governance or external tx, but only by
 // another native Go module directly. Thus, the keeper doesn't place any access controls on it, that is the
 // responsibility or the app developer (who passes the wasm.Keeper in app.go)
 func (k Keeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "sudo")
+	ctx, refundGas := k.trackGasRefund(ctx)
+	if err := k.assertNoReentrancy(ctx, contractAddress); err != nil {
+		return nil, err
+	}
+	ctx = types.WithContractOnCallStack(ctx, contractAddress)
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
 	}
+	if contractInfo.Terminated {
+		return nil, sdkerrors.Wrap(types.ErrContractTerminated, contractAddress.String())
+	}
+	if contractInfo.Paused {
+		return nil, sdkerrors.Wrap(types.ErrContractPaused, contractAddress.String())
+	}
 
-	sudoSetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
+	sudoSetupCosts := k.instanceGasRegister(ctx).InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
 	ctx.GasMeter().ConsumeGas(sudoSetupCosts, "Loading CosmWasm module: sudo")
 
 	env := types.NewEnv(ctx, contractAddress)
 
+	resetQueryCache(ctx)
+
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	var res *wasmvmtypes.Response
+	var gasUsed uint64
+	gasBreakdown := k.startGasBreakdown(ctx, contractAddress)
+	execErr := callVM(func() (err error) {
+		res, gasUsed, err = k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+		return err
+	})
+	gasBreakdown(gasUsed)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, wrapVMError(execErr, types.ErrExecuteFailed)
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -468,20 +1144,58 @@ func (k Keeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
 	))
 
+	if err := k.assertResponseDataSize(ctx, res.Data); err != nil {
+		return nil, err
+	}
+
 	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Messages, res.Attributes, res.Data, res.Events)
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "dispatch")
 	}
 
+	refundGas()
 	return data, nil
 }
 
 // reply is only called from keeper internal functions (dispatchSubmessages) after processing the submessage
 func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+	if gasLimit := k.GetMaxReplyGas(ctx); gasLimit != 0 {
+		return k.replyWithGasLimit(ctx, gasLimit, contractAddress, reply)
+	}
+	return k.replyContract(ctx, contractAddress, reply)
+}
+
+// replyWithGasLimit calls the reply entrypoint with a gas meter limited to the max reply gas param. The gas spent is
+// charged to the parent gas meter. When the limit is exceeded, the full limit is charged and an error returned so
+// that the reply fails like any other reply error.
+func (k Keeper) replyWithGasLimit(ctx sdk.Context, gasLimit uint64, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) (data []byte, err error) {
+	subCtx := ctx.WithGasMeter(sdk.NewGasMeter(gasLimit))
+
+	// catch out of gas panic and charge the entire gas limit
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			ctx.GasMeter().ConsumeGas(gasLimit, "Reply OutOfGas panic")
+			err = sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "reply exceeds max reply gas")
+		}
+	}()
+	data, err = k.replyContract(subCtx, contractAddress, reply)
+
+	// make sure we charge the parent what was spent
+	ctx.GasMeter().ConsumeGas(subCtx.GasMeter().GasConsumed(), "From reply with limited gas")
+	return data, err
+}
+
+func (k Keeper) replyContract(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
 	}
+	if contractInfo.Terminated {
+		return nil, sdkerrors.Wrap(types.ErrContractTerminated, contractAddress.String())
+	}
 
 	// always consider this pinned
 	replyCosts := k.gasRegister.ReplyCosts(true, reply)
@@ -489,19 +1203,34 @@ func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply was
 
 	env := types.NewEnv(ctx, contractAddress)
 
+	resetQueryCache(ctx)
+
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Reply(codeInfo.CodeHash, env, reply, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	var res *wasmvmtypes.Response
+	var gasUsed uint64
+	execErr := callVM(func() (err error) {
+		res, gasUsed, err = k.wasmVM.Reply(codeInfo.CodeHash, env, reply, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+		return err
+	})
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, wrapVMError(execErr, types.ErrExecuteFailed)
 	}
 
-	ctx.EventManager().EmitEvent(sdk.NewEvent(
-		types.EventTypeReply,
+	attrs := []sdk.Attribute{
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
-	))
+		sdk.NewAttribute(types.AttributeKeySubMsgID, strconv.FormatUint(reply.ID, 10)),
+	}
+	if subMsgGas, ok := types.SubMsgGasUsed(ctx); ok {
+		attrs = append(attrs, sdk.NewAttribute(types.AttributeKeyGasUsed, strconv.FormatUint(subMsgGas, 10)))
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeReply, attrs...))
+
+	if err := k.assertResponseDataSize(ctx, res.Data); err != nil {
+		return nil, err
+	}
 
 	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Messages, res.Attributes, res.Data, res.Events)
 	if err != nil {
@@ -522,6 +1251,23 @@ func (k Keeper) removeFromContractCodeSecondaryIndex(ctx sdk.Context, contractAd
 	ctx.KVStore(k.storeKey).Delete(types.GetContractByCreatedSecondaryIndexKey(contractAddress, entry))
 }
 
+// addToContractCreatorSecondaryIndex adds element to the index for contracts-by-creator queries
+func (k Keeper) addToContractCreatorSecondaryIndex(ctx sdk.Context, creatorAddress sdk.AccAddress, position *types.AbsoluteTxPosition, contractAddress sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetContractByCreatorSecondaryIndexKey(creatorAddress, position, contractAddress), []byte{})
+}
+
+// IterateContractsByCreator iterates over all contracts with given creator address ASC on instantiation time.
+func (k Keeper) IterateContractsByCreator(ctx sdk.Context, creator sdk.AccAddress, cb func(address sdk.AccAddress) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractsByCreatorPrefix(creator))
+	for iter := prefixStore.Iterator(nil, nil); iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if cb(key[types.AbsoluteTxPositionLen:]) {
+			return
+		}
+	}
+}
+
 // IterateContractsByCode iterates over all contracts with given codeID ASC on code update time.
 func (k Keeper) IterateContractsByCode(ctx sdk.Context, codeID uint64, cb func(address sdk.AccAddress) bool) {
 	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractByCodeIDSecondaryIndexPrefix(codeID))
@@ -533,19 +1279,214 @@ func (k Keeper) IterateContractsByCode(ctx sdk.Context, codeID uint64, cb func(a
 	}
 }
 
+// addInFlightPacket records a packet sent by the contract until it is acknowledged or timed out
+func (k Keeper) addInFlightPacket(ctx sdk.Context, contractAddr sdk.AccAddress, packet types.InFlightPacket) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetInFlightPacketKey(contractAddr, packet.SourcePort, packet.SourceChannel, packet.Sequence), k.cdc.MustMarshal(&packet))
+	store.Set(types.GetInFlightPacketSenderKey(packet.SourcePort, packet.SourceChannel, packet.Sequence), contractAddr)
+}
+
+// RemoveInFlightPacket deletes the record of a packet sent by a contract. Packets that were not sent by a contract
+// are ignored.
+func (k Keeper) RemoveInFlightPacket(ctx sdk.Context, portID, channelID string, sequence uint64) {
+	store := ctx.KVStore(k.storeKey)
+	senderKey := types.GetInFlightPacketSenderKey(portID, channelID, sequence)
+	contractAddr := store.Get(senderKey)
+	if contractAddr == nil {
+		return
+	}
+	store.Delete(senderKey)
+	store.Delete(types.GetInFlightPacketKey(contractAddr, portID, channelID, sequence))
+}
+
+// IterateInFlightPackets iterates over the packets sent by the contract that were neither acknowledged nor timed out.
+func (k Keeper) IterateInFlightPackets(ctx sdk.Context, contractAddr sdk.AccAddress, cb func(types.InFlightPacket) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetInFlightPacketsPrefix(contractAddr))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var packet types.InFlightPacket
+		k.cdc.MustUnmarshal(iter.Value(), &packet)
+		if cb(packet) {
+			return
+		}
+	}
+}
+
+// importInFlightPackets restores the packets sent by the contract. A packet can only be tracked for one sender.
+func (k Keeper) importInFlightPackets(ctx sdk.Context, contractAddr sdk.AccAddress, packets []types.InFlightPacket) error {
+	store := ctx.KVStore(k.storeKey)
+	for _, packet := range packets {
+		if store.Has(types.GetInFlightPacketSenderKey(packet.SourcePort, packet.SourceChannel, packet.Sequence)) {
+			return sdkerrors.Wrapf(types.ErrDuplicate, "in-flight packet: %s/%s/%d", packet.SourcePort, packet.SourceChannel, packet.Sequence)
+		}
+		k.addInFlightPacket(ctx, contractAddr, packet)
+	}
+	return nil
+}
+
 func (k Keeper) setContractAdmin(ctx sdk.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ AuthorizationPolicy) error {
 	contractInfo := k.GetContractInfo(ctx, contractAddress)
 	if contractInfo == nil {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
 	}
-	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
-		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	if err := assertGovManagedAccess(contractInfo, authZ); err != nil {
+		return err
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	contractInfo.Admin = newAdmin.String()
+	action := types.AdminActionUpdateAdmin
+	if newAdmin == nil {
+		// without an admin the contract must not stay migratable by a delegated permission
+		contractInfo.MigratePermission = nil
+		action = types.AdminActionClearAdmin
+	}
+	k.storeContractInfo(ctx, contractAddress, contractInfo)
+	emitAdminActionEvent(ctx, action, contractAddress, caller)
+	return nil
+}
+
+// assertGovManagedAccess rejects admin operations on a contract managed by governance that are not authorized by
+// governance.
+func assertGovManagedAccess(contractInfo *types.ContractInfo, authZ AuthorizationPolicy) error {
+	if contractInfo.GovManaged && !authZ.CanModifyGovManagedContract() {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "contract is managed by governance")
+	}
+	return nil
+}
+
+// setContractPaused sets the paused flag of the contract. Only the admin or governance can pause a contract.
+func (k Keeper) setContractPaused(ctx sdk.Context, contractAddress, caller sdk.AccAddress, paused bool, authZ AuthorizationPolicy) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if err := assertGovManagedAccess(contractInfo, authZ); err != nil {
+		return err
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	contractInfo.Paused = paused
+	k.storeContractInfo(ctx, contractAddress, contractInfo)
+	action := types.AdminActionPause
+	if !paused {
+		action = types.AdminActionUnpause
+	}
+	emitAdminActionEvent(ctx, action, contractAddress, caller)
+	return nil
+}
+
+// setContractMigratePermission restricts who can migrate the contract. A nil permission restores the default where
+// the admin can migrate. Only actors that are allowed to migrate the contract can change the permission.
+func (k Keeper) setContractMigratePermission(ctx sdk.Context, contractAddress, caller sdk.AccAddress, permission *types.AccessConfig, authZ AuthorizationPolicy) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if err := assertGovManagedAccess(contractInfo, authZ); err != nil {
+		return err
+	}
+	if !authZ.CanMigrateContract(contractInfo.MigratePermission, contractInfo.AdminAddr(), caller) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if permission != nil {
+		if err := types.ValidateMigratePermission(*permission); err != nil {
+			return sdkerrors.Wrap(err, "migrate permission")
+		}
+	}
+	contractInfo.MigratePermission = permission
+	k.storeContractInfo(ctx, contractAddress, contractInfo)
+	emitAdminActionEvent(ctx, types.AdminActionUpdateMigratePermission, contractAddress, caller)
+	return nil
+}
+
+// setContractExecutePermission restricts who can execute the contract. A nil permission restores the default where
+// everybody can execute. Only the admin can change the permission.
+func (k Keeper) setContractExecutePermission(ctx sdk.Context, contractAddress, caller sdk.AccAddress, permission *types.AccessConfig, authZ AuthorizationPolicy) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if err := assertGovManagedAccess(contractInfo, authZ); err != nil {
+		return err
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if permission != nil {
+		if err := permission.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, "execute permission")
+		}
+	}
+	contractInfo.ExecutePermission = permission
+	k.storeContractInfo(ctx, contractAddress, contractInfo)
+	emitAdminActionEvent(ctx, types.AdminActionUpdateExecutePermission, contractAddress, caller)
+	return nil
+}
+
+// setContractAcceptedDenoms restricts the denoms that can be sent as funds with an execute call. An empty list
+// restores the default where all denoms are accepted. Only the admin can change the accepted denoms.
+func (k Keeper) setContractAcceptedDenoms(ctx sdk.Context, contractAddress, caller sdk.AccAddress, denoms []string, authZ AuthorizationPolicy) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if err := assertGovManagedAccess(contractInfo, authZ); err != nil {
+		return err
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if err := types.ValidateAcceptedDenoms(denoms); err != nil {
+		return sdkerrors.Wrap(err, "accepted denoms")
+	}
+	if len(denoms) == 0 {
+		denoms = nil
+	}
+	contractInfo.AcceptedDenoms = denoms
+	k.storeContractInfo(ctx, contractAddress, contractInfo)
+	emitAdminActionEvent(ctx, types.AdminActionUpdateAcceptedDenoms, contractAddress, caller)
+	return nil
+}
+
+// terminateContract disables all calls to the contract permanently, except for queries
+func (k Keeper) terminateContract(ctx sdk.Context, contractAddress sdk.AccAddress) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if contractInfo.Terminated {
+		return sdkerrors.Wrap(types.ErrContractTerminated, contractAddress.String())
 	}
-	contractInfo.Admin = newAdmin.String()
+	contractInfo.Terminated = true
 	k.storeContractInfo(ctx, contractAddress, contractInfo)
+	k.addCodeInstanceCount(ctx, contractInfo.CodeID, -1)
+	// a terminated contract can not be migrated anymore
+	k.setMigrationProgress(ctx, contractAddress, nil)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeTerminate,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+	))
 	return nil
 }
 
+// emitAdminActionEvent emits the audit event for a privileged contract operation. Operations authorized by
+// governance have no caller and are reported with the gov module account as admin.
+func emitAdminActionEvent(ctx sdk.Context, action string, contractAddress, caller sdk.AccAddress) {
+	if caller.Empty() {
+		caller = authtypes.NewModuleAddress(govtypes.ModuleName)
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeAdminAction,
+		sdk.NewAttribute(types.AttributeKeyAction, action),
+		sdk.NewAttribute(types.AttributeKeyAdmin, caller.String()),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+	))
+}
+
 func (k Keeper) appendToContractHistory(ctx sdk.Context, contractAddr sdk.AccAddress, newEntries ...types.ContractCodeHistoryEntry) {
 	store := ctx.KVStore(k.storeKey)
 	// find last element position
@@ -588,28 +1529,182 @@ func (k Keeper) getLastContractHistoryEntry(ctx sdk.Context, contractAddr sdk.Ac
 }
 
 // QuerySmart queries the smart contract itself.
+// Within a transaction, results are served from the query cache when enabled by params. The gas consumed
+// by the original query is charged again for every cache hit.
+// Errors returned by the contract are of type `types.ErrQueryFailed` and contain the contract's error message,
+// failures in the wasm vm are of type `types.ErrVMFailure`. Out of gas panics with `sdk.ErrorOutOfGas`.
 func (k Keeper) QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "query-smart")
+	cache := k.queryCache(ctx)
+	if cache == nil {
+		return k.querySmart(ctx, contractAddr, req)
+	}
+	if rsp, gasUsed, ok := cache.Get(contractAddr, req); ok {
+		ctx.GasMeter().ConsumeGas(gasUsed, "wasm query cache")
+		return rsp, nil
+	}
+	gasBefore := ctx.GasMeter().GasConsumed()
+	rsp, err := k.querySmart(ctx, contractAddr, req)
+	if err != nil {
+		return nil, err
+	}
+	cache.Set(contractAddr, req, rsp, ctx.GasMeter().GasConsumed()-gasBefore)
+	return rsp, nil
+}
+
+func (k Keeper) querySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error) {
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return nil, err
 	}
 
-	smartQuerySetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(req))
+	smartQuerySetupCosts := k.instanceGasRegister(ctx).InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(req))
 	ctx.GasMeter().ConsumeGas(smartQuerySetupCosts, "Loading CosmWasm module: query")
 
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	env := types.NewEnv(ctx, contractAddr)
-	queryResult, gasUsed, qErr := k.wasmVM.Query(codeInfo.CodeHash, env, req, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), k.runtimeGasForContract(ctx), costJSONDeserialization)
+	var queryResult []byte
+	var gasUsed uint64
+	qErr := callVM(func() (err error) {
+		queryResult, gasUsed, err = k.wasmVM.Query(codeInfo.CodeHash, env, req, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), k.runtimeGasForContract(ctx), costJSONDeserialization)
+		return err
+	})
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if qErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrQueryFailed, qErr.Error())
+		return nil, wrapQueryVMError(qErr)
 	}
 	return queryResult, nil
 }
 
+// queryCache returns the query cache of the current transaction or nil when not available or disabled.
+// The cache is only used for queries of executing contracts as other callers are not covered by the cache resets.
+func (k Keeper) queryCache(ctx sdk.Context) *types.QueryCache {
+	cache := types.QueryCacheFromContext(ctx)
+	if cache == nil || !types.IsContractExecuting(ctx) || !cache.IsEnabled(func() bool { return k.IsQueryCacheEnabled(ctx) }) {
+		return nil
+	}
+	return cache
+}
+
+// resetQueryCache drops all cached query results of the transaction. It is called whenever state may be modified.
+func resetQueryCache(ctx sdk.Context) {
+	if cache := types.QueryCacheFromContext(ctx); cache != nil {
+		cache.Reset()
+	}
+}
+
+// queryCacheResettingStore resets the query cache of the transaction on every write to the contract state
+type queryCacheResettingStore struct {
+	sdk.KVStore
+	cache *types.QueryCache
+}
+
+// Set resets the query cache and sets the value
+func (s queryCacheResettingStore) Set(key, value []byte) {
+	s.cache.Reset()
+	s.KVStore.Set(key, value)
+}
+
+// Delete resets the query cache and deletes the key
+func (s queryCacheResettingStore) Delete(key []byte) {
+	s.cache.Reset()
+	s.KVStore.Delete(key)
+}
+
+// QuerySmartWithGasLimit queries the smart contract itself with a dedicated gas meter bounded by the given limit.
+// The gas meter of the given context is not charged so that callers like BeginBlocker code in other modules
+// can query contracts without risking their own gas budget.
+// When the limit is exceeded an error of type `sdkerrors.ErrOutOfGas` is returned, contract failures
+// are returned as `types.ErrQueryFailed` and failures in the wasm vm as `types.ErrVMFailure`.
+func (k Keeper) QuerySmartWithGasLimit(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte, gasLimit uint64) (rsp []byte, err error) {
+	queryCtx := ctx.WithGasMeter(sdk.NewGasMeter(gasLimit))
+	// recover from out-of-gas panic only
+	defer func() {
+		if r := recover(); r != nil {
+			rType, ok := r.(sdk.ErrorOutOfGas)
+			if !ok {
+				panic(r)
+			}
+			err = sdkerrors.Wrapf(sdkerrors.ErrOutOfGas,
+				"out of gas in location: %v; gasWanted: %d, gasUsed: %d",
+				rType.Descriptor, queryCtx.GasMeter().Limit(), queryCtx.GasMeter().GasConsumed(),
+			)
+			rsp = nil
+		}
+	}()
+	return k.QuerySmart(queryCtx, contractAddr, req)
+}
+
+// SimulateExecute runs a contract execution, including all dispatched submessages, in a cached context
+// with a dedicated gas meter bounded by the given limit. Nothing is committed to the store.
+// The gas used and the events emitted are returned to preview the outcome of the execution.
+func (k Keeper) SimulateExecute(ctx sdk.Context, gasLimit sdk.Gas, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) (data []byte, gasUsed sdk.Gas, events sdk.Events, err error) {
+	gasUsed, events, err = k.simulate(ctx, gasLimit, func(simCtx sdk.Context) error {
+		var err error
+		data, err = k.execute(simCtx, contractAddress, caller, msg, coins)
+		return err
+	})
+	if err != nil {
+		return nil, gasUsed, nil, err
+	}
+	return data, gasUsed, events, nil
+}
+
+// PreviewExecuteEvents runs a contract execution, including all dispatched submessages, in a cached context that is
+// rolled back. Only the events emitted are returned so that clients can preview the effects of an execution.
+// No events are returned when the execution fails.
+func (k Keeper) PreviewExecuteEvents(ctx sdk.Context, gasLimit sdk.Gas, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) (sdk.Events, error) {
+	_, events, err := k.simulate(ctx, gasLimit, func(simCtx sdk.Context) error {
+		_, err := k.execute(simCtx, contractAddress, caller, msg, coins)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// SimulateInstantiate runs a contract instantiation, including all dispatched submessages, in a cached context
+// with a dedicated gas meter bounded by the given limit. Nothing is committed to the store.
+// The gas used and the events emitted are returned to preview the outcome of the instantiation.
+func (k Keeper) SimulateInstantiate(ctx sdk.Context, gasLimit sdk.Gas, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins) (contractAddress sdk.AccAddress, data []byte, gasUsed sdk.Gas, events sdk.Events, err error) {
+	gasUsed, events, err = k.simulate(ctx, gasLimit, func(simCtx sdk.Context) error {
+		var err error
+		contractAddress, data, err = k.instantiate(simCtx, codeID, creator, admin, initMsg, label, deposit, DefaultAuthorizationPolicy{})
+		return err
+	})
+	if err != nil {
+		return nil, nil, gasUsed, nil, err
+	}
+	return contractAddress, data, gasUsed, events, nil
+}
+
+// simulate executes the given function in a cached context that is never committed. The gas used and the
+// events emitted are tracked independently from the parent context.
+func (k Keeper) simulate(ctx sdk.Context, gasLimit sdk.Gas, fn func(simCtx sdk.Context) error) (gasUsed sdk.Gas, events sdk.Events, err error) {
+	cacheCtx, _ := ctx.CacheContext()
+	em := sdk.NewEventManager()
+	simCtx := cacheCtx.WithGasMeter(sdk.NewGasMeter(gasLimit)).WithEventManager(em)
+	// recover from out-of-gas panic only
+	defer func() {
+		if r := recover(); r != nil {
+			rType, ok := r.(sdk.ErrorOutOfGas)
+			if !ok {
+				panic(r)
+			}
+			err = sdkerrors.Wrapf(sdkerrors.ErrOutOfGas,
+				"out of gas in location: %v; gasWanted: %d, gasUsed: %d",
+				rType.Descriptor, simCtx.GasMeter().Limit(), simCtx.GasMeter().GasConsumed(),
+			)
+			gasUsed, events = simCtx.GasMeter().GasConsumed(), nil
+		}
+	}()
+	err = fn(simCtx)
+	return simCtx.GasMeter().GasConsumed(), em.Events(), err
+}
+
 // QueryRaw returns the contract's state for give key. Returns `nil` when key is `nil`.
 func (k Keeper) QueryRaw(ctx sdk.Context, contractAddress sdk.AccAddress, key []byte) []byte {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "query-raw")
@@ -637,9 +1732,11 @@ func (k Keeper) contractInstance(ctx sdk.Context, contractAddress sdk.AccAddress
 	}
 	var codeInfo types.CodeInfo
 	k.cdc.MustUnmarshal(codeInfoBz, &codeInfo)
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
-	return contractInfo, codeInfo, prefixStore, nil
+	// client queries skip the bookkeeping so that heavy query traffic does not evict the codes used by txs
+	if !types.IsExternalQuery(ctx) {
+		k.pinnedCodes.touch(codeInfo.CodeHash)
+	}
+	return contractInfo, codeInfo, k.contractStore(ctx, contractAddress), nil
 }
 
 func (k Keeper) GetContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) *types.ContractInfo {
@@ -683,16 +1780,81 @@ func (k Keeper) GetContractState(ctx sdk.Context, contractAddress sdk.AccAddress
 	return prefixStore.Iterator(nil, nil)
 }
 
-func (k Keeper) importContractState(ctx sdk.Context, contractAddress sdk.AccAddress, models []types.Model) error {
+// GetContractStateBatch returns up to limit state models of the contract in key order, starting after the given key.
+// The returned next key is the last key of the batch when more models exist, nil otherwise.
+func (k Keeper) GetContractStateBatch(ctx sdk.Context, contractAddress sdk.AccAddress, startAfter []byte, limit uint32) ([]types.Model, []byte) {
+	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
+	var start []byte
+	if len(startAfter) != 0 {
+		// smallest key that is greater than startAfter
+		start = append(append([]byte{}, startAfter...), 0)
+	}
+	iter := prefixStore.Iterator(start, nil)
+	defer iter.Close()
+
+	models := make([]types.Model, 0, limit)
+	for ; iter.Valid() && uint32(len(models)) < limit; iter.Next() {
+		models = append(models, types.Model{Key: iter.Key(), Value: iter.Value()})
+	}
+	if !iter.Valid() || len(models) == 0 {
+		return models, nil
+	}
+	return models, models[len(models)-1].Key
+}
+
+// GetMigrationProgress returns the stored state iteration position of an unfinished contract migration or nil.
+func (k Keeper) GetMigrationProgress(ctx sdk.Context, contractAddress sdk.AccAddress) []byte {
+	return ctx.KVStore(k.storeKey).Get(types.GetMigrationProgressKey(contractAddress))
+}
+
+// setMigrationProgress stores the state iteration position of a contract migration. A nil position removes it.
+func (k Keeper) setMigrationProgress(ctx sdk.Context, contractAddress sdk.AccAddress, position []byte) {
+	store := ctx.KVStore(k.storeKey)
+	if len(position) == 0 {
+		store.Delete(types.GetMigrationProgressKey(contractAddress))
+		return
+	}
+	store.Set(types.GetMigrationProgressKey(contractAddress), position)
+}
+
+// setContractAccountCodeID updates the code ID stored with the contract account. Accounts of other types are not modified.
+func (k Keeper) setContractAccountCodeID(ctx sdk.Context, contractAddress sdk.AccAddress, codeID uint64) {
+	contractAccount, ok := k.accountKeeper.GetAccount(ctx, contractAddress).(*types.ContractAccount)
+	if !ok {
+		return
+	}
+	contractAccount.CodeID = codeID
+	k.accountKeeper.SetAccount(ctx, contractAccount)
+}
+
+// ImportContractState writes the raw key value models into the store of an existing contract. It is used by the
+// genesis import and can be used by test harnesses to seed a contract's state. All models are validated before
+// anything is written so that duplicate keys within the import or keys already set in the contract's store do
+// not result in a partial import.
+func (k Keeper) ImportContractState(ctx sdk.Context, contractAddress sdk.AccAddress, models []types.Model) error {
+	if !k.HasContractInfo(ctx, contractAddress) {
+		return sdkerrors.Wrapf(types.ErrNotFound, "contract: %s", contractAddress)
+	}
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
 	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
+	keys := make(map[string]struct{}, len(models))
 	for _, model := range models {
-		if model.Value == nil {
-			model.Value = []byte{}
+		if err := model.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "model key: %x", model.Key)
+		}
+		if _, exists := keys[string(model.Key)]; exists {
+			return sdkerrors.Wrapf(types.ErrDuplicate, "duplicate key in import: %x", model.Key)
 		}
+		keys[string(model.Key)] = struct{}{}
 		if prefixStore.Has(model.Key) {
 			return sdkerrors.Wrapf(types.ErrDuplicate, "duplicate key: %x", model.Key)
 		}
+	}
+	for _, model := range models {
+		if model.Value == nil {
+			model.Value = []byte{}
+		}
 		prefixStore.Set(model.Key, model.Value)
 	}
 	return nil
@@ -738,6 +1900,39 @@ func (k Keeper) GetByteCode(ctx sdk.Context, codeID uint64) ([]byte, error) {
 	return k.wasmVM.GetCode(codeInfo.CodeHash)
 }
 
+// CodeValidator validates the byte code of a stored wasm code. An error is returned for invalid code.
+type CodeValidator func(codeID uint64, codeInfo types.CodeInfo, wasmCode []byte) error
+
+// ValidateStoredCodes runs the validator on the byte code of all stored codes and returns the IDs of the codes
+// that failed validation or where the byte code could not be loaded. This can be used in an upgrade handler to
+// find codes that are not supported by a new wasm VM version and decide to pin, skip or flag them.
+func (k Keeper) ValidateStoredCodes(ctx sdk.Context, validate CodeValidator) []uint64 {
+	var failed []uint64
+	k.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+		wasmCode, err := k.wasmVM.GetCode(info.CodeHash)
+		if err != nil || validate(codeID, info, wasmCode) != nil {
+			failed = append(failed, codeID)
+		}
+		return false
+	})
+	return failed
+}
+
+// WasmVMCodeValidator returns a CodeValidator that re-runs the static validation and compilation of the wasm VM
+// and ensures that the checksum is not modified.
+func (k Keeper) WasmVMCodeValidator() CodeValidator {
+	return func(codeID uint64, info types.CodeInfo, wasmCode []byte) error {
+		checksum, err := k.wasmVM.Create(wasmCode)
+		if err != nil {
+			return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
+		}
+		if !bytes.Equal(checksum, info.CodeHash) {
+			return sdkerrors.Wrapf(types.ErrInvalid, "checksum mismatch for code id %d", codeID)
+		}
+		return nil
+	}
+}
+
 // PinCode pins the wasm contract in wasmvm cache
 func (k Keeper) pinCode(ctx sdk.Context, codeID uint64) error {
 	codeInfo := k.GetCodeInfo(ctx, codeID)
@@ -745,7 +1940,7 @@ func (k Keeper) pinCode(ctx sdk.Context, codeID uint64) error {
 		return sdkerrors.Wrap(types.ErrNotFound, "code info")
 	}
 
-	if err := k.wasmVM.Pin(codeInfo.CodeHash); err != nil {
+	if err := k.pinInVM(ctx, codeInfo.CodeHash); err != nil {
 		return sdkerrors.Wrap(types.ErrPinContractFailed, err.Error())
 	}
 	store := ctx.KVStore(k.storeKey)
@@ -768,6 +1963,7 @@ func (k Keeper) unpinCode(ctx sdk.Context, codeID uint64) error {
 	if err := k.wasmVM.Unpin(codeInfo.CodeHash); err != nil {
 		return sdkerrors.Wrap(types.ErrUnpinContractFailed, err.Error())
 	}
+	k.pinnedCodes.remove(codeInfo.CodeHash)
 
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(types.GetPinnedCodeIndexPrefix(codeID))
@@ -779,12 +1975,108 @@ func (k Keeper) unpinCode(ctx sdk.Context, codeID uint64) error {
 	return nil
 }
 
+// maxReportedCodeReferences limits the number of contract addresses listed in a failed code removal
+const maxReportedCodeReferences = 10
+
+// removeCode deletes the code info of a code that is not referenced by any contract instance anymore.
+// The code is unpinned first when pinned. The compiled wasm stays in the wasmvm file cache as
+// the VM provides no removal and other code ids may share the same checksum.
+func (k Keeper) removeCode(ctx sdk.Context, codeID uint64) error {
+	codeInfo := k.GetCodeInfo(ctx, codeID)
+	if codeInfo == nil {
+		return sdkerrors.Wrap(types.ErrNotFound, "code info")
+	}
+	var refs []string
+	var total int
+	k.IterateContractsByCode(ctx, codeID, func(address sdk.AccAddress) bool {
+		if total < maxReportedCodeReferences {
+			refs = append(refs, address.String())
+		}
+		total++
+		return false
+	})
+	if total != 0 {
+		return sdkerrors.Wrapf(types.ErrInvalid, "code is referenced by %d contracts: %s", total, strings.Join(refs, ", "))
+	}
+	if k.IsPinnedCode(ctx, codeID) {
+		if err := k.unpinCode(ctx, codeID); err != nil {
+			return err
+		}
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetCodeKey(codeID))
+	if count := k.GetCodeCount(ctx); count > 0 {
+		k.setCodeCount(ctx, count-1)
+	}
+	store.Delete(types.GetCodeInstanceCountKey(codeID))
+	k.removeFromCodeChecksumIndex(ctx, codeInfo.CodeHash, codeID)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRemoveCode,
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+	))
+	return nil
+}
+
+// GetCodeCount returns the number of stored codes. Removed codes are not counted.
+func (k Keeper) GetCodeCount(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.CodeCountKey)
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) setCodeCount(ctx sdk.Context, count uint64) {
+	ctx.KVStore(k.storeKey).Set(types.CodeCountKey, sdk.Uint64ToBigEndian(count))
+}
+
+// GetCodeInstanceCount returns the number of contracts of the code that are not terminated
+func (k Keeper) GetCodeInstanceCount(ctx sdk.Context, codeID uint64) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetCodeInstanceCountKey(codeID))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// addCodeInstanceCount adds the delta to the number of contract instances of the code. The count does not drop
+// below zero.
+func (k Keeper) addCodeInstanceCount(ctx sdk.Context, codeID uint64, delta int64) {
+	count := k.GetCodeInstanceCount(ctx, codeID)
+	switch {
+	case delta >= 0:
+		count += uint64(delta)
+	case count > uint64(-delta):
+		count -= uint64(-delta)
+	default:
+		count = 0
+	}
+	k.setCodeInstanceCount(ctx, codeID, count)
+}
+
+func (k Keeper) setCodeInstanceCount(ctx sdk.Context, codeID uint64, count uint64) {
+	ctx.KVStore(k.storeKey).Set(types.GetCodeInstanceCountKey(codeID), sdk.Uint64ToBigEndian(count))
+}
+
 // IsPinnedCode returns true when codeID is pinned in wasmvm cache
 func (k Keeper) IsPinnedCode(ctx sdk.Context, codeID uint64) bool {
 	store := ctx.KVStore(k.storeKey)
 	return store.Has(types.GetPinnedCodeIndexPrefix(codeID))
 }
 
+// GetPinnedCodeIDs returns the ids of all codes that are pinned in wasmvm cache in ascending order
+func (k Keeper) GetPinnedCodeIDs(ctx sdk.Context) []uint64 {
+	var r []uint64
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.PinnedCodeIndexPrefix)
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		r = append(r, types.ParsePinnedCodeIndex(iter.Key()))
+	}
+	return r
+}
+
 // InitializePinnedCodes updates wasmvm to pin to cache all contracts marked as pinned
 func (k Keeper) InitializePinnedCodes(ctx sdk.Context) error {
 	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.PinnedCodeIndexPrefix)
@@ -794,13 +2086,42 @@ func (k Keeper) InitializePinnedCodes(ctx sdk.Context) error {
 		if codeInfo == nil {
 			return sdkerrors.Wrap(types.ErrNotFound, "code info")
 		}
-		if err := k.wasmVM.Pin(codeInfo.CodeHash); err != nil {
+		if err := k.pinInVM(ctx, codeInfo.CodeHash); err != nil {
 			return sdkerrors.Wrap(types.ErrPinContractFailed, err.Error())
 		}
 	}
 	return nil
 }
 
+// pinInVM pins the code into the wasmvm memory cache. With a pinned code memory budget configured, the least
+// recently used codes are evicted from the memory cache to stay within the budget and a code that exceeds the
+// budget on its own is not pinned in memory. Either way, the pinned code index in the store is not affected.
+func (k Keeper) pinInVM(ctx sdk.Context, checksum []byte) error {
+	if k.pinnedCodes.budget == 0 {
+		return k.wasmVM.Pin(checksum)
+	}
+	code, err := k.wasmVM.GetCode(checksum)
+	if err != nil {
+		return err
+	}
+	size := uint64(len(code))
+	if !k.pinnedCodes.fits(size) {
+		moduleLogger(ctx).Error("code exceeds pinned code memory budget, not pinned in memory", "checksum", hex.EncodeToString(checksum), "size", size)
+		return nil
+	}
+	if err := k.wasmVM.Pin(checksum); err != nil {
+		return err
+	}
+	for _, evicted := range k.pinnedCodes.add(checksum, size) {
+		if err := k.wasmVM.Unpin(evicted); err != nil {
+			moduleLogger(ctx).Error("failed to evict pinned code from memory", "checksum", hex.EncodeToString(evicted), "error", err.Error())
+			continue
+		}
+		moduleLogger(ctx).Info("evicted pinned code from memory", "checksum", hex.EncodeToString(evicted))
+	}
+	return nil
+}
+
 // setContractInfoExtension updates the extension point data that is stored with the contract info
 func (k Keeper) setContractInfoExtension(ctx sdk.Context, contractAddr sdk.AccAddress, ext types.ContractInfoExtension) error {
 	info := k.GetContractInfo(ctx, contractAddr)
@@ -864,13 +2185,163 @@ func (k Keeper) consumeRuntimeGas(ctx sdk.Context, gas uint64) {
 	}
 }
 
-// generates a contract address from codeID + instanceID
+// startGasBreakdown is called before a contract call. The returned function must be called with the VM gas used
+// after the call returned and before the VM gas is consumed. It emits an event with the gas consumed by the wasm
+// execution in the VM and by the host functions, like storage access and queries, that are charged on the SDK gas
+// meter during the call. The event is only emitted when enabled with the `WithGasBreakdownEvents` option and is
+// purely informational: the gas consumed is not modified.
+func (k Keeper) startGasBreakdown(ctx sdk.Context, contractAddress sdk.AccAddress) func(vmGasUsed uint64) {
+	if !k.gasBreakdownEvents {
+		return func(uint64) {}
+	}
+	hostGasStart := ctx.GasMeter().GasConsumed()
+	return func(vmGasUsed uint64) {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeGasBreakdown,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyVMGas, strconv.FormatUint(k.gasRegister.FromWasmVMGas(vmGasUsed), 10)),
+			sdk.NewAttribute(types.AttributeKeyHostGas, strconv.FormatUint(ctx.GasMeter().GasConsumed()-hostGasStart, 10)),
+		))
+	}
+}
+
+// existingContractAddressAccount ensures that the new contract address is not in use already.
+// An address that holds an account or a balance is rejected with ErrAccountExists to prevent
+// address-squatting by pre-funding a predicted contract address. When pre-funded addresses are
+// allowed, an unused base account (no pubkey, zero sequence) is accepted and returned to be reused.
+func (k Keeper) existingContractAddressAccount(ctx sdk.Context, contractAddress sdk.AccAddress) (*authtypes.BaseAccount, error) {
+	existingAcct := k.accountKeeper.GetAccount(ctx, contractAddress)
+	if existingAcct == nil {
+		if !k.allowPrefundedContractAddress && !k.bankView.GetAllBalances(ctx, contractAddress).IsZero() {
+			return nil, sdkerrors.Wrap(types.ErrAccountExists, "address has a balance")
+		}
+		return nil, nil
+	}
+	if !k.allowPrefundedContractAddress {
+		return nil, sdkerrors.Wrap(types.ErrAccountExists, existingAcct.GetAddress().String())
+	}
+	baseAcct, ok := existingAcct.(*authtypes.BaseAccount)
+	if !ok || baseAcct.GetSequence() != 0 || baseAcct.GetPubKey() != nil {
+		return nil, sdkerrors.Wrap(types.ErrAccountExists, existingAcct.GetAddress().String())
+	}
+	return baseAcct, nil
+}
+
+// reserveContractAddress reserves the contract address for the next instantiation of the code with the given checksum
+// by the creator. The address must not be used by a contract or account nor be reserved already.
+func (k Keeper) reserveContractAddress(ctx sdk.Context, contractAddress, creator sdk.AccAddress, codeHash []byte) error {
+	if k.HasContractInfo(ctx, contractAddress) {
+		return sdkerrors.Wrap(types.ErrAccountExists, "contract")
+	}
+	if k.GetReservedContractAddress(ctx, contractAddress) != nil {
+		return sdkerrors.Wrap(types.ErrDuplicate, "contract address already reserved")
+	}
+	if _, err := k.existingContractAddressAccount(ctx, contractAddress); err != nil {
+		return err
+	}
+	k.storeReservedContractAddress(ctx, contractAddress, creator, codeHash)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeReserveContractAddress,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyCreator, creator.String()),
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(codeHash)),
+	))
+	return nil
+}
+
+func (k Keeper) storeReservedContractAddress(ctx sdk.Context, contractAddress, creator sdk.AccAddress, codeHash []byte) {
+	reservation := types.ReservedContractAddress{
+		ContractAddress: contractAddress.String(),
+		Creator:         creator.String(),
+		CodeHash:        codeHash,
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetReservedContractAddressKey(contractAddress), k.cdc.MustMarshal(&reservation))
+	store.Set(types.GetReservedContractAddressByCreatorKey(creator, codeHash, contractAddress), []byte{})
+}
+
+// GetReservedContractAddress returns the reservation of the contract address or nil when the address is not reserved.
+func (k Keeper) GetReservedContractAddress(ctx sdk.Context, contractAddress sdk.AccAddress) *types.ReservedContractAddress {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetReservedContractAddressKey(contractAddress))
+	if bz == nil {
+		return nil
+	}
+	var reservation types.ReservedContractAddress
+	k.cdc.MustUnmarshal(bz, &reservation)
+	return &reservation
+}
+
+// reservedContractAddress returns the first contract address reserved for the creator and code checksum or nil when
+// there is none
+func (k Keeper) reservedContractAddress(ctx sdk.Context, creator sdk.AccAddress, codeHash []byte) sdk.AccAddress {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetReservedContractAddressByCreatorPrefix(creator, codeHash))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	if !iter.Valid() {
+		return nil
+	}
+	return iter.Key()
+}
+
+// releaseContractAddress removes the reservation of the contract address
+func (k Keeper) releaseContractAddress(ctx sdk.Context, contractAddress, creator sdk.AccAddress, codeHash []byte) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetReservedContractAddressKey(contractAddress))
+	store.Delete(types.GetReservedContractAddressByCreatorKey(creator, codeHash, contractAddress))
+}
+
+// IterateReservedContractAddresses iterates over the contract addresses reserved by governance that were not
+// instantiated into, yet.
+func (k Keeper) IterateReservedContractAddresses(ctx sdk.Context, cb func(types.ReservedContractAddress) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ReservedContractAddressPrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var reservation types.ReservedContractAddress
+		k.cdc.MustUnmarshal(iter.Value(), &reservation)
+		if cb(reservation) {
+			return
+		}
+	}
+}
+
+// importReservedContractAddress restores a contract address reservation
+func (k Keeper) importReservedContractAddress(ctx sdk.Context, reservation types.ReservedContractAddress) error {
+	contractAddress, err := sdk.AccAddressFromBech32(reservation.ContractAddress)
+	if err != nil {
+		return sdkerrors.Wrap(err, "contract address")
+	}
+	creator, err := sdk.AccAddressFromBech32(reservation.Creator)
+	if err != nil {
+		return sdkerrors.Wrap(err, "creator")
+	}
+	if k.HasContractInfo(ctx, contractAddress) {
+		return sdkerrors.Wrapf(types.ErrAccountExists, "contract: %s", reservation.ContractAddress)
+	}
+	if k.GetReservedContractAddress(ctx, contractAddress) != nil {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "reserved contract address: %s", reservation.ContractAddress)
+	}
+	k.storeReservedContractAddress(ctx, contractAddress, creator, reservation.CodeHash)
+	return nil
+}
+
+// generates a contract address from codeID + instanceID. Addresses that are reserved by governance are skipped.
 func (k Keeper) generateContractAddress(ctx sdk.Context, codeID uint64) sdk.AccAddress {
-	instanceID := k.autoIncrementID(ctx, types.KeyLastInstanceID)
-	return BuildContractAddress(codeID, instanceID)
+	for {
+		instanceID := k.autoIncrementID(ctx, types.KeyLastInstanceID)
+		contractAddress := k.addressGenerator(codeID, instanceID)
+		if !ctx.KVStore(k.storeKey).Has(types.GetReservedContractAddressKey(contractAddress)) {
+			return contractAddress
+		}
+	}
 }
 
+// AddressGenerator builds a deterministic contract address from the code ID and a unique instance ID
+type AddressGenerator func(codeID, instanceID uint64) sdk.AccAddress
+
 // BuildContractAddress builds an sdk account address for a contract.
+// This is the default AddressGenerator.
 func BuildContractAddress(codeID, instanceID uint64) sdk.AccAddress {
 	contractID := make([]byte, 16)
 	binary.BigEndian.PutUint64(contractID[:8], codeID)
@@ -919,11 +2390,55 @@ func (k Keeper) importContract(ctx sdk.Context, contractAddr sdk.AccAddress, c *
 		return sdkerrors.Wrapf(types.ErrDuplicate, "contract: %s", contractAddr)
 	}
 
+	creatorAddress, err := sdk.AccAddressFromBech32(c.Creator)
+	if err != nil {
+		return sdkerrors.Wrap(err, "creator")
+	}
+
 	historyEntry := c.ResetFromGenesis(ctx)
 	k.appendToContractHistory(ctx, contractAddr, historyEntry)
 	k.storeContractInfo(ctx, contractAddr, c)
+	if !c.Terminated {
+		k.addCodeInstanceCount(ctx, c.CodeID, 1)
+	}
 	k.addToContractCodeSecondaryIndex(ctx, contractAddr, historyEntry)
-	return k.importContractState(ctx, contractAddr, state)
+	k.addToContractCreatorSecondaryIndex(ctx, creatorAddress, historyEntry.Updated, contractAddr)
+	return k.ImportContractState(ctx, contractAddr, state)
+}
+
+// reinstantiateContract calls the instantiate entrypoint of an imported contract with the creator as sender and
+// without funds. This is used on genesis import to initialize a contract instead of restoring its state.
+func (k Keeper) reinstantiateContract(ctx sdk.Context, contractAddress sdk.AccAddress, initMsg []byte) error {
+	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
+	if err != nil {
+		return err
+	}
+	creator, err := sdk.AccAddressFromBech32(contractInfo.Creator)
+	if err != nil {
+		return sdkerrors.Wrap(err, "creator")
+	}
+	ctx = types.WithContractOnCallStack(ctx, contractAddress)
+	env := types.NewEnv(ctx, contractAddress)
+	info := types.NewInfo(creator, nil)
+	resetQueryCache(ctx)
+	querier := k.newQueryHandler(ctx, contractAddress)
+	gas := k.runtimeGasForContract(ctx)
+	var res *wasmvmtypes.Response
+	var gasUsed uint64
+	gasBreakdown := k.startGasBreakdown(ctx, contractAddress)
+	err = callVM(func() (err error) {
+		res, gasUsed, err = k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+		return err
+	})
+	gasBreakdown(gasUsed)
+	k.consumeRuntimeGas(ctx, gasUsed)
+	if err != nil {
+		return wrapVMError(err, types.ErrInstantiateFailed)
+	}
+	if _, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Messages, res.Attributes, res.Data, res.Events); err != nil {
+		return sdkerrors.Wrap(err, "dispatch")
+	}
+	return nil
 }
 
 func (k Keeper) newQueryHandler(ctx sdk.Context, contractAddress sdk.AccAddress) QueryHandler {
@@ -961,7 +2476,30 @@ func moduleLogger(ctx sdk.Context) log.Logger {
 
 // Querier creates a new grpc querier instance
 func Querier(k *Keeper) *grpcQuerier {
-	return NewGrpcQuerier(k.cdc, k.storeKey, k, k.queryGasLimit)
+	return NewGrpcQuerier(k.cdc, k.storeKey, k, k.queryGasLimit).
+		WithCheckTxQueryGasLimit(k.queryCheckTxGasLimit).
+		WithSimulationGasLimit(k.simulationGasLimit)
+}
+
+// GetCapabilities returns the sorted features supported for contracts, like "iterator" or "stargate"
+func (k Keeper) GetCapabilities() []string {
+	return k.capabilities
+}
+
+// parseCapabilities returns the sorted, comma separated features without duplicates
+func parseCapabilities(features string) []string {
+	unique := make(map[string]struct{})
+	r := make([]string, 0)
+	for _, f := range strings.Split(features, ",") {
+		f = strings.TrimSpace(f)
+		if _, exists := unique[f]; f == "" || exists {
+			continue
+		}
+		unique[f] = struct{}{}
+		r = append(r, f)
+	}
+	sort.Strings(r)
+	return r
 }
 
 // QueryGasLimit returns the gas limit for smart queries.
@@ -971,6 +2509,55 @@ func (k Keeper) QueryGasLimit() sdk.Gas {
 
 // BankCoinTransferrer replicates the cosmos-sdk behaviour as in
 // https://github.com/cosmos/cosmos-sdk/blob/v0.41.4/x/bank/keeper/msg_server.go#L26
+// DefaultGasPriceSource returns the bond denom with a zero price. Chains that run a fee market or otherwise agree
+// on gas prices in consensus must wire their own source with the `WithGasPriceSource` option.
+type DefaultGasPriceSource struct {
+	staking types.StakingKeeper
+}
+
+// NewDefaultGasPriceSource constructor
+func NewDefaultGasPriceSource(staking types.StakingKeeper) DefaultGasPriceSource {
+	return DefaultGasPriceSource{staking: staking}
+}
+
+// MinGasPrices returns the bond denom with a zero price
+func (s DefaultGasPriceSource) MinGasPrices(ctx sdk.Context) sdk.DecCoins {
+	// not built with sdk.NewDecCoins as it removes zero amounts
+	return sdk.DecCoins{sdk.NewDecCoinFromDec(s.staking.BondDenom(ctx), sdk.ZeroDec())}
+}
+
+// GovParamSubspaceSource reads the governance params from the params subspace of the gov module. It can be used
+// when the wasm keeper is constructed before the gov keeper, as the gov router depends on the wasm keeper.
+type GovParamSubspaceSource struct {
+	subspace paramtypes.Subspace
+}
+
+// NewGovParamSubspaceSource constructor. The subspace must have the gov key table registered.
+func NewGovParamSubspaceSource(subspace paramtypes.Subspace) GovParamSubspaceSource {
+	return GovParamSubspaceSource{subspace: subspace}
+}
+
+// GetDepositParams returns the current deposit params of the gov module
+func (s GovParamSubspaceSource) GetDepositParams(ctx sdk.Context) govtypes.DepositParams {
+	var p govtypes.DepositParams
+	s.subspace.Get(ctx, govtypes.ParamStoreKeyDepositParams, &p)
+	return p
+}
+
+// GetVotingParams returns the current voting params of the gov module
+func (s GovParamSubspaceSource) GetVotingParams(ctx sdk.Context) govtypes.VotingParams {
+	var p govtypes.VotingParams
+	s.subspace.Get(ctx, govtypes.ParamStoreKeyVotingParams, &p)
+	return p
+}
+
+// GetTallyParams returns the current tally params of the gov module
+func (s GovParamSubspaceSource) GetTallyParams(ctx sdk.Context) govtypes.TallyParams {
+	var p govtypes.TallyParams
+	s.subspace.Get(ctx, govtypes.ParamStoreKeyTallyParams, &p)
+	return p
+}
+
 type BankCoinTransferrer struct {
 	keeper types.BankKeeper
 }
@@ -2,10 +2,13 @@ package keeper
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"math"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,11 +20,15 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -125,6 +132,10 @@ func TestCreateStoresInstantiatePermission(t *testing.T) {
 				CodeUploadAccess:             types.AllowEverybody,
 				InstantiateDefaultPermission: spec.srcPermission,
 				MaxWasmCodeSize:              types.DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   types.DefaultMaxMsgSize,
+				InstanceCost:                 types.DefaultInstanceCost,
+				CompileCost:                  types.DefaultCompileCost,
+				MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
 			})
 			fundAccounts(t, ctx, accKeeper, bankKeeper, myAddr, deposit)
 
@@ -208,6 +219,148 @@ func TestCreateDuplicate(t *testing.T) {
 	require.Equal(t, hackatomWasm, storedCode)
 }
 
+func TestCreateDuplicateDeduplicated(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	accKeeper, keeper, bankKeeper := keepers.AccountKeeper, keepers.ContractKeeper, keepers.BankKeeper
+
+	params := keepers.WasmKeeper.GetParams(ctx)
+	params.DeduplicateCodeUploads = true
+	keepers.WasmKeeper.setParams(ctx, params)
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
+	creatorA := createFakeFundedAccount(t, ctx, accKeeper, bankKeeper, deposit)
+	creatorB := createFakeFundedAccount(t, ctx, accKeeper, bankKeeper, deposit)
+
+	// when the same code is uploaded by two txs of the same block
+	var codeIDs []uint64
+	for _, creator := range []sdk.AccAddress{creatorA, creatorB} {
+		txCtx, commit := ctx.CacheContext()
+		em := sdk.NewEventManager()
+		codeID, err := keeper.Create(txCtx.WithEventManager(em), creator, hackatomWasm, nil)
+		require.NoError(t, err)
+		commit()
+		assert.Contains(t, em.Events(), sdk.NewEvent(types.EventTypeStoreCode, sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10))))
+		codeIDs = append(codeIDs, codeID)
+	}
+
+	// then both converge to the first code id
+	assert.Equal(t, []uint64{1, 1}, codeIDs)
+	assert.Nil(t, keepers.WasmKeeper.GetCodeInfo(ctx, 2))
+	assert.Equal(t, creatorA.String(), keepers.WasmKeeper.GetCodeInfo(ctx, 1).Creator)
+	gotID, found := keepers.WasmKeeper.GetCodeIDByChecksum(ctx, keepers.WasmKeeper.GetCodeInfo(ctx, 1).CodeHash)
+	require.True(t, found)
+	assert.Equal(t, uint64(1), gotID)
+
+	// and a different code gets a new id
+	burnerWasm, err := ioutil.ReadFile("./testdata/burner.wasm")
+	require.NoError(t, err)
+	codeID, err := keeper.Create(ctx, creatorB, burnerWasm, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), codeID)
+
+	// and the same code with a different instantiate permission is not merged into the first one
+	onlyB := types.AccessTypeOnlyAddress.With(creatorB)
+	codeID, err = keeper.Create(ctx, creatorB, hackatomWasm, &onlyB)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), codeID)
+	assert.Equal(t, onlyB, keepers.WasmKeeper.GetCodeInfo(ctx, codeID).InstantiateConfig)
+
+	// when the first code is removed
+	checksum := keepers.WasmKeeper.GetCodeInfo(ctx, 1).CodeHash
+	require.NoError(t, keepers.WasmKeeper.removeCode(ctx, 1))
+
+	// then the index moves to the remaining code with the same checksum
+	gotID, found = keepers.WasmKeeper.GetCodeIDByChecksum(ctx, checksum)
+	require.True(t, found)
+	assert.Equal(t, uint64(3), gotID)
+}
+
+func TestCreateWithMaxCodeCount(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	accKeeper, keeper, bankKeeper := keepers.AccountKeeper, keepers.ContractKeeper, keepers.BankKeeper
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
+	creator := createFakeFundedAccount(t, ctx, accKeeper, bankKeeper, deposit)
+
+	const maxCodes = 2
+	params := keepers.WasmKeeper.GetParams(ctx)
+	params.MaxCodeCount = maxCodes
+	keepers.WasmKeeper.setParams(ctx, params)
+
+	for i := 1; i <= maxCodes; i++ {
+		codeID, err := keeper.Create(ctx, creator, hackatomWasm, nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(i), codeID)
+	}
+
+	// when the cap is reached
+	_, err := keeper.Create(ctx, creator, hackatomWasm, nil)
+	require.True(t, types.ErrCreateFailed.Is(err), err)
+	assert.Contains(t, err.Error(), "max code count reached")
+
+	// then governance can raise it
+	params.MaxCodeCount = maxCodes + 1
+	keepers.WasmKeeper.setParams(ctx, params)
+	codeID, err := keeper.Create(ctx, creator, hackatomWasm, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(maxCodes+1), codeID)
+	assert.Equal(t, uint64(maxCodes+1), keepers.WasmKeeper.GetCodeCount(ctx))
+
+	// and a removed code frees up room for a new upload
+	require.NoError(t, keepers.WasmKeeper.removeCode(ctx, 1))
+	assert.Equal(t, uint64(maxCodes), keepers.WasmKeeper.GetCodeCount(ctx))
+	codeID, err = keeper.Create(ctx, creator, hackatomWasm, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(maxCodes+2), codeID)
+	_, err = keeper.Create(ctx, creator, hackatomWasm, nil)
+	require.True(t, types.ErrCreateFailed.Is(err), err)
+}
+
+func TestCreateWithCodeSource(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	accKeeper, bankKeeper := keepers.AccountKeeper, keepers.BankKeeper
+	creator := createFakeFundedAccount(t, ctx, accKeeper, bankKeeper, sdk.NewCoins(sdk.NewInt64Coin("denom", 100000)))
+	msgServer := NewMsgServerImpl(keepers.ContractKeeper)
+
+	const (
+		mySource  = "https://github.com/CosmWasm/cosmwasm/tree/v1.0.0/contracts/hackatom"
+		myBuilder = "cosmwasm/rust-optimizer:0.12.4"
+	)
+	// when
+	rsp, err := msgServer.StoreCode(sdk.WrapSDKContext(ctx), &types.MsgStoreCode{
+		Sender:       creator.String(),
+		WASMByteCode: hackatomWasm,
+		Source:       mySource,
+		Builder:      myBuilder,
+	})
+
+	// then
+	require.NoError(t, err)
+	codeInfo := keepers.WasmKeeper.GetCodeInfo(ctx, rsp.CodeID)
+	require.NotNil(t, codeInfo)
+	assert.Equal(t, mySource, codeInfo.Source)
+	assert.Equal(t, myBuilder, codeInfo.Builder)
+
+	// and returned by the queries
+	q := Querier(keepers.WasmKeeper)
+	codeRsp, err := q.Code(sdk.WrapSDKContext(ctx), &types.QueryCodeRequest{CodeId: rsp.CodeID})
+	require.NoError(t, err)
+	assert.Equal(t, mySource, codeRsp.Source)
+	assert.Equal(t, myBuilder, codeRsp.Builder)
+	codesRsp, err := q.Codes(sdk.WrapSDKContext(ctx), &types.QueryCodesRequest{})
+	require.NoError(t, err)
+	require.Len(t, codesRsp.CodeInfos, 1)
+	assert.Equal(t, mySource, codesRsp.CodeInfos[0].Source)
+	assert.Equal(t, myBuilder, codesRsp.CodeInfos[0].Builder)
+
+	// and codes stored without metadata have none
+	codeID, err := keepers.ContractKeeper.Create(ctx, creator, hackatomWasm, nil)
+	require.NoError(t, err)
+	codeInfo = keepers.WasmKeeper.GetCodeInfo(ctx, codeID)
+	assert.Empty(t, codeInfo.Source)
+	assert.Empty(t, codeInfo.Builder)
+}
+
 func TestCreateWithSimulation(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	accKeeper, keeper, bankKeeper := keepers.AccountKeeper, keepers.ContractKeeper, keepers.BankKeeper
@@ -310,7 +463,7 @@ func TestInstantiate(t *testing.T) {
 
 	gasAfter := ctx.GasMeter().GasConsumed()
 	if types.EnableGasVerification {
-		require.Equal(t, uint64(0x16e8a), gasAfter-gasBefore)
+		require.Equal(t, uint64(0x18f8c), gasAfter-gasBefore)
 	}
 
 	// ensure it is stored properly
@@ -331,13 +484,610 @@ func TestInstantiate(t *testing.T) {
 	// and events emitted
 	expEvt := sdk.Events{
 		sdk.NewEvent("instantiate",
-			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("code_id", "1")),
+			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("code_id", "1"),
+			sdk.NewAttribute("admin", ""), sdk.NewAttribute("label", "demo contract 1")),
 		sdk.NewEvent("wasm",
 			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("Let the", "hacking begin")),
 	}
 	assert.Equal(t, expEvt, em.Events())
 }
 
+func TestInstantiateEventAttributes(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	_, _, myAdmin := keyPubAddr()
+
+	specs := map[string]struct {
+		srcAdmin sdk.AccAddress
+		srcLabel string
+		expAdmin string
+	}{
+		"with admin": {
+			srcAdmin: myAdmin,
+			srcLabel: "my label",
+			expAdmin: myAdmin.String(),
+		},
+		"without admin": {
+			srcLabel: "other label",
+			expAdmin: "",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			em := sdk.NewEventManager()
+			// when
+			gotAddr, _, err := keepers.ContractKeeper.Instantiate(ctx.WithEventManager(em), example.CodeID, example.CreatorAddr, spec.srcAdmin, []byte(`{}`), spec.srcLabel, nil)
+			// then
+			require.NoError(t, err)
+			require.NotEmpty(t, em.Events())
+			exp := sdk.NewEvent("instantiate",
+				sdk.NewAttribute("_contract_address", gotAddr.String()),
+				sdk.NewAttribute("code_id", strconv.FormatUint(example.CodeID, 10)),
+				sdk.NewAttribute("admin", spec.expAdmin),
+				sdk.NewAttribute("label", spec.srcLabel),
+			)
+			assert.Equal(t, exp, em.Events()[0])
+		})
+	}
+}
+
+func TestContractAccount(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	newCode := StoreRandomContract(t, ctx, keepers, &mock)
+
+	// when instantiated
+	contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, example.CreatorAddr, []byte(`{}`), "my label", sdk.NewCoins(sdk.NewInt64Coin("denom", 1)))
+	require.NoError(t, err)
+
+	// then
+	acc := keepers.AccountKeeper.GetAccount(ctx, contractAddr)
+	require.True(t, types.IsContractAccount(acc))
+	contractAccount := acc.(*types.ContractAccount)
+	assert.Equal(t, example.CodeID, contractAccount.CodeID)
+	assert.Equal(t, contractAddr, contractAccount.GetAddress())
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("denom", 1)), keepers.BankKeeper.GetAllBalances(ctx, contractAddr))
+
+	// and when migrated
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, example.CreatorAddr, newCode.CodeID, []byte(`{}`))
+	require.NoError(t, err)
+
+	// then
+	contractAccount = keepers.AccountKeeper.GetAccount(ctx, contractAddr).(*types.ContractAccount)
+	assert.Equal(t, newCode.CodeID, contractAccount.CodeID)
+	assert.Equal(t, acc.GetAccountNumber(), contractAccount.GetAccountNumber())
+}
+
+func TestInvalidFunds(t *testing.T) {
+	specs := map[string]struct {
+		srcFunds sdk.Coins
+		expErr   bool
+	}{
+		"sorted funds": {
+			srcFunds: sdk.Coins{sdk.NewInt64Coin("denom", 1), sdk.NewInt64Coin("stake", 1)},
+		},
+		"no funds": {},
+		"unsorted funds": {
+			srcFunds: sdk.Coins{sdk.NewInt64Coin("stake", 1), sdk.NewInt64Coin("denom", 1)},
+			expErr:   true,
+		},
+		"zero amount": {
+			srcFunds: sdk.Coins{sdk.NewInt64Coin("denom", 0)},
+			expErr:   true,
+		},
+		"duplicate denom": {
+			srcFunds: sdk.Coins{sdk.NewInt64Coin("denom", 1), sdk.NewInt64Coin("denom", 1)},
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			var mock wasmtesting.MockWasmer
+			wasmtesting.MakeInstantiable(&mock)
+			mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+				return &wasmvmtypes.Response{}, 0, nil
+			}
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			fundAccounts(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, example.CreatorAddr, sdk.NewCoins(sdk.NewInt64Coin("denom", 10), sdk.NewInt64Coin("stake", 10)))
+
+			// when
+			_, _, instErr := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "label", spec.srcFunds)
+			_, execErr := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), spec.srcFunds)
+
+			// then
+			if spec.expErr {
+				assert.True(t, sdkerrors.ErrInvalidCoins.Is(instErr), "got %+v", instErr)
+				assert.True(t, sdkerrors.ErrInvalidCoins.Is(execErr), "got %+v", execErr)
+				return
+			}
+			assert.NoError(t, instErr)
+			assert.NoError(t, execErr)
+		})
+	}
+}
+
+func TestExecuteAs(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	var gotInfo wasmvmtypes.MessageInfo
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		gotInfo = info
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	user := createFakeFundedAccount(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, deposit)
+
+	// when
+	_, err := keepers.WasmKeeper.ExecuteAs(ctx, example.Contract, user, []byte(`{}`), deposit)
+
+	// then the contract sees the user as sender
+	require.NoError(t, err)
+	assert.Equal(t, user.String(), gotInfo.Sender)
+	assert.Equal(t, wasmvmtypes.Coins{wasmvmtypes.NewCoin(100, "denom")}, gotInfo.Funds)
+	// and the funds were moved from the user
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, user).IsZero())
+	assert.Equal(t, deposit, keepers.BankKeeper.GetAllBalances(ctx, example.Contract))
+}
+
+func TestExecuteAsRejectsNonUserCallers(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	var executed bool
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		executed = true
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	otherContract := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	specs := map[string]struct {
+		srcCaller sdk.AccAddress
+		expErr    *sdkerrors.Error
+	}{
+		"empty caller": {
+			srcCaller: nil,
+			expErr:    sdkerrors.ErrUnknownAddress,
+		},
+		"contract caller": {
+			srcCaller: otherContract.Contract,
+			expErr:    sdkerrors.ErrUnauthorized,
+		},
+		"module account caller": {
+			srcCaller: authtypes.NewModuleAddress(distributiontypes.ModuleName),
+			expErr:    sdkerrors.ErrUnauthorized,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			executed = false
+			_, gotErr := keepers.WasmKeeper.ExecuteAs(ctx, example.Contract, spec.srcCaller, []byte(`{}`), nil)
+			assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+			assert.False(t, executed)
+		})
+	}
+}
+
+func TestGasCostParams(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	wasmCode := append(append([]byte{}, wasmIdent...), bytes.Repeat([]byte{1}, 10)...)
+
+	measure := func(params types.Params) (executeGas, createGas sdk.Gas) {
+		cacheCtx, _ := ctx.CacheContext()
+		keepers.WasmKeeper.setParams(cacheCtx, params)
+
+		cacheCtx = cacheCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		_, err := keepers.ContractKeeper.Execute(cacheCtx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+		require.NoError(t, err)
+		executeGas = cacheCtx.GasMeter().GasConsumed()
+
+		cacheCtx = cacheCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		_, err = keepers.ContractKeeper.Create(cacheCtx, example.CreatorAddr, wasmCode, nil)
+		require.NoError(t, err)
+		createGas = cacheCtx.GasMeter().GasConsumed()
+		return
+	}
+	defaultExecuteGas, defaultCreateGas := measure(types.DefaultParams())
+
+	// when costs are modified (same number of digits to not change the param read costs)
+	params := types.DefaultParams()
+	params.InstanceCost = 70_000
+	params.CompileCost = 5
+	gotExecuteGas, gotCreateGas := measure(params)
+
+	// then
+	assert.Equal(t, defaultExecuteGas+10_000, gotExecuteGas)
+	assert.Equal(t, defaultCreateGas+2*uint64(len(wasmCode)), gotCreateGas)
+}
+
+func TestMigrateWithStateBatches(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	var gotKeys []string
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		bz, err := querier.Query(wasmvmtypes.QueryRequest{Custom: []byte(`{"wasmd":{"migration_state_batch":{"resume":true,"limit":2}}}`)}, gasLimit)
+		if err != nil {
+			return nil, 0, err
+		}
+		var res types.MigrationStateBatchResponse
+		if err := json.Unmarshal(bz, &res); err != nil {
+			return nil, 0, err
+		}
+		for _, m := range res.Models {
+			gotKeys = append(gotKeys, string(m.Key))
+		}
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	contractAddr := example.Contract
+	require.NoError(t, keepers.WasmKeeper.ImportContractState(ctx, contractAddr, []types.Model{
+		{Key: []byte("a"), Value: []byte("1")},
+		{Key: []byte("b"), Value: []byte("2")},
+		{Key: []byte("c"), Value: []byte("3")},
+		{Key: []byte("d"), Value: []byte("4")},
+		{Key: []byte("e"), Value: []byte("5")},
+	}))
+
+	// when first batch migrated
+	_, err := keepers.ContractKeeper.Migrate(ctx, contractAddr, example.CreatorAddr, example.CodeID, []byte(`{}`))
+	require.NoError(t, err)
+	// then progress is stored
+	assert.Equal(t, []string{"a", "b"}, gotKeys)
+	assert.Equal(t, []byte("b"), keepers.WasmKeeper.GetMigrationProgress(ctx, contractAddr))
+
+	// when resumed
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, example.CreatorAddr, example.CodeID, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, gotKeys)
+	assert.Equal(t, []byte("d"), keepers.WasmKeeper.GetMigrationProgress(ctx, contractAddr))
+
+	// when completed
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, example.CreatorAddr, example.CodeID, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, gotKeys)
+	assert.Nil(t, keepers.WasmKeeper.GetMigrationProgress(ctx, contractAddr))
+
+	// when an unfinished migration is followed by one that does not iterate the state
+	keepers.WasmKeeper.setMigrationProgress(ctx, contractAddr, []byte("b"))
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, example.CreatorAddr, example.CodeID, []byte(`{}`))
+	require.NoError(t, err)
+	// then the progress is cleared
+	assert.Nil(t, keepers.WasmKeeper.GetMigrationProgress(ctx, contractAddr))
+}
+
+func TestInstantiateWithPrefundedContractAddress(t *testing.T) {
+	specs := map[string]struct {
+		allowPrefunded bool
+		setup          func(t *testing.T, ctx sdk.Context, keepers TestKeepers, addr sdk.AccAddress)
+		expErr         *sdkerrors.Error
+	}{
+		"clean address": {
+			setup: func(t *testing.T, ctx sdk.Context, keepers TestKeepers, addr sdk.AccAddress) {},
+		},
+		"clean address with prefunding allowed": {
+			allowPrefunded: true,
+			setup:          func(t *testing.T, ctx sdk.Context, keepers TestKeepers, addr sdk.AccAddress) {},
+		},
+		"prefunded address rejected": {
+			setup: func(t *testing.T, ctx sdk.Context, keepers TestKeepers, addr sdk.AccAddress) {
+				fundAccounts(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, addr, sdk.NewCoins(sdk.NewInt64Coin("denom", 100)))
+			},
+			expErr: types.ErrAccountExists,
+		},
+		"prefunded address accepted": {
+			allowPrefunded: true,
+			setup: func(t *testing.T, ctx sdk.Context, keepers TestKeepers, addr sdk.AccAddress) {
+				fundAccounts(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, addr, sdk.NewCoins(sdk.NewInt64Coin("denom", 100)))
+			},
+		},
+		"used account rejected": {
+			allowPrefunded: true,
+			setup: func(t *testing.T, ctx sdk.Context, keepers TestKeepers, addr sdk.AccAddress) {
+				fundAccounts(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, addr, sdk.NewCoins(sdk.NewInt64Coin("denom", 100)))
+				acc := keepers.AccountKeeper.GetAccount(ctx, addr)
+				require.NoError(t, acc.SetSequence(1))
+				keepers.AccountKeeper.SetAccount(ctx, acc)
+			},
+			expErr: types.ErrAccountExists,
+		},
+		"non base account rejected": {
+			allowPrefunded: true,
+			setup: func(t *testing.T, ctx sdk.Context, keepers TestKeepers, addr sdk.AccAddress) {
+				acc := keepers.AccountKeeper.NewAccount(ctx, types.NewContractAccount(authtypes.NewBaseAccountWithAddress(addr), 1))
+				keepers.AccountKeeper.SetAccount(ctx, acc)
+			},
+			expErr: types.ErrAccountExists,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var opts []Option
+			if spec.allowPrefunded {
+				opts = append(opts, WithAllowPrefundedContractAddress())
+			}
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, opts...)
+			var mock wasmtesting.MockWasmer
+			wasmtesting.MakeInstantiable(&mock)
+			example := StoreRandomContract(t, ctx, keepers, &mock)
+			expAddr := BuildContractAddress(example.CodeID, 1)
+			spec.setup(t, ctx, keepers, expAddr)
+			preBalance := keepers.BankKeeper.GetAllBalances(ctx, expAddr)
+			var preAccNumber uint64
+			if acc := keepers.AccountKeeper.GetAccount(ctx, expAddr); acc != nil {
+				preAccNumber = acc.GetAccountNumber()
+			}
+
+			// when
+			gotAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "my label", sdk.NewCoins(sdk.NewInt64Coin("denom", 1)))
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), "got %+v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, expAddr, gotAddr)
+			acc := keepers.AccountKeeper.GetAccount(ctx, gotAddr)
+			require.True(t, types.IsContractAccount(acc))
+			if !preBalance.IsZero() {
+				assert.Equal(t, preAccNumber, acc.GetAccountNumber())
+			}
+			assert.Equal(t, preBalance.Add(sdk.NewInt64Coin("denom", 1)), keepers.BankKeeper.GetAllBalances(ctx, gotAddr))
+		})
+	}
+}
+
+func TestInstantiateWithCustomAddressGenerator(t *testing.T) {
+	var capturedCodeID, capturedInstanceID uint64
+	myAddr := RandomAccountAddress(t)
+	generator := func(codeID, instanceID uint64) sdk.AccAddress {
+		capturedCodeID, capturedInstanceID = codeID, instanceID
+		return myAddr
+	}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithContractAddressGenerator(generator))
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+
+	// when
+	gotAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "my label", nil)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, myAddr, gotAddr)
+	assert.Equal(t, example.CodeID, capturedCodeID)
+	assert.Equal(t, uint64(1), capturedInstanceID)
+	assert.True(t, keepers.WasmKeeper.HasContractInfo(ctx, myAddr))
+}
+
+func TestInstantiateWithReservedContractAddress(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	creator, other := example.CreatorAddr, RandomAccountAddress(t)
+	checksum := keepers.WasmKeeper.GetCodeInfo(ctx, example.CodeID).CodeHash
+	otherCodeID, err := keepers.ContractKeeper.Create(ctx, creator, append(wasmIdent, []byte("other code")...), nil)
+	require.NoError(t, err)
+	reservedAddr := RandomAccountAddress(t)
+
+	// when an address is reserved for the creator and code
+	require.NoError(t, keepers.ContractKeeper.ReserveContractAddress(ctx, reservedAddr, creator, checksum))
+	exp := &types.ReservedContractAddress{ContractAddress: reservedAddr.String(), Creator: creator.String(), CodeHash: checksum}
+	assert.Equal(t, exp, keepers.WasmKeeper.GetReservedContractAddress(ctx, reservedAddr))
+	// then it can not be reserved again
+	err = keepers.ContractKeeper.ReserveContractAddress(ctx, reservedAddr, other, checksum)
+	require.True(t, types.ErrDuplicate.Is(err), err)
+
+	// and other creators get a generated address
+	gotAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, other, nil, []byte(`{}`), "other", nil)
+	require.NoError(t, err)
+	assert.Equal(t, BuildContractAddress(example.CodeID, 1), gotAddr)
+
+	// and the creator gets a generated address for other codes
+	gotAddr, _, err = keepers.ContractKeeper.Instantiate(ctx, otherCodeID, creator, nil, []byte(`{}`), "other code", nil)
+	require.NoError(t, err)
+	assert.Equal(t, BuildContractAddress(otherCodeID, 2), gotAddr)
+
+	// and the creator instantiates the code into the reserved address
+	gotAddr, _, err = keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, []byte(`{}`), "reserved", nil)
+	require.NoError(t, err)
+	assert.Equal(t, reservedAddr, gotAddr)
+	assert.True(t, keepers.WasmKeeper.HasContractInfo(ctx, reservedAddr))
+	// which releases the reservation
+	assert.Nil(t, keepers.WasmKeeper.GetReservedContractAddress(ctx, reservedAddr))
+
+	// and the next instantiation by the creator gets a generated address again
+	gotAddr, _, err = keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, []byte(`{}`), "generated", nil)
+	require.NoError(t, err)
+	assert.Equal(t, BuildContractAddress(example.CodeID, 3), gotAddr)
+
+	// and an existing contract address can not be reserved
+	err = keepers.ContractKeeper.ReserveContractAddress(ctx, gotAddr, other, checksum)
+	require.True(t, types.ErrAccountExists.Is(err), err)
+}
+
+func TestInstantiateSkipsReservedContractAddress(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	creator, other := example.CreatorAddr, RandomAccountAddress(t)
+	checksum := keepers.WasmKeeper.GetCodeInfo(ctx, example.CodeID).CodeHash
+
+	// the next generated address is reserved for the creator
+	nextAddr := BuildContractAddress(example.CodeID, 1)
+	require.NoError(t, keepers.ContractKeeper.ReserveContractAddress(ctx, nextAddr, creator, checksum))
+
+	// when another creator instantiates
+	gotAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, other, nil, []byte(`{}`), "other", nil)
+
+	// then the reserved address is skipped
+	require.NoError(t, err)
+	assert.Equal(t, BuildContractAddress(example.CodeID, 2), gotAddr)
+	assert.False(t, keepers.WasmKeeper.HasContractInfo(ctx, nextAddr))
+	require.NotNil(t, keepers.WasmKeeper.GetReservedContractAddress(ctx, nextAddr))
+
+	// and the creator still instantiates into it
+	gotAddr, _, err = keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, []byte(`{}`), "reserved", nil)
+	require.NoError(t, err)
+	assert.Equal(t, nextAddr, gotAddr)
+}
+
+func TestContractInstantiatesContract(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		instantiateMsg := wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Instantiate: &wasmvmtypes.InstantiateMsg{
+			CodeID: example.CodeID,
+			Msg:    []byte(`{}`),
+			Funds:  wasmvmtypes.Coins{},
+			Label:  "child",
+		}}}
+		return &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{{Msg: instantiateMsg, ReplyOn: wasmvmtypes.ReplyNever}}}, 0, nil
+	}
+	specs := map[string]struct {
+		allowed bool
+		expErr  *sdkerrors.Error
+	}{
+		"allowed": {
+			allowed: true,
+		},
+		"disabled": {
+			allowed: false,
+			expErr:  types.ErrUnsupportedForContract,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			params := keepers.WasmKeeper.GetParams(ctx)
+			params.AllowContractInstantiation = spec.allowed
+			keepers.WasmKeeper.setParams(ctx, params)
+
+			// when the contract instantiates another contract
+			_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+			// then
+			require.True(t, spec.expErr.Is(err), "exp %v got %+v", spec.expErr, err)
+			if spec.expErr != nil {
+				assert.Equal(t, uint64(1), keepers.WasmKeeper.GetCodeInstanceCount(ctx, example.CodeID))
+			} else {
+				assert.Equal(t, uint64(2), keepers.WasmKeeper.GetCodeInstanceCount(ctx, example.CodeID))
+			}
+
+			// and users can always instantiate
+			_, _, err = keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "user", nil)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInstantiateWithChecksumAllowlist(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	allowed := StoreRandomContract(t, ctx, keepers, &mock)
+	notAllowed := StoreRandomContract(t, ctx, keepers, &mock)
+
+	params := keepers.WasmKeeper.GetParams(ctx)
+	params.CodeChecksumAllowlist = [][]byte{keepers.WasmKeeper.GetCodeInfo(ctx, allowed.CodeID).CodeHash}
+	keepers.WasmKeeper.setParams(ctx, params)
+
+	specs := map[string]struct {
+		codeID uint64
+		expErr *sdkerrors.Error
+	}{
+		"allowlisted": {
+			codeID: allowed.CodeID,
+		},
+		"not allowlisted": {
+			codeID: notAllowed.CodeID,
+			expErr: types.ErrChecksumNotAllowed,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			_, _, err := keepers.ContractKeeper.Instantiate(cacheCtx, spec.codeID, allowed.CreatorAddr, nil, []byte(`{}`), "my label", nil)
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	// and storing code that is not allowlisted is still possible
+	_, err := keepers.ContractKeeper.Create(ctx, allowed.CreatorAddr, append(append([]byte{}, wasmIdent...), bytes.Repeat([]byte{3}, 10)...), nil)
+	require.NoError(t, err)
+}
+
+func TestMigrateWithChecksumAllowlist(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	allowed := StoreRandomContract(t, ctx, keepers, &mock)
+	notAllowed := StoreRandomContract(t, ctx, keepers, &mock)
+
+	params := keepers.WasmKeeper.GetParams(ctx)
+	params.CodeChecksumAllowlist = [][]byte{
+		keepers.WasmKeeper.GetCodeInfo(ctx, example.CodeID).CodeHash,
+		keepers.WasmKeeper.GetCodeInfo(ctx, allowed.CodeID).CodeHash,
+	}
+	keepers.WasmKeeper.setParams(ctx, params)
+
+	specs := map[string]struct {
+		codeID uint64
+		expErr *sdkerrors.Error
+	}{
+		"allowlisted": {
+			codeID: allowed.CodeID,
+		},
+		"not allowlisted": {
+			codeID: notAllowed.CodeID,
+			expErr: types.ErrChecksumNotAllowed,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			_, err := keepers.ContractKeeper.Migrate(cacheCtx, example.Contract, example.CreatorAddr, spec.codeID, []byte(`{}`))
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestInstantiateWithDeposit(t *testing.T) {
 	var (
 		bob  = bytes.Repeat([]byte{1}, 32)
@@ -544,7 +1294,7 @@ func TestExecute(t *testing.T) {
 	// make sure gas is properly deducted from ctx
 	gasAfter := ctx.GasMeter().GasConsumed()
 	if types.EnableGasVerification {
-		require.Equal(t, uint64(0x17621), gasAfter-gasBefore)
+		require.Equal(t, uint64(0x1823f), gasAfter-gasBefore)
 	}
 	// ensure bob now exists and got both payments released
 	bobAcct = accKeeper.GetAccount(ctx, bob)
@@ -742,6 +1492,281 @@ func TestExecuteWithCpuLoop(t *testing.T) {
 
 }
 
+func TestExecuteWithMemo(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	var capturedMsgs [][]byte
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		capturedMsgs = append(capturedMsgs, executeMsg)
+		return &wasmvmtypes.Response{Data: []byte("my-data")}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	msgServer := NewMsgServerImpl(keepers.ContractKeeper)
+
+	specs := map[string]struct {
+		memo       string
+		expEvtAttr []sdk.Attribute
+	}{
+		"with memo": {
+			memo: "my memo",
+			expEvtAttr: []sdk.Attribute{
+				sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+				sdk.NewAttribute(types.AttributeKeyMemo, "my memo"),
+			},
+		},
+		"without memo": {
+			expEvtAttr: []sdk.Attribute{
+				sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			capturedMsgs = nil
+			em := sdk.NewEventManager()
+			// when
+			rsp, err := msgServer.ExecuteContract(sdk.WrapSDKContext(ctx.WithEventManager(em)), &types.MsgExecuteContract{
+				Sender:   example.CreatorAddr.String(),
+				Contract: example.Contract.String(),
+				Msg:      []byte(`{"foo":"bar"}`),
+				Memo:     spec.memo,
+			})
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, []byte("my-data"), rsp.Data)
+			// the contract does not see the memo
+			assert.Equal(t, [][]byte{[]byte(`{"foo":"bar"}`)}, capturedMsgs)
+			assert.Contains(t, em.Events(), sdk.NewEvent(types.EventTypeExecute, spec.expEvtAttr...))
+		})
+	}
+}
+
+func TestExecuteContractOriginEvent(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	contractA := SeedNewContractInstance(t, ctx, keepers, &mock)
+	contractB, _, err := keepers.ContractKeeper.Instantiate(ctx, contractA.CodeID, contractA.CreatorAddr, nil, []byte(`{}`), "b", nil)
+	require.NoError(t, err)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		if string(executeMsg) != `{"call_b":{}}` {
+			return &wasmvmtypes.Response{}, 0, nil
+		}
+		return &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{{
+			ReplyOn: wasmvmtypes.ReplyNever,
+			Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{
+				ContractAddr: contractB.String(),
+				Msg:          []byte(`{}`),
+			}}},
+		}}}, 0, nil
+	}
+
+	// when A executes B
+	em := sdk.NewEventManager()
+	_, err = keepers.ContractKeeper.Execute(ctx.WithEventManager(em), contractA.Contract, contractA.CreatorAddr, []byte(`{"call_b":{}}`), nil)
+	require.NoError(t, err)
+
+	// then the execute event of B carries A as origin
+	assert.Contains(t, em.Events(), sdk.NewEvent(types.EventTypeExecute,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractB.String()),
+		sdk.NewAttribute(types.AttributeKeyContractOrigin, contractA.Contract.String()),
+	))
+	// and the direct execute of A has no origin
+	assert.Contains(t, em.Events(), sdk.NewEvent(types.EventTypeExecute,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractA.Contract.String()),
+	))
+
+	// when B is executed directly
+	em = sdk.NewEventManager()
+	_, err = keepers.ContractKeeper.Execute(ctx.WithEventManager(em), contractB, contractA.CreatorAddr, []byte(`{}`), nil)
+	require.NoError(t, err)
+
+	// then there is no origin
+	assert.Contains(t, em.Events(), sdk.NewEvent(types.EventTypeExecute,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractB.String()),
+	))
+}
+
+func TestExecuteWithRateLimiter(t *testing.T) {
+	limiter := &perBlockRateLimiter{max: 1}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithExecuteRateLimiter(limiter))
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	other := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	// first execute in the block passes
+	_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+	require.NoError(t, err)
+
+	// second execute in the same block is rejected
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+	assert.True(t, types.ErrRateLimited.Is(err), err)
+
+	// other contracts are not affected
+	_, err = keepers.ContractKeeper.Execute(ctx, other.Contract, other.CreatorAddr, []byte(`{}`), nil)
+	require.NoError(t, err)
+
+	// and the limit applies per block
+	nextBlockCtx := ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	_, err = keepers.ContractKeeper.Execute(nextBlockCtx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+	require.NoError(t, err)
+}
+
+// perBlockRateLimiter limits the executes per block and contract. The counter is kept in memory for testing only;
+// production implementations must count in the store.
+type perBlockRateLimiter struct {
+	max    int
+	height int64
+	counts map[string]int
+}
+
+func (l *perBlockRateLimiter) CheckExecute(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	if l.counts == nil || l.height != ctx.BlockHeight() {
+		l.height, l.counts = ctx.BlockHeight(), make(map[string]int)
+	}
+	if l.counts[contractAddr.String()] >= l.max {
+		return sdkerrors.Wrapf(types.ErrRateLimited, "max %d executes per block", l.max)
+	}
+	l.counts[contractAddr.String()]++
+	return nil
+}
+
+func TestExecuteWithGasRefund(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	stateKeys := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		for _, k := range stateKeys {
+			store.Delete(k)
+		}
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		for _, k := range stateKeys {
+			store.Delete(k)
+		}
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	// execute returns the gas consumed by a contract that deletes the state keys. Deletions of keys that were not
+	// written before cost the same gas but are not refunded.
+	execute := func(t *testing.T, percent uint32, withState bool) sdk.Gas {
+		return consumedGas(t, ctx, keepers, example.Contract, stateKeys, percent, withState, func(ctx sdk.Context) error {
+			_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+			return err
+		})
+	}
+	sudo := func(t *testing.T, percent uint32, withState bool) sdk.Gas {
+		return consumedGas(t, ctx, keepers, example.Contract, stateKeys, percent, withState, func(ctx sdk.Context) error {
+			_, err := keepers.WasmKeeper.Sudo(ctx, example.Contract, []byte(`{}`))
+			return err
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, execute(t, 0, false), execute(t, 0, true))
+	})
+	t.Run("refund per deleted key", func(t *testing.T) {
+		gasWithoutRefund := execute(t, 100, false)
+		assert.Equal(t, gasWithoutRefund-3*DefaultDeletionRefund, execute(t, 100, true))
+	})
+	t.Run("refund per deleted key in sudo", func(t *testing.T) {
+		gasWithoutRefund := sudo(t, 100, false)
+		assert.Equal(t, gasWithoutRefund-3*DefaultDeletionRefund, sudo(t, 100, true))
+	})
+	t.Run("capped by share of gas used", func(t *testing.T) {
+		gasWithoutRefund := execute(t, 1, false)
+		refund := gasWithoutRefund - execute(t, 1, true)
+		assert.Greater(t, refund, uint64(0))
+		assert.LessOrEqual(t, refund, gasWithoutRefund/100)
+		assert.Less(t, refund, 3*DefaultDeletionRefund)
+	})
+}
+
+// consumedGas returns the gas consumed by the contract call with the given max gas refund percent. With state, the
+// state keys are written before so that their deletion by the contract is refunded.
+func consumedGas(t *testing.T, ctx sdk.Context, keepers TestKeepers, contractAddr sdk.AccAddress, stateKeys [][]byte, percent uint32, withState bool, call func(ctx sdk.Context) error) sdk.Gas {
+	ctx, _ = ctx.CacheContext()
+	params := keepers.WasmKeeper.GetParams(ctx)
+	params.MaxGasRefundPercent = percent
+	keepers.WasmKeeper.setParams(ctx, params)
+	if withState {
+		store := keepers.WasmKeeper.contractStore(ctx, contractAddr)
+		for _, k := range stateKeys {
+			store.Set(k, []byte("value"))
+		}
+	}
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	require.NoError(t, call(ctx))
+	return ctx.GasMeter().GasConsumed()
+}
+
+func TestExecuteWithMaxGasPerTxPercent(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	accKeeper, keeper, bankKeeper := keepers.AccountKeeper, keepers.ContractKeeper, keepers.BankKeeper
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
+	creator := createFakeFundedAccount(t, ctx, accKeeper, bankKeeper, deposit.Add(deposit...))
+	fred := createFakeFundedAccount(t, ctx, accKeeper, bankKeeper, sdk.NewCoins(sdk.NewInt64Coin("denom", 5000)))
+
+	contractID, err := keeper.Create(ctx, creator, hackatomWasm, nil)
+	require.NoError(t, err)
+	initMsgBz := HackatomExampleInitMsg{Verifier: fred, Beneficiary: RandomAccountAddress(t)}.GetBytes(t)
+	addr, _, err := keepers.ContractKeeper.Instantiate(ctx, contractID, creator, nil, initMsgBz, "demo contract", deposit)
+	require.NoError(t, err)
+
+	params := keepers.WasmKeeper.GetParams(ctx)
+	params.MaxGasPerTxPercent = 10
+	keepers.WasmKeeper.setParams(ctx, params)
+
+	// block gas limit of 4M, so that a tx can consume 400k gas max although it has a higher gas limit
+	const maxBlockGas, maxTxGas = 4_000_000, 400_000
+	ctx = ctx.WithConsensusParams(&abci.ConsensusParams{Block: &abci.BlockParams{MaxGas: maxBlockGas}})
+
+	specs := map[string]struct {
+		srcMsg      []byte
+		expOutOfGas bool
+	}{
+		"within limit": {
+			srcMsg: []byte(`{"release":{}}`),
+		},
+		"exceeds limit": {
+			srcMsg:      []byte(`{"cpu_loop":{}}`),
+			expOutOfGas: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			txCtx, _ := ctx.WithGasMeter(sdk.NewGasMeter(maxBlockGas)).CacheContext()
+			txCtx, err := NewLimitTxGasDecorator(keepers.WasmKeeper).AnteHandle(txCtx, nil, false, func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+				return ctx, nil
+			})
+			require.NoError(t, err)
+
+			// when
+			if spec.expOutOfGas {
+				require.Panics(t, func() {
+					_, _ = keepers.ContractKeeper.Execute(txCtx, addr, fred, spec.srcMsg, nil)
+				})
+				assert.True(t, txCtx.GasMeter().IsOutOfGas())
+				return
+			}
+			_, err = keepers.ContractKeeper.Execute(txCtx, addr, fred, spec.srcMsg, nil)
+
+			// then
+			require.NoError(t, err)
+			assert.Less(t, txCtx.GasMeter().GasConsumed(), uint64(maxTxGas))
+		})
+	}
+}
+
 func TestExecuteWithStorageLoop(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	accKeeper, keeper, bankKeeper := keepers.AccountKeeper, keepers.ContractKeeper, keepers.BankKeeper
@@ -778,9 +1803,188 @@ func TestExecuteWithStorageLoop(t *testing.T) {
 		require.True(t, ok, "%v", r)
 	}()
 
-	// this should throw out of gas exception (panic)
-	_, err = keepers.ContractKeeper.Execute(ctx, addr, fred, []byte(`{"storage_loop":{}}`), nil)
-	require.True(t, false, "We must panic before this line")
+	// this should throw out of gas exception (panic)
+	_, err = keepers.ContractKeeper.Execute(ctx, addr, fred, []byte(`{"storage_loop":{}}`), nil)
+	require.True(t, false, "We must panic before this line")
+}
+
+func TestReentrancyGuard(t *testing.T) {
+	specs := map[string]struct {
+		keeperOpts  []Option
+		ctxDecorate func(sdk.Context) sdk.Context
+		expErr      *sdkerrors.Error
+		expCalls    int
+	}{
+		"guard enabled by keeper option": {
+			keeperOpts: []Option{WithReentrancyGuard()},
+			expErr:     types.ErrReentrancy,
+		},
+		"guard enabled for call": {
+			ctxDecorate: types.WithReentrancyGuard,
+			expErr:      types.ErrReentrancy,
+		},
+		"guard disabled": {
+			expCalls: 3,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, spec.keeperOpts...)
+			var mock wasmtesting.MockWasmer
+			wasmtesting.MakeInstantiable(&mock)
+			contractA := SeedNewContractInstance(t, ctx, keepers, &mock).Contract
+			contractB := SeedNewContractInstance(t, ctx, keepers, &mock).Contract
+
+			// contract A calls B and B calls A back once
+			var calls int
+			mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+				calls++
+				if calls > 2 {
+					return &wasmvmtypes.Response{}, 0, nil
+				}
+				other := contractB
+				if env.Contract.Address == contractB.String() {
+					other = contractA
+				}
+				return &wasmvmtypes.Response{
+					Messages: []wasmvmtypes.SubMsg{{
+						ReplyOn: wasmvmtypes.ReplyNever,
+						Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{
+							Execute: &wasmvmtypes.ExecuteMsg{ContractAddr: other.String(), Msg: []byte(`{}`), Funds: wasmvmtypes.Coins{}},
+						}},
+					}},
+				}, 0, nil
+			}
+			if spec.ctxDecorate != nil {
+				ctx = spec.ctxDecorate(ctx)
+			}
+
+			// when
+			_, err := keepers.ContractKeeper.Execute(ctx, contractA, RandomAccountAddress(t), []byte(`{}`), nil)
+
+			// then
+			if spec.expErr != nil {
+				require.True(t, errors.Is(err, spec.expErr), "got %+v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expCalls, calls)
+		})
+	}
+}
+
+func TestSimulateExecute(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		store.Set([]byte("foo"), []byte("bar"))
+		return &wasmvmtypes.Response{
+			Data:       []byte("my-data"),
+			Attributes: []wasmvmtypes.EventAttribute{{Key: "my", Value: "attribute"}},
+		}, 1, nil
+	}
+	gasBefore := ctx.GasMeter().GasConsumed()
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 1))
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+	// when
+	data, gasUsed, events, err := keepers.WasmKeeper.SimulateExecute(ctx, 1_000_000, example.Contract, example.CreatorAddr, []byte(`{}`), deposit)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []byte("my-data"), data)
+	assert.NotZero(t, gasUsed)
+	var eventTypes []string
+	for _, e := range events {
+		eventTypes = append(eventTypes, e.Type)
+	}
+	assert.Contains(t, eventTypes, "transfer")
+	assert.Contains(t, eventTypes, "execute")
+	assert.Contains(t, eventTypes, "wasm")
+	// nothing committed or charged
+	assert.Equal(t, gasBefore, ctx.GasMeter().GasConsumed())
+	assert.Nil(t, keepers.WasmKeeper.QueryRaw(ctx, example.Contract, []byte("foo")))
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, example.Contract).IsZero())
+	assert.Empty(t, ctx.EventManager().Events())
+
+	// and when the gas limit is exceeded
+	_, _, _, err = keepers.WasmKeeper.SimulateExecute(ctx, 1, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+	assert.True(t, sdkerrors.ErrOutOfGas.Is(err), "got %+v", err)
+}
+
+func TestPreviewExecuteEvents(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	recipient := RandomAccountAddress(t)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		if string(executeMsg) == `{"fail":{}}` {
+			return nil, 1, errors.New("my error")
+		}
+		store.Set([]byte("foo"), []byte("bar"))
+		return &wasmvmtypes.Response{
+			Attributes: []wasmvmtypes.EventAttribute{{Key: "my", Value: "attribute"}},
+			Messages: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplyNever,
+				Msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+					ToAddress: recipient.String(),
+					Amount:    wasmvmtypes.Coins{wasmvmtypes.NewCoin(1, "denom")},
+				}}},
+			}},
+		}, 1, nil
+	}
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 1))
+	gasBefore := ctx.GasMeter().GasConsumed()
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+	// when
+	gotEvents, err := keepers.WasmKeeper.PreviewExecuteEvents(ctx, 1_000_000, example.Contract, example.CreatorAddr, []byte(`{}`), deposit)
+
+	// then
+	require.NoError(t, err)
+	// nothing committed or charged
+	assert.Equal(t, gasBefore, ctx.GasMeter().GasConsumed())
+	assert.Nil(t, keepers.WasmKeeper.QueryRaw(ctx, example.Contract, []byte("foo")))
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, recipient).IsZero())
+	assert.Empty(t, ctx.EventManager().Events())
+
+	// and when executed for real
+	em := sdk.NewEventManager()
+	_, err = keepers.ContractKeeper.Execute(ctx.WithEventManager(em), example.Contract, example.CreatorAddr, []byte(`{}`), deposit)
+	require.NoError(t, err)
+	// then the same events are emitted, including the ones of the submessage
+	assert.Equal(t, em.Events(), gotEvents)
+	assert.Equal(t, deposit, keepers.BankKeeper.GetAllBalances(ctx, recipient))
+
+	// and when the execution fails
+	gotEvents, err = keepers.WasmKeeper.PreviewExecuteEvents(ctx, 1_000_000, example.Contract, example.CreatorAddr, []byte(`{"fail":{}}`), nil)
+	assert.True(t, types.ErrExecuteFailed.Is(err), "got %+v", err)
+	assert.Nil(t, gotEvents)
+}
+
+func TestSimulateInstantiate(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	gasBefore := ctx.GasMeter().GasConsumed()
+
+	// when
+	contractAddr, _, gasUsed, events, err := keepers.WasmKeeper.SimulateInstantiate(ctx, 1_000_000, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "my label", nil)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, BuildContractAddress(example.CodeID, 1), contractAddr)
+	assert.NotZero(t, gasUsed)
+	require.Len(t, events, 1)
+	assert.Equal(t, "instantiate", events[0].Type)
+	// nothing committed or charged
+	assert.Equal(t, gasBefore, ctx.GasMeter().GasConsumed())
+	assert.False(t, keepers.WasmKeeper.HasContractInfo(ctx, contractAddr))
+	assert.Nil(t, keepers.AccountKeeper.GetAccount(ctx, contractAddr))
 }
 
 func TestMigrate(t *testing.T) {
@@ -998,6 +2202,614 @@ func TestMigrateReplacesTheSecondIndex(t *testing.T) {
 	require.False(t, exists)
 }
 
+func TestSetContractPaused(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) ([]byte, uint64, error) {
+		return []byte(`"ok"`), 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	admin, contractAddr := example.CreatorAddr, example.Contract
+
+	// unauthorized callers can not pause
+	err := keepers.ContractKeeper.SetContractPaused(ctx, contractAddr, RandomAccountAddress(t), true)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+	err = keepers.ContractKeeper.SetContractPaused(ctx, RandomAccountAddress(t), admin, true)
+	require.True(t, sdkerrors.ErrInvalidRequest.Is(err), err)
+
+	// when paused by the admin
+	require.NoError(t, keepers.ContractKeeper.SetContractPaused(ctx, contractAddr, admin, true))
+
+	// then execute and sudo are rejected
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, admin, []byte(`{}`), nil)
+	assert.True(t, types.ErrContractPaused.Is(err), err)
+	_, err = keepers.WasmKeeper.Sudo(ctx, contractAddr, []byte(`{}`))
+	assert.True(t, types.ErrContractPaused.Is(err), err)
+
+	// but queries are served
+	gotRsp, err := keepers.WasmKeeper.QuerySmart(ctx, contractAddr, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`"ok"`), gotRsp)
+
+	// and the flag is exposed in the contract info query
+	q := Querier(keepers.WasmKeeper)
+	gotInfo, err := q.ContractInfo(sdk.WrapSDKContext(ctx), &types.QueryContractInfoRequest{Address: contractAddr.String()})
+	require.NoError(t, err)
+	assert.True(t, gotInfo.Paused)
+
+	// when unpaused by governance
+	require.NoError(t, NewGovPermissionKeeper(keepers.WasmKeeper).SetContractPaused(ctx, contractAddr, nil, false))
+
+	// then executions are accepted again
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, admin, []byte(`{}`), nil)
+	require.NoError(t, err)
+	_, err = keepers.WasmKeeper.Sudo(ctx, contractAddr, []byte(`{}`))
+	require.NoError(t, err)
+	assert.False(t, keepers.WasmKeeper.GetContractInfo(ctx, contractAddr).Paused)
+}
+
+func TestSetContractMigratePermission(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	admin, contractAddr, codeID := example.CreatorAddr, example.Contract, example.CodeID
+	govKeeper := NewGovPermissionKeeper(keepers.WasmKeeper)
+	msgServer := NewMsgServerImpl(keepers.ContractKeeper)
+	updatePermission := func(sender sdk.AccAddress, permission *types.AccessConfig) error {
+		_, err := msgServer.UpdateMigratePermission(sdk.WrapSDKContext(ctx), &types.MsgUpdateMigratePermission{
+			Sender:            sender.String(),
+			Contract:          contractAddr.String(),
+			MigratePermission: permission,
+		})
+		return err
+	}
+
+	// unauthorized callers can not set the permission
+	err := updatePermission(RandomAccountAddress(t), &types.AllowNobody)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+	// and everybody is not supported
+	err = keepers.ContractKeeper.SetContractMigratePermission(ctx, contractAddr, admin, &types.AllowEverybody)
+	require.True(t, types.ErrInvalid.Is(err), err)
+
+	// when the admin restricts migrations to governance
+	require.NoError(t, updatePermission(admin, &types.AllowNobody))
+
+	// then the admin can not migrate anymore
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, admin, codeID, []byte(`{}`))
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+	// nor restore the permission
+	err = updatePermission(admin, nil)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+	// but governance can migrate
+	_, err = govKeeper.Migrate(ctx, contractAddr, nil, codeID, []byte(`{}`))
+	require.NoError(t, err)
+
+	// when governance allows an other address to migrate
+	migrator := RandomAccountAddress(t)
+	onlyMigrator := types.AccessTypeOnlyAddress.With(migrator)
+	require.NoError(t, govKeeper.SetContractMigratePermission(ctx, contractAddr, nil, &onlyMigrator))
+
+	// then this address can migrate while the admin can not
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, migrator, codeID, []byte(`{}`))
+	require.NoError(t, err)
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, admin, codeID, []byte(`{}`))
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+
+	// when the permission is removed
+	require.NoError(t, govKeeper.SetContractMigratePermission(ctx, contractAddr, nil, nil))
+
+	// then the admin can migrate again
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, admin, codeID, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Nil(t, keepers.WasmKeeper.GetContractInfo(ctx, contractAddr).MigratePermission)
+
+	// when the admin delegates migrations and clears the admin afterwards
+	require.NoError(t, updatePermission(admin, &onlyMigrator))
+	require.NoError(t, keepers.ContractKeeper.ClearContractAdmin(ctx, contractAddr, admin))
+
+	// then the permission is removed and nobody but governance can migrate
+	assert.Nil(t, keepers.WasmKeeper.GetContractInfo(ctx, contractAddr).MigratePermission)
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, migrator, codeID, []byte(`{}`))
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+}
+
+func TestSetContractExecutePermission(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	admin, contractAddr := example.CreatorAddr, example.Contract
+	allowed, other := RandomAccountAddress(t), RandomAccountAddress(t)
+	msgServer := NewMsgServerImpl(keepers.ContractKeeper)
+	updatePermission := func(sender sdk.AccAddress, permission *types.AccessConfig) error {
+		_, err := msgServer.UpdateExecutePermission(sdk.WrapSDKContext(ctx), &types.MsgUpdateExecutePermission{
+			Sender:            sender.String(),
+			Contract:          contractAddr.String(),
+			ExecutePermission: permission,
+		})
+		return err
+	}
+
+	// everybody can execute by default
+	_, err := keepers.ContractKeeper.Execute(ctx, contractAddr, other, []byte(`{}`), nil)
+	require.NoError(t, err)
+
+	// unauthorized callers can not set the permission
+	onlyAllowed := types.AccessTypeOnlyAddress.With(allowed)
+	err = updatePermission(allowed, &onlyAllowed)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+
+	// when the admin restricts execution to an address
+	require.NoError(t, updatePermission(admin, &onlyAllowed))
+
+	// then this address can execute
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, allowed, []byte(`{}`), nil)
+	require.NoError(t, err)
+	// while others are rejected
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, other, []byte(`{}`), nil)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+
+	// when the permission is removed
+	require.NoError(t, updatePermission(admin, nil))
+
+	// then everybody can execute again
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, other, []byte(`{}`), nil)
+	require.NoError(t, err)
+	assert.Nil(t, keepers.WasmKeeper.GetContractInfo(ctx, contractAddr).ExecutePermission)
+}
+
+func TestSetContractAcceptedDenoms(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	admin, contractAddr := example.CreatorAddr, example.Contract
+	user := createFakeFundedAccount(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, sdk.NewCoins(
+		sdk.NewInt64Coin("stake", 100),
+		sdk.NewInt64Coin("foreign", 100),
+	))
+	nativeFunds := sdk.NewCoins(sdk.NewInt64Coin("stake", 1))
+	foreignFunds := sdk.NewCoins(sdk.NewInt64Coin("foreign", 1))
+	msgServer := NewMsgServerImpl(keepers.ContractKeeper)
+	updateDenoms := func(sender sdk.AccAddress, denoms []string) error {
+		_, err := msgServer.UpdateAcceptedDenoms(sdk.WrapSDKContext(ctx), &types.MsgUpdateAcceptedDenoms{
+			Sender:   sender.String(),
+			Contract: contractAddr.String(),
+			Denoms:   denoms,
+		})
+		return err
+	}
+
+	// all denoms are accepted by default
+	_, err := keepers.ContractKeeper.Execute(ctx, contractAddr, user, []byte(`{}`), foreignFunds)
+	require.NoError(t, err)
+
+	// unauthorized callers can not set the accepted denoms
+	err = updateDenoms(user, []string{"stake"})
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+
+	// when the admin restricts the funds to the native denom
+	require.NoError(t, updateDenoms(admin, []string{"stake"}))
+
+	// then native funds are accepted
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, user, []byte(`{}`), nativeFunds)
+	require.NoError(t, err)
+	// while foreign funds are rejected before the transfer
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, user, []byte(`{}`), foreignFunds)
+	require.True(t, sdkerrors.ErrInvalidCoins.Is(err), err)
+	assert.Equal(t, sdk.NewInt64Coin("foreign", 99), keepers.BankKeeper.GetBalance(ctx, user, "foreign"))
+
+	// when the restriction is removed
+	require.NoError(t, updateDenoms(admin, nil))
+
+	// then all denoms are accepted again
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, user, []byte(`{}`), foreignFunds)
+	require.NoError(t, err)
+	assert.Empty(t, keepers.WasmKeeper.GetContractInfo(ctx, contractAddr).AcceptedDenoms)
+}
+
+func TestGasBreakdownEvents(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithGasBreakdownEvents())
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	const vmGasUsed = 1_000_000
+	// a storage heavy contract
+	writeState := func(store wasmvm.KVStore) {
+		for i := 0; i < 100; i++ {
+			store.Set([]byte("key-"+strconv.Itoa(i)), bytes.Repeat([]byte{1}, 100))
+		}
+	}
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		writeState(store)
+		return &wasmvmtypes.Response{}, vmGasUsed, nil
+	}
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		writeState(store)
+		return &wasmvmtypes.Response{}, vmGasUsed, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	specs := map[string]func(ctx sdk.Context) error{
+		"execute": func(ctx sdk.Context) error {
+			_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+			return err
+		},
+		"sudo": func(ctx sdk.Context) error {
+			_, err := keepers.WasmKeeper.Sudo(ctx, example.Contract, []byte(`{}`))
+			return err
+		},
+	}
+	for name, call := range specs {
+		t.Run(name, func(t *testing.T) {
+			em := sdk.NewEventManager()
+			ctx, _ := ctx.CacheContext()
+
+			// when
+			err := call(ctx.WithEventManager(em))
+
+			// then
+			require.NoError(t, err)
+			var found bool
+			for _, e := range em.Events() {
+				if e.Type != types.EventTypeGasBreakdown {
+					continue
+				}
+				found = true
+				attrs := make(map[string]string, len(e.Attributes))
+				for _, a := range e.Attributes {
+					attrs[string(a.Key)] = string(a.Value)
+				}
+				assert.Equal(t, example.Contract.String(), attrs[types.AttributeKeyContractAddr])
+				vmGas, err := strconv.ParseUint(attrs[types.AttributeKeyVMGas], 10, 64)
+				require.NoError(t, err)
+				hostGas, err := strconv.ParseUint(attrs[types.AttributeKeyHostGas], 10, 64)
+				require.NoError(t, err)
+				assert.Equal(t, keepers.WasmKeeper.gasRegister.FromWasmVMGas(vmGasUsed), vmGas)
+				// 100 writes with a flat cost of 2000 gas each
+				assert.Greater(t, hostGas, uint64(100*2000))
+				assert.Greater(t, hostGas, vmGas)
+			}
+			assert.True(t, found)
+		})
+	}
+}
+
+func TestCodeInstanceCount(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{
+			Messages: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplyNever,
+				Msg:     wasmvmtypes.CosmosMsg{Custom: []byte(`{"wasmd":{"terminate":{}}}`)},
+			}},
+		}, 0, nil
+	}
+	mock.GetCodeFn = func(codeID wasmvm.Checksum) (wasmvm.WasmCode, error) {
+		return []byte("my code"), nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	q := Querier(keepers.WasmKeeper)
+	queryCount := func() uint64 {
+		t.Helper()
+		rsp, err := q.Code(sdk.WrapSDKContext(ctx), &types.QueryCodeRequest{CodeId: example.CodeID})
+		require.NoError(t, err)
+		assert.Equal(t, keepers.WasmKeeper.GetCodeInstanceCount(ctx, example.CodeID), rsp.InstanceCount)
+		return rsp.InstanceCount
+	}
+	require.Equal(t, uint64(1), queryCount())
+
+	// when another contract is instantiated
+	_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "second", nil)
+	require.NoError(t, err)
+	// then
+	assert.Equal(t, uint64(2), queryCount())
+
+	// when a contract terminates
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+	require.NoError(t, err)
+	// then
+	assert.Equal(t, uint64(1), queryCount())
+}
+
+func TestContractTerminatesItself(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	recipient := RandomAccountAddress(t)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		// terminate and return the funds
+		return &wasmvmtypes.Response{
+			Messages: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplyNever,
+				Msg:     wasmvmtypes.CosmosMsg{Custom: []byte(`{"wasmd":{"terminate":{}}}`)},
+			}, {
+				ReplyOn: wasmvmtypes.ReplyNever,
+				Msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+					ToAddress: recipient.String(),
+					Amount:    wasmvmtypes.Coins{wasmvmtypes.NewCoin(100, "denom")},
+				}}},
+			}},
+		}, 0, nil
+	}
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) ([]byte, uint64, error) {
+		return []byte(`"ok"`), 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	caller, contractAddr := example.CreatorAddr, example.Contract
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	keepers.WasmKeeper.setMigrationProgress(ctx, contractAddr, []byte("unfinished"))
+
+	// when the contract terminates itself
+	em := sdk.NewEventManager()
+	_, err := keepers.ContractKeeper.Execute(ctx.WithEventManager(em), contractAddr, caller, []byte(`{}`), deposit)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, keepers.WasmKeeper.GetContractInfo(ctx, contractAddr).Terminated)
+	assert.Contains(t, em.Events(), sdk.NewEvent(types.EventTypeTerminate, sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String())))
+	assert.Nil(t, keepers.WasmKeeper.GetMigrationProgress(ctx, contractAddr))
+	// and the final funds transfer was executed
+	assert.Equal(t, deposit, keepers.BankKeeper.GetAllBalances(ctx, recipient))
+
+	// and further execute and sudo calls are rejected
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, caller, []byte(`{}`), nil)
+	assert.True(t, types.ErrContractTerminated.Is(err), err)
+	_, err = keepers.WasmKeeper.Sudo(ctx, contractAddr, []byte(`{}`))
+	assert.True(t, types.ErrContractTerminated.Is(err), err)
+
+	// even when unpaused by the admin
+	require.NoError(t, keepers.ContractKeeper.SetContractPaused(ctx, contractAddr, caller, false))
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, caller, []byte(`{}`), nil)
+	assert.True(t, types.ErrContractTerminated.Is(err), err)
+
+	// and so are migrate, reply and IBC calls
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, caller, example.CodeID, []byte(`{}`))
+	assert.True(t, types.ErrContractTerminated.Is(err), err)
+	_, err = keepers.WasmKeeper.reply(ctx, contractAddr, wasmvmtypes.Reply{})
+	assert.True(t, types.ErrContractTerminated.Is(err), err)
+	_, err = keepers.WasmKeeper.OnRecvPacket(ctx, contractAddr, wasmvmtypes.IBCPacketReceiveMsg{})
+	assert.True(t, types.ErrContractTerminated.Is(err), err)
+	err = keepers.WasmKeeper.OnOpenChannel(ctx, contractAddr, wasmvmtypes.IBCChannelOpenMsg{})
+	assert.True(t, types.ErrContractTerminated.Is(err), err)
+	err = keepers.WasmKeeper.OnAckPacket(ctx, contractAddr, wasmvmtypes.IBCPacketAckMsg{})
+	assert.True(t, types.ErrContractTerminated.Is(err), err)
+	err = keepers.WasmKeeper.OnTimeoutPacket(ctx, contractAddr, wasmvmtypes.IBCPacketTimeoutMsg{})
+	assert.True(t, types.ErrContractTerminated.Is(err), err)
+
+	// but queries are served
+	gotRsp, err := keepers.WasmKeeper.QuerySmart(ctx, contractAddr, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`"ok"`), gotRsp)
+}
+
+func TestContractTerminatesItselfWithCustomMessageHandler(t *testing.T) {
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{
+			Messages: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplyNever,
+				Msg:     wasmvmtypes.CosmosMsg{Custom: []byte(`{"wasmd":{"terminate":{}}}`)},
+			}},
+		}, 0, nil
+	}
+	// a custom message handler that does not know the terminate message
+	customHandler := &wasmtesting.MockMessageHandler{DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+		return nil, nil, types.ErrUnknownMsg
+	}}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithMessageHandler(customHandler))
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	// when
+	_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, keepers.WasmKeeper.GetContractInfo(ctx, example.Contract).Terminated)
+}
+
+func TestContractGasHint(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	recipient := RandomAccountAddress(t)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{
+			Messages: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplyNever,
+				Msg:     wasmvmtypes.CosmosMsg{Custom: []byte(`{"wasmd":{"gas_hint":{"msg_id":1,"expected_gas":12345}}}`)},
+			}, {
+				ID:      1,
+				ReplyOn: wasmvmtypes.ReplyNever,
+				Msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+					ToAddress: recipient.String(),
+					Amount:    wasmvmtypes.Coins{wasmvmtypes.NewCoin(100, "denom")},
+				}}},
+			}},
+			Data: []byte("my-data"),
+		}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+
+	// when
+	em := sdk.NewEventManager()
+	gotData, err := keepers.ContractKeeper.Execute(ctx.WithEventManager(em), example.Contract, example.CreatorAddr, []byte(`{}`), deposit)
+
+	// then the hint is reported
+	require.NoError(t, err)
+	assert.Contains(t, em.Events(), sdk.NewEvent(types.EventTypeGasHint,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+		sdk.NewAttribute(types.AttributeKeySubMsgID, "1"),
+		sdk.NewAttribute(types.AttributeKeyExpectedGas, "12345"),
+	))
+	// and the execution is not affected
+	assert.Equal(t, []byte("my-data"), gotData)
+	assert.Equal(t, deposit, keepers.BankKeeper.GetAllBalances(ctx, recipient))
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, example.Contract).IsZero())
+}
+
+func TestDispatchedMessageEventsAttributedToContract(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	recipient := RandomAccountAddress(t)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{
+			Messages: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplyNever,
+				Msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+					ToAddress: recipient.String(),
+					Amount:    wasmvmtypes.Coins{wasmvmtypes.NewCoin(100, "denom")},
+				}}},
+			}},
+		}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	caller, contractAddr := example.CreatorAddr, example.Contract
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	keepers.WasmKeeper.setMigrationProgress(ctx, contractAddr, []byte("unfinished"))
+
+	// when
+	em := sdk.NewEventManager()
+	_, err := keepers.ContractKeeper.Execute(ctx.WithEventManager(em), contractAddr, caller, []byte(`{}`), deposit)
+
+	// then
+	require.NoError(t, err)
+	var transferEvents []sdk.Event
+	for _, e := range em.Events() {
+		if e.Type == banktypes.EventTypeTransfer {
+			transferEvents = append(transferEvents, e)
+		}
+	}
+	require.Len(t, transferEvents, 2)
+	// the deposit sent by the caller is not attributed
+	assert.Equal(t, sdk.NewEvent(banktypes.EventTypeTransfer,
+		sdk.NewAttribute(banktypes.AttributeKeyRecipient, contractAddr.String()),
+		sdk.NewAttribute(banktypes.AttributeKeySender, caller.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, "100denom"),
+	), transferEvents[0])
+	// the bank send dispatched by the contract is
+	assert.Equal(t, sdk.NewEvent(banktypes.EventTypeTransfer,
+		sdk.NewAttribute(banktypes.AttributeKeyRecipient, recipient.String()),
+		sdk.NewAttribute(banktypes.AttributeKeySender, contractAddr.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, "100denom"),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+	), transferEvents[1])
+}
+
+func TestAdminActionEvents(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	admin, contractAddr := example.CreatorAddr, example.Contract
+	newAdmin := RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		do        func(ctx sdk.Context) error
+		expAction string
+		expAdmin  sdk.AccAddress
+	}{
+		"execute": {
+			do: func(ctx sdk.Context) error {
+				_, err := keepers.ContractKeeper.Execute(ctx, contractAddr, admin, []byte(`{}`), nil)
+				return err
+			},
+		},
+		"migrate": {
+			do: func(ctx sdk.Context) error {
+				_, err := keepers.ContractKeeper.Migrate(ctx, contractAddr, admin, example.CodeID, []byte(`{}`))
+				return err
+			},
+			expAction: types.AdminActionMigrate,
+			expAdmin:  admin,
+		},
+		"update admin": {
+			do: func(ctx sdk.Context) error {
+				return keepers.ContractKeeper.UpdateContractAdmin(ctx, contractAddr, admin, newAdmin)
+			},
+			expAction: types.AdminActionUpdateAdmin,
+			expAdmin:  admin,
+		},
+		"clear admin": {
+			do: func(ctx sdk.Context) error {
+				return keepers.ContractKeeper.ClearContractAdmin(ctx, contractAddr, admin)
+			},
+			expAction: types.AdminActionClearAdmin,
+			expAdmin:  admin,
+		},
+		"update admin by governance": {
+			do: func(ctx sdk.Context) error {
+				return NewGovPermissionKeeper(keepers.WasmKeeper).UpdateContractAdmin(ctx, contractAddr, nil, newAdmin)
+			},
+			expAction: types.AdminActionUpdateAdmin,
+			expAdmin:  authtypes.NewModuleAddress(govtypes.ModuleName),
+		},
+		"pause": {
+			do: func(ctx sdk.Context) error {
+				return keepers.ContractKeeper.SetContractPaused(ctx, contractAddr, admin, true)
+			},
+			expAction: types.AdminActionPause,
+			expAdmin:  admin,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cacheCtx, _ := ctx.CacheContext()
+			em := sdk.NewEventManager()
+			cacheCtx = cacheCtx.WithEventManager(em)
+			// when
+			require.NoError(t, spec.do(cacheCtx))
+			// then
+			var gotEvents []sdk.Event
+			for _, e := range em.Events() {
+				if e.Type == types.EventTypeAdminAction {
+					gotEvents = append(gotEvents, e)
+				}
+			}
+			if spec.expAction == "" {
+				assert.Empty(t, gotEvents)
+				return
+			}
+			exp := sdk.NewEvent(types.EventTypeAdminAction,
+				sdk.NewAttribute("action", spec.expAction),
+				sdk.NewAttribute("admin", spec.expAdmin.String()),
+				sdk.NewAttribute("_contract_address", contractAddr.String()),
+			)
+			assert.Equal(t, []sdk.Event{exp}, gotEvents)
+		})
+	}
+}
+
 func TestMigrateWithDispatchedMessage(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	accKeeper, keeper, bankKeeper := keepers.AccountKeeper, keepers.ContractKeeper, keepers.BankKeeper
@@ -1034,9 +2846,19 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 	type dict map[string]interface{}
 	expEvents := []dict{
 		{
-			"Type": "migrate",
+			"Type": "migrate",
+			"Attr": []dict{
+				{"code_id": "2"},
+				{"_contract_address": contractAddr},
+				{"src_checksum": hex.EncodeToString(keepers.WasmKeeper.GetCodeInfo(ctx, originalContractID).CodeHash)},
+				{"dest_checksum": hex.EncodeToString(keepers.WasmKeeper.GetCodeInfo(ctx, burnerContractID).CodeHash)},
+			},
+		},
+		{
+			"Type": "admin_action",
 			"Attr": []dict{
-				{"code_id": "2"},
+				{"action": "migrate"},
+				{"admin": fred},
 				{"_contract_address": contractAddr},
 			},
 		},
@@ -1054,6 +2876,7 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 				{"recipient": myPayoutAddr},
 				{"sender": contractAddr},
 				{"amount": "100000denom"},
+				{"_contract_address": contractAddr},
 			},
 		},
 	}
@@ -1369,6 +3192,177 @@ func TestClearContractAdmin(t *testing.T) {
 	}
 }
 
+func TestImportContractState(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	k := keepers.WasmKeeper
+	require.NoError(t, k.ImportContractState(ctx, example.Contract, []types.Model{{Key: []byte("existing"), Value: []byte("1")}}))
+
+	specs := map[string]struct {
+		srcContract sdk.AccAddress
+		srcModels   []types.Model
+		expErr      *sdkerrors.Error
+	}{
+		"all good": {
+			srcContract: example.Contract,
+			srcModels:   []types.Model{{Key: []byte("a"), Value: []byte("1")}, {Key: []byte("b"), Value: []byte("2")}},
+		},
+		"nil value": {
+			srcContract: example.Contract,
+			srcModels:   []types.Model{{Key: []byte("a")}},
+		},
+		"unknown contract": {
+			srcContract: RandomAccountAddress(t),
+			srcModels:   []types.Model{{Key: []byte("a"), Value: []byte("1")}},
+			expErr:      types.ErrNotFound,
+		},
+		"duplicate key in import": {
+			srcContract: example.Contract,
+			srcModels:   []types.Model{{Key: []byte("a"), Value: []byte("1")}, {Key: []byte("a"), Value: []byte("2")}},
+			expErr:      types.ErrDuplicate,
+		},
+		"key exists in store": {
+			srcContract: example.Contract,
+			srcModels:   []types.Model{{Key: []byte("a"), Value: []byte("1")}, {Key: []byte("existing"), Value: []byte("2")}},
+			expErr:      types.ErrDuplicate,
+		},
+		"empty key": {
+			srcContract: example.Contract,
+			srcModels:   []types.Model{{Key: []byte{}, Value: []byte("1")}},
+			expErr:      types.ErrEmpty,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			tCtx, _ := ctx.CacheContext()
+			gotErr := k.ImportContractState(tCtx, spec.srcContract, spec.srcModels)
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				// nothing written
+				assert.Nil(t, k.QueryRaw(tCtx, example.Contract, []byte("a")))
+				return
+			}
+			require.NoError(t, gotErr)
+			for _, m := range spec.srcModels {
+				exp := m.Value
+				if exp == nil {
+					exp = []byte{}
+				}
+				assert.Equal(t, exp, k.QueryRaw(tCtx, spec.srcContract, m.Key))
+			}
+			assert.Equal(t, []byte("1"), k.QueryRaw(tCtx, spec.srcContract, []byte("existing")))
+		})
+	}
+}
+
+func TestValidateStoredCodes(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+
+	storedCodes := make(map[string]wasmvm.WasmCode)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.CreateFn = func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+		checksum, err := wasmtesting.HashOnlyCreateFn(code)
+		storedCodes[string(checksum)] = code
+		return checksum, err
+	}
+	mock.GetCodeFn = func(checksum wasmvm.Checksum) (wasmvm.WasmCode, error) {
+		code, ok := storedCodes[string(checksum)]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+		return code, nil
+	}
+	var codeIDs []uint64
+	for i := 0; i < 3; i++ {
+		codeIDs = append(codeIDs, StoreRandomContract(t, ctx, keepers, &mock).CodeID)
+	}
+	// the second code is not supported by the VM anymore
+	invalidCode, err := k.GetByteCode(ctx, codeIDs[1])
+	require.NoError(t, err)
+	mock.CreateFn = func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+		if bytes.Equal(code, invalidCode) {
+			return nil, errors.New("unsupported")
+		}
+		return wasmtesting.HashOnlyCreateFn(code)
+	}
+
+	specs := map[string]struct {
+		validator CodeValidator
+		exp       []uint64
+	}{
+		"wasm vm validator": {
+			validator: k.WasmVMCodeValidator(),
+			exp:       []uint64{codeIDs[1]},
+		},
+		"custom validator": {
+			validator: func(codeID uint64, _ types.CodeInfo, _ []byte) error {
+				if codeID == codeIDs[2] {
+					return errors.New("testing")
+				}
+				return nil
+			},
+			exp: []uint64{codeIDs[2]},
+		},
+		"all valid": {
+			validator: func(uint64, types.CodeInfo, []byte) error { return nil },
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			gotFailed := k.ValidateStoredCodes(ctx, spec.validator)
+			assert.Equal(t, spec.exp, gotFailed)
+		})
+	}
+
+	// and when byte code can not be loaded
+	codeInfo := k.GetCodeInfo(ctx, codeIDs[0])
+	delete(storedCodes, string(codeInfo.CodeHash))
+	gotFailed := k.ValidateStoredCodes(ctx, func(uint64, types.CodeInfo, []byte) error { return nil })
+	assert.Equal(t, []uint64{codeIDs[0]}, gotFailed)
+}
+
+func TestBootstrapContract(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.PinFn = func(checksum wasmvm.Checksum) error { return nil }
+	k.wasmVM = &mock
+	wasmCode := append(append([]byte{}, wasmIdent...), bytes.Repeat([]byte{1}, 10)...)
+	admin := RandomAccountAddress(t)
+
+	// when
+	em := sdk.NewEventManager()
+	contractAddr, codeID, err := k.BootstrapContract(ctx.WithEventManager(em), wasmCode, []byte(`{}`), "system", admin)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), codeID)
+	assert.True(t, k.IsPinnedCode(ctx, codeID))
+	info := k.GetContractInfo(ctx, contractAddr)
+	require.NotNil(t, info)
+	assert.Equal(t, codeID, info.CodeID)
+	assert.Equal(t, admin.String(), info.Admin)
+	assert.NotEmpty(t, em.Events())
+
+	// when instantiate fails
+	mock.InstantiateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return nil, 0, errors.New("testing")
+	}
+	em = sdk.NewEventManager()
+	_, _, err = k.BootstrapContract(ctx.WithEventManager(em), wasmCode, []byte(`{}`), "system", admin)
+
+	// then all steps are rolled back
+	require.Error(t, err)
+	assert.Nil(t, k.GetCodeInfo(ctx, 2))
+	assert.Equal(t, uint64(2), k.PeekAutoIncrementID(ctx, types.KeyLastCodeID))
+	assert.Empty(t, em.Events())
+}
+
 func TestPinCode(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	k := keepers.WasmKeeper
@@ -1460,6 +3454,63 @@ func TestInitializePinnedCodes(t *testing.T) {
 	}
 }
 
+func TestPinCodeMemoryBudget(t *testing.T) {
+	wasmConfig := types.DefaultWasmConfig()
+	wasmConfig.PinnedCodeMemoryBudget = 1 // MiB
+	ctx, keepers := createTestInput(t, false, SupportedFeatures, wasmConfig, dbm.NewMemDB())
+	k := keepers.WasmKeeper
+
+	codeSizes := make(map[string]int)
+	var pinned, unpinned []string
+	mock := wasmtesting.MockWasmer{
+		GetCodeFn: func(checksum wasmvm.Checksum) (wasmvm.WasmCode, error) {
+			return make([]byte, codeSizes[string(checksum)]), nil
+		},
+		PinFn: func(checksum wasmvm.Checksum) error {
+			pinned = append(pinned, string(checksum))
+			return nil
+		},
+		UnpinFn: func(checksum wasmvm.Checksum) error {
+			unpinned = append(unpinned, string(checksum))
+			return nil
+		},
+	}
+	wasmtesting.MakeInstantiable(&mock)
+	storeCode := func(size int) (uint64, string) {
+		codeID := StoreRandomContract(t, ctx, keepers, &mock).CodeID
+		checksum := string(k.GetCodeInfo(ctx, codeID).CodeHash)
+		codeSizes[checksum] = size
+		return codeID, checksum
+	}
+	const kiB = 1024
+	codeID1, checksum1 := storeCode(400 * kiB)
+	codeID2, checksum2 := storeCode(400 * kiB)
+	codeID3, checksum3 := storeCode(400 * kiB)
+	require.NoError(t, k.pinCode(ctx, codeID1))
+	require.NoError(t, k.pinCode(ctx, codeID2))
+	// code 1 is used after code 2 was pinned
+	k.pinnedCodes.touch([]byte(checksum1))
+
+	// when pinning beyond the budget
+	gotErr := k.pinCode(ctx, codeID3)
+
+	// then the least recently used code is evicted from memory
+	require.NoError(t, gotErr)
+	assert.Equal(t, []string{checksum1, checksum2, checksum3}, pinned)
+	assert.Equal(t, []string{checksum2}, unpinned)
+	// but the on-chain pinned set is not modified
+	for _, codeID := range []uint64{codeID1, codeID2, codeID3} {
+		assert.True(t, k.IsPinnedCode(ctx, codeID), "code %d", codeID)
+	}
+
+	// and a code that exceeds the budget on its own is not pinned in memory
+	codeID4, _ := storeCode(2 * 1024 * kiB)
+	require.NoError(t, k.pinCode(ctx, codeID4))
+	assert.Len(t, pinned, 3)
+	assert.Equal(t, []string{checksum2}, unpinned)
+	assert.True(t, k.IsPinnedCode(ctx, codeID4))
+}
+
 func TestPinnedContractLoops(t *testing.T) {
 	// a pinned contract that calls itself via submessages should terminate with an
 	// error at some point
@@ -1495,7 +3546,8 @@ func TestPinnedContractLoops(t *testing.T) {
 			},
 		}, 0, nil
 	}
-	ctx = ctx.WithGasMeter(sdk.NewGasMeter(20000))
+	// enough gas for a few loops with the param reads of each execution
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(25000))
 	require.PanicsWithValue(t, sdk.ErrorOutOfGas{Descriptor: "ReadFlat"}, func() {
 		k.execute(ctx, example.Contract, RandomAccountAddress(t), anyMsg, nil)
 	})
@@ -1604,7 +3656,7 @@ func TestReply(t *testing.T) {
 				return &wasmvmtypes.Response{Data: []byte("foo")}, 1, nil
 			},
 			expData: []byte("foo"),
-			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()))},
+			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()), sdk.NewAttribute("msg_id", "0"))},
 		},
 		"with query": {
 			replyFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
@@ -1620,7 +3672,7 @@ func TestReply(t *testing.T) {
 				return &wasmvmtypes.Response{Data: []byte("foo")}, 1, nil
 			},
 			expData: []byte("foo"),
-			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()))},
+			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()), sdk.NewAttribute("msg_id", "0"))},
 		},
 		"with query error handled": {
 			replyFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
@@ -1630,7 +3682,7 @@ func TestReply(t *testing.T) {
 				return &wasmvmtypes.Response{Data: []byte("foo")}, 1, nil
 			},
 			expData: []byte("foo"),
-			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()))},
+			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()), sdk.NewAttribute("msg_id", "0"))},
 		},
 		"error": {
 			replyFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
@@ -1655,6 +3707,92 @@ func TestReply(t *testing.T) {
 	}
 }
 
+func TestReplyMaxGas(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	const maxReplyGas = 100_000
+	params := types.DefaultParams()
+	params.MaxReplyGas = maxReplyGas
+	k.setParams(ctx, params)
+	// reading the param is charged to the parent gas meter
+	paramCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	k.GetMaxReplyGas(paramCtx)
+	paramReadGas := paramCtx.GasMeter().GasConsumed()
+
+	specs := map[string]struct {
+		vmGasUsed uint64
+		expErr    *sdkerrors.Error
+	}{
+		"within limit": {
+			vmGasUsed: 10_000 * DefaultGasMultiplier,
+		},
+		"exceeds limit": {
+			vmGasUsed: 2 * maxReplyGas * DefaultGasMultiplier,
+			expErr:    sdkerrors.ErrOutOfGas,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+				return &wasmvmtypes.Response{}, spec.vmGasUsed, nil
+			}
+			// run twice to ensure the result is deterministic
+			var gasConsumed []sdk.Gas
+			for i := 0; i < 2; i++ {
+				cacheCtx, _ := ctx.CacheContext()
+				cacheCtx = cacheCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+				_, gotErr := k.reply(cacheCtx, example.Contract, wasmvmtypes.Reply{})
+				if spec.expErr != nil {
+					require.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+					assert.Equal(t, sdk.Gas(maxReplyGas)+paramReadGas, cacheCtx.GasMeter().GasConsumed())
+				} else {
+					require.NoError(t, gotErr)
+					assert.Less(t, cacheCtx.GasMeter().GasConsumed(), sdk.Gas(maxReplyGas))
+				}
+				gasConsumed = append(gasConsumed, cacheCtx.GasMeter().GasConsumed())
+			}
+			assert.Equal(t, gasConsumed[0], gasConsumed[1])
+		})
+	}
+}
+
+func TestContractStoreIteratorLimit(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	params := types.DefaultParams()
+	params.MaxIteratorItems = 3
+	keepers.WasmKeeper.setParams(ctx, params)
+
+	var gotKeys, gotReverseKeys []string
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		for i := 0; i < 10; i++ {
+			store.Set([]byte{byte(i)}, []byte("value"))
+		}
+		gotKeys, gotReverseKeys = nil, nil
+		for iter := store.Iterator(nil, nil); iter.Valid(); iter.Next() {
+			gotKeys = append(gotKeys, string(iter.Key()))
+		}
+		for iter := store.ReverseIterator([]byte{2}, nil); iter.Valid(); iter.Next() {
+			gotReverseKeys = append(gotReverseKeys, string(iter.Key()))
+		}
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	// run twice to ensure the result is deterministic
+	for i := 0; i < 2; i++ {
+		_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"\x00", "\x01", "\x02"}, gotKeys)
+		assert.Equal(t, []string{"\x09", "\x08", "\x07"}, gotReverseKeys)
+	}
+}
+
 func TestQueryIsolation(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	k := keepers.WasmKeeper
@@ -1686,6 +3824,346 @@ func TestQueryIsolation(t *testing.T) {
 	assert.Nil(t, ctx.KVStore(k.storeKey).Get([]byte(`set_in_query`)))
 }
 
+func TestMaxMsgSize(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	const maxMsgSize = 100
+	params := types.DefaultParams()
+	params.MaxMsgSize = maxMsgSize
+	keepers.WasmKeeper.setParams(ctx, params)
+
+	// msgOfSize returns a valid json message with the given length
+	msgOfSize := func(n int) []byte {
+		return []byte(`{"a":"` + strings.Repeat("x", n-8) + `"}`)
+	}
+	var vmCalled bool
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		vmCalled = true
+		if string(executeMsg) != `{"dispatch":{}}` {
+			return &wasmvmtypes.Response{}, 0, nil
+		}
+		return &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{{
+			ReplyOn: wasmvmtypes.ReplyNever,
+			Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{
+				ContractAddr: example.Contract.String(),
+				Msg:          msgOfSize(maxMsgSize + 1),
+			}}},
+		}}}, 0, nil
+	}
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		vmCalled = true
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	mock.InstantiateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		vmCalled = true
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+
+	specs := map[string]struct {
+		exec   func(ctx sdk.Context, msg []byte) error
+		srcMsg []byte
+		expErr bool
+	}{
+		"instantiate just under limit": {
+			exec: func(ctx sdk.Context, msg []byte) error {
+				_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, msg, "", nil)
+				return err
+			},
+			srcMsg: msgOfSize(maxMsgSize),
+		},
+		"instantiate just over limit": {
+			exec: func(ctx sdk.Context, msg []byte) error {
+				_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, msg, "", nil)
+				return err
+			},
+			srcMsg: msgOfSize(maxMsgSize + 1),
+			expErr: true,
+		},
+		"execute just under limit": {
+			exec: func(ctx sdk.Context, msg []byte) error {
+				_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, msg, nil)
+				return err
+			},
+			srcMsg: msgOfSize(maxMsgSize),
+		},
+		"execute just over limit": {
+			exec: func(ctx sdk.Context, msg []byte) error {
+				_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, msg, nil)
+				return err
+			},
+			srcMsg: msgOfSize(maxMsgSize + 1),
+			expErr: true,
+		},
+		"migrate just under limit": {
+			exec: func(ctx sdk.Context, msg []byte) error {
+				_, err := keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, msg)
+				return err
+			},
+			srcMsg: msgOfSize(maxMsgSize),
+		},
+		"migrate just over limit": {
+			exec: func(ctx sdk.Context, msg []byte) error {
+				_, err := keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, msg)
+				return err
+			},
+			srcMsg: msgOfSize(maxMsgSize + 1),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			vmCalled = false
+			ctx, _ := ctx.CacheContext()
+			// when
+			gotErr := spec.exec(ctx, spec.srcMsg)
+			// then
+			if spec.expErr {
+				require.True(t, types.ErrLimit.Is(gotErr), "got %+v", gotErr)
+				assert.False(t, vmCalled)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.True(t, vmCalled)
+		})
+	}
+	t.Run("submessage over limit", func(t *testing.T) {
+		ctx, _ := ctx.CacheContext()
+		_, gotErr := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{"dispatch":{}}`), nil)
+		require.True(t, types.ErrLimit.Is(gotErr), "got %+v", gotErr)
+	})
+}
+
+func TestMaxContractResponseDataSize(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	const maxDataSize = 100
+	params := types.DefaultParams()
+	params.MaxContractResponseDataSize = maxDataSize
+	keepers.WasmKeeper.setParams(ctx, params)
+
+	var dataSize int
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{Data: make([]byte, dataSize)}, 0, nil
+	}
+	mock.InstantiateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{Data: make([]byte, dataSize)}, 0, nil
+	}
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{Data: make([]byte, dataSize)}, 0, nil
+	}
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{Data: make([]byte, dataSize)}, 0, nil
+	}
+	mock.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{Data: make([]byte, dataSize)}, 0, nil
+	}
+
+	instantiate := func(ctx sdk.Context) error {
+		_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "", nil)
+		return err
+	}
+	execute := func(ctx sdk.Context) error {
+		_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+		return err
+	}
+	migrate := func(ctx sdk.Context) error {
+		_, err := keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, []byte(`{}`))
+		return err
+	}
+	sudo := func(ctx sdk.Context) error {
+		_, err := keepers.WasmKeeper.Sudo(ctx, example.Contract, []byte(`{}`))
+		return err
+	}
+	reply := func(ctx sdk.Context) error {
+		_, err := keepers.WasmKeeper.reply(ctx, example.Contract, wasmvmtypes.Reply{})
+		return err
+	}
+	specs := map[string]struct {
+		exec        func(ctx sdk.Context) error
+		srcDataSize int
+		expErr      bool
+	}{
+		"instantiate at limit":   {exec: instantiate, srcDataSize: maxDataSize},
+		"instantiate over limit": {exec: instantiate, srcDataSize: maxDataSize + 1, expErr: true},
+		"execute at limit":       {exec: execute, srcDataSize: maxDataSize},
+		"execute over limit":     {exec: execute, srcDataSize: maxDataSize + 1, expErr: true},
+		"migrate at limit":       {exec: migrate, srcDataSize: maxDataSize},
+		"migrate over limit":     {exec: migrate, srcDataSize: maxDataSize + 1, expErr: true},
+		"sudo at limit":          {exec: sudo, srcDataSize: maxDataSize},
+		"sudo over limit":        {exec: sudo, srcDataSize: maxDataSize + 1, expErr: true},
+		"reply at limit":         {exec: reply, srcDataSize: maxDataSize},
+		"reply over limit":       {exec: reply, srcDataSize: maxDataSize + 1, expErr: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			dataSize = spec.srcDataSize
+			ctx, _ := ctx.CacheContext()
+			// when
+			gotErr := spec.exec(ctx)
+			// then
+			if spec.expErr {
+				require.True(t, types.ErrLimit.Is(gotErr), "got %+v", gotErr)
+				assert.Contains(t, gotErr.Error(), "contract response data size 101 exceeds max 100")
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestQuerySmartWithGasLimit(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	specs := map[string]struct {
+		gasLimit  uint64
+		queryErr  error
+		queryGas  uint64
+		expErr    *sdkerrors.Error
+		expResult []byte
+	}{
+		"success": {
+			gasLimit:  10_000_000,
+			expResult: []byte(`{"ok":true}`),
+		},
+		"out of gas in setup": {
+			gasLimit: 1,
+			expErr:   sdkerrors.ErrOutOfGas,
+		},
+		"out of gas in contract": {
+			gasLimit: 1_000_000,
+			queryGas: math.MaxUint64 / 2,
+			expErr:   sdkerrors.ErrOutOfGas,
+		},
+		"contract error": {
+			gasLimit: 10_000_000,
+			queryErr: errors.New("my error"),
+			expErr:   types.ErrQueryFailed,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) ([]byte, uint64, error) {
+				return []byte(`{"ok":true}`), spec.queryGas, spec.queryErr
+			}
+			parentGasMeter := sdk.NewGasMeter(1)
+			// when
+			gotResult, gotErr := k.QuerySmartWithGasLimit(ctx.WithGasMeter(parentGasMeter), example.Contract, []byte(`{}`), spec.gasLimit)
+			// then
+			assert.Equal(t, uint64(0), parentGasMeter.GasConsumed())
+			if spec.expErr != nil {
+				require.True(t, spec.expErr.Is(gotErr), "got error: %+v", gotErr)
+				assert.Nil(t, gotResult)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expResult, gotResult)
+		})
+	}
+}
+
+func TestQuerySmartWithQueryCache(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	var queryCalls int
+	mock := wasmtesting.MockWasmer{
+		QueryFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) ([]byte, uint64, error) {
+			queryCalls++
+			return []byte(`{"ok":true}`), 1_000, nil
+		},
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+			return &wasmvmtypes.Response{}, 0, nil
+		},
+	}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	other := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	querySmart := func(ctx sdk.Context, contract sdk.AccAddress, msg string) ([]byte, sdk.Gas) {
+		t.Helper()
+		gasBefore := ctx.GasMeter().GasConsumed()
+		rsp, err := k.QuerySmart(ctx, contract, []byte(msg))
+		require.NoError(t, err)
+		return rsp, ctx.GasMeter().GasConsumed() - gasBefore
+	}
+
+	// queries of an executing contract within a tx
+	newTxCtx := func() sdk.Context {
+		return types.WithContractOnCallStack(types.WithQueryCache(ctx, types.NewQueryCache()), RandomAccountAddress(t))
+	}
+
+	// when disabled by params
+	txCtx := newTxCtx()
+	querySmart(txCtx, example.Contract, `{}`)
+	_, expGas := querySmart(txCtx, example.Contract, `{}`)
+	// then all queries are executed
+	assert.Equal(t, 2, queryCalls)
+
+	// when enabled
+	params := k.GetParams(ctx)
+	params.QueryCacheEnabled = true
+	k.setParams(ctx, params)
+	queryCalls = 0
+	txCtx = newTxCtx()
+	first, _ := querySmart(txCtx, example.Contract, `{}`)
+	second, gotGas := querySmart(txCtx, example.Contract, `{}`)
+	// then repeated queries return the cached result and are charged the same gas
+	assert.Equal(t, first, second)
+	assert.Equal(t, expGas, gotGas)
+	assert.Equal(t, 1, queryCalls)
+
+	// and queries with other input or contract are not served from the cache
+	querySmart(txCtx, example.Contract, `{"other":{}}`)
+	querySmart(txCtx, other.Contract, `{}`)
+	assert.Equal(t, 3, queryCalls)
+
+	// when a contract is executed
+	_, err := keepers.ContractKeeper.Execute(txCtx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+	require.NoError(t, err)
+	// then all cached results are dropped
+	querySmart(txCtx, example.Contract, `{}`)
+	querySmart(txCtx, other.Contract, `{}`)
+	assert.Equal(t, 5, queryCalls)
+
+	// when a contract store is written
+	k.contractStore(txCtx, other.Contract).Set([]byte("foo"), []byte("bar"))
+	// then all cached results are dropped
+	querySmart(txCtx, example.Contract, `{}`)
+	assert.Equal(t, 6, queryCalls)
+
+	// when a contract dispatches a message
+	_, err = keepers.WasmKeeper.handleContractResponse(txCtx, other.Contract, "", []wasmvmtypes.SubMsg{{
+		ReplyOn: wasmvmtypes.ReplyNever,
+		Msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+			ToAddress: RandomBech32AccountAddress(t),
+			Amount:    wasmvmtypes.Coins{},
+		}}},
+	}}, nil, nil, nil)
+	// then all cached results are dropped
+	require.NoError(t, err)
+	querySmart(txCtx, example.Contract, `{}`)
+	assert.Equal(t, 7, queryCalls)
+
+	// and outside of a contract execution all queries are executed
+	noContractCtx := types.WithQueryCache(ctx, types.NewQueryCache())
+	querySmart(noContractCtx, other.Contract, `{}`)
+	querySmart(noContractCtx, other.Contract, `{}`)
+	assert.Equal(t, 9, queryCalls)
+
+	// and without a cache in the context all queries are executed
+	querySmart(ctx, other.Contract, `{}`)
+	assert.Equal(t, 10, queryCalls)
+}
+
 func TestBuildContractAddress(t *testing.T) {
 	specs := map[string]struct {
 		srcCodeID     uint64
@@ -0,0 +1,184 @@
+package keeper
+
+import (
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 migrates from version 1 to 2.
+// The contracts-by-creator secondary index is populated from the existing contracts,
+// contract base accounts are converted into contract accounts and params introduced
+// with version 2 are set to their defaults. Contracts that were stored without a created
+// position are backfilled with the zero position before they are indexed.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	m.setMissingParamDefaults(ctx)
+	m.backfillCreatedPositions(ctx)
+
+	type indexEntry struct {
+		creator  sdk.AccAddress
+		created  *types.AbsoluteTxPosition
+		contract sdk.AccAddress
+		codeID   uint64
+	}
+	// collect first to not write to the store while iterating it
+	var entries []indexEntry
+	var err error
+	m.keeper.IterateContractInfo(ctx, func(contractAddr sdk.AccAddress, info types.ContractInfo) bool {
+		var creator sdk.AccAddress
+		if creator, err = sdk.AccAddressFromBech32(info.Creator); err != nil {
+			err = sdkerrors.Wrapf(err, "creator of contract %s", contractAddr)
+			return true
+		}
+		entries = append(entries, indexEntry{creator: creator, created: info.Created, contract: contractAddr, codeID: info.CodeID})
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		m.keeper.addToContractCreatorSecondaryIndex(ctx, e.creator, e.created, e.contract)
+		m.migrateContractAccount(ctx, e.contract, e.codeID)
+	}
+	return nil
+}
+
+// Migrate2to3 migrates from version 2 to 3.
+// The created position is exposed in contract info queries now. As a best effort, contracts that were stored
+// without a position are backfilled with the zero position. Params introduced with version 3 are set to their defaults.
+func (m Migrator) Migrate2to3(ctx sdk.Context) error {
+	m.setMissingParamDefaults(ctx)
+	m.backfillCreatedPositions(ctx)
+	return nil
+}
+
+// Migrate3to4 migrates from version 3 to 4.
+// The number of contract instances per code is tracked now. The counters are rebuilt from the
+// contracts that are not terminated. Params introduced with version 4 are set to their defaults.
+func (m Migrator) Migrate3to4(ctx sdk.Context) error {
+	m.setMissingParamDefaults(ctx)
+
+	counts := make(map[uint64]uint64)
+	var codeIDs []uint64
+	m.keeper.IterateContractInfo(ctx, func(_ sdk.AccAddress, info types.ContractInfo) bool {
+		if info.Terminated {
+			return false
+		}
+		if _, exists := counts[info.CodeID]; !exists {
+			codeIDs = append(codeIDs, info.CodeID)
+		}
+		counts[info.CodeID]++
+		return false
+	})
+	// iterate in a deterministic order
+	for _, codeID := range codeIDs {
+		m.keeper.setCodeInstanceCount(ctx, codeID, counts[codeID])
+	}
+	return nil
+}
+
+// Migrate4to5 migrates from version 4 to 5.
+// The code id by checksum index is populated from the stored codes. When a code was uploaded multiple times,
+// the index points to the first upload. Params introduced with version 5 are set to their defaults.
+func (m Migrator) Migrate4to5(ctx sdk.Context) error {
+	m.setMissingParamDefaults(ctx)
+
+	type indexEntry struct {
+		checksum []byte
+		codeID   uint64
+	}
+	// collect first to not write to the store while iterating it
+	var entries []indexEntry
+	m.keeper.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+		entries = append(entries, indexEntry{checksum: info.CodeHash, codeID: codeID})
+		return false
+	})
+	// code ids are iterated in ascending order
+	for _, e := range entries {
+		m.keeper.addToCodeChecksumIndex(ctx, e.checksum, e.codeID)
+	}
+	return nil
+}
+
+// Migrate5to6 migrates from version 5 to 6.
+// The number of stored codes is tracked now to enforce the max code count. The counter is rebuilt from the
+// stored codes. Code hash commitments are stored by creator and checksum now and are added to the expiry queue.
+// Params introduced with version 6 are set to their defaults.
+func (m Migrator) Migrate5to6(ctx sdk.Context) error {
+	m.setMissingParamDefaults(ctx)
+
+	var count uint64
+	m.keeper.IterateCodeInfos(ctx, func(uint64, types.CodeInfo) bool {
+		count++
+		return false
+	})
+	m.keeper.setCodeCount(ctx, count)
+
+	// collect first to not write to the store while iterating it
+	var commitments []types.CodeCommitment
+	m.keeper.IterateCodeCommitments(ctx, func(c types.CodeCommitment) bool {
+		commitments = append(commitments, c)
+		return false
+	})
+	store := ctx.KVStore(m.keeper.storeKey)
+	for _, c := range commitments {
+		creator, err := sdk.AccAddressFromBech32(c.Creator)
+		if err != nil {
+			return sdkerrors.Wrapf(err, "creator of code commitment %X", c.Checksum)
+		}
+		// the commitments were stored by checksum only
+		store.Delete(append(types.CodeCommitmentPrefix, c.Checksum...))
+		m.keeper.storeCodeCommitment(ctx, creator, c)
+	}
+	return nil
+}
+
+// backfillCreatedPositions sets the zero position for all contracts that were stored without a created position
+func (m Migrator) backfillCreatedPositions(ctx sdk.Context) {
+	var contracts []sdk.AccAddress
+	m.keeper.IterateContractInfo(ctx, func(contractAddr sdk.AccAddress, info types.ContractInfo) bool {
+		if info.Created == nil {
+			contracts = append(contracts, contractAddr)
+		}
+		return false
+	})
+	for _, contractAddr := range contracts {
+		info := m.keeper.GetContractInfo(ctx, contractAddr)
+		info.Created = &types.AbsoluteTxPosition{}
+		m.keeper.storeContractInfo(ctx, contractAddr, info)
+	}
+}
+
+// migrateContractAccount converts the base account of a contract into a contract account.
+// Accounts of any other type are not modified.
+func (m Migrator) migrateContractAccount(ctx sdk.Context, contractAddr sdk.AccAddress, codeID uint64) {
+	baseAccount, ok := m.keeper.accountKeeper.GetAccount(ctx, contractAddr).(*authtypes.BaseAccount)
+	if !ok {
+		return
+	}
+	m.keeper.accountKeeper.SetAccount(ctx, types.NewContractAccount(baseAccount, codeID))
+}
+
+// setMissingParamDefaults stores the default value for all params that do not exist in the store, yet
+func (m Migrator) setMissingParamDefaults(ctx sdk.Context) {
+	defaults := types.DefaultParams()
+	for _, pair := range defaults.ParamSetPairs() {
+		if !m.keeper.paramSpace.Has(ctx, pair.Key) {
+			m.keeper.paramSpace.Set(ctx, pair.Key, reflect.ValueOf(pair.Value).Elem().Interface())
+		}
+	}
+}
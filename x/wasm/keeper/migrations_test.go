@@ -0,0 +1,237 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate1To2(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+
+	creator1, creator2 := RandomAccountAddress(t), RandomAccountAddress(t)
+	// contracts stored without the creator index as in a v1 store
+	contracts := []struct {
+		addr    sdk.AccAddress
+		creator sdk.AccAddress
+		created types.AbsoluteTxPosition
+	}{
+		{addr: BuildContractAddress(1, 1), creator: creator1, created: types.AbsoluteTxPosition{BlockHeight: 2, TxIndex: 1}},
+		{addr: BuildContractAddress(1, 2), creator: creator2, created: types.AbsoluteTxPosition{BlockHeight: 2, TxIndex: 2}},
+		{addr: BuildContractAddress(1, 3), creator: creator1, created: types.AbsoluteTxPosition{BlockHeight: 1, TxIndex: 1}},
+	}
+	for _, c := range contracts {
+		c := c
+		info := types.ContractInfoFixture(func(i *types.ContractInfo) {
+			i.Creator = c.creator.String()
+			i.Created = &c.created
+		})
+		wasmKeeper.storeContractInfo(ctx, c.addr, &info)
+	}
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate1to2(ctx)
+
+	// then
+	require.NoError(t, err)
+	specs := map[string]struct {
+		creator      sdk.AccAddress
+		expContracts []sdk.AccAddress
+	}{
+		"creator with multiple contracts ordered by creation": {
+			creator:      creator1,
+			expContracts: []sdk.AccAddress{contracts[2].addr, contracts[0].addr},
+		},
+		"creator with single contract": {
+			creator:      creator2,
+			expContracts: []sdk.AccAddress{contracts[1].addr},
+		},
+		"unknown creator": {
+			creator: RandomAccountAddress(t),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var got []sdk.AccAddress
+			wasmKeeper.IterateContractsByCreator(ctx, spec.creator, func(address sdk.AccAddress) bool {
+				got = append(got, address)
+				return false
+			})
+			assert.Equal(t, spec.expContracts, got)
+		})
+	}
+}
+
+func TestMigrate1To2WithoutCreatedPosition(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+
+	creator := RandomAccountAddress(t)
+	withoutPosition, withPosition := BuildContractAddress(1, 1), BuildContractAddress(1, 2)
+	legacyInfo := types.ContractInfoFixture(func(i *types.ContractInfo) {
+		i.Creator = creator.String()
+		i.Created = nil
+	})
+	wasmKeeper.storeContractInfo(ctx, withoutPosition, &legacyInfo)
+	info := types.ContractInfoFixture(func(i *types.ContractInfo) {
+		i.Creator = creator.String()
+		i.Created = &types.AbsoluteTxPosition{BlockHeight: 3, TxIndex: 4}
+	})
+	wasmKeeper.storeContractInfo(ctx, withPosition, &info)
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate1to2(ctx)
+
+	// then the position is backfilled
+	require.NoError(t, err)
+	assert.Equal(t, &types.AbsoluteTxPosition{}, wasmKeeper.GetContractInfo(ctx, withoutPosition).Created)
+	assert.Equal(t, &types.AbsoluteTxPosition{BlockHeight: 3, TxIndex: 4}, wasmKeeper.GetContractInfo(ctx, withPosition).Created)
+	// and both contracts are indexed
+	var got []sdk.AccAddress
+	wasmKeeper.IterateContractsByCreator(ctx, creator, func(address sdk.AccAddress) bool {
+		got = append(got, address)
+		return false
+	})
+	assert.Equal(t, []sdk.AccAddress{withoutPosition, withPosition}, got)
+}
+
+func TestMigrate1To2ContractAccounts(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+
+	contractWithAccount, contractWithoutAccount := BuildContractAddress(1, 1), BuildContractAddress(1, 2)
+	for _, addr := range []sdk.AccAddress{contractWithAccount, contractWithoutAccount} {
+		info := types.ContractInfoFixture(func(i *types.ContractInfo) {
+			i.CodeID = 7
+		})
+		wasmKeeper.storeContractInfo(ctx, addr, &info)
+	}
+	// contract account stored as base account as in a v1 store
+	baseAccount := keepers.AccountKeeper.NewAccountWithAddress(ctx, contractWithAccount)
+	keepers.AccountKeeper.SetAccount(ctx, baseAccount)
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate1to2(ctx)
+
+	// then
+	require.NoError(t, err)
+	gotAccount, ok := keepers.AccountKeeper.GetAccount(ctx, contractWithAccount).(*types.ContractAccount)
+	require.True(t, ok)
+	assert.Equal(t, uint64(7), gotAccount.CodeID)
+	assert.Equal(t, baseAccount.GetAccountNumber(), gotAccount.GetAccountNumber())
+	assert.Equal(t, contractWithAccount, gotAccount.GetAddress())
+
+	assert.Nil(t, keepers.AccountKeeper.GetAccount(ctx, contractWithoutAccount))
+}
+
+func TestMigrate2To3(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+
+	withoutPosition, withPosition := BuildContractAddress(1, 1), BuildContractAddress(1, 2)
+	legacyInfo := types.ContractInfoFixture(func(i *types.ContractInfo) {
+		i.Created = nil
+	})
+	wasmKeeper.storeContractInfo(ctx, withoutPosition, &legacyInfo)
+	info := types.ContractInfoFixture(func(i *types.ContractInfo) {
+		i.Created = &types.AbsoluteTxPosition{BlockHeight: 3, TxIndex: 4}
+	})
+	wasmKeeper.storeContractInfo(ctx, withPosition, &info)
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate2to3(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, &types.AbsoluteTxPosition{}, wasmKeeper.GetContractInfo(ctx, withoutPosition).Created)
+	assert.Equal(t, &types.AbsoluteTxPosition{BlockHeight: 3, TxIndex: 4}, wasmKeeper.GetContractInfo(ctx, withPosition).Created)
+	assert.Equal(t, legacyInfo.Creator, wasmKeeper.GetContractInfo(ctx, withoutPosition).Creator)
+}
+
+func TestMigrate3To4(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+
+	// contracts stored without instance counters as in a v3 store
+	for i, codeID := range []uint64{1, 1, 2} {
+		info := types.ContractInfoFixture(func(i *types.ContractInfo) {
+			i.CodeID = codeID
+		})
+		wasmKeeper.storeContractInfo(ctx, BuildContractAddress(codeID, uint64(i+1)), &info)
+	}
+	terminated := types.ContractInfoFixture(func(i *types.ContractInfo) {
+		i.CodeID = 2
+		i.Terminated = true
+	})
+	wasmKeeper.storeContractInfo(ctx, BuildContractAddress(2, 4), &terminated)
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate3to4(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), wasmKeeper.GetCodeInstanceCount(ctx, 1))
+	assert.Equal(t, uint64(1), wasmKeeper.GetCodeInstanceCount(ctx, 2))
+	assert.Equal(t, uint64(0), wasmKeeper.GetCodeInstanceCount(ctx, 3))
+}
+
+func TestMigrate4To5(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+
+	// codes stored without checksum index as in a v4 store
+	checksumA, checksumB := []byte("checksumA"), []byte("checksumB")
+	for i, checksum := range [][]byte{checksumA, checksumB, checksumA} {
+		info := types.CodeInfoFixture(func(info *types.CodeInfo) {
+			info.CodeHash = checksum
+		})
+		wasmKeeper.storeCodeInfo(ctx, uint64(i+1), info)
+	}
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate4to5(ctx)
+
+	// then
+	require.NoError(t, err)
+	gotID, found := wasmKeeper.GetCodeIDByChecksum(ctx, checksumA)
+	require.True(t, found)
+	assert.Equal(t, uint64(1), gotID)
+	gotID, found = wasmKeeper.GetCodeIDByChecksum(ctx, checksumB)
+	require.True(t, found)
+	assert.Equal(t, uint64(2), gotID)
+}
+
+func TestMigrate5To6(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	wasmKeeper := keepers.WasmKeeper
+
+	// codes stored without counter as in a v5 store, code 2 was removed
+	for _, codeID := range []uint64{1, 3, 4} {
+		wasmKeeper.storeCodeInfo(ctx, codeID, types.CodeInfoFixture())
+	}
+	// and a code commitment stored by checksum only
+	creator := RandomAccountAddress(t)
+	commitment := types.CodeCommitment{Creator: creator.String(), Checksum: bytes.Repeat([]byte{1}, 32), CodeSize: 5, ExpiryHeight: 10}
+	ctx.KVStore(wasmKeeper.storeKey).Set(append(types.CodeCommitmentPrefix, commitment.Checksum...), wasmKeeper.cdc.MustMarshal(&commitment))
+
+	// when
+	err := NewMigrator(*wasmKeeper).Migrate5to6(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), wasmKeeper.GetCodeCount(ctx))
+	gotCommitment, found := wasmKeeper.GetCodeCommitment(ctx, creator, commitment.Checksum)
+	require.True(t, found)
+	assert.Equal(t, commitment, gotCommitment)
+	assert.False(t, ctx.KVStore(wasmKeeper.storeKey).Has(append(types.CodeCommitmentPrefix, commitment.Checksum...)))
+	// and the commitment is pruned on expiry
+	wasmKeeper.PruneExpiredCodeCommitments(ctx.WithBlockHeight(11))
+	_, found = wasmKeeper.GetCodeCommitment(ctx, creator, commitment.Checksum)
+	assert.False(t, found)
+}
@@ -1,6 +1,9 @@
 package keeper
 
 import (
+	"encoding/json"
+	"errors"
+
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -35,6 +38,8 @@ func NewMessageDispatcher(messenger Messenger, keeper replyer) *MessageDispatche
 func (d MessageDispatcher) DispatchMessages(ctx sdk.Context, contractAddr sdk.AccAddress, ibcPort string, msgs []wasmvmtypes.CosmosMsg) error {
 	for _, msg := range msgs {
 		events, _, err := d.messenger.DispatchMsg(ctx, contractAddr, ibcPort, msg)
+		// the message may have modified any state that cached query results depend on
+		resetQueryCache(ctx)
 		if err != nil {
 			return err
 		}
@@ -72,7 +77,8 @@ func (d MessageDispatcher) dispatchMsgWithGasLimit(ctx sdk.Context, contractAddr
 }
 
 // DispatchSubmessages builds a sandbox to execute these messages and returns the execution result to the contract
-// that dispatched them, both on success as well as failure
+// that dispatched them, both on success as well as failure.
+// Events are emitted in dispatch order: the events of each submessage are followed by the events of its reply.
 func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk.AccAddress, ibcPort string, msgs []wasmvmtypes.SubMsg) ([]byte, error) {
 	var rsp []byte
 	for _, msg := range msgs {
@@ -87,8 +93,14 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 		subCtx = subCtx.WithEventManager(em)
 
 		// check how much gas left locally, optionally wrap the gas meter
-		gasRemaining := ctx.GasMeter().Limit() - ctx.GasMeter().GasConsumed()
+		gasBefore := ctx.GasMeter().GasConsumed()
+		gasRemaining := ctx.GasMeter().Limit() - gasBefore
 		limitGas := msg.GasLimit != nil && (*msg.GasLimit < gasRemaining)
+		refundTracker := types.GasRefundTrackerFromContext(ctx)
+		var deletedKeysBefore uint64
+		if refundTracker != nil {
+			deletedKeysBefore = refundTracker.DeletedKeys()
+		}
 
 		var err error
 		var events []sdk.Event
@@ -98,18 +110,28 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 		} else {
 			events, data, err = d.messenger.DispatchMsg(subCtx, contractAddr, ibcPort, msg.Msg)
 		}
+		gasUsed := ctx.GasMeter().GasConsumed() - gasBefore
+		// the message may have modified any state that cached query results depend on
+		resetQueryCache(ctx)
 
 		// if it succeeds, commit state changes from submessage, and pass on events to Event Manager
 		var filteredEvents []sdk.Event
 		if err == nil {
 			commit()
 			filteredEvents = filterEvents(append(em.Events(), events...))
-			ctx.EventManager().EmitEvents(filteredEvents)
-		} // on failure, revert state from sandbox, and ignore events (just skip doing the above)
+			ctx.EventManager().EmitEvents(attributeEventsToContract(filteredEvents, contractAddr))
+		} else {
+			// on failure, revert state from sandbox, and ignore events (just skip doing the above).
+			// deletions in the reverted state are not refunded
+			if refundTracker != nil {
+				refundTracker.RevertTo(deletedKeysBefore)
+			}
+		}
 
-		// we only callback if requested. Short-circuit here the cases we don't want to
+		// we only callback if requested. Short-circuit here the cases we don't want to.
+		// Without an error reply the contract can not recover so that the whole parent message fails
 		if (msg.ReplyOn == wasmvmtypes.ReplySuccess || msg.ReplyOn == wasmvmtypes.ReplyNever) && err != nil {
-			return nil, err
+			return nil, sdkerrors.Wrapf(err, "submessage id %d", msg.ID)
 		}
 		if msg.ReplyOn == wasmvmtypes.ReplyNever || (msg.ReplyOn == wasmvmtypes.ReplyError && err == nil) {
 			continue
@@ -132,7 +154,7 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 			}
 		} else {
 			result = wasmvmtypes.SubcallResult{
-				Err: err.Error(),
+				Err: replyErrorMessage(err),
 			}
 		}
 
@@ -144,7 +166,7 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 
 		// we can ignore any result returned as there is nothing to do with the data
 		// and the events are already in the ctx.EventManager()
-		rspData, err := d.keeper.reply(ctx, contractAddr, reply)
+		rspData, err := d.keeper.reply(types.WithSubMsgGasUsed(ctx, gasUsed), contractAddr, reply)
 		switch {
 		case err != nil:
 			return nil, sdkerrors.Wrap(err, "reply")
@@ -155,6 +177,20 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 	return rsp, nil
 }
 
+// replyErrorMessage returns the json encoded types.ReplyError when the error was caused by a types.StructuredError.
+// Other errors are passed as error string.
+func replyErrorMessage(err error) string {
+	var structured *types.StructuredError
+	if !errors.As(err, &structured) {
+		return err.Error()
+	}
+	bz, jsonErr := json.Marshal(structured.ReplyError(err.Error()))
+	if jsonErr != nil {
+		return err.Error()
+	}
+	return string(bz)
+}
+
 func filterEvents(events []sdk.Event) []sdk.Event {
 	// pre-allocate space for efficiency
 	res := make([]sdk.Event, 0, len(events))
@@ -166,6 +202,35 @@ func filterEvents(events []sdk.Event) []sdk.Event {
 	return res
 }
 
+// attributeEventsToContract returns copies of the events with the address of the dispatching contract added so that
+// module events can be traced back to the contract. Events that carry a contract address already, like the events
+// of nested contract calls, are returned unmodified.
+func attributeEventsToContract(events []sdk.Event, contractAddr sdk.AccAddress) []sdk.Event {
+	res := make([]sdk.Event, len(events))
+	for i, ev := range events {
+		if hasContractAddrAttribute(ev) {
+			res[i] = ev
+			continue
+		}
+		attrs := make([]abci.EventAttribute, len(ev.Attributes), len(ev.Attributes)+1)
+		copy(attrs, ev.Attributes)
+		res[i] = sdk.Event{
+			Type:       ev.Type,
+			Attributes: append(attrs, abci.EventAttribute{Key: []byte(types.AttributeKeyContractAddr), Value: []byte(contractAddr.String())}),
+		}
+	}
+	return res
+}
+
+func hasContractAddrAttribute(ev sdk.Event) bool {
+	for _, a := range ev.Attributes {
+		if string(a.Key) == types.AttributeKeyContractAddr {
+			return true
+		}
+	}
+	return false
+}
+
 func sdkEventsToWasmVMEvents(events []sdk.Event) []wasmvmtypes.Event {
 	res := make([]wasmvmtypes.Event, len(events))
 	for i, ev := range events {
@@ -1,20 +1,26 @@
 package keeper
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 func TestDispatchSubmessages(t *testing.T) {
+	myContractAddr := RandomAccountAddress(t)
+	myContractAttr := sdk.NewAttribute(types.AttributeKeyContractAddr, myContractAddr.String())
 	noReplyCalled := &mockReplyer{}
 	var anyGasLimit uint64 = 1
 	specs := map[string]struct {
@@ -101,7 +107,7 @@ func TestDispatchSubmessages(t *testing.T) {
 			expCommits: []bool{true},
 			expEvents: []sdk.Event{{
 				Type:       "myEvent",
-				Attributes: []abci.EventAttribute{{Key: []byte("foo"), Value: []byte("bar")}},
+				Attributes: []abci.EventAttribute{{Key: []byte("foo"), Value: []byte("bar")}, {Key: []byte(myContractAttr.Key), Value: []byte(myContractAttr.Value)}},
 			},
 				sdk.NewEvent("wasm-reply"),
 			},
@@ -121,7 +127,7 @@ func TestDispatchSubmessages(t *testing.T) {
 			expCommits: []bool{true},
 			expEvents: []sdk.Event{{
 				Type:       "myEvent",
-				Attributes: []abci.EventAttribute{{Key: []byte("foo"), Value: []byte("bar")}},
+				Attributes: []abci.EventAttribute{{Key: []byte("foo"), Value: []byte("bar")}, {Key: []byte(myContractAttr.Key), Value: []byte(myContractAttr.Value)}},
 			}},
 		},
 		"with context events - discarded on failure": {
@@ -291,7 +297,7 @@ func TestDispatchSubmessages(t *testing.T) {
 			},
 			expData:    nil,
 			expCommits: []bool{true},
-			expEvents:  []sdk.Event{sdk.NewEvent("execute", sdk.NewAttribute("foo", "bar"))},
+			expEvents:  []sdk.Event{sdk.NewEvent("execute", sdk.NewAttribute("foo", "bar"), myContractAttr)},
 		},
 		"reply gets proper events": {
 			msgs: []wasmvmtypes.SubMsg{{ID: 1, ReplyOn: wasmvmtypes.ReplyAlways}},
@@ -336,7 +342,7 @@ func TestDispatchSubmessages(t *testing.T) {
 			expCommits: []bool{true},
 			expEvents: []sdk.Event{
 				sdk.NewEvent("execute", sdk.NewAttribute("_contract_address", "placeholder-random-addr")),
-				sdk.NewEvent("wasm", sdk.NewAttribute("random", "data")),
+				sdk.NewEvent("wasm", sdk.NewAttribute("random", "data"), myContractAttr),
 				sdk.NewEvent("wasm-reply"),
 			},
 		},
@@ -345,11 +351,12 @@ func TestDispatchSubmessages(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			var mockStore wasmtesting.MockCommitMultiStore
 			em := sdk.NewEventManager()
-			ctx := sdk.Context{}.WithMultiStore(&mockStore).
+			ctx := sdk.Context{}.WithContext(context.Background()).
+				WithMultiStore(&mockStore).
 				WithGasMeter(sdk.NewGasMeter(100)).
 				WithEventManager(em)
 			d := NewMessageDispatcher(spec.msgHandler, spec.replyer)
-			gotData, gotErr := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "any_port", spec.msgs)
+			gotData, gotErr := d.DispatchSubmessages(ctx, myContractAddr, "any_port", spec.msgs)
 			if spec.expErr {
 				require.Error(t, gotErr)
 				assert.Empty(t, em.Events())
@@ -368,6 +375,268 @@ func TestDispatchSubmessages(t *testing.T) {
 	}
 }
 
+func TestDispatchSubmessagesErrorPropagation(t *testing.T) {
+	failingMsgHandler := &wasmtesting.MockMessageHandler{
+		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+			return nil, nil, sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "testing")
+		},
+	}
+	recoveringReplyer := &mockReplyer{
+		replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+			if reply.Result.Err == "" {
+				return nil, errors.New("expected error result")
+			}
+			return []byte("recovered"), nil
+		},
+	}
+	failingReplyer := &mockReplyer{
+		replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+			return nil, types.ErrInvalid
+		},
+	}
+	specs := map[string]struct {
+		srcMsg  wasmvmtypes.SubMsg
+		replyer *mockReplyer
+		expErr  *sdkerrors.Error
+		expData []byte
+	}{
+		"reply never - parent fails with submessage error": {
+			srcMsg:  wasmvmtypes.SubMsg{ID: 1, ReplyOn: wasmvmtypes.ReplyNever},
+			replyer: &mockReplyer{},
+			expErr:  sdkerrors.ErrInsufficientFunds,
+		},
+		"reply on success - parent fails with submessage error": {
+			srcMsg:  wasmvmtypes.SubMsg{ID: 1, ReplyOn: wasmvmtypes.ReplySuccess},
+			replyer: &mockReplyer{},
+			expErr:  sdkerrors.ErrInsufficientFunds,
+		},
+		"reply on error - contract recovers": {
+			srcMsg:  wasmvmtypes.SubMsg{ID: 1, ReplyOn: wasmvmtypes.ReplyError},
+			replyer: recoveringReplyer,
+			expData: []byte("recovered"),
+		},
+		"reply always - contract recovers": {
+			srcMsg:  wasmvmtypes.SubMsg{ID: 1, ReplyOn: wasmvmtypes.ReplyAlways},
+			replyer: recoveringReplyer,
+			expData: []byte("recovered"),
+		},
+		"reply on error - contract decides to fail": {
+			srcMsg:  wasmvmtypes.SubMsg{ID: 1, ReplyOn: wasmvmtypes.ReplyError},
+			replyer: failingReplyer,
+			expErr:  types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var mockStore wasmtesting.MockCommitMultiStore
+			ctx := sdk.Context{}.WithContext(context.Background()).
+				WithMultiStore(&mockStore).
+				WithGasMeter(sdk.NewGasMeter(100)).
+				WithEventManager(sdk.NewEventManager())
+			d := NewMessageDispatcher(failingMsgHandler, spec.replyer)
+			msgs := []wasmvmtypes.SubMsg{spec.srcMsg}
+
+			// when
+			gotData, gotErr := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "any_port", msgs)
+
+			// then
+			if spec.expErr != nil {
+				require.Error(t, gotErr)
+				assert.True(t, spec.expErr.Is(gotErr), gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expData, gotData)
+			assert.Equal(t, []bool{false}, mockStore.Committed)
+		})
+	}
+}
+
+func TestDispatchSubmessagesStructuredErrorReply(t *testing.T) {
+	specs := map[string]struct {
+		handlerErr error
+		expErr     string
+		expReply   *types.ReplyError
+	}{
+		"structured error": {
+			handlerErr: sdkerrors.Wrap(types.NewStructuredError(sdkerrors.ErrInsufficientFunds, []byte("my data")), "testing"),
+			expReply: &types.ReplyError{
+				Codespace: sdkerrors.RootCodespace,
+				Code:      sdkerrors.ErrInsufficientFunds.ABCICode(),
+				Data:      []byte("my data"),
+				Message:   "testing: insufficient funds",
+			},
+		},
+		"structured error without data": {
+			handlerErr: types.NewStructuredError(types.ErrFundsLocked, nil),
+			expReply: &types.ReplyError{
+				Codespace: types.DefaultCodespace,
+				Code:      types.ErrFundsLocked.ABCICode(),
+				Message:   "funds locked",
+			},
+		},
+		"string error": {
+			handlerErr: sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "testing"),
+			expErr:     "testing: insufficient funds",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var capturedErr string
+			failingMsgHandler := &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+					return nil, nil, spec.handlerErr
+				},
+			}
+			replyer := &mockReplyer{
+				replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+					capturedErr = reply.Result.Err
+					return nil, nil
+				},
+			}
+			var mockStore wasmtesting.MockCommitMultiStore
+			ctx := sdk.Context{}.WithContext(context.Background()).
+				WithMultiStore(&mockStore).
+				WithGasMeter(sdk.NewGasMeter(100)).
+				WithEventManager(sdk.NewEventManager())
+			d := NewMessageDispatcher(failingMsgHandler, replyer)
+			msgs := []wasmvmtypes.SubMsg{{ID: 1, ReplyOn: wasmvmtypes.ReplyError}}
+
+			// when
+			_, gotErr := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "any_port", msgs)
+
+			// then
+			require.NoError(t, gotErr)
+			if spec.expReply == nil {
+				assert.Equal(t, spec.expErr, capturedErr)
+				return
+			}
+			var gotReply types.ReplyError
+			require.NoError(t, json.Unmarshal([]byte(capturedErr), &gotReply))
+			assert.Equal(t, *spec.expReply, gotReply)
+		})
+	}
+}
+
+func TestDispatchSubmessagesReplyCorrelation(t *testing.T) {
+	type capturedReply struct {
+		reply   wasmvmtypes.Reply
+		gasUsed uint64
+		found   bool
+	}
+	var capturedReplies []capturedReply
+	captureReply := mockReplyer{
+		replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+			gasUsed, found := types.SubMsgGasUsed(ctx)
+			capturedReplies = append(capturedReplies, capturedReply{reply: reply, gasUsed: gasUsed, found: found})
+			return nil, nil
+		},
+	}
+	submsg := func(id uint64) wasmvmtypes.SubMsg {
+		return wasmvmtypes.SubMsg{
+			ID:      id,
+			Msg:     wasmvmtypes.CosmosMsg{Custom: []byte(fmt.Sprintf("%d", id))},
+			ReplyOn: wasmvmtypes.ReplyAlways,
+		}
+	}
+	var nestedDispatcher *MessageDispatcher
+	msgHandler := &wasmtesting.MockMessageHandler{
+		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+			switch string(msg.Custom) {
+			case "1":
+				ctx.GasMeter().ConsumeGas(100, "testing")
+				return nil, [][]byte{[]byte("one")}, nil
+			case "2":
+				ctx.GasMeter().ConsumeGas(200, "testing")
+				return nil, nil, errors.New("testing")
+			case "3":
+				// nested dispatch with an id that is used on the outer level, too
+				ctx.GasMeter().ConsumeGas(300, "testing")
+				_, err := nestedDispatcher.DispatchSubmessages(ctx, contractAddr, contractIBCPortID, []wasmvmtypes.SubMsg{submsg(1)})
+				return nil, [][]byte{[]byte("three")}, err
+			}
+			panic("unexpected message")
+		},
+	}
+	nestedDispatcher = NewMessageDispatcher(msgHandler, captureReply)
+	d := NewMessageDispatcher(msgHandler, captureReply)
+	var mockStore wasmtesting.MockCommitMultiStore
+	ctx := sdk.Context{}.WithContext(context.Background()).
+		WithMultiStore(&mockStore).
+		WithGasMeter(sdk.NewGasMeter(1_000_000)).
+		WithEventManager(sdk.NewEventManager())
+
+	// when
+	_, gotErr := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "any_port", []wasmvmtypes.SubMsg{submsg(1), submsg(2), submsg(3)})
+
+	// then
+	require.NoError(t, gotErr)
+	require.Len(t, capturedReplies, 4)
+	// the nested reply is processed before the reply to the third message
+	nested, outer := capturedReplies[2], []capturedReply{capturedReplies[0], capturedReplies[1], capturedReplies[3]}
+	assert.Equal(t, uint64(1), nested.reply.ID)
+	assert.Equal(t, []byte("one"), nested.reply.Result.Ok.Data)
+	assert.Equal(t, uint64(100), nested.gasUsed)
+
+	assert.Equal(t, uint64(1), outer[0].reply.ID)
+	assert.Equal(t, []byte("one"), outer[0].reply.Result.Ok.Data)
+	assert.Equal(t, uint64(100), outer[0].gasUsed)
+
+	assert.Equal(t, uint64(2), outer[1].reply.ID)
+	assert.Equal(t, "testing", outer[1].reply.Result.Err)
+	assert.Equal(t, uint64(200), outer[1].gasUsed)
+
+	assert.Equal(t, uint64(3), outer[2].reply.ID)
+	assert.Equal(t, []byte("three"), outer[2].reply.Result.Ok.Data)
+	assert.Equal(t, uint64(400), outer[2].gasUsed)
+	for _, r := range capturedReplies {
+		assert.True(t, r.found)
+	}
+}
+
+func TestDispatchSubmessagesEventOrder(t *testing.T) {
+	msgHandler := &wasmtesting.MockMessageHandler{
+		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+			ctx.EventManager().EmitEvent(sdk.NewEvent("emitted-" + string(msg.Custom)))
+			return []sdk.Event{sdk.NewEvent("returned-" + string(msg.Custom))}, nil, nil
+		},
+	}
+	replyer := mockReplyer{
+		replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+			ctx.EventManager().EmitEvent(sdk.NewEvent(fmt.Sprintf("reply-%d", reply.ID)))
+			return nil, nil
+		},
+	}
+	msgs := []wasmvmtypes.SubMsg{
+		{ID: 1, Msg: wasmvmtypes.CosmosMsg{Custom: []byte("1")}, ReplyOn: wasmvmtypes.ReplyNever},
+		{ID: 2, Msg: wasmvmtypes.CosmosMsg{Custom: []byte("2")}, ReplyOn: wasmvmtypes.ReplySuccess},
+		{ID: 3, Msg: wasmvmtypes.CosmosMsg{Custom: []byte("3")}, ReplyOn: wasmvmtypes.ReplyError},
+	}
+	var mockStore wasmtesting.MockCommitMultiStore
+	em := sdk.NewEventManager()
+	ctx := sdk.Context{}.WithContext(context.Background()).
+		WithMultiStore(&mockStore).
+		WithGasMeter(sdk.NewGasMeter(1_000_000)).
+		WithEventManager(em)
+	ctx.EventManager().EmitEvent(sdk.NewEvent("parent"))
+	myContractAddr := RandomAccountAddress(t)
+	myContractAttr := sdk.NewAttribute(types.AttributeKeyContractAddr, myContractAddr.String())
+
+	// when
+	_, gotErr := NewMessageDispatcher(msgHandler, replyer).DispatchSubmessages(ctx, myContractAddr, "any_port", msgs)
+
+	// then
+	require.NoError(t, gotErr)
+	exp := sdk.Events{
+		sdk.NewEvent("parent"),
+		sdk.NewEvent("emitted-1", myContractAttr), sdk.NewEvent("returned-1", myContractAttr),
+		sdk.NewEvent("emitted-2", myContractAttr), sdk.NewEvent("returned-2", myContractAttr),
+		sdk.NewEvent("reply-2"),
+		sdk.NewEvent("emitted-3", myContractAttr), sdk.NewEvent("returned-3", myContractAttr),
+	}
+	assert.Equal(t, exp, em.Events())
+}
+
 type mockReplyer struct {
 	replyFn func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error)
 }
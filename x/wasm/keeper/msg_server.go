@@ -32,6 +32,7 @@ func (m msgServer) StoreCode(goCtx context.Context, msg *types.MsgStoreCode) (*t
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
+	ctx = types.WithCodeSource(ctx, msg.Source, msg.Builder)
 	codeID, err := m.keeper.Create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission)
 	if err != nil {
 		return nil, err
@@ -42,6 +43,52 @@ func (m msgServer) StoreCode(goCtx context.Context, msg *types.MsgStoreCode) (*t
 	}, nil
 }
 
+func (m msgServer) CommitCodeHash(goCtx context.Context, msg *types.MsgCommitCodeHash) (*types.MsgCommitCodeHashResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	expiryHeight, err := m.keeper.CommitCodeHash(ctx, senderAddr, msg.Checksum, msg.CodeSize, msg.InstantiatePermission)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgCommitCodeHashResponse{
+		ExpiryHeight: expiryHeight,
+	}, nil
+}
+
+func (m msgServer) RevealCode(goCtx context.Context, msg *types.MsgRevealCode) (*types.MsgRevealCodeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	codeID, err := m.keeper.RevealCode(ctx, senderAddr, msg.Checksum, msg.WASMByteCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRevealCodeResponse{
+		CodeID: codeID,
+	}, nil
+}
+
 func (m msgServer) InstantiateContract(goCtx context.Context, msg *types.MsgInstantiateContract) (*types.MsgInstantiateContractResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
@@ -62,7 +109,13 @@ func (m msgServer) InstantiateContract(goCtx context.Context, msg *types.MsgInst
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
-	contractAddr, data, err := m.keeper.Instantiate(ctx, msg.CodeID, senderAddr, adminAddr, msg.Msg, msg.Label, msg.Funds)
+	var contractAddr sdk.AccAddress
+	var data []byte
+	if msg.GovManaged {
+		contractAddr, data, err = m.keeper.InstantiateGovManaged(ctx, msg.CodeID, senderAddr, msg.Msg, msg.Label, msg.Funds)
+	} else {
+		contractAddr, data, err = m.keeper.Instantiate(ctx, msg.CodeID, senderAddr, adminAddr, msg.Msg, msg.Label, msg.Funds)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -90,16 +143,49 @@ func (m msgServer) ExecuteContract(goCtx context.Context, msg *types.MsgExecuteC
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
-	data, err := m.keeper.Execute(ctx, contractAddr, senderAddr, msg.Msg, msg.Funds)
+	if msg.Memo != "" {
+		ctx = types.WithExecuteMemo(ctx, msg.Memo)
+	}
+	funds := msg.Funds
+	var lockID uint64
+	if msg.Timelock != nil {
+		// the funds are held by the module instead of being sent with the execution
+		lockID, err = m.keeper.SetFundsTimelock(ctx, contractAddr, senderAddr, funds, msg.Timelock.UnlockHeight, msg.Timelock.UnlockTime)
+		if err != nil {
+			return nil, err
+		}
+		funds = nil
+	}
+	data, err := m.keeper.Execute(ctx, contractAddr, senderAddr, msg.Msg, funds)
 	if err != nil {
 		return nil, err
 	}
 
 	return &types.MsgExecuteContractResponse{
-		Data: data,
+		Data:       data,
+		TimelockID: lockID,
 	}, nil
 }
 
+func (m msgServer) ReleaseTimelockedFunds(goCtx context.Context, msg *types.MsgReleaseTimelockedFunds) (*types.MsgReleaseTimelockedFundsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.keeper.ReleaseTimelockedFunds(ctx, contractAddr, msg.LockID); err != nil {
+		return nil, err
+	}
+	return &types.MsgReleaseTimelockedFundsResponse{}, nil
+}
+
 func (m msgServer) MigrateContract(goCtx context.Context, msg *types.MsgMigrateContract) (*types.MsgMigrateContractResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
@@ -178,3 +264,75 @@ func (m msgServer) ClearAdmin(goCtx context.Context, msg *types.MsgClearAdmin) (
 
 	return &types.MsgClearAdminResponse{}, nil
 }
+
+func (m msgServer) UpdateExecutePermission(goCtx context.Context, msg *types.MsgUpdateExecutePermission) (*types.MsgUpdateExecutePermissionResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.keeper.SetContractExecutePermission(ctx, contractAddr, senderAddr, msg.ExecutePermission); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateExecutePermissionResponse{}, nil
+}
+
+func (m msgServer) UpdateMigratePermission(goCtx context.Context, msg *types.MsgUpdateMigratePermission) (*types.MsgUpdateMigratePermissionResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.keeper.SetContractMigratePermission(ctx, contractAddr, senderAddr, msg.MigratePermission); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateMigratePermissionResponse{}, nil
+}
+
+func (m msgServer) UpdateAcceptedDenoms(goCtx context.Context, msg *types.MsgUpdateAcceptedDenoms) (*types.MsgUpdateAcceptedDenomsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	if err := m.keeper.SetContractAcceptedDenoms(ctx, contractAddr, senderAddr, msg.Denoms); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateAcceptedDenomsResponse{}, nil
+}
@@ -66,6 +66,22 @@ func WithQueryPlugins(x *QueryPlugins) Option {
 	})
 }
 
+// WithDisabledQueryPlugins is an optional constructor parameter to disable individual branches of the default query
+// plugins, for example the `QueryPluginStaking`. Queries to a disabled branch fail with `types.ErrUnknownMsg`.
+// This option expects the default `QueryHandler` set an should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithDisabledQueryPlugins(names ...string) Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		var err error
+		if k.wasmVMQueryHandler, err = q.Disable(names...); err != nil {
+			panic(err)
+		}
+	})
+}
+
 // WithMessageEncoders is an optional constructor parameter to pass custom message encoder to the default wasm message handler.
 // This option expects the `DefaultMessageHandler` set and should not be combined with Option `WithMessageHandler` or `WithMessageHandlerDecorator`.
 func WithMessageEncoders(x *MessageEncoders) Option {
@@ -74,16 +90,99 @@ func WithMessageEncoders(x *MessageEncoders) Option {
 		if !ok {
 			panic(fmt.Sprintf("Unsupported message handler type: %T", k.messenger))
 		}
-		s, ok := q.handlers[0].(SDKMessageHandler)
-		if !ok {
-			panic(fmt.Sprintf("Unexpected message handler type: %T", q.handlers[0]))
-		}
+		i, s := q.sdkMessageHandler()
 		e, ok := s.encoders.(MessageEncoders)
 		if !ok {
 			panic(fmt.Sprintf("Unsupported encoder type: %T", s.encoders))
 		}
 		s.encoders = e.Merge(x)
-		q.handlers[0] = s
+		q.handlers[i] = s
+	})
+}
+
+// WithAcceptedMsgTypeURLs is an optional constructor parameter to restrict the sdk messages that contracts can dispatch
+// to the given type urls, for example "/cosmos.staking.v1beta1.MsgDelegate". Other types are rejected with
+// `sdkerrors.ErrUnauthorized`. Without this option all message types are accepted.
+// This option expects the `DefaultMessageHandler` set and should not be combined with Option `WithMessageHandler` or `WithMessageHandlerDecorator`.
+func WithAcceptedMsgTypeURLs(typeURLs ...string) Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.messenger.(*MessageHandlerChain)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported message handler type: %T", k.messenger))
+		}
+		i, s := q.sdkMessageHandler()
+		s.acceptedMsgTypeURLs = make(map[string]struct{}, len(typeURLs))
+		for _, u := range typeURLs {
+			s.acceptedMsgTypeURLs[u] = struct{}{}
+		}
+		q.handlers[i] = s
+	})
+}
+
+// WithDispatchedMsgEvents is an optional constructor parameter to emit an event with the message type and the length
+// of the result data for each sdk message that a contract dispatches. The data itself is not part of the event.
+// This option expects the `DefaultMessageHandler` set and should not be combined with Option `WithMessageHandler` or `WithMessageHandlerDecorator`.
+func WithDispatchedMsgEvents() Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.messenger.(*MessageHandlerChain)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported message handler type: %T", k.messenger))
+		}
+		i, s := q.sdkMessageHandler()
+		s.dispatchedMsgEvents = true
+		q.handlers[i] = s
+	})
+}
+
+// WithMessageHandlerOrder is an optional constructor parameter to reorder the handlers of the default message handler
+// chain or to insert custom handlers. The handlers are called in the returned order until one can handle the message,
+// so that a custom handler before the SDK message handler can intercept messages which would otherwise be routed to
+// the SDK modules. The default order is the SDK, IBC and Burn message handler. Nil handlers are rejected.
+// This option expects the `DefaultMessageHandler` set and should not be combined with Option `WithMessageHandler` or `WithMessageHandlerDecorator`.
+func WithMessageHandlerOrder(d func(handlers []Messenger) []Messenger) Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.messenger.(*MessageHandlerChain)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported message handler type: %T", k.messenger))
+		}
+		handlers := d(append([]Messenger{}, q.handlers...))
+		if len(handlers) == 0 {
+			panic("message handler chain must not be empty")
+		}
+		chain := NewMessageHandlerChain(handlers[0], handlers[1:]...)
+		chain.middlewares = q.middlewares
+		k.messenger = chain
+	})
+}
+
+// WithMessageHandlerAt is an optional constructor parameter to insert a custom handler into the default message handler
+// chain at the given position. Position 0 puts the handler before the SDK message handler.
+// This option expects the `DefaultMessageHandler` set and should not be combined with Option `WithMessageHandler` or `WithMessageHandlerDecorator`.
+func WithMessageHandlerAt(pos int, x Messenger) Option {
+	return WithMessageHandlerOrder(func(handlers []Messenger) []Messenger {
+		if pos < 0 || pos > len(handlers) {
+			panic(fmt.Sprintf("message handler position out of range: %d", pos))
+		}
+		return append(handlers[:pos], append([]Messenger{x}, handlers[pos:]...)...)
+	})
+}
+
+// WithMessageHandlerMiddleware is an optional constructor parameter to add middlewares that are called before and
+// after each message that a contract dispatches, for example to log or reject messages. The middlewares are called
+// in the given order after the middlewares that are set already.
+// This option expects the `DefaultMessageHandler` set and should not be combined with Option `WithMessageHandler` or `WithMessageHandlerDecorator`.
+func WithMessageHandlerMiddleware(x ...MessageHandlerMiddleware) Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.messenger.(*MessageHandlerChain)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported message handler type: %T", k.messenger))
+		}
+		for i := range x {
+			if x[i] == nil {
+				panic(fmt.Sprintf("middleware must not be nil at position : %d", i))
+			}
+		}
+		q.middlewares = append(q.middlewares, x...)
 	})
 }
 
@@ -102,7 +201,9 @@ func WithVMCacheMetrics(r prometheus.Registerer) Option {
 
 // WithGasRegister set a new gas register to implement custom gas costs.
 // When the "gas multiplier" for wasmvm gas conversion is modified inside the new register,
-// make sure to also use `WithApiCosts` option for non default values
+// make sure to also use `WithApiCosts` option for non default values.
+// The optional CompileComplexityGasRegister, SignatureVerifyGasRegister, HashGasRegister and
+// DeletionRefundGasRegister interfaces can be implemented by the new register to customize these costs, too.
 func WithGasRegister(x GasRegister) Option {
 	return optsFn(func(k *Keeper) {
 		k.gasRegister = x
@@ -116,3 +217,71 @@ func WithAPICosts(human, canonical uint64) Option {
 		costCanonical = canonical
 	})
 }
+
+// WithGasPriceSource is an optional constructor parameter to set the source of the gas prices that contracts can
+// read with the min gas prices chain query. The default source returns the bond denom with a zero price.
+func WithGasPriceSource(x GasPriceSource) Option {
+	return optsFn(func(k *Keeper) {
+		k.gasPriceSource = x
+	})
+}
+
+// WithExecuteRateLimiter is an optional constructor parameter to limit how often a contract can be executed.
+// The limiter is consulted before each execute and rejects it with an error. By default there is no limit.
+func WithExecuteRateLimiter(x ExecuteRateLimiter) Option {
+	return optsFn(func(k *Keeper) {
+		k.executeRateLimiter = x
+	})
+}
+
+// WithReentrancyGuard is an optional constructor parameter to reject execute and sudo calls to contracts
+// that are already executing in the current call stack with `types.ErrReentrancy`. Without this option
+// the guard can still be enabled per call with `types.WithReentrancyGuard` on the context.
+func WithReentrancyGuard() Option {
+	return optsFn(func(k *Keeper) {
+		k.reentrancyGuard = true
+	})
+}
+
+// WithContractAddressGenerator is an optional constructor parameter to replace the default contract address
+// generator `BuildContractAddress`. The generator must return unique and deterministic addresses.
+func WithContractAddressGenerator(x AddressGenerator) Option {
+	return optsFn(func(k *Keeper) {
+		k.addressGenerator = x
+	})
+}
+
+// WithAllowPrefundedContractAddress is an optional constructor parameter to accept instantiation on a contract address
+// that received funds before. Only unused base accounts (no pubkey, zero sequence) are taken over by the contract.
+// By default any existing account or balance on the new contract address is rejected.
+func WithAllowPrefundedContractAddress() Option {
+	return optsFn(func(k *Keeper) {
+		k.allowPrefundedContractAddress = true
+	})
+}
+
+// WithGasBreakdownEvents is an optional constructor parameter to emit an event for each instantiate, execute, migrate
+// and sudo call with the gas consumed by the wasm execution in the VM and by host functions, like storage access and
+// queries. This helps contract authors and chains to analyse gas costs. The events are for debugging only and do
+// not change the gas consumed.
+func WithGasBreakdownEvents() Option {
+	return optsFn(func(k *Keeper) {
+		k.gasBreakdownEvents = true
+	})
+}
+
+// WithGovParamSource is an optional constructor parameter to set the source of the governance params that are
+// returned by the gov params chain query. Without a source the query is not supported.
+func WithGovParamSource(x GovParamSource) Option {
+	return optsFn(func(k *Keeper) {
+		k.govParamSource = x
+	})
+}
+
+// WithConnectionKeeper is an optional constructor parameter to set the IBC connection keeper that is used by the
+// ibc client connections chain query. Without a connection keeper the query is not supported.
+func WithConnectionKeeper(x types.ConnectionKeeper) Option {
+	return optsFn(func(k *Keeper) {
+		k.connectionKeeper = x
+	})
+}
@@ -3,6 +3,8 @@ package keeper
 import (
 	"testing"
 
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	distributionkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
@@ -28,7 +30,7 @@ func TestConstructorOptions(t *testing.T) {
 		"message handler": {
 			srcOpt: WithMessageHandler(&wasmtesting.MockMessageHandler{}),
 			verify: func(t *testing.T, k Keeper) {
-				assert.IsType(t, &wasmtesting.MockMessageHandler{}, k.messenger)
+				assert.IsType(t, &wasmtesting.MockMessageHandler{}, customMessenger(t, k))
 			},
 		},
 		"query plugins": {
@@ -43,7 +45,18 @@ func TestConstructorOptions(t *testing.T) {
 				return &wasmtesting.MockMessageHandler{}
 			}),
 			verify: func(t *testing.T, k Keeper) {
-				assert.IsType(t, &wasmtesting.MockMessageHandler{}, k.messenger)
+				assert.IsType(t, &wasmtesting.MockMessageHandler{}, customMessenger(t, k))
+			},
+		},
+		"gas charging message handler decorator": {
+			srcOpt: WithMessageHandlerDecorator(func(old Messenger) Messenger {
+				return NewGasChargingMessageHandler(old, map[string]MsgGasSurcharge{MsgVariantAuthz: {Flat: 1}})
+			}),
+			verify: func(t *testing.T, k Keeper) {
+				require.IsType(t, GasChargingMessageHandler{}, customMessenger(t, k))
+				h := customMessenger(t, k).(GasChargingMessageHandler)
+				assert.IsType(t, &MessageHandlerChain{}, h.next)
+				assert.Equal(t, map[string]MsgGasSurcharge{MsgVariantAuthz: {Flat: 1}}, h.surcharges)
 			},
 		},
 		"query plugins decorator": {
@@ -75,6 +88,85 @@ func TestConstructorOptions(t *testing.T) {
 				assert.Equal(t, uint64(2), costCanonical)
 			},
 		},
+		"reentrancy guard": {
+			srcOpt: WithReentrancyGuard(),
+			verify: func(t *testing.T, k Keeper) {
+				assert.True(t, k.reentrancyGuard)
+			},
+		},
+		"execute rate limiter": {
+			srcOpt: WithExecuteRateLimiter(&perBlockRateLimiter{}),
+			verify: func(t *testing.T, k Keeper) {
+				assert.IsType(t, &perBlockRateLimiter{}, k.executeRateLimiter)
+			},
+		},
+		"allow prefunded contract address": {
+			srcOpt: WithAllowPrefundedContractAddress(),
+			verify: func(t *testing.T, k Keeper) {
+				assert.True(t, k.allowPrefundedContractAddress)
+			},
+		},
+		"gas breakdown events": {
+			srcOpt: WithGasBreakdownEvents(),
+			verify: func(t *testing.T, k Keeper) {
+				assert.True(t, k.gasBreakdownEvents)
+			},
+		},
+		"dispatched msg events": {
+			srcOpt: WithDispatchedMsgEvents(),
+			verify: func(t *testing.T, k Keeper) {
+				require.IsType(t, &MessageHandlerChain{}, k.messenger)
+				_, s := k.messenger.(*MessageHandlerChain).sdkMessageHandler()
+				assert.True(t, s.dispatchedMsgEvents)
+			},
+		},
+		"message handler middleware": {
+			srcOpt: WithMessageHandlerMiddleware(MessageHandlerHooks{}),
+			verify: func(t *testing.T, k Keeper) {
+				require.IsType(t, &MessageHandlerChain{}, k.messenger)
+				assert.Len(t, k.messenger.(*MessageHandlerChain).middlewares, 1)
+			},
+		},
+		"contract address generator": {
+			srcOpt: WithContractAddressGenerator(func(codeID, instanceID uint64) sdk.AccAddress {
+				return sdk.AccAddress{0x1}
+			}),
+			verify: func(t *testing.T, k Keeper) {
+				assert.Equal(t, sdk.AccAddress{0x1}, k.addressGenerator(1, 1))
+			},
+		},
+		"message handler at position": {
+			srcOpt: WithMessageHandlerAt(0, &wasmtesting.MockMessageHandler{}),
+			verify: func(t *testing.T, k Keeper) {
+				require.IsType(t, &MessageHandlerChain{}, k.messenger)
+				handlers := k.messenger.(*MessageHandlerChain).handlers
+				require.Len(t, handlers, 6)
+				assert.IsType(t, &wasmtesting.MockMessageHandler{}, handlers[0])
+				assert.IsType(t, SDKMessageHandler{}, handlers[1])
+			},
+		},
+		"message handler order": {
+			srcOpt: WithMessageHandlerOrder(func(handlers []Messenger) []Messenger {
+				return []Messenger{handlers[1], handlers[0]}
+			}),
+			verify: func(t *testing.T, k Keeper) {
+				require.IsType(t, &MessageHandlerChain{}, k.messenger)
+				handlers := k.messenger.(*MessageHandlerChain).handlers
+				require.Len(t, handlers, 3)
+				assert.IsType(t, IBCRawPacketHandler{}, handlers[0])
+				assert.IsType(t, SDKMessageHandler{}, handlers[1])
+				// the terminate handler is always added last
+				assert.IsType(t, MessageHandlerFunc(nil), handlers[2])
+			},
+		},
+		"disabled query plugins": {
+			srcOpt: WithDisabledQueryPlugins(QueryPluginStaking),
+			verify: func(t *testing.T, k Keeper) {
+				require.IsType(t, QueryPlugins{}, k.wasmVMQueryHandler)
+				_, err := k.wasmVMQueryHandler.(QueryPlugins).Staking(sdk.Context{}, &wasmvmtypes.StakingQuery{})
+				assert.True(t, types.ErrUnknownMsg.Is(err), err)
+			},
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
@@ -85,6 +177,34 @@ func TestConstructorOptions(t *testing.T) {
 
 }
 
+// customMessenger returns the custom message handler that is chained with the terminate handler of the keeper
+func customMessenger(t *testing.T, k Keeper) Messenger {
+	t.Helper()
+	require.IsType(t, &MessageHandlerChain{}, k.messenger)
+	handlers := k.messenger.(*MessageHandlerChain).handlers
+	require.Len(t, handlers, 2)
+	assert.IsType(t, MessageHandlerFunc(nil), handlers[1])
+	return handlers[0]
+}
+
+func TestMessageHandlerOrderRejectsInvalidHandlers(t *testing.T) {
+	specs := map[string]Option{
+		"nil handler":           WithMessageHandlerAt(0, nil),
+		"position out of range": WithMessageHandlerAt(6, &wasmtesting.MockMessageHandler{}),
+		"negative position":     WithMessageHandlerAt(-1, &wasmtesting.MockMessageHandler{}),
+		"empty chain": WithMessageHandlerOrder(func(handlers []Messenger) []Messenger {
+			return nil
+		}),
+	}
+	for name, opt := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Panics(t, func() {
+				NewKeeper(nil, nil, paramtypes.NewSubspace(nil, nil, nil, nil, ""), authkeeper.AccountKeeper{}, nil, stakingkeeper.Keeper{}, distributionkeeper.Keeper{}, nil, nil, nil, nil, nil, nil, nil, "tempDir", types.DefaultWasmConfig(), SupportedFeatures, opt)
+			})
+		})
+	}
+}
+
 func setApiDefaults() {
 	costHumanize = DefaultGasCostHumanAddress * DefaultGasMultiplier
 	costCanonical = DefaultGasCostCanonicalAddress * DefaultGasMultiplier
@@ -0,0 +1,93 @@
+package keeper
+
+import (
+	"container/list"
+	"sync"
+)
+
+// pinnedCodeCache tracks the codes that are pinned in the wasmvm memory cache of this node. When the total size of
+// the pinned codes exceeds the budget, the least recently used codes are evicted from the wasmvm memory cache.
+// The size of a code is approximated by the size of its wasm byte code.
+//
+// The cache is node local. Evictions do not modify the pinned code index in the store so that the gas discount
+// of pinned codes stays the same on all nodes. An evicted code is loaded from the wasmvm file cache when used.
+type pinnedCodeCache struct {
+	mu sync.Mutex
+	// budget is the max total size in bytes. Zero means no limit.
+	budget uint64
+	total  uint64
+	// lru holds the pinned codes, the most recently used at the front
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type pinnedCodeEntry struct {
+	checksum []byte
+	size     uint64
+}
+
+func newPinnedCodeCache(budget uint64) *pinnedCodeCache {
+	return &pinnedCodeCache{
+		budget:  budget,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// fits returns true when a code of the given size can be pinned within the budget
+func (c *pinnedCodeCache) fits(size uint64) bool {
+	return c.budget == 0 || size <= c.budget
+}
+
+// add registers a pinned code as most recently used and returns the checksums of the least recently used codes
+// that have to be evicted to stay within the budget.
+func (c *pinnedCodeCache) add(checksum []byte, size uint64) [][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[string(checksum)]; ok {
+		c.lru.MoveToFront(e)
+		return nil
+	}
+	c.entries[string(checksum)] = c.lru.PushFront(&pinnedCodeEntry{checksum: checksum, size: size})
+	c.total += size
+	if c.budget == 0 {
+		return nil
+	}
+	var evicted [][]byte
+	for c.total > c.budget && c.lru.Len() > 1 {
+		entry := c.lru.Remove(c.lru.Back()).(*pinnedCodeEntry)
+		delete(c.entries, string(entry.checksum))
+		c.total -= entry.size
+		evicted = append(evicted, entry.checksum)
+	}
+	return evicted
+}
+
+// touch marks the code as most recently used when it is tracked
+func (c *pinnedCodeCache) touch(checksum []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[string(checksum)]; ok {
+		c.lru.MoveToFront(e)
+	}
+}
+
+// remove stops tracking the code
+func (c *pinnedCodeCache) remove(checksum []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[string(checksum)]
+	if !ok {
+		return
+	}
+	c.total -= c.lru.Remove(e).(*pinnedCodeEntry).size
+	delete(c.entries, string(checksum))
+}
+
+// has returns true when the code is pinned in the wasmvm memory cache
+func (c *pinnedCodeCache) has(checksum []byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.entries[string(checksum)]
+	return ok
+}
@@ -2,6 +2,8 @@ package keeper
 
 import (
 	"encoding/hex"
+	"strconv"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -43,6 +45,12 @@ func NewWasmProposalHandlerX(k types.ContractOpsKeeper, enabledProposalTypes []t
 			return handlePinCodesProposal(ctx, k, *c)
 		case *types.UnpinCodesProposal:
 			return handleUnpinCodesProposal(ctx, k, *c)
+		case *types.BatchStoreCodeProposal:
+			return handleBatchStoreCodeProposal(ctx, k, *c)
+		case *types.RemoveCodeProposal:
+			return handleRemoveCodeProposal(ctx, k, *c)
+		case *types.ReserveContractAddressProposal:
+			return handleReserveContractAddressProposal(ctx, k, *c)
 		default:
 			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized wasm proposal content type: %T", c)
 		}
@@ -62,6 +70,35 @@ func handleStoreCodeProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types
 	return err
 }
 
+// handleBatchStoreCodeProposal stores all codes or none when any of them fails. The assigned code ids are
+// emitted in the proposal result event.
+func handleBatchStoreCodeProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.BatchStoreCodeProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+
+	runAsAddr, err := sdk.AccAddressFromBech32(p.RunAs)
+	if err != nil {
+		return sdkerrors.Wrap(err, "run as address")
+	}
+	cacheCtx, commit := ctx.CacheContext()
+	codeIDs := make([]string, len(p.Codes))
+	for i, c := range p.Codes {
+		codeID, err := k.Create(cacheCtx, runAsAddr, c.WASMByteCode, c.InstantiatePermission)
+		if err != nil {
+			return sdkerrors.Wrapf(err, "code %d", i)
+		}
+		codeIDs[i] = strconv.FormatUint(codeID, 10)
+	}
+	commit()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeGovContractResult,
+		sdk.NewAttribute(types.AttributeKeyCodeIDs, strings.Join(codeIDs, ",")),
+	))
+	return nil
+}
+
 func handleInstantiateProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.InstantiateContractProposal) error {
 	if err := p.ValidateBasic(); err != nil {
 		return err
@@ -155,6 +192,28 @@ func handlePinCodesProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.
 	return nil
 }
 
+func handleRemoveCodeProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.RemoveCodeProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	return k.RemoveCode(ctx, p.CodeID)
+}
+
+func handleReserveContractAddressProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.ReserveContractAddressProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(p.Contract)
+	if err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	creatorAddr, err := sdk.AccAddressFromBech32(p.Creator)
+	if err != nil {
+		return sdkerrors.Wrap(err, "creator")
+	}
+	return k.ReserveContractAddress(ctx, contractAddr, creatorAddr, p.CodeHash)
+}
+
 func handleUnpinCodesProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.UnpinCodesProposal) error {
 	if err := p.ValidateBasic(); err != nil {
 		return err
@@ -6,13 +6,17 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"strconv"
 	"testing"
 
 	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/cosmos/cosmos-sdk/x/params/types/proposal"
 	"github.com/stretchr/testify/assert"
@@ -28,6 +32,10 @@ func TestStoreCodeProposal(t *testing.T) {
 		CodeUploadAccess:             types.AllowNobody,
 		InstantiateDefaultPermission: types.AccessTypeNobody,
 		MaxWasmCodeSize:              types.DefaultMaxWasmCodeSize,
+		MaxMsgSize:                   types.DefaultMaxMsgSize,
+		InstanceCost:                 types.DefaultInstanceCost,
+		CompileCost:                  types.DefaultCompileCost,
+		MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
 	})
 	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
 	require.NoError(t, err)
@@ -58,6 +66,95 @@ func TestStoreCodeProposal(t *testing.T) {
 	assert.Equal(t, wasmCode, storedCode)
 }
 
+func TestBatchStoreCodeProposal(t *testing.T) {
+	hackatomCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+	burnerCode, err := ioutil.ReadFile("./testdata/burner.wasm")
+	require.NoError(t, err)
+	onlyAddrConfig := types.AccessTypeOnlyAddress.With(RandomAccountAddress(t))
+
+	specs := map[string]struct {
+		srcCodes   []types.StoreCodeEntry
+		expErr     bool
+		expCodeIDs string
+	}{
+		"all stored": {
+			srcCodes: []types.StoreCodeEntry{
+				{WASMByteCode: hackatomCode},
+				{WASMByteCode: burnerCode, InstantiatePermission: &onlyAddrConfig},
+			},
+			expCodeIDs: "1,2",
+		},
+		"none stored when one fails": {
+			srcCodes: []types.StoreCodeEntry{
+				{WASMByteCode: hackatomCode},
+				{WASMByteCode: []byte("not a wasm code")},
+			},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+			wasmKeeper.setParams(ctx, types.Params{
+				CodeUploadAccess:             types.AllowNobody,
+				InstantiateDefaultPermission: types.AccessTypeNobody,
+				MaxWasmCodeSize:              types.DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   types.DefaultMaxMsgSize,
+				InstanceCost:                 types.DefaultInstanceCost,
+				CompileCost:                  types.DefaultCompileCost,
+				MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
+			})
+			myActorAddress := RandomBech32AccountAddress(t)
+			src := types.BatchStoreCodeProposalFixture(func(p *types.BatchStoreCodeProposal) {
+				p.RunAs = myActorAddress
+				p.Codes = spec.srcCodes
+			})
+			em := sdk.NewEventManager()
+
+			handler := govKeeper.Router().GetRoute(src.ProposalRoute())
+			if spec.expErr {
+				// when stored
+				_, err := govKeeper.SubmitProposal(ctx, src)
+				require.Error(t, err)
+				// and executed directly
+				err = handler(ctx, src)
+				// then
+				require.Error(t, err)
+				assert.Nil(t, wasmKeeper.GetCodeInfo(ctx, 1))
+				return
+			}
+
+			// when stored
+			storedProposal, err := govKeeper.SubmitProposal(ctx, src)
+			require.NoError(t, err)
+
+			// and proposal execute
+			err = handler(ctx.WithEventManager(em), storedProposal.GetContent())
+
+			// then
+			require.NoError(t, err)
+			for i, c := range spec.srcCodes {
+				codeID := uint64(i + 1)
+				cInfo := wasmKeeper.GetCodeInfo(ctx, codeID)
+				require.NotNil(t, cInfo)
+				assert.Equal(t, myActorAddress, cInfo.Creator)
+				if c.InstantiatePermission != nil {
+					assert.Equal(t, *c.InstantiatePermission, cInfo.InstantiateConfig)
+				}
+				storedCode, err := wasmKeeper.GetByteCode(ctx, codeID)
+				require.NoError(t, err)
+				assert.Equal(t, c.WASMByteCode, storedCode)
+			}
+			events := em.Events()
+			require.NotEmpty(t, events)
+			exp := sdk.NewEvent(types.EventTypeGovContractResult, sdk.NewAttribute("code_ids", spec.expCodeIDs))
+			assert.Equal(t, exp, events[len(events)-1])
+		})
+	}
+}
+
 func TestInstantiateProposal(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, "staking")
 	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
@@ -65,6 +162,10 @@ func TestInstantiateProposal(t *testing.T) {
 		CodeUploadAccess:             types.AllowNobody,
 		InstantiateDefaultPermission: types.AccessTypeNobody,
 		MaxWasmCodeSize:              types.DefaultMaxWasmCodeSize,
+		MaxMsgSize:                   types.DefaultMaxMsgSize,
+		InstanceCost:                 types.DefaultInstanceCost,
+		CompileCost:                  types.DefaultCompileCost,
+		MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
 	})
 
 	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
@@ -129,6 +230,10 @@ func TestMigrateProposal(t *testing.T) {
 		CodeUploadAccess:             types.AllowNobody,
 		InstantiateDefaultPermission: types.AccessTypeNobody,
 		MaxWasmCodeSize:              types.DefaultMaxWasmCodeSize,
+		MaxMsgSize:                   types.DefaultMaxMsgSize,
+		InstanceCost:                 types.DefaultInstanceCost,
+		CompileCost:                  types.DefaultCompileCost,
+		MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
 	})
 
 	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
@@ -198,11 +303,12 @@ func TestMigrateProposal(t *testing.T) {
 	}}
 	assert.Equal(t, expHistory, wasmKeeper.GetContractHistory(ctx, contractAddr))
 	// and events emitted
-	require.Len(t, em.Events(), 2)
+	require.Len(t, em.Events(), 3)
 	assert.Equal(t, types.EventTypeMigrate, em.Events()[0].Type)
-	require.Equal(t, types.EventTypeGovContractResult, em.Events()[1].Type)
-	require.Len(t, em.Events()[1].Attributes, 1)
-	assert.Equal(t, types.AttributeKeyResultDataHex, string(em.Events()[1].Attributes[0].Key))
+	assert.Equal(t, types.EventTypeAdminAction, em.Events()[1].Type)
+	require.Equal(t, types.EventTypeGovContractResult, em.Events()[2].Type)
+	require.Len(t, em.Events()[2].Attributes, 1)
+	assert.Equal(t, types.AttributeKeyResultDataHex, string(em.Events()[2].Attributes[0].Key))
 }
 
 func TestAdminProposals(t *testing.T) {
@@ -269,6 +375,10 @@ func TestAdminProposals(t *testing.T) {
 				CodeUploadAccess:             types.AllowNobody,
 				InstantiateDefaultPermission: types.AccessTypeNobody,
 				MaxWasmCodeSize:              types.DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   types.DefaultMaxMsgSize,
+				InstanceCost:                 types.DefaultInstanceCost,
+				CompileCost:                  types.DefaultCompileCost,
+				MaxContractResponseDataSize:  types.DefaultMaxContractResponseDataSize,
 			})
 
 			codeInfoFixture := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
@@ -364,6 +474,47 @@ func TestUpdateParamsProposal(t *testing.T) {
 	}
 }
 
+func TestParamsQueryAfterUpdateParamsProposal(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking")
+	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+	wasmKeeper.setParams(ctx, types.DefaultParams())
+
+	proposal := proposal.ParameterChangeProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Changes: []proposal.ParamChange{{
+			Subspace: types.DefaultParamspace,
+			Key:      string(types.ParamStoreKeyMaxWasmCodeSize),
+			Value:    `"1024"`,
+		}},
+	}
+
+	// when stored
+	storedProposal, err := govKeeper.SubmitProposal(ctx, &proposal)
+	require.NoError(t, err)
+
+	// and proposal execute
+	handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
+	err = handler(ctx, storedProposal.GetContent())
+	require.NoError(t, err)
+
+	// then the grpc query returns the new params
+	expParams := types.DefaultParams()
+	expParams.MaxWasmCodeSize = 1024
+	rsp, err := Querier(wasmKeeper).Params(sdk.WrapSDKContext(ctx), &types.QueryParamsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, expParams, rsp.Params)
+
+	// and the chain query as well
+	raw, err := ChainQuerier(wasmKeeper, nil)(ctx, RandomAccountAddress(t), &types.ChainQuery{ModuleParams: &types.ModuleParamsQuery{}})
+	require.NoError(t, err)
+	var gotParams types.Params
+	require.NoError(t, wasmKeeper.cdc.UnmarshalJSON(raw, &gotParams))
+	// empty lists do not round trip as nil through json
+	assert.True(t, expParams.Equal(gotParams), "got %#v", gotParams)
+	assert.Contains(t, string(raw), `"max_wasm_code_size":"1024"`)
+}
+
 func TestPinCodesProposal(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, "staking")
 	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
@@ -538,3 +689,183 @@ func TestUnpinCodesProposal(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveCodeProposal(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking")
+	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+
+	var gotUnpinnedChecksums []wasmvm.Checksum
+	mock := wasmtesting.MockWasmer{
+		PinFn: func(checksum wasmvm.Checksum) error { return nil },
+		UnpinFn: func(checksum wasmvm.Checksum) error {
+			gotUnpinnedChecksums = append(gotUnpinnedChecksums, checksum)
+			return nil
+		},
+		MigrateFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+			return &wasmvmtypes.Response{}, 0, nil
+		},
+	}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	otherCode := StoreRandomContract(t, ctx, keepers, &mock)
+	require.NoError(t, keepers.ContractKeeper.PinCode(ctx, example.CodeID))
+	codeHash := wasmKeeper.GetCodeInfo(ctx, example.CodeID).CodeHash
+
+	proposal := types.RemoveCodeProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		CodeID:      example.CodeID,
+	}
+	handler := govKeeper.Router().GetRoute(proposal.ProposalRoute())
+
+	// when an instance still references the code
+	err := handler(ctx, &proposal)
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), example.Contract.String())
+	assert.NotNil(t, wasmKeeper.GetCodeInfo(ctx, example.CodeID))
+	assert.True(t, wasmKeeper.IsPinnedCode(ctx, example.CodeID))
+	assert.Empty(t, gotUnpinnedChecksums)
+	// and the proposal is rejected on submission
+	_, err = govKeeper.SubmitProposal(ctx, &proposal)
+	require.Error(t, err)
+
+	// when the instance was migrated away
+	_, err = keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, otherCode.CodeID, []byte(`{}`))
+	require.NoError(t, err)
+	storedProposal, err := govKeeper.SubmitProposal(ctx, &proposal)
+	require.NoError(t, err)
+	gotUnpinnedChecksums = nil
+	em := sdk.NewEventManager()
+	err = handler(ctx.WithEventManager(em), storedProposal.GetContent())
+
+	// then
+	require.NoError(t, err)
+	assert.Nil(t, wasmKeeper.GetCodeInfo(ctx, example.CodeID))
+	assert.False(t, wasmKeeper.IsPinnedCode(ctx, example.CodeID))
+	assert.Equal(t, []wasmvm.Checksum{codeHash}, gotUnpinnedChecksums)
+	assert.NotNil(t, wasmKeeper.GetCodeInfo(ctx, otherCode.CodeID))
+	exp := sdk.NewEvent(types.EventTypeRemoveCode, sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(example.CodeID, 10)))
+	assert.Contains(t, em.Events(), exp)
+
+	// and removing again fails
+	err = handler(ctx, storedProposal.GetContent())
+	assert.True(t, types.ErrNotFound.Is(err), err)
+}
+
+func TestReserveContractAddressProposal(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking")
+	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	checksum := wasmKeeper.GetCodeInfo(ctx, example.CodeID).CodeHash
+	reservedAddr := RandomAccountAddress(t)
+
+	proposal := types.ReserveContractAddressProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contract:    reservedAddr.String(),
+		Creator:     example.CreatorAddr.String(),
+		CodeHash:    checksum,
+	}
+	storedProposal, err := govKeeper.SubmitProposal(ctx, &proposal)
+	require.NoError(t, err)
+	handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
+
+	// when
+	em := sdk.NewEventManager()
+	err = handler(ctx.WithEventManager(em), storedProposal.GetContent())
+
+	// then
+	require.NoError(t, err)
+	reservation := wasmKeeper.GetReservedContractAddress(ctx, reservedAddr)
+	require.NotNil(t, reservation)
+	assert.Equal(t, example.CreatorAddr.String(), reservation.Creator)
+	assert.Equal(t, checksum, reservation.CodeHash)
+	exp := sdk.NewEvent(types.EventTypeReserveContractAddress,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, reservedAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyCreator, example.CreatorAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(checksum)),
+	)
+	assert.Contains(t, em.Events(), exp)
+
+	// and the creator instantiates into the reserved address
+	gotAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "reserved", nil)
+	require.NoError(t, err)
+	assert.Equal(t, reservedAddr, gotAddr)
+}
+
+func TestGovManagedContract(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking")
+	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	newCode := StoreRandomContract(t, ctx, keepers, &mock)
+	creator := example.CreatorAddr
+	govAddr := authtypes.NewModuleAddress(govtypes.ModuleName)
+
+	msgServer := NewMsgServerImpl(keepers.ContractKeeper)
+	rsp, err := msgServer.InstantiateContract(sdk.WrapSDKContext(ctx), &types.MsgInstantiateContract{
+		Sender:     creator.String(),
+		CodeID:     example.CodeID,
+		Label:      "gov managed",
+		Msg:        []byte(`{}`),
+		GovManaged: true,
+	})
+	require.NoError(t, err)
+	contractAddr, err := sdk.AccAddressFromBech32(rsp.Address)
+	require.NoError(t, err)
+	contractInfo := wasmKeeper.GetContractInfo(ctx, contractAddr)
+	assert.True(t, contractInfo.GovManaged)
+	assert.Equal(t, govAddr.String(), contractInfo.Admin)
+
+	// direct admin messages are rejected, even with the admin address as sender
+	for _, sender := range []sdk.AccAddress{creator, govAddr} {
+		_, err = msgServer.MigrateContract(sdk.WrapSDKContext(ctx), &types.MsgMigrateContract{
+			Sender:   sender.String(),
+			Contract: contractAddr.String(),
+			CodeID:   newCode.CodeID,
+			Msg:      []byte(`{}`),
+		})
+		assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+		_, err = msgServer.UpdateAdmin(sdk.WrapSDKContext(ctx), &types.MsgUpdateAdmin{
+			Sender:   sender.String(),
+			NewAdmin: creator.String(),
+			Contract: contractAddr.String(),
+		})
+		assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+		_, err = msgServer.ClearAdmin(sdk.WrapSDKContext(ctx), &types.MsgClearAdmin{
+			Sender:   sender.String(),
+			Contract: contractAddr.String(),
+		})
+		assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+	}
+	assert.Equal(t, example.CodeID, wasmKeeper.GetContractInfo(ctx, contractAddr).CodeID)
+
+	// when migrated by a gov proposal
+	proposal := types.MigrateContractProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contract:    contractAddr.String(),
+		CodeID:      newCode.CodeID,
+		Msg:         []byte(`{}`),
+		RunAs:       creator.String(),
+	}
+	storedProposal, err := govKeeper.SubmitProposal(ctx, &proposal)
+	require.NoError(t, err)
+	handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
+	err = handler(ctx, storedProposal.GetContent())
+
+	// then
+	require.NoError(t, err)
+	contractInfo = wasmKeeper.GetContractInfo(ctx, contractAddr)
+	assert.Equal(t, newCode.CodeID, contractInfo.CodeID)
+	assert.True(t, contractInfo.GovManaged)
+	assert.Equal(t, govAddr.String(), contractInfo.Admin)
+}
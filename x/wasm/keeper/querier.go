@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/binary"
 	"runtime/debug"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 	"github.com/cosmos/cosmos-sdk/types/query"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -19,16 +22,46 @@ import (
 
 var _ types.QueryServer = &grpcQuerier{}
 
+// MaxSmartContractStateBatchSize is the max number of smart queries in a single batch request
+const MaxSmartContractStateBatchSize = 50
+
 type grpcQuerier struct {
 	cdc           codec.Codec
 	storeKey      sdk.StoreKey
 	keeper        types.ViewKeeper
 	queryGasLimit sdk.Gas
+	// checkTxQueryGasLimit is the tighter gas limit for smart queries sent by clients, like wallet simulations
+	checkTxQueryGasLimit sdk.Gas
+	// simulationGasLimit is the gas limit for simulated contract executions and instantiations
+	simulationGasLimit sdk.Gas
 }
 
 // NewGrpcQuerier constructor
 func NewGrpcQuerier(cdc codec.Codec, storeKey sdk.StoreKey, keeper types.ViewKeeper, queryGasLimit sdk.Gas) *grpcQuerier { //nolint:revive
-	return &grpcQuerier{cdc: cdc, storeKey: storeKey, keeper: keeper, queryGasLimit: queryGasLimit}
+	return &grpcQuerier{cdc: cdc, storeKey: storeKey, keeper: keeper, queryGasLimit: queryGasLimit, checkTxQueryGasLimit: queryGasLimit, simulationGasLimit: queryGasLimit}
+}
+
+// WithCheckTxQueryGasLimit sets the gas limit for smart queries sent by clients and not by contracts.
+// The limit is capped by the regular query gas limit.
+func (q *grpcQuerier) WithCheckTxQueryGasLimit(limit sdk.Gas) *grpcQuerier {
+	q.checkTxQueryGasLimit = limit
+	return q
+}
+
+// WithSimulationGasLimit sets the gas limit for simulated contract executions and instantiations.
+func (q *grpcQuerier) WithSimulationGasLimit(limit sdk.Gas) *grpcQuerier {
+	q.simulationGasLimit = limit
+	return q
+}
+
+// smartQueryGasLimit returns the gas limit for a smart query in the given context.
+// Queries sent by clients, like wallet simulations, take the fast path with a tighter limit. The gRPC query
+// context is always in CheckTx mode so that the query origin is taken from the context instead.
+func (q grpcQuerier) smartQueryGasLimit(ctx sdk.Context) sdk.Gas {
+	if types.IsExternalQuery(ctx) && q.checkTxQueryGasLimit != 0 && q.checkTxQueryGasLimit < q.queryGasLimit {
+		return q.checkTxQueryGasLimit
+	}
+	return q.queryGasLimit
 }
 
 func (q grpcQuerier) ContractInfo(c context.Context, req *types.QueryContractInfoRequest) (*types.QueryContractInfoResponse, error) {
@@ -110,6 +143,34 @@ func (q grpcQuerier) ContractsByCode(c context.Context, req *types.QueryContract
 	}, nil
 }
 
+func (q grpcQuerier) ContractsByCreator(c context.Context, req *types.QueryContractsByCreatorRequest) (*types.QueryContractsByCreatorResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	creatorAddr, err := sdk.AccAddressFromBech32(req.CreatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]string, 0)
+
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetContractsByCreatorPrefix(creatorAddr))
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			var contractAddr sdk.AccAddress = key[types.AbsoluteTxPositionLen:]
+			r = append(r, contractAddr.String())
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractsByCreatorResponse{
+		ContractAddresses: r,
+		Pagination:        pageRes,
+	}, nil
+}
+
 func (q grpcQuerier) AllContractState(c context.Context, req *types.QueryAllContractStateRequest) (*types.QueryAllContractStateResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -161,10 +222,64 @@ func (q grpcQuerier) RawContractState(c context.Context, req *types.QueryRawCont
 	return &types.QueryRawContractStateResponse{Data: rsp}, nil
 }
 
-func (q grpcQuerier) SmartContractState(c context.Context, req *types.QuerySmartContractStateRequest) (rsp *types.QuerySmartContractStateResponse, err error) {
+// withQueryHeight sets the height requested with the gRPC block height header on the context so that a contract
+// queried at a historical height gets this height in its env. The baseapp already loads the store at the requested
+// version, which also contains the contract info and code id that were current at this height, and rejects heights
+// that are not available anymore due to pruning. The block time is not modified.
+func withQueryHeight(c context.Context, ctx sdk.Context) sdk.Context {
+	md, ok := metadata.FromIncomingContext(c)
+	if !ok {
+		return ctx
+	}
+	heightHeaders := md.Get(grpctypes.GRPCBlockHeightHeader)
+	if len(heightHeaders) != 1 {
+		return ctx
+	}
+	height, err := strconv.ParseInt(heightHeaders[0], 10, 64)
+	if err != nil || height <= 0 || height >= ctx.BlockHeight() {
+		return ctx
+	}
+	return ctx.WithBlockHeight(height)
+}
+
+func (q grpcQuerier) SmartContractState(c context.Context, req *types.QuerySmartContractStateRequest) (*types.QuerySmartContractStateResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
+	bz, err := q.smartContractState(withQueryHeight(c, sdk.UnwrapSDKContext(c)), *req)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QuerySmartContractStateResponse{Data: bz}, nil
+}
+
+// SmartContractStateBatch runs multiple smart queries. A failing query does not abort the batch but its error is
+// returned in the result.
+func (q grpcQuerier) SmartContractStateBatch(c context.Context, req *types.QuerySmartContractStateBatchRequest) (*types.QuerySmartContractStateBatchResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	switch n := len(req.Queries); {
+	case n == 0:
+		return nil, status.Error(codes.InvalidArgument, "empty queries")
+	case n > MaxSmartContractStateBatchSize:
+		return nil, status.Errorf(codes.InvalidArgument, "max batch size exceeded: %d", MaxSmartContractStateBatchSize)
+	}
+	ctx := withQueryHeight(c, sdk.UnwrapSDKContext(c))
+	results := make([]types.SmartContractStateResult, len(req.Queries))
+	for i, item := range req.Queries {
+		bz, err := q.smartContractState(ctx, item)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Data = bz
+	}
+	return &types.QuerySmartContractStateBatchResponse{Results: results}, nil
+}
+
+// smartContractState runs a single smart query with its own gas meter
+func (q grpcQuerier) smartContractState(ctx sdk.Context, req types.QuerySmartContractStateRequest) (bz []byte, err error) {
 	if err := req.QueryData.ValidateBasic(); err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid query data")
 	}
@@ -172,7 +287,10 @@ func (q grpcQuerier) SmartContractState(c context.Context, req *types.QuerySmart
 	if err != nil {
 		return nil, err
 	}
-	ctx := sdk.UnwrapSDKContext(c).WithGasMeter(sdk.NewGasMeter(q.queryGasLimit))
+	if !types.IsQueryFromContract(ctx) {
+		ctx = types.WithExternalQuery(ctx)
+	}
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(q.smartQueryGasLimit(ctx)))
 	// recover from out-of-gas panic
 	defer func() {
 		if r := recover(); r != nil {
@@ -185,7 +303,7 @@ func (q grpcQuerier) SmartContractState(c context.Context, req *types.QuerySmart
 			default:
 				err = sdkerrors.ErrPanic
 			}
-			rsp = nil
+			bz = nil
 			moduleLogger(ctx).
 				Debug("smart query contract",
 					"error", "recovering panic",
@@ -194,15 +312,14 @@ func (q grpcQuerier) SmartContractState(c context.Context, req *types.QuerySmart
 		}
 	}()
 
-	bz, err := q.keeper.QuerySmart(ctx, contractAddr, req.QueryData)
+	bz, err = q.keeper.QuerySmart(ctx, contractAddr, req.QueryData)
 	switch {
 	case err != nil:
 		return nil, err
 	case bz == nil:
 		return nil, types.ErrNotFound
 	}
-	return &types.QuerySmartContractStateResponse{Data: bz}, nil
-
+	return bz, nil
 }
 
 func (q grpcQuerier) Code(c context.Context, req *types.QueryCodeRequest) (*types.QueryCodeResponse, error) {
@@ -238,10 +355,14 @@ func (q grpcQuerier) Codes(c context.Context, req *types.QueryCodesRequest) (*ty
 			if err := q.cdc.Unmarshal(value, &c); err != nil {
 				return false, err
 			}
+			codeID := binary.BigEndian.Uint64(key)
 			r = append(r, types.CodeInfoResponse{
-				CodeID:   binary.BigEndian.Uint64(key),
-				Creator:  c.Creator,
-				DataHash: c.CodeHash,
+				CodeID:        codeID,
+				Creator:       c.Creator,
+				DataHash:      c.CodeHash,
+				InstanceCount: q.keeper.GetCodeInstanceCount(ctx, codeID),
+				Source:        c.Source,
+				Builder:       c.Builder,
 			})
 		}
 		return true, nil
@@ -257,8 +378,6 @@ func queryContractInfo(ctx sdk.Context, addr sdk.AccAddress, keeper types.ViewKe
 	if info == nil {
 		return nil, types.ErrNotFound
 	}
-	// redact the Created field (just used for sorting, not part of public API)
-	info.Created = nil
 	return &types.QueryContractInfoResponse{
 		Address:      addr.String(),
 		ContractInfo: *info,
@@ -275,9 +394,12 @@ func queryCode(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) (*types.
 		return nil, nil
 	}
 	info := types.CodeInfoResponse{
-		CodeID:   codeID,
-		Creator:  res.Creator,
-		DataHash: res.CodeHash,
+		CodeID:        codeID,
+		Creator:       res.Creator,
+		DataHash:      res.CodeHash,
+		InstanceCount: keeper.GetCodeInstanceCount(ctx, codeID),
+		Source:        res.Source,
+		Builder:       res.Builder,
 	}
 
 	code, err := keeper.GetByteCode(ctx, codeID)
@@ -312,3 +434,210 @@ func (q grpcQuerier) PinnedCodes(c context.Context, req *types.QueryPinnedCodesR
 	}, nil
 
 }
+
+func (q grpcQuerier) Capabilities(c context.Context, req *types.QueryCapabilitiesRequest) (*types.QueryCapabilitiesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	return &types.QueryCapabilitiesResponse{
+		WasmvmVersion: wasmvmVersion(),
+		Capabilities:  q.keeper.GetCapabilities(),
+	}, nil
+}
+
+func (q grpcQuerier) Params(c context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	params := q.keeper.GetParams(sdk.UnwrapSDKContext(c))
+	return &types.QueryParamsResponse{Params: params}, nil
+}
+
+func (q grpcQuerier) InFlightPackets(c context.Context, req *types.QueryInFlightPacketsRequest) (*types.QueryInFlightPacketsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.InFlightPacket, 0)
+
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetInFlightPacketsPrefix(contractAddr))
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			var packet types.InFlightPacket
+			if err := q.cdc.Unmarshal(value, &packet); err != nil {
+				return false, err
+			}
+			r = append(r, packet)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryInFlightPacketsResponse{
+		Packets:    r,
+		Pagination: pageRes,
+	}, nil
+}
+
+// TimelockedFunds returns the pending timelocked funds of a contract
+func (q grpcQuerier) TimelockedFunds(c context.Context, req *types.QueryTimelockedFundsRequest) (*types.QueryTimelockedFundsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.TimelockedFunds, 0)
+
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetTimelockedFundsPrefix(contractAddr))
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			var lock types.TimelockedFunds
+			if err := q.cdc.Unmarshal(value, &lock); err != nil {
+				return false, err
+			}
+			r = append(r, lock)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryTimelockedFundsResponse{
+		Locks:      r,
+		Pagination: pageRes,
+	}, nil
+}
+
+// wasmvmVersion returns the version of the wasmvm module that the binary was built with or "unknown"
+// when no build info is available
+func wasmvmVersion() string {
+	const wasmvmModulePath = "github.com/CosmWasm/wasmvm"
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, d := range info.Deps {
+		if d.Path != wasmvmModulePath {
+			continue
+		}
+		if d.Replace != nil && d.Replace.Version != "" {
+			return d.Replace.Version
+		}
+		return d.Version
+	}
+	return "unknown"
+}
+
+func (q grpcQuerier) SimulateExecuteContract(c context.Context, req *types.QuerySimulateExecuteContractRequest) (rsp *types.QuerySimulateExecuteContractResponse, err error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if err := req.Msg.ValidateBasic(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid msg")
+	}
+	if !req.Funds.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid funds")
+	}
+	senderAddr, err := sdk.AccAddressFromBech32(req.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	defer recoverSimulationPanic(ctx, &err)
+
+	data, gasUsed, events, err := q.keeper.SimulateExecute(ctx, q.simulationGasLimit, contractAddr, senderAddr, req.Msg, req.Funds)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QuerySimulateExecuteContractResponse{
+		GasUsed: gasUsed,
+		Data:    data,
+		Events:  events.ToABCIEvents(),
+	}, nil
+}
+
+func (q grpcQuerier) PreviewExecuteContract(c context.Context, req *types.QueryPreviewExecuteContractRequest) (rsp *types.QueryPreviewExecuteContractResponse, err error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if err := req.Msg.ValidateBasic(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid msg")
+	}
+	if !req.Funds.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid funds")
+	}
+	senderAddr, err := sdk.AccAddressFromBech32(req.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	defer recoverSimulationPanic(ctx, &err)
+
+	events, execErr := q.keeper.PreviewExecuteEvents(ctx, q.simulationGasLimit, contractAddr, senderAddr, req.Msg, req.Funds)
+	if execErr != nil {
+		return &types.QueryPreviewExecuteContractResponse{Error: execErr.Error()}, nil
+	}
+	return &types.QueryPreviewExecuteContractResponse{Events: events.ToABCIEvents()}, nil
+}
+
+func (q grpcQuerier) SimulateInstantiateContract(c context.Context, req *types.QuerySimulateInstantiateContractRequest) (rsp *types.QuerySimulateInstantiateContractResponse, err error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if err := req.Msg.ValidateBasic(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid msg")
+	}
+	if !req.Funds.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid funds")
+	}
+	senderAddr, err := sdk.AccAddressFromBech32(req.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	var adminAddr sdk.AccAddress
+	if req.Admin != "" {
+		if adminAddr, err = sdk.AccAddressFromBech32(req.Admin); err != nil {
+			return nil, sdkerrors.Wrap(err, "admin")
+		}
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	defer recoverSimulationPanic(ctx, &err)
+
+	contractAddr, data, gasUsed, events, err := q.keeper.SimulateInstantiate(ctx, q.simulationGasLimit, req.CodeId, senderAddr, adminAddr, req.Msg, req.Label, req.Funds)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QuerySimulateInstantiateContractResponse{
+		Address: contractAddr.String(),
+		GasUsed: gasUsed,
+		Data:    data,
+		Events:  events.ToABCIEvents(),
+	}, nil
+}
+
+// recoverSimulationPanic converts a panic in a simulation into an error
+func recoverSimulationPanic(ctx sdk.Context, err *error) {
+	if r := recover(); r != nil {
+		*err = sdkerrors.ErrPanic
+		moduleLogger(ctx).
+			Debug("simulate contract",
+				"error", "recovering panic",
+				"stacktrace", string(debug.Stack()))
+	}
+}
@@ -10,12 +10,14 @@ import (
 	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	cosmwasm "github.com/CosmWasm/wasmvm"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 	"github.com/cosmos/cosmos-sdk/types/query"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/stretchr/testify/assert"
@@ -36,7 +38,7 @@ func TestQueryAllContractState(t *testing.T) {
 		{Key: []byte{0x0, 0x1}, Value: []byte(`{"count":8}`)},
 		{Key: []byte("foo"), Value: []byte(`"bar"`)},
 	}
-	require.NoError(t, keeper.importContractState(ctx, contractAddr, contractModel))
+	require.NoError(t, keeper.ImportContractState(ctx, contractAddr, contractModel))
 
 	q := Querier(keeper)
 	specs := map[string]struct {
@@ -156,6 +158,120 @@ func TestQuerySmartContractState(t *testing.T) {
 	}
 }
 
+func TestQuerySmartContractStateBatch(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	exampleContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+	contractAddr := exampleContract.Contract.String()
+	q := Querier(keepers.WasmKeeper)
+
+	// when
+	got, err := q.SmartContractStateBatch(sdk.WrapSDKContext(ctx), &types.QuerySmartContractStateBatchRequest{
+		Queries: []types.QuerySmartContractStateRequest{
+			{Address: contractAddr, QueryData: []byte(`{"verifier":{}}`)},
+			{Address: RandomBech32AccountAddress(t), QueryData: []byte(`{"verifier":{}}`)},
+			{Address: contractAddr, QueryData: []byte(`{"verifier":{}}`)},
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, got.Results, 3)
+	expResp := fmt.Sprintf(`{"verifier":"%s"}`, exampleContract.VerifierAddr.String())
+	assert.JSONEq(t, expResp, string(got.Results[0].Data))
+	assert.Empty(t, got.Results[0].Error)
+	assert.Nil(t, got.Results[1].Data)
+	assert.Contains(t, got.Results[1].Error, types.ErrNotFound.Error())
+	assert.JSONEq(t, expResp, string(got.Results[2].Data))
+	assert.Empty(t, got.Results[2].Error)
+
+	// and the batch size is capped
+	tooMany := make([]types.QuerySmartContractStateRequest, MaxSmartContractStateBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = types.QuerySmartContractStateRequest{Address: contractAddr, QueryData: []byte(`{"verifier":{}}`)}
+	}
+	_, err = q.SmartContractStateBatch(sdk.WrapSDKContext(ctx), &types.QuerySmartContractStateBatchRequest{Queries: tooMany})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = q.SmartContractStateBatch(sdk.WrapSDKContext(ctx), &types.QuerySmartContractStateBatchRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = q.SmartContractStateBatch(sdk.WrapSDKContext(ctx), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestQuerySmartContractStateAtHistoricalHeight(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	cms := ctx.MultiStore().(sdk.CommitMultiStore)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	type queryResult struct {
+		Checksum []byte `json:"checksum"`
+		State    string `json:"state"`
+		Height   uint64 `json:"height"`
+	}
+	mock.QueryFn = func(codeID cosmwasm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store cosmwasm.KVStore, goapi cosmwasm.GoAPI, querier cosmwasm.Querier, gasMeter cosmwasm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) ([]byte, uint64, error) {
+		bz, err := json.Marshal(queryResult{Checksum: codeID, State: string(store.Get([]byte("state"))), Height: env.Block.Height})
+		return bz, 0, err
+	}
+	mock.MigrateFn = func(codeID cosmwasm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store cosmwasm.KVStore, goapi cosmwasm.GoAPI, querier cosmwasm.Querier, gasMeter cosmwasm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		store.Set([]byte("state"), []byte("after migration"))
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	newCodeID := StoreRandomContract(t, ctx, keepers, &mock).CodeID
+	require.NoError(t, keepers.WasmKeeper.ImportContractState(ctx, example.Contract, []types.Model{{Key: []byte("state"), Value: []byte("before migration")}}))
+	oldChecksum := keepers.WasmKeeper.GetCodeInfo(ctx, example.CodeID).CodeHash
+	newChecksum := keepers.WasmKeeper.GetCodeInfo(ctx, newCodeID).CodeHash
+
+	// commit state before migration
+	heightBeforeMigration := cms.Commit().Version
+	_, err := keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, newCodeID, []byte(`{}`))
+	require.NoError(t, err)
+	heightAfterMigration := cms.Commit().Version
+	latestHeight := heightAfterMigration + 1
+
+	specs := map[string]struct {
+		srcHeight int64
+		srcHeader bool
+		exp       queryResult
+	}{
+		"before migration": {
+			srcHeight: heightBeforeMigration,
+			srcHeader: true,
+			exp:       queryResult{Checksum: oldChecksum, State: "before migration", Height: uint64(heightBeforeMigration)},
+		},
+		"after migration": {
+			srcHeight: heightAfterMigration,
+			srcHeader: true,
+			exp:       queryResult{Checksum: newChecksum, State: "after migration", Height: uint64(heightAfterMigration)},
+		},
+		"latest without header": {
+			srcHeight: heightAfterMigration,
+			exp:       queryResult{Checksum: newChecksum, State: "after migration", Height: uint64(latestHeight)},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// the baseapp loads the store version requested with the block height header
+			historicalStore, err := cms.CacheMultiStoreWithVersion(spec.srcHeight)
+			require.NoError(t, err)
+			queryCtx := ctx.WithMultiStore(historicalStore).WithBlockHeight(latestHeight)
+			c := sdk.WrapSDKContext(queryCtx)
+			if spec.srcHeader {
+				c = metadata.NewIncomingContext(c, metadata.Pairs(grpctypes.GRPCBlockHeightHeader, fmt.Sprintf("%d", spec.srcHeight)))
+			}
+			// when
+			got, err := Querier(keepers.WasmKeeper).SmartContractState(c, &types.QuerySmartContractStateRequest{
+				Address:   example.Contract.String(),
+				QueryData: []byte(`{}`),
+			})
+			// then
+			require.NoError(t, err)
+			var gotResult queryResult
+			require.NoError(t, json.Unmarshal(got.Data, &gotResult))
+			assert.Equal(t, spec.exp, gotResult)
+		})
+	}
+}
+
 func TestQuerySmartContractPanics(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	contractAddr := BuildContractAddress(1, 1)
@@ -201,6 +317,69 @@ func TestQuerySmartContractPanics(t *testing.T) {
 	}
 }
 
+func TestQuerySmartContractStateCheckTxGasLimit(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	contractAddr := BuildContractAddress(1, 1)
+	keepers.WasmKeeper.storeCodeInfo(ctx, 1, types.CodeInfo{})
+	keepers.WasmKeeper.storeContractInfo(ctx, contractAddr, &types.ContractInfo{
+		CodeID:  1,
+		Created: types.NewAbsoluteTxPosition(ctx),
+	})
+	ctx = ctx.WithLogger(log.TestingLogger())
+
+	const (
+		queryGasLimit        sdk.Gas = 1_000_000
+		checkTxQueryGasLimit sdk.Gas = 200_000
+	)
+	specs := map[string]struct {
+		contractQuery bool
+		contractGas   sdk.Gas
+		expErr        *sdkErrors.Error
+	}{
+		"contract query - within limit": {
+			contractQuery: true,
+			contractGas:   1,
+		},
+		"client query - within limit": {
+			contractGas: 1,
+		},
+		"contract query - above client limit": {
+			contractQuery: true,
+			contractGas:   checkTxQueryGasLimit,
+		},
+		"client query - above client limit": {
+			contractGas: checkTxQueryGasLimit,
+			expErr:      sdkErrors.ErrOutOfGas,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			keepers.WasmKeeper.wasmVM = &wasmtesting.MockWasmer{QueryFn: func(checksum cosmwasm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store cosmwasm.KVStore, goapi cosmwasm.GoAPI, querier cosmwasm.Querier, gasMeter cosmwasm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) ([]byte, uint64, error) {
+				return []byte(`{"ok":true}`), spec.contractGas * DefaultGasMultiplier, nil
+			}}
+			// the gRPC query context is always in check tx mode
+			queryCtx := ctx.WithIsCheckTx(true)
+			if spec.contractQuery {
+				queryCtx = types.WithQueryFromContract(queryCtx)
+			}
+			q := NewGrpcQuerier(keepers.WasmKeeper.cdc, keepers.WasmKeeper.storeKey, keepers.WasmKeeper, queryGasLimit).
+				WithCheckTxQueryGasLimit(checkTxQueryGasLimit)
+			// when
+			got, err := q.SmartContractState(sdk.WrapSDKContext(queryCtx), &types.QuerySmartContractStateRequest{
+				Address:   contractAddr.String(),
+				QueryData: types.RawContractMessage("{}"),
+			})
+			if spec.expErr != nil {
+				require.True(t, spec.expErr.Is(err), "got error: %+v", err)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"ok":true}`, string(got.Data))
+		})
+	}
+}
+
 func TestQueryRawContractState(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	keeper := keepers.WasmKeeper
@@ -211,7 +390,7 @@ func TestQueryRawContractState(t *testing.T) {
 		{Key: []byte("foo"), Value: []byte(`"bar"`)},
 		{Key: []byte{0x0, 0x1}, Value: []byte(`{"count":8}`)},
 	}
-	require.NoError(t, keeper.importContractState(ctx, exampleContract.Contract, contractModel))
+	require.NoError(t, keeper.ImportContractState(ctx, exampleContract.Contract, contractModel))
 
 	q := Querier(keeper)
 	specs := map[string]struct {
@@ -312,6 +491,62 @@ func TestQueryContractListByCodeOrdering(t *testing.T) {
 	}
 }
 
+func TestQueryContractsByCreatorList(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	otherCreator := RandomAccountAddress(t)
+
+	var expContracts []string
+	for i := 0; i < 5; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), fmt.Sprintf("contract %d", i), nil)
+		require.NoError(t, err)
+		expContracts = append(expContracts, contractAddr.String())
+	}
+	_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, otherCreator, nil, []byte(`{}`), "other", nil)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		srcQuery     *types.QueryContractsByCreatorRequest
+		expContracts []string
+		expErr       bool
+	}{
+		"query all": {
+			srcQuery:     &types.QueryContractsByCreatorRequest{CreatorAddress: example.CreatorAddr.String()},
+			expContracts: expContracts,
+		},
+		"with pagination offset": {
+			srcQuery: &types.QueryContractsByCreatorRequest{
+				CreatorAddress: example.CreatorAddr.String(),
+				Pagination:     &query.PageRequest{Offset: 1, Limit: 2},
+			},
+			expContracts: expContracts[1:3],
+		},
+		"other creator": {
+			srcQuery:     &types.QueryContractsByCreatorRequest{CreatorAddress: RandomBech32AccountAddress(t)},
+			expContracts: []string{},
+		},
+		"invalid creator address": {
+			srcQuery: &types.QueryContractsByCreatorRequest{CreatorAddress: "invalid"},
+			expErr:   true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			q := Querier(keepers.WasmKeeper)
+			got, err := q.ContractsByCreator(sdk.WrapSDKContext(ctx), spec.srcQuery)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expContracts, got.ContractAddresses)
+		})
+	}
+}
+
 func TestQueryContractHistory(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	keeper := keepers.WasmKeeper
@@ -560,20 +795,16 @@ func TestQueryContractInfo(t *testing.T) {
 			src:    &types.QueryContractInfoRequest{Address: contractAddr.String()},
 			stored: types.ContractInfoFixture(),
 			expRsp: &types.QueryContractInfoResponse{
-				Address: contractAddr.String(),
-				ContractInfo: types.ContractInfoFixture(func(info *types.ContractInfo) {
-					info.Created = nil // not returned on queries
-				}),
+				Address:      contractAddr.String(),
+				ContractInfo: types.ContractInfoFixture(),
 			},
 		},
 		"with extension": {
 			src:    &types.QueryContractInfoRequest{Address: contractAddr.String()},
 			stored: types.ContractInfoFixture(myExtension),
 			expRsp: &types.QueryContractInfoResponse{
-				Address: contractAddr.String(),
-				ContractInfo: types.ContractInfoFixture(myExtension, func(info *types.ContractInfo) {
-					info.Created = nil // not returned on queries
-				}),
+				Address:      contractAddr.String(),
+				ContractInfo: types.ContractInfoFixture(myExtension),
 			},
 		},
 		"not found": {
@@ -662,3 +893,121 @@ func fromBase64(s string) []byte {
 	}
 	return r
 }
+
+func TestQuerySimulateExecuteContract(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	mock.ExecuteFn = func(codeID cosmwasm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store cosmwasm.KVStore, goapi cosmwasm.GoAPI, querier cosmwasm.Querier, gasMeter cosmwasm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{Data: []byte("my-data")}, 1, nil
+	}
+	q := Querier(keepers.WasmKeeper)
+
+	specs := map[string]struct {
+		src    *types.QuerySimulateExecuteContractRequest
+		expErr bool
+	}{
+		"simulate": {
+			src: &types.QuerySimulateExecuteContractRequest{Sender: example.CreatorAddr.String(), Address: example.Contract.String(), Msg: []byte(`{}`)},
+		},
+		"invalid msg": {
+			src:    &types.QuerySimulateExecuteContractRequest{Sender: example.CreatorAddr.String(), Address: example.Contract.String(), Msg: []byte(`not json`)},
+			expErr: true,
+		},
+		"invalid sender": {
+			src:    &types.QuerySimulateExecuteContractRequest{Sender: "invalid", Address: example.Contract.String(), Msg: []byte(`{}`)},
+			expErr: true,
+		},
+		"unknown contract": {
+			src:    &types.QuerySimulateExecuteContractRequest{Sender: example.CreatorAddr.String(), Address: RandomBech32AccountAddress(t), Msg: []byte(`{}`)},
+			expErr: true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := q.SimulateExecuteContract(sdk.WrapSDKContext(ctx), spec.src)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []byte("my-data"), got.Data)
+			assert.NotZero(t, got.GasUsed)
+			assert.NotEmpty(t, got.Events)
+		})
+	}
+}
+
+func TestQueryPreviewExecuteContract(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	mock.ExecuteFn = func(codeID cosmwasm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store cosmwasm.KVStore, goapi cosmwasm.GoAPI, querier cosmwasm.Querier, gasMeter cosmwasm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		if string(executeMsg) == `{"fail":{}}` {
+			return nil, 1, errors.New("my error")
+		}
+		return &wasmvmtypes.Response{Attributes: []wasmvmtypes.EventAttribute{{Key: "my", Value: "attribute"}}}, 1, nil
+	}
+	q := Querier(keepers.WasmKeeper)
+
+	specs := map[string]struct {
+		src        *types.QueryPreviewExecuteContractRequest
+		expEvents  bool
+		expExecErr bool
+		expErr     bool
+	}{
+		"preview": {
+			src:       &types.QueryPreviewExecuteContractRequest{Sender: example.CreatorAddr.String(), Address: example.Contract.String(), Msg: []byte(`{}`)},
+			expEvents: true,
+		},
+		"execution fails": {
+			src:        &types.QueryPreviewExecuteContractRequest{Sender: example.CreatorAddr.String(), Address: example.Contract.String(), Msg: []byte(`{"fail":{}}`)},
+			expExecErr: true,
+		},
+		"unknown contract": {
+			src:        &types.QueryPreviewExecuteContractRequest{Sender: example.CreatorAddr.String(), Address: RandomBech32AccountAddress(t), Msg: []byte(`{}`)},
+			expExecErr: true,
+		},
+		"invalid msg": {
+			src:    &types.QueryPreviewExecuteContractRequest{Sender: example.CreatorAddr.String(), Address: example.Contract.String(), Msg: []byte(`not json`)},
+			expErr: true,
+		},
+		"invalid sender": {
+			src:    &types.QueryPreviewExecuteContractRequest{Sender: "invalid", Address: example.Contract.String(), Msg: []byte(`{}`)},
+			expErr: true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := q.PreviewExecuteContract(sdk.WrapSDKContext(ctx), spec.src)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expEvents, len(got.Events) != 0)
+			assert.Equal(t, spec.expExecErr, got.Error != "")
+		})
+	}
+}
+
+func TestQueryCapabilities(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "stargate,iterator,staking")
+	q := Querier(keepers.WasmKeeper)
+
+	got, err := q.Capabilities(sdk.WrapSDKContext(ctx), &types.QueryCapabilitiesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"iterator", "staking", "stargate"}, got.Capabilities)
+	assert.NotEmpty(t, got.WasmvmVersion)
+
+	_, err = q.Capabilities(sdk.WrapSDKContext(ctx), nil)
+	require.Error(t, err)
+}
@@ -1,19 +1,29 @@
 package keeper
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"golang.org/x/crypto/ripemd160" // nolint: staticcheck // required for address derivation schemes of contracts
+	"golang.org/x/crypto/sha3"
 )
 
 type QueryHandler struct {
@@ -51,6 +61,7 @@ func (q QueryHandler) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) (
 	sdkGas := q.gasRegister.FromWasmVMGas(gasLimit)
 	// discard all changes/ events in subCtx by not committing the cached context
 	subCtx, _ := q.Ctx.WithGasMeter(sdk.NewGasMeter(sdkGas)).CacheContext()
+	subCtx = types.WithQueryFromContract(subCtx)
 
 	// make sure we charge the higher level context even on panic
 	defer func() {
@@ -67,6 +78,7 @@ type CustomQuerier func(ctx sdk.Context, request json.RawMessage) ([]byte, error
 
 type QueryPlugins struct {
 	Bank     func(ctx sdk.Context, request *wasmvmtypes.BankQuery) ([]byte, error)
+	Chain    func(ctx sdk.Context, caller sdk.AccAddress, request *types.ChainQuery) ([]byte, error)
 	Custom   CustomQuerier
 	IBC      func(ctx sdk.Context, caller sdk.AccAddress, request *wasmvmtypes.IBCQuery) ([]byte, error)
 	Staking  func(ctx sdk.Context, request *wasmvmtypes.StakingQuery) ([]byte, error)
@@ -78,6 +90,29 @@ type contractMetaDataSource interface {
 	GetContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) *types.ContractInfo
 }
 
+type chainQueryKeeper interface {
+	HasContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) bool
+	QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error)
+	GetContractStateBatch(ctx sdk.Context, contractAddress sdk.AccAddress, startAfter []byte, limit uint32) ([]types.Model, []byte)
+	GetMigrationProgress(ctx sdk.Context, contractAddress sdk.AccAddress) []byte
+	signatureVerifyCosts(algorithm string) sdk.Gas
+	hashCosts(dataLen int) sdk.Gas
+	contractBalance(ctx sdk.Context, contractAddress sdk.AccAddress) sdk.Coins
+	isModuleAccount(ctx sdk.Context, addr sdk.AccAddress) bool
+	minGasPrices(ctx sdk.Context) sdk.DecCoins
+	delegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (sdk.DecCoins, error)
+	totalSupply(ctx sdk.Context, denom string) sdk.Coin
+	stakingPool(ctx sdk.Context) (bonded, notBonded sdk.Coin)
+	validator(ctx sdk.Context, valAddr sdk.ValAddress) (stakingtypes.Validator, bool)
+	activeValidatorSet(ctx sdk.Context) (bondedCount, maxValidators uint32)
+	GetParams(ctx sdk.Context) types.Params
+	GetContractHistory(ctx sdk.Context, contractAddr sdk.AccAddress) []types.ContractCodeHistoryEntry
+	GetContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) *types.ContractInfo
+	GetAverageBlockTime(ctx sdk.Context) time.Duration
+	govParams(ctx sdk.Context) (govtypes.DepositParams, govtypes.VotingParams, govtypes.TallyParams, error)
+	clientConnections(ctx sdk.Context, clientID, startAfter string, limit uint32) ([]types.IBCConnection, string, error)
+}
+
 type wasmQueryKeeper interface {
 	contractMetaDataSource
 	QueryRaw(ctx sdk.Context, contractAddress sdk.AccAddress, key []byte) []byte
@@ -91,10 +126,14 @@ func DefaultQueryPlugins(
 	distKeeper types.DistributionKeeper,
 	channelKeeper types.ChannelKeeper,
 	queryRouter GRPCQueryRouter,
-	wasm wasmQueryKeeper,
+	wasm interface {
+		wasmQueryKeeper
+		chainQueryKeeper
+	},
 ) QueryPlugins {
 	return QueryPlugins{
 		Bank:     BankQuerier(bank),
+		Chain:    ChainQuerier(wasm, DefaultExposedModuleAccounts),
 		Custom:   NoCustomQuerier,
 		IBC:      IBCQuerier(wasm, channelKeeper),
 		Staking:  StakingQuerier(staking, distKeeper),
@@ -111,6 +150,9 @@ func (e QueryPlugins) Merge(o *QueryPlugins) QueryPlugins {
 	if o.Bank != nil {
 		e.Bank = o.Bank
 	}
+	if o.Chain != nil {
+		e.Chain = o.Chain
+	}
 	if o.Custom != nil {
 		e.Custom = o.Custom
 	}
@@ -129,6 +171,47 @@ func (e QueryPlugins) Merge(o *QueryPlugins) QueryPlugins {
 	return e
 }
 
+// names of the query plugin branches that can be disabled
+const (
+	QueryPluginBank     = "bank"
+	QueryPluginChain    = "chain"
+	QueryPluginCustom   = "custom"
+	QueryPluginIBC      = "ibc"
+	QueryPluginStaking  = "staking"
+	QueryPluginStargate = "stargate"
+	QueryPluginWasm     = "wasm"
+)
+
+// Disable replaces the given plugin branches with a querier that rejects all requests with types.ErrUnknownMsg.
+// Distribution data is served by the staking branch and disabled with it.
+func (e QueryPlugins) Disable(names ...string) (QueryPlugins, error) {
+	for _, name := range names {
+		name := name
+		reject := func() error {
+			return sdkerrors.Wrapf(types.ErrUnknownMsg, "%s query plugin disabled", name)
+		}
+		switch name {
+		case QueryPluginBank:
+			e.Bank = func(sdk.Context, *wasmvmtypes.BankQuery) ([]byte, error) { return nil, reject() }
+		case QueryPluginChain:
+			e.Chain = func(sdk.Context, sdk.AccAddress, *types.ChainQuery) ([]byte, error) { return nil, reject() }
+		case QueryPluginCustom:
+			e.Custom = func(sdk.Context, json.RawMessage) ([]byte, error) { return nil, reject() }
+		case QueryPluginIBC:
+			e.IBC = func(sdk.Context, sdk.AccAddress, *wasmvmtypes.IBCQuery) ([]byte, error) { return nil, reject() }
+		case QueryPluginStaking:
+			e.Staking = func(sdk.Context, *wasmvmtypes.StakingQuery) ([]byte, error) { return nil, reject() }
+		case QueryPluginStargate:
+			e.Stargate = func(sdk.Context, *wasmvmtypes.StargateQuery) ([]byte, error) { return nil, reject() }
+		case QueryPluginWasm:
+			e.Wasm = func(sdk.Context, *wasmvmtypes.WasmQuery) ([]byte, error) { return nil, reject() }
+		default:
+			return e, sdkerrors.Wrapf(types.ErrInvalid, "unknown query plugin: %s", name)
+		}
+	}
+	return e, nil
+}
+
 // HandleQuery executes the requested query
 func (e QueryPlugins) HandleQuery(ctx sdk.Context, caller sdk.AccAddress, request wasmvmtypes.QueryRequest) ([]byte, error) {
 	// do the query
@@ -136,6 +219,15 @@ func (e QueryPlugins) HandleQuery(ctx sdk.Context, caller sdk.AccAddress, reques
 		return e.Bank(ctx, request.Bank)
 	}
 	if request.Custom != nil {
+		// chain queries are sent via the custom channel in their own namespace so that they never shadow
+		// the queries of the chain's custom querier
+		chainQuery, ok, err := types.DecodeChainQuery(request.Custom)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok:
+			return e.Chain(ctx, caller, chainQuery)
+		}
 		return e.Custom(ctx, request.Custom)
 	}
 	if request.IBC != nil {
@@ -188,6 +280,334 @@ func NoCustomQuerier(sdk.Context, json.RawMessage) ([]byte, error) {
 	return nil, wasmvmtypes.UnsupportedRequest{Kind: "custom"}
 }
 
+// DefaultExposedModuleAccounts are the module accounts that contracts can query the address for by default
+var DefaultExposedModuleAccounts = []string{
+	authtypes.FeeCollectorName,
+	distributiontypes.ModuleName,
+	govtypes.ModuleName,
+	stakingtypes.BondedPoolName,
+	stakingtypes.NotBondedPoolName,
+}
+
+// ChainQuerier handles the chain queries that wasmd provides to contracts via the custom query channel.
+// Module account addresses are only returned for the given exposed module names.
+func ChainQuerier(k chainQueryKeeper, exposedModuleAccounts []string) func(ctx sdk.Context, caller sdk.AccAddress, request *types.ChainQuery) ([]byte, error) {
+	exposed := make(map[string]struct{}, len(exposedModuleAccounts))
+	for _, n := range exposedModuleAccounts {
+		exposed[n] = struct{}{}
+	}
+	return func(ctx sdk.Context, caller sdk.AccAddress, request *types.ChainQuery) ([]byte, error) {
+		if request.ModuleAccount != nil {
+			if _, ok := exposed[request.ModuleAccount.Name]; !ok {
+				return nil, sdkerrors.Wrapf(types.ErrInvalid, "module account not exposed: %q", request.ModuleAccount.Name)
+			}
+			res := types.ModuleAccountResponse{
+				Address: authtypes.NewModuleAddress(request.ModuleAccount.Name).String(),
+			}
+			return json.Marshal(res)
+		}
+		if request.IsContract != nil {
+			addr, err := sdk.AccAddressFromBech32(request.IsContract.Address)
+			if err != nil {
+				return nil, sdkerrors.Wrap(err, request.IsContract.Address)
+			}
+			return json.Marshal(types.IsContractResponse{IsContract: k.HasContractInfo(ctx, addr)})
+		}
+		if request.ValidateAddress != nil {
+			addr, err := sdk.AccAddressFromBech32(request.ValidateAddress.Address)
+			if err != nil {
+				return json.Marshal(types.ValidateAddressResponse{})
+			}
+			res := types.ValidateAddressResponse{
+				Valid:           true,
+				IsContract:      k.HasContractInfo(ctx, addr),
+				IsModuleAccount: k.isModuleAccount(ctx, addr),
+			}
+			return json.Marshal(res)
+		}
+		if request.MigrationStateBatch != nil {
+			cursor := types.MigrationCursorFromContext(ctx)
+			if cursor == nil || !cursor.Contract().Equals(caller) {
+				return nil, sdkerrors.Wrap(types.ErrInvalid, "state batch only supported during contract migration")
+			}
+			limit := request.MigrationStateBatch.Limit
+			switch {
+			case limit == 0:
+				limit = types.DefaultStateBatchLimit
+			case limit > types.MaxStateBatchLimit:
+				limit = types.MaxStateBatchLimit
+			}
+			startAfter := request.MigrationStateBatch.StartAfter
+			if request.MigrationStateBatch.Resume {
+				if position, updated := cursor.Position(); updated {
+					// continue within the same migrate call
+					startAfter = position
+				} else {
+					startAfter = k.GetMigrationProgress(ctx, caller)
+				}
+			}
+			models, next := k.GetContractStateBatch(ctx, caller, startAfter, limit)
+			cursor.Update(next)
+			res := types.MigrationStateBatchResponse{
+				Models:  make([]types.StateModel, len(models)),
+				NextKey: next,
+			}
+			for i, m := range models {
+				res.Models[i] = types.StateModel{Key: m.Key, Value: m.Value}
+			}
+			return json.Marshal(res)
+		}
+		if request.Randomness != nil {
+			seed, err := randomnessSeed(ctx, caller)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(types.RandomnessResponse{Seed: seed})
+		}
+		if request.ContractBalance != nil {
+			res := types.ContractBalanceResponse{
+				Amount: convertSdkCoinsToWasmCoins(k.contractBalance(ctx, caller)),
+			}
+			return json.Marshal(res)
+		}
+		if request.MinGasPrices != nil {
+			prices := k.minGasPrices(ctx)
+			res := types.MinGasPricesResponse{
+				GasPrices: make([]types.DecCoin, len(prices)),
+			}
+			for i, p := range prices {
+				res.GasPrices[i] = types.DecCoin{Denom: p.Denom, Amount: p.Amount.String()}
+			}
+			return json.Marshal(res)
+		}
+		if request.DelegationRewards != nil {
+			valAddr, err := sdk.ValAddressFromBech32(request.DelegationRewards.Validator)
+			if err != nil {
+				return nil, sdkerrors.Wrap(err, request.DelegationRewards.Validator)
+			}
+			rewards, err := k.delegationRewards(ctx, caller, valAddr)
+			if err != nil {
+				return nil, err
+			}
+			res := types.DelegationRewardsResponse{
+				Rewards: make([]types.DecCoin, len(rewards)),
+			}
+			for i, r := range rewards {
+				res.Rewards[i] = types.DecCoin{Denom: r.Denom, Amount: r.Amount.String()}
+			}
+			return json.Marshal(res)
+		}
+		if request.TotalSupply != nil {
+			res := types.TotalSupplyResponse{
+				Amount: convertSdkCoinToWasmCoin(k.totalSupply(ctx, request.TotalSupply.Denom)),
+			}
+			return json.Marshal(res)
+		}
+		if request.StakingPool != nil {
+			bonded, notBonded := k.stakingPool(ctx)
+			res := types.StakingPoolResponse{
+				BondedTokens:    convertSdkCoinToWasmCoin(bonded),
+				NotBondedTokens: convertSdkCoinToWasmCoin(notBonded),
+			}
+			return json.Marshal(res)
+		}
+		if request.Validator != nil {
+			valAddr, err := sdk.ValAddressFromBech32(request.Validator.Address)
+			if err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, request.Validator.Address)
+			}
+			var res types.ValidatorResponse
+			if v, found := k.validator(ctx, valAddr); found {
+				res.Validator = &types.ValidatorInfo{
+					Address:    v.OperatorAddress,
+					Commission: v.Commission.Rate.String(),
+					Jailed:     v.Jailed,
+					Status:     v.Status.String(),
+				}
+			}
+			return json.Marshal(res)
+		}
+		if request.ActiveValidatorSet != nil {
+			bonded, max := k.activeValidatorSet(ctx)
+			res := types.ActiveValidatorSetResponse{
+				BondedCount:   bonded,
+				MaxValidators: max,
+			}
+			return json.Marshal(res)
+		}
+		if request.ModuleParams != nil {
+			params := k.GetParams(ctx)
+			return codec.ProtoMarshalJSON(&params, nil)
+		}
+		if request.CodeHistory != nil {
+			history := k.GetContractHistory(ctx, caller)
+			res := types.CodeHistoryResponse{
+				Entries: make([]types.CodeHistoryEntry, len(history)),
+			}
+			for i, e := range history {
+				res.Entries[i] = types.CodeHistoryEntry{
+					Operation: codeHistoryOperationNames[e.Operation],
+					CodeID:    e.CodeID,
+				}
+				if e.Updated != nil {
+					res.Entries[i].Height = e.Updated.BlockHeight
+				}
+			}
+			return json.Marshal(res)
+		}
+		if request.IBCClientConnections != nil {
+			limit := request.IBCClientConnections.Limit
+			switch {
+			case limit == 0:
+				limit = types.DefaultIBCConnectionsLimit
+			case limit > types.MaxIBCConnectionsLimit:
+				limit = types.MaxIBCConnectionsLimit
+			}
+			conns, next, err := k.clientConnections(ctx, request.IBCClientConnections.ClientID, request.IBCClientConnections.StartAfter, limit)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(types.IBCClientConnectionsResponse{Connections: conns, NextKey: next})
+		}
+		if request.GovParams != nil {
+			deposit, voting, tally, err := k.govParams(ctx)
+			if err != nil {
+				return nil, err
+			}
+			res := types.GovParamsResponse{
+				MinDeposit:       convertSdkCoinsToWasmCoins(deposit.MinDeposit),
+				MaxDepositPeriod: uint64(deposit.MaxDepositPeriod),
+				VotingPeriod:     uint64(voting.VotingPeriod),
+				Quorum:           tally.Quorum.String(),
+				Threshold:        tally.Threshold.String(),
+				VetoThreshold:    tally.VetoThreshold.String(),
+			}
+			return json.Marshal(res)
+		}
+		if request.AverageBlockTime != nil {
+			return json.Marshal(types.AverageBlockTimeResponse{Nanos: uint64(k.GetAverageBlockTime(ctx))})
+		}
+		if request.TxPosition != nil {
+			txIndex, ok := types.TXCounter(ctx)
+			if !ok {
+				return nil, sdkerrors.Wrap(types.ErrNotFound, "tx position not available outside of a transaction")
+			}
+			return json.Marshal(types.TxPositionResponse{Height: uint64(ctx.BlockHeight()), TxIndex: txIndex})
+		}
+		if request.ContractAdmin != nil {
+			addr, err := sdk.AccAddressFromBech32(request.ContractAdmin.ContractAddr)
+			if err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, request.ContractAdmin.ContractAddr)
+			}
+			info := k.GetContractInfo(ctx, addr)
+			if info == nil {
+				return nil, sdkerrors.Wrap(types.ErrNotFound, "contract")
+			}
+			res := types.ContractAdminResponse{
+				Admin:   info.Admin,
+				IsAdmin: info.Admin != "" && info.Admin == caller.String(),
+			}
+			return json.Marshal(res)
+		}
+		if request.TrySmart != nil {
+			return trySmartQuery(ctx, k, request.TrySmart)
+		}
+		if request.Sha256 != nil {
+			ctx.GasMeter().ConsumeGas(k.hashCosts(len(request.Sha256.Data)), "sha256 hash")
+			digest := sha256.Sum256(request.Sha256.Data)
+			return json.Marshal(types.HashResponse{Digest: digest[:]})
+		}
+		if request.Ripemd160 != nil {
+			ctx.GasMeter().ConsumeGas(k.hashCosts(len(request.Ripemd160.Data)), "ripemd160 hash")
+			h := ripemd160.New()
+			h.Write(request.Ripemd160.Data)
+			return json.Marshal(types.HashResponse{Digest: h.Sum(nil)})
+		}
+		if request.Keccak256 != nil {
+			ctx.GasMeter().ConsumeGas(k.hashCosts(len(request.Keccak256.Data)), "keccak256 hash")
+			h := sha3.NewLegacyKeccak256()
+			h.Write(request.Keccak256.Data)
+			return json.Marshal(types.HashResponse{Digest: h.Sum(nil)})
+		}
+		if request.Secp256k1Verify != nil {
+			return verifySignature(ctx, k, types.SignatureAlgorithmSecp256k1, request.Secp256k1Verify)
+		}
+		if request.Ed25519Verify != nil {
+			return verifySignature(ctx, k, types.SignatureAlgorithmEd25519, request.Ed25519Verify)
+		}
+		return nil, wasmvmtypes.UnsupportedRequest{Kind: "unknown ChainQuery variant"}
+	}
+}
+
+// codeHistoryOperationNames maps the code history operations to the names returned to contracts
+var codeHistoryOperationNames = map[types.ContractCodeHistoryOperationType]string{
+	types.ContractCodeHistoryOperationTypeInit:    "init",
+	types.ContractCodeHistoryOperationTypeMigrate: "migrate",
+	types.ContractCodeHistoryOperationTypeGenesis: "genesis",
+}
+
+// randomnessSeed returns the seed for the contract that is derived from the hash and height of the current block
+func randomnessSeed(ctx sdk.Context, contractAddr sdk.AccAddress) ([]byte, error) {
+	if ctx.IsCheckTx() {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "randomness not supported in check tx")
+	}
+	blockHash := ctx.HeaderHash()
+	if len(blockHash) == 0 {
+		return nil, sdkerrors.Wrap(types.ErrEmpty, "block hash")
+	}
+	h := sha256.New()
+	h.Write(blockHash)
+	h.Write(sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())))
+	h.Write(contractAddr)
+	return h.Sum(nil), nil
+}
+
+// trySmartQuery queries the contract and returns its failure as structured data. Invalid requests are returned as
+// error. Out of gas panics are not recovered so that they still abort the caller.
+func trySmartQuery(ctx sdk.Context, k chainQueryKeeper, request *types.TrySmartQuery) ([]byte, error) {
+	addr, err := sdk.AccAddressFromBech32(request.ContractAddr)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, request.ContractAddr)
+	}
+	msg := types.RawContractMessage(request.Msg)
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, sdkerrors.Wrap(err, "json msg")
+	}
+	rsp, err := k.QuerySmart(ctx, addr, msg)
+	if err == nil {
+		return json.Marshal(types.TrySmartResponse{Data: rsp})
+	}
+	codespace, code, log := sdkerrors.ABCIInfo(err, false)
+	return json.Marshal(types.TrySmartResponse{Error: &types.QueryError{Codespace: codespace, Code: code, Message: log}})
+}
+
+// verifySignature charges the costs for a single verification before the signature is verified so that the
+// gas consumed is deterministic and proportional to the number of verifications. The message is hashed by the
+// verification, so the hash costs for the message length are charged on top.
+func verifySignature(ctx sdk.Context, k chainQueryKeeper, algorithm string, request *types.SignatureVerifyQuery) ([]byte, error) {
+	ctx.GasMeter().ConsumeGas(k.signatureVerifyCosts(algorithm), algorithm+" signature verification")
+	ctx.GasMeter().ConsumeGas(k.hashCosts(len(request.Message)), algorithm+" message hash")
+	var pubKey cryptotypes.PubKey
+	switch algorithm {
+	case types.SignatureAlgorithmSecp256k1:
+		if len(request.PublicKey) != secp256k1.PubKeySize {
+			return nil, sdkerrors.Wrapf(types.ErrInvalid, "public key length: %d", len(request.PublicKey))
+		}
+		pubKey = &secp256k1.PubKey{Key: request.PublicKey}
+	case types.SignatureAlgorithmEd25519:
+		if len(request.PublicKey) != ed25519.PubKeySize {
+			return nil, sdkerrors.Wrapf(types.ErrInvalid, "public key length: %d", len(request.PublicKey))
+		}
+		pubKey = &ed25519.PubKey{Key: request.PublicKey}
+	default:
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "unsupported signature algorithm: %s", algorithm)
+	}
+	res := types.SignatureVerifyResponse{
+		Verifies: pubKey.VerifySignature(request.Message, request.Signature),
+	}
+	return json.Marshal(res)
+}
+
 func IBCQuerier(wasm contractMetaDataSource, channelKeeper types.ChannelKeeper) func(ctx sdk.Context, caller sdk.AccAddress, request *wasmvmtypes.IBCQuery) ([]byte, error) {
 	return func(ctx sdk.Context, caller sdk.AccAddress, request *wasmvmtypes.IBCQuery) ([]byte, error) {
 		if request.PortID != nil {
@@ -259,8 +679,19 @@ func IBCQuerier(wasm contractMetaDataSource, channelKeeper types.ChannelKeeper)
 	}
 }
 
+// stargateQueryDenylist contains the query paths that contracts must not call. The simulations run a contract
+// execution with their own gas meter that is not charged to the calling contract.
+var stargateQueryDenylist = map[string]struct{}{
+	"/cosmwasm.wasm.v1.Query/SimulateExecuteContract":     {},
+	"/cosmwasm.wasm.v1.Query/SimulateInstantiateContract": {},
+	"/cosmwasm.wasm.v1.Query/PreviewExecuteContract":      {},
+}
+
 func StargateQuerier(queryRouter GRPCQueryRouter) func(ctx sdk.Context, request *wasmvmtypes.StargateQuery) ([]byte, error) {
 	return func(ctx sdk.Context, msg *wasmvmtypes.StargateQuery) ([]byte, error) {
+		if _, denied := stargateQueryDenylist[msg.Path]; denied {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: fmt.Sprintf("query '%s' not allowed for contracts", msg.Path)}
+		}
 		route := queryRouter.Route(msg.Path)
 		if route == nil {
 			return nil, wasmvmtypes.UnsupportedRequest{Kind: fmt.Sprintf("No route to query '%s'", msg.Path)}
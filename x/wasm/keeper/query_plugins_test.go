@@ -1,15 +1,29 @@
 package keeper
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
+	wasmvm "github.com/CosmWasm/wasmvm"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	connectiontypes "github.com/cosmos/ibc-go/v2/modules/core/03-connection/types"
 	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -316,6 +330,52 @@ func TestIBCQuerier(t *testing.T) {
 
 }
 
+func TestStargateQuerierDeniesSimulations(t *testing.T) {
+	var routed []string
+	router := mockGRPCQueryRouter{RouteFn: func(path string) GRPCQueryHandler {
+		return func(ctx sdk.Context, req abci.RequestQuery) (abci.ResponseQuery, error) {
+			routed = append(routed, path)
+			return abci.ResponseQuery{Value: []byte("ok")}, nil
+		}
+	}}
+	q := StargateQuerier(router)
+	specs := map[string]struct {
+		srcPath string
+		expErr  bool
+	}{
+		"simulate execute":     {srcPath: "/cosmwasm.wasm.v1.Query/SimulateExecuteContract", expErr: true},
+		"simulate instantiate": {srcPath: "/cosmwasm.wasm.v1.Query/SimulateInstantiateContract", expErr: true},
+		"preview execute":      {srcPath: "/cosmwasm.wasm.v1.Query/PreviewExecuteContract", expErr: true},
+		"other query":          {srcPath: "/cosmwasm.wasm.v1.Query/ContractInfo"},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			routed = nil
+			gotBz, gotErr := q(sdk.Context{}, &wasmvmtypes.StargateQuery{Path: spec.srcPath})
+			if spec.expErr {
+				var unsupported wasmvmtypes.UnsupportedRequest
+				require.True(t, errors.As(gotErr, &unsupported), "got %+v", gotErr)
+				assert.Empty(t, routed)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, []byte("ok"), gotBz)
+			assert.Equal(t, []string{spec.srcPath}, routed)
+		})
+	}
+}
+
+type mockGRPCQueryRouter struct {
+	RouteFn func(path string) GRPCQueryHandler
+}
+
+func (m mockGRPCQueryRouter) Route(path string) GRPCQueryHandler {
+	if m.RouteFn == nil {
+		panic("not expected to be called")
+	}
+	return m.RouteFn(path)
+}
+
 func TestBankQuerierBalance(t *testing.T) {
 	mock := bankKeeperMock{GetBalanceFn: func(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin {
 		return sdk.NewCoin(denom, sdk.NewInt(1))
@@ -341,6 +401,826 @@ func TestBankQuerierBalance(t *testing.T) {
 	assert.Equal(t, exp, got)
 }
 
+func TestChainQuerierModuleAccount(t *testing.T) {
+	specs := map[string]struct {
+		srcName    string
+		srcExposed []string
+		expAddr    sdk.AccAddress
+		expErr     bool
+	}{
+		"distribution": {
+			srcName:    distributiontypes.ModuleName,
+			srcExposed: DefaultExposedModuleAccounts,
+			expAddr:    authtypes.NewModuleAddress(distributiontypes.ModuleName),
+		},
+		"bonded pool": {
+			srcName:    stakingtypes.BondedPoolName,
+			srcExposed: DefaultExposedModuleAccounts,
+			expAddr:    authtypes.NewModuleAddress(stakingtypes.BondedPoolName),
+		},
+		"custom exposed module": {
+			srcName:    "mymodule",
+			srcExposed: []string{"mymodule"},
+			expAddr:    authtypes.NewModuleAddress("mymodule"),
+		},
+		"not exposed module": {
+			srcName:    "mint",
+			srcExposed: DefaultExposedModuleAccounts,
+			expErr:     true,
+		},
+		"empty name": {
+			srcExposed: DefaultExposedModuleAccounts,
+			expErr:     true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := ChainQuerier(nil, spec.srcExposed)
+			gotBz, gotErr := q(sdk.Context{}, RandomAccountAddress(t), &types.ChainQuery{ModuleAccount: &types.ModuleAccountQuery{Name: spec.srcName}})
+			if spec.expErr {
+				assert.True(t, types.ErrInvalid.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			var got types.ModuleAccountResponse
+			require.NoError(t, json.Unmarshal(gotBz, &got))
+			assert.Equal(t, spec.expAddr.String(), got.Address)
+		})
+	}
+}
+
+func TestChainQuerierIsContract(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	specs := map[string]struct {
+		srcAddress string
+		expResult  bool
+		expErr     bool
+	}{
+		"contract": {
+			srcAddress: example.Contract.String(),
+			expResult:  true,
+		},
+		"regular account": {
+			srcAddress: example.CreatorAddr.String(),
+		},
+		"unknown address": {
+			srcAddress: RandomBech32AccountAddress(t),
+		},
+		"invalid address": {
+			srcAddress: "not an address",
+			expErr:     true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := ChainQuerier(keepers.WasmKeeper, nil)
+			queryCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			gotBz, gotErr := q(queryCtx, example.Contract, &types.ChainQuery{IsContract: &types.IsContractQuery{Address: spec.srcAddress}})
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			var got types.IsContractResponse
+			require.NoError(t, json.Unmarshal(gotBz, &got))
+			assert.Equal(t, spec.expResult, got.IsContract)
+			// a single store lookup is charged
+			assert.Equal(t, storetypes.KVGasConfig().HasCost, queryCtx.GasMeter().GasConsumed())
+		})
+	}
+}
+
+func TestChainQuerierValidateAddress(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	moduleAcc := keepers.AccountKeeper.GetModuleAccount(ctx, authtypes.FeeCollectorName)
+
+	validAddr := RandomBech32AccountAddress(t)
+	// replace the last character of the checksum
+	lastChar := "q"
+	if strings.HasSuffix(validAddr, lastChar) {
+		lastChar = "p"
+	}
+	invalidChecksum := validAddr[:len(validAddr)-1] + lastChar
+
+	specs := map[string]struct {
+		srcAddress string
+		exp        types.ValidateAddressResponse
+	}{
+		"regular account": {
+			srcAddress: validAddr,
+			exp:        types.ValidateAddressResponse{Valid: true},
+		},
+		"contract": {
+			srcAddress: example.Contract.String(),
+			exp:        types.ValidateAddressResponse{Valid: true, IsContract: true},
+		},
+		"module account": {
+			srcAddress: moduleAcc.GetAddress().String(),
+			exp:        types.ValidateAddressResponse{Valid: true, IsModuleAccount: true},
+		},
+		"invalid prefix": {
+			srcAddress: sdk.MustBech32ifyAddressBytes("other", example.Contract),
+		},
+		"invalid checksum": {
+			srcAddress: invalidChecksum,
+		},
+		"empty": {
+			srcAddress: "",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := ChainQuerier(keepers.WasmKeeper, nil)
+			gotBz, gotErr := q(ctx, example.Contract, &types.ChainQuery{ValidateAddress: &types.ValidateAddressQuery{Address: spec.srcAddress}})
+			require.NoError(t, gotErr)
+			var got types.ValidateAddressResponse
+			require.NoError(t, json.Unmarshal(gotBz, &got))
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestChainQuerierRandomness(t *testing.T) {
+	blockHash := bytes.Repeat([]byte{1}, 32)
+	myContract, otherContract := RandomAccountAddress(t), RandomAccountAddress(t)
+	query := &types.ChainQuery{Randomness: &types.RandomnessQuery{}}
+	ctx1, keepers := CreateTestInput(t, false, SupportedFeatures)
+	ctx1 = ctx1.WithHeaderHash(blockHash).WithBlockHeight(10)
+	q := ChainQuerier(keepers.WasmKeeper, nil)
+	querySeed := func(t *testing.T, ctx sdk.Context, contract sdk.AccAddress) []byte {
+		gotBz, err := q(ctx, contract, query)
+		require.NoError(t, err)
+		var got types.RandomnessResponse
+		require.NoError(t, json.Unmarshal(gotBz, &got))
+		require.Len(t, got.Seed, 32)
+		return got.Seed
+	}
+
+	// same seed in two identical contexts
+	ctx2, _ := CreateTestInput(t, false, SupportedFeatures)
+	ctx2 = ctx2.WithHeaderHash(blockHash).WithBlockHeight(10)
+	mySeed := querySeed(t, ctx1, myContract)
+	assert.Equal(t, mySeed, querySeed(t, ctx2, myContract))
+
+	// but different seeds for other contracts, blocks and heights
+	assert.NotEqual(t, mySeed, querySeed(t, ctx1, otherContract))
+	assert.NotEqual(t, mySeed, querySeed(t, ctx1.WithHeaderHash(bytes.Repeat([]byte{2}, 32)), myContract))
+	assert.NotEqual(t, mySeed, querySeed(t, ctx1.WithBlockHeight(11), myContract))
+
+	// and rejected without stable block hash
+	_, err := q(ctx1.WithIsCheckTx(true), myContract, query)
+	assert.Error(t, err)
+	_, err = q(ctx1.WithHeaderHash(nil), myContract, query)
+	assert.Error(t, err)
+}
+
+func TestChainQuerierContractBalance(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	funded := SeedNewContractInstance(t, ctx, keepers, &mock)
+	require.NoError(t, keepers.BankKeeper.SendCoins(ctx, funded.CreatorAddr, funded.Contract, sdk.NewCoins(sdk.NewInt64Coin("denom", 100))))
+	multiFunded := SeedNewContractInstance(t, ctx, keepers, &mock)
+	multiFunds := sdk.NewCoins(sdk.NewInt64Coin("adenom", 1), sdk.NewInt64Coin("bdenom", 2), sdk.NewInt64Coin("cdenom", 3))
+	fundAccounts(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, multiFunded.Contract, multiFunds)
+	unfunded := SeedNewContractInstance(t, ctx, keepers, &mock)
+	readCosts := func(coins ...sdk.Coin) sdk.Gas {
+		gas := DefaultContractBalanceFlatCost
+		for _, c := range coins {
+			gas += DefaultContractBalancePerByteCost * uint64(len(c.Denom)+c.Size())
+		}
+		return gas
+	}
+
+	specs := map[string]struct {
+		srcContract sdk.AccAddress
+		expAmount   wasmvmtypes.Coins
+		expGas      sdk.Gas
+	}{
+		"with balance": {
+			srcContract: funded.Contract,
+			expAmount:   wasmvmtypes.Coins{{Denom: "denom", Amount: "100"}},
+			expGas:      readCosts(sdk.NewInt64Coin("denom", 100)),
+		},
+		"with multiple denoms": {
+			srcContract: multiFunded.Contract,
+			expAmount:   wasmvmtypes.Coins{{Denom: "adenom", Amount: "1"}, {Denom: "bdenom", Amount: "2"}, {Denom: "cdenom", Amount: "3"}},
+			expGas:      readCosts(multiFunds...),
+		},
+		"without balance": {
+			srcContract: unfunded.Contract,
+			expAmount:   wasmvmtypes.Coins{},
+			expGas:      DefaultContractBalanceFlatCost,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := ChainQuerier(keepers.WasmKeeper, nil)
+			queryCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			gotBz, gotErr := q(queryCtx, spec.srcContract, &types.ChainQuery{ContractBalance: &types.ContractBalanceQuery{}})
+			require.NoError(t, gotErr)
+			var got types.ContractBalanceResponse
+			require.NoError(t, json.Unmarshal(gotBz, &got))
+			assert.ElementsMatch(t, spec.expAmount, got.Amount)
+			assert.Equal(t, spec.expGas, queryCtx.GasMeter().GasConsumed())
+
+			// and same result as the bank query
+			bankCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			bankBz, err := BankQuerier(keepers.BankKeeper)(bankCtx, &wasmvmtypes.BankQuery{AllBalances: &wasmvmtypes.AllBalancesQuery{Address: spec.srcContract.String()}})
+			require.NoError(t, err)
+			assert.JSONEq(t, string(bankBz), string(gotBz))
+			// but cheaper when there are balances to read
+			if len(spec.expAmount) != 0 {
+				assert.Less(t, queryCtx.GasMeter().GasConsumed(), bankCtx.GasMeter().GasConsumed())
+			}
+		})
+	}
+}
+
+func TestChainQuerierSignatureVerify(t *testing.T) {
+	gasConfig := DefaultGasRegisterConfig()
+	gasConfig.Secp256k1VerifyCost = 1234
+	gasConfig.Ed25519VerifyCost = 567
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithGasRegister(NewWasmGasRegister(gasConfig)))
+
+	msg := []byte("my message")
+	secpKey := secp256k1.GenPrivKey()
+	secpSig, err := secpKey.Sign(msg)
+	require.NoError(t, err)
+	edKey := ed25519.GenPrivKey()
+	edSig, err := edKey.Sign(msg)
+	require.NoError(t, err)
+	// the message hash is charged on top of the verification
+	hashCosts := func(msg []byte) sdk.Gas {
+		return DefaultHashFlatCost + DefaultHashPerByteCost*uint64(len(msg))
+	}
+
+	specs := map[string]struct {
+		src         types.ChainQuery
+		expVerifies bool
+		expGas      sdk.Gas
+		expErr      bool
+	}{
+		"secp256k1 valid": {
+			src:         types.ChainQuery{Secp256k1Verify: &types.SignatureVerifyQuery{Message: msg, Signature: secpSig, PublicKey: secpKey.PubKey().Bytes()}},
+			expVerifies: true,
+			expGas:      1234 + hashCosts(msg),
+		},
+		"secp256k1 other message": {
+			src:    types.ChainQuery{Secp256k1Verify: &types.SignatureVerifyQuery{Message: []byte("other"), Signature: secpSig, PublicKey: secpKey.PubKey().Bytes()}},
+			expGas: 1234 + hashCosts([]byte("other")),
+		},
+		"secp256k1 invalid signature": {
+			src:    types.ChainQuery{Secp256k1Verify: &types.SignatureVerifyQuery{Message: msg, Signature: []byte("invalid"), PublicKey: secpKey.PubKey().Bytes()}},
+			expGas: 1234 + hashCosts(msg),
+		},
+		"secp256k1 invalid public key": {
+			src:    types.ChainQuery{Secp256k1Verify: &types.SignatureVerifyQuery{Message: msg, Signature: secpSig, PublicKey: edKey.PubKey().Bytes()}},
+			expGas: 1234 + hashCosts(msg),
+			expErr: true,
+		},
+		"ed25519 valid": {
+			src:         types.ChainQuery{Ed25519Verify: &types.SignatureVerifyQuery{Message: msg, Signature: edSig, PublicKey: edKey.PubKey().Bytes()}},
+			expVerifies: true,
+			expGas:      567 + hashCosts(msg),
+		},
+		"ed25519 other message": {
+			src:    types.ChainQuery{Ed25519Verify: &types.SignatureVerifyQuery{Message: []byte("other"), Signature: edSig, PublicKey: edKey.PubKey().Bytes()}},
+			expGas: 567 + hashCosts([]byte("other")),
+		},
+		"ed25519 invalid public key": {
+			src:    types.ChainQuery{Ed25519Verify: &types.SignatureVerifyQuery{Message: msg, Signature: edSig, PublicKey: secpKey.PubKey().Bytes()}},
+			expGas: 567 + hashCosts(msg),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := ChainQuerier(keepers.WasmKeeper, nil)
+			queryCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			gotBz, gotErr := q(queryCtx, RandomAccountAddress(t), &spec.src)
+			// costs are charged independent of the result
+			assert.Equal(t, spec.expGas, queryCtx.GasMeter().GasConsumed())
+			if spec.expErr {
+				assert.True(t, types.ErrInvalid.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			var got types.SignatureVerifyResponse
+			require.NoError(t, json.Unmarshal(gotBz, &got))
+			assert.Equal(t, spec.expVerifies, got.Verifies)
+		})
+	}
+}
+
+func TestChainQuerierSignatureVerifyGasIsProportional(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	msg := []byte("my message")
+	key := secp256k1.GenPrivKey()
+	sig, err := key.Sign(msg)
+	require.NoError(t, err)
+	query := types.ChainQuery{Secp256k1Verify: &types.SignatureVerifyQuery{Message: msg, Signature: sig, PublicKey: key.PubKey().Bytes()}}
+
+	q := ChainQuerier(keepers.WasmKeeper, nil)
+	for _, n := range []uint64{1, 2, 10} {
+		queryCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		for i := uint64(0); i < n; i++ {
+			_, err := q(queryCtx, RandomAccountAddress(t), &query)
+			require.NoError(t, err)
+		}
+		expCosts := DefaultSecp256k1VerifyCost + DefaultHashFlatCost + DefaultHashPerByteCost*uint64(len(msg))
+		assert.Equal(t, n*expCosts, queryCtx.GasMeter().GasConsumed(), "verifications: %d", n)
+	}
+}
+
+func TestChainQuerierMinGasPrices(t *testing.T) {
+	specs := map[string]struct {
+		srcOpts []Option
+		exp     []types.DecCoin
+	}{
+		"default source": {
+			exp: []types.DecCoin{{Denom: "stake", Amount: "0.000000000000000000"}},
+		},
+		"custom source": {
+			srcOpts: []Option{WithGasPriceSource(mockGasPriceSource{
+				prices: sdk.NewDecCoins(sdk.NewDecCoinFromDec("atom", sdk.NewDecWithPrec(25, 3)), sdk.NewInt64DecCoin("stake", 1)),
+			})},
+			exp: []types.DecCoin{{Denom: "atom", Amount: "0.025000000000000000"}, {Denom: "stake", Amount: "1.000000000000000000"}},
+		},
+		"custom source without prices": {
+			srcOpts: []Option{WithGasPriceSource(mockGasPriceSource{})},
+			exp:     []types.DecCoin{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, spec.srcOpts...)
+			q := ChainQuerier(keepers.WasmKeeper, nil)
+			gotBz, gotErr := q(ctx, RandomAccountAddress(t), &types.ChainQuery{MinGasPrices: &types.MinGasPricesQuery{}})
+			require.NoError(t, gotErr)
+			var got types.MinGasPricesResponse
+			require.NoError(t, json.Unmarshal(gotBz, &got))
+			assert.Equal(t, spec.exp, got.GasPrices)
+		})
+	}
+}
+
+func TestChainQuerierHash(t *testing.T) {
+	specs := map[string]struct {
+		src       types.ChainQuery
+		expDigest string
+	}{
+		"sha256": {
+			src:       types.ChainQuery{Sha256: &types.HashQuery{Data: []byte("abc")}},
+			expDigest: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		"sha256 empty": {
+			src:       types.ChainQuery{Sha256: &types.HashQuery{}},
+			expDigest: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		"ripemd160": {
+			src:       types.ChainQuery{Ripemd160: &types.HashQuery{Data: []byte("abc")}},
+			expDigest: "8eb208f7e05d987a9b044a8e98c6b087f15a0bfc",
+		},
+		"ripemd160 empty": {
+			src:       types.ChainQuery{Ripemd160: &types.HashQuery{}},
+			expDigest: "9c1185a5c5e9fc54612808977ee8f548b2258d31",
+		},
+		"keccak256": {
+			src:       types.ChainQuery{Keccak256: &types.HashQuery{Data: []byte("abc")}},
+			expDigest: "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45",
+		},
+		"keccak256 empty": {
+			src:       types.ChainQuery{Keccak256: &types.HashQuery{}},
+			expDigest: "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			q := ChainQuerier(keepers.WasmKeeper, nil)
+			gotBz, gotErr := q(ctx, RandomAccountAddress(t), &spec.src)
+			require.NoError(t, gotErr)
+			var got types.HashResponse
+			require.NoError(t, json.Unmarshal(gotBz, &got))
+			assert.Equal(t, spec.expDigest, hex.EncodeToString(got.Digest))
+			// gas depends on the data length only
+			var dataLen int
+			switch {
+			case spec.src.Sha256 != nil:
+				dataLen = len(spec.src.Sha256.Data)
+			case spec.src.Ripemd160 != nil:
+				dataLen = len(spec.src.Ripemd160.Data)
+			default:
+				dataLen = len(spec.src.Keccak256.Data)
+			}
+			assert.Equal(t, DefaultHashFlatCost+DefaultHashPerByteCost*uint64(dataLen), ctx.GasMeter().GasConsumed())
+		})
+	}
+}
+
+func TestChainQuerierTrySmart(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) ([]byte, uint64, error) {
+		switch string(queryMsg) {
+		case `{"fail":{}}`:
+			return nil, 0, errors.New("contract broken")
+		case `{"out_of_gas":{}}`:
+			return nil, gasLimit + 1, wasmvmtypes.OutOfGasError{}
+		}
+		return []byte(`{"ok":true}`), 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	q := ChainQuerier(keepers.WasmKeeper, nil)
+
+	specs := map[string]struct {
+		src    types.TrySmartQuery
+		exp    types.TrySmartResponse
+		expErr bool
+	}{
+		"query succeeds": {
+			src: types.TrySmartQuery{ContractAddr: example.Contract.String(), Msg: []byte(`{"ok":{}}`)},
+			exp: types.TrySmartResponse{Data: []byte(`{"ok":true}`)},
+		},
+		"contract failure returned as data": {
+			src: types.TrySmartQuery{ContractAddr: example.Contract.String(), Msg: []byte(`{"fail":{}}`)},
+			exp: types.TrySmartResponse{Error: &types.QueryError{
+				Codespace: types.DefaultCodespace,
+				Code:      types.ErrQueryFailed.ABCICode(),
+				Message:   "contract broken: query wasm contract failed",
+			}},
+		},
+		"unknown contract returned as data": {
+			src: types.TrySmartQuery{ContractAddr: RandomBech32AccountAddress(t), Msg: []byte(`{"ok":{}}`)},
+			exp: types.TrySmartResponse{Error: &types.QueryError{
+				Codespace: types.DefaultCodespace,
+				Code:      types.ErrNotFound.ABCICode(),
+				Message:   "contract: not found",
+			}},
+		},
+		"invalid address": {
+			src:    types.TrySmartQuery{ContractAddr: "invalid", Msg: []byte(`{"ok":{}}`)},
+			expErr: true,
+		},
+		"invalid json msg": {
+			src:    types.TrySmartQuery{ContractAddr: example.Contract.String(), Msg: []byte(`not json`)},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotBz, gotErr := q(ctx, RandomAccountAddress(t), &types.ChainQuery{TrySmart: &spec.src})
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			var got types.TrySmartResponse
+			require.NoError(t, json.Unmarshal(gotBz, &got))
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+
+	// out of gas is not recoverable
+	limitedCtx := ctx.WithGasMeter(sdk.NewGasMeter(100_000))
+	assert.Panics(t, func() {
+		_, _ = q(limitedCtx, RandomAccountAddress(t), &types.ChainQuery{TrySmart: &types.TrySmartQuery{ContractAddr: example.Contract.String(), Msg: []byte(`{"out_of_gas":{}}`)}})
+	})
+}
+
+type mockGasPriceSource struct {
+	prices sdk.DecCoins
+}
+
+func (m mockGasPriceSource) MinGasPrices(ctx sdk.Context) sdk.DecCoins {
+	return m.prices
+}
+
+func TestChainQuerierCodeHistory(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	ctx = ctx.WithBlockHeight(10)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	newCode := StoreRandomContract(t, ctx, keepers, &mock)
+	q := ChainQuerier(keepers.WasmKeeper, nil)
+	query := types.ChainQuery{CodeHistory: &types.CodeHistoryQuery{}}
+
+	// when migrated in a later block
+	ctx = ctx.WithBlockHeight(20)
+	_, err := keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, newCode.CodeID, []byte(`{}`))
+	require.NoError(t, err)
+
+	// then the contract sees both entries in chronological order
+	raw, err := q(ctx, example.Contract, &query)
+	require.NoError(t, err)
+	var res types.CodeHistoryResponse
+	mustParse(t, raw, &res)
+	exp := []types.CodeHistoryEntry{
+		{Operation: "init", CodeID: example.CodeID, Height: 10},
+		{Operation: "migrate", CodeID: newCode.CodeID, Height: 20},
+	}
+	assert.Equal(t, exp, res.Entries)
+
+	// and non contracts have no history
+	raw, err = q(ctx, RandomAccountAddress(t), &query)
+	require.NoError(t, err)
+	mustParse(t, raw, &res)
+	assert.Empty(t, res.Entries)
+}
+
+func TestChainQuerierGovParams(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	q := ChainQuerier(keepers.WasmKeeper, nil)
+	query := types.ChainQuery{GovParams: &types.GovParamsQuery{}}
+
+	// not supported without a source
+	_, err := q(ctx, RandomAccountAddress(t), &query)
+	assert.IsType(t, wasmvmtypes.UnsupportedRequest{}, err)
+
+	// when configured
+	WithGovParamSource(keepers.GovKeeper).apply(keepers.WasmKeeper)
+	raw, err := q(ctx, RandomAccountAddress(t), &query)
+
+	// then the gov module params are returned
+	require.NoError(t, err)
+	var res types.GovParamsResponse
+	mustParse(t, raw, &res)
+	deposit, voting, tally := keepers.GovKeeper.GetDepositParams(ctx), keepers.GovKeeper.GetVotingParams(ctx), keepers.GovKeeper.GetTallyParams(ctx)
+	exp := types.GovParamsResponse{
+		MinDeposit:       convertSdkCoinsToWasmCoins(deposit.MinDeposit),
+		MaxDepositPeriod: uint64(deposit.MaxDepositPeriod),
+		VotingPeriod:     uint64(voting.VotingPeriod),
+		Quorum:           tally.Quorum.String(),
+		Threshold:        tally.Threshold.String(),
+		VetoThreshold:    tally.VetoThreshold.String(),
+	}
+	assert.Equal(t, exp, res)
+	assert.NotZero(t, res.VotingPeriod)
+}
+
+func TestChainQuerierTxPosition(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	ctx = ctx.WithBlockHeight(100)
+	q := ChainQuerier(keepers.WasmKeeper, nil)
+	query := types.ChainQuery{TxPosition: &types.TxPositionQuery{}}
+	anteHandler := NewCountTXDecorator(keepers.WasmKeeper.storeKey)
+
+	// when 3 txs are processed in a block
+	for i := uint32(0); i < 3; i++ {
+		var raw []byte
+		_, err := anteHandler.AnteHandle(ctx, nil, false, func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+			var err error
+			raw, err = q(ctx, RandomAccountAddress(t), &query)
+			return ctx, err
+		})
+		require.NoError(t, err)
+		// then each tx sees its position
+		var res types.TxPositionResponse
+		mustParse(t, raw, &res)
+		assert.Equal(t, types.TxPositionResponse{Height: 100, TxIndex: i}, res)
+	}
+
+	// and queries outside of a tx fail
+	noTxCtx := ctx.WithContext(context.Background()) // drop the tx counter of the test setup
+	_, err := q(noTxCtx, RandomAccountAddress(t), &query)
+	assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+}
+
+func TestChainQuerierContractAdmin(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	registry := SeedNewContractInstance(t, ctx, keepers, &mock)
+	managed, _, err := keepers.ContractKeeper.Instantiate(ctx, registry.CodeID, registry.CreatorAddr, registry.Contract, []byte(`{}`), "managed", nil)
+	require.NoError(t, err)
+	q := ChainQuerier(keepers.WasmKeeper, nil)
+
+	specs := map[string]struct {
+		srcCaller sdk.AccAddress
+		srcTarget string
+		exp       types.ContractAdminResponse
+		expErr    *sdkerrors.Error
+	}{
+		"caller is admin": {
+			srcCaller: registry.Contract,
+			srcTarget: managed.String(),
+			exp:       types.ContractAdminResponse{Admin: registry.Contract.String(), IsAdmin: true},
+		},
+		"caller is not admin": {
+			srcCaller: RandomAccountAddress(t),
+			srcTarget: managed.String(),
+			exp:       types.ContractAdminResponse{Admin: registry.Contract.String()},
+		},
+		"unknown contract": {
+			srcCaller: registry.Contract,
+			srcTarget: RandomBech32AccountAddress(t),
+			expErr:    types.ErrNotFound,
+		},
+		"invalid address": {
+			srcCaller: registry.Contract,
+			srcTarget: "invalid",
+			expErr:    sdkerrors.ErrInvalidAddress,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			raw, gotErr := q(ctx, spec.srcCaller, &types.ChainQuery{ContractAdmin: &types.ContractAdminQuery{ContractAddr: spec.srcTarget}})
+			if spec.expErr != nil {
+				require.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			var res types.ContractAdminResponse
+			mustParse(t, raw, &res)
+			assert.Equal(t, spec.exp, res)
+		})
+	}
+}
+
+func TestChainQuerierIBCClientConnections(t *testing.T) {
+	connectionStates := map[string]connectiontypes.State{
+		"connection-0": connectiontypes.OPEN,
+		"connection-1": connectiontypes.TRYOPEN,
+		"connection-2": connectiontypes.OPEN,
+	}
+	connKeeper := wasmtesting.MockConnectionKeeper{
+		GetClientConnectionPathsFn: func(ctx sdk.Context, clientID string) ([]string, bool) {
+			if clientID != "07-tendermint-0" {
+				return nil, false
+			}
+			return []string{"connection-0", "connection-1", "connection-2"}, true
+		},
+		GetConnectionFn: func(ctx sdk.Context, connectionID string) (connectiontypes.ConnectionEnd, bool) {
+			state, ok := connectionStates[connectionID]
+			return connectiontypes.ConnectionEnd{ClientId: "07-tendermint-0", State: state}, ok
+		},
+	}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithConnectionKeeper(connKeeper))
+	q := ChainQuerier(keepers.WasmKeeper, nil)
+
+	specs := map[string]struct {
+		src     types.IBCClientConnectionsQuery
+		expConn []types.IBCConnection
+		expNext string
+	}{
+		"all connections": {
+			src: types.IBCClientConnectionsQuery{ClientID: "07-tendermint-0"},
+			expConn: []types.IBCConnection{
+				{ID: "connection-0", State: "STATE_OPEN"},
+				{ID: "connection-1", State: "STATE_TRYOPEN"},
+				{ID: "connection-2", State: "STATE_OPEN"},
+			},
+		},
+		"first page": {
+			src: types.IBCClientConnectionsQuery{ClientID: "07-tendermint-0", Limit: 2},
+			expConn: []types.IBCConnection{
+				{ID: "connection-0", State: "STATE_OPEN"},
+				{ID: "connection-1", State: "STATE_TRYOPEN"},
+			},
+			expNext: "connection-1",
+		},
+		"next page": {
+			src: types.IBCClientConnectionsQuery{ClientID: "07-tendermint-0", StartAfter: "connection-1", Limit: 2},
+			expConn: []types.IBCConnection{
+				{ID: "connection-2", State: "STATE_OPEN"},
+			},
+		},
+		"unknown client": {
+			src:     types.IBCClientConnectionsQuery{ClientID: "07-tendermint-99"},
+			expConn: []types.IBCConnection{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			query := spec.src
+			raw, err := q(ctx, RandomAccountAddress(t), &types.ChainQuery{IBCClientConnections: &query})
+			require.NoError(t, err)
+			var res types.IBCClientConnectionsResponse
+			mustParse(t, raw, &res)
+			assert.Equal(t, spec.expConn, res.Connections)
+			assert.Equal(t, spec.expNext, res.NextKey)
+		})
+	}
+}
+
+func TestChainQuerierMigrationStateBatchRequiresMigration(t *testing.T) {
+	myContract := RandomAccountAddress(t)
+	specs := map[string]struct {
+		srcCtx sdk.Context
+	}{
+		"no migration": {
+			srcCtx: sdk.Context{}.WithContext(context.Background()),
+		},
+		"other contract migrating": {
+			srcCtx: types.WithMigrationCursor(sdk.Context{}.WithContext(context.Background()), types.NewMigrationCursor(RandomAccountAddress(t))),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := ChainQuerier(nil, nil)
+			_, gotErr := q(spec.srcCtx, myContract, &types.ChainQuery{MigrationStateBatch: &types.MigrationStateBatchQuery{}})
+			assert.True(t, types.ErrInvalid.Is(gotErr), "got %+v", gotErr)
+		})
+	}
+}
+
+func TestHandleChainQuery(t *testing.T) {
+	var chainCalled, customCalled bool
+	plugins := QueryPlugins{
+		Chain: func(ctx sdk.Context, caller sdk.AccAddress, request *types.ChainQuery) ([]byte, error) {
+			chainCalled = true
+			return nil, nil
+		},
+		Custom: func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+			customCalled = true
+			return nil, nil
+		},
+	}
+	specs := map[string]struct {
+		src       json.RawMessage
+		expChain  bool
+		expCustom bool
+	}{
+		"chain query": {
+			src:      []byte(`{"wasmd":{"module_account":{"name":"gov"}}}`),
+			expChain: true,
+		},
+		"custom query": {
+			src:       []byte(`{"ping":{}}`),
+			expCustom: true,
+		},
+		"custom query with chain query key": {
+			src:       []byte(`{"module_account":{"name":"gov"}}`),
+			expCustom: true,
+		},
+		"custom query with namespace in other case": {
+			src:       []byte(`{"WASMD":{"module_account":{"name":"gov"}}}`),
+			expCustom: true,
+		},
+		"custom query with namespace and other keys": {
+			src:       []byte(`{"wasmd":{"module_account":{"name":"gov"}},"ping":{}}`),
+			expCustom: true,
+		},
+		"non object custom query": {
+			src:       []byte(`"ping"`),
+			expCustom: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			chainCalled, customCalled = false, false
+			_, err := plugins.HandleQuery(sdk.Context{}, RandomAccountAddress(t), wasmvmtypes.QueryRequest{Custom: spec.src})
+			require.NoError(t, err)
+			assert.Equal(t, spec.expChain, chainCalled)
+			assert.Equal(t, spec.expCustom, customCalled)
+		})
+	}
+	// and an invalid payload in the chain namespace is rejected instead of being passed to the custom querier
+	chainCalled, customCalled = false, false
+	_, err := plugins.HandleQuery(sdk.Context{}, RandomAccountAddress(t), wasmvmtypes.QueryRequest{Custom: []byte(`{"wasmd":"ping"}`)})
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	assert.False(t, chainCalled)
+	assert.False(t, customCalled)
+}
+
+func TestDisabledQueryPlugins(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithDisabledQueryPlugins(QueryPluginStaking))
+	q := keepers.WasmKeeper.wasmVMQueryHandler
+	caller := RandomAccountAddress(t)
+
+	// when staking is queried
+	_, err := q.HandleQuery(ctx, caller, wasmvmtypes.QueryRequest{
+		Staking: &wasmvmtypes.StakingQuery{BondedDenom: &struct{}{}},
+	})
+	// then
+	assert.True(t, types.ErrUnknownMsg.Is(err), err)
+
+	// and other plugins still work
+	bz, err := q.HandleQuery(ctx, caller, wasmvmtypes.QueryRequest{
+		Bank: &wasmvmtypes.BankQuery{AllBalances: &wasmvmtypes.AllBalancesQuery{Address: caller.String()}},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, bz)
+
+	// and unknown plugin names are rejected
+	_, err = QueryPlugins{}.Disable("unknown")
+	assert.True(t, types.ErrInvalid.Is(err), err)
+}
+
 func TestContractInfoWasmQuerier(t *testing.T) {
 	var myValidContractAddr = RandomBech32AccountAddress(t)
 	var myCreatorAddr = RandomBech32AccountAddress(t)
@@ -480,6 +1360,9 @@ func (m mockWasmQueryKeeper) IsPinnedCode(ctx sdk.Context, codeID uint64) bool {
 type bankKeeperMock struct {
 	GetBalanceFn     func(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin
 	GetAllBalancesFn func(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
+	GetSupplyFn      func(ctx sdk.Context, denom string) sdk.Coin
+
+	IterateAccountBalancesFn func(ctx sdk.Context, addr sdk.AccAddress, cb func(sdk.Coin) bool)
 }
 
 func (m bankKeeperMock) GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin {
@@ -495,3 +1378,17 @@ func (m bankKeeperMock) GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) sdk
 	}
 	return m.GetAllBalancesFn(ctx, addr)
 }
+
+func (m bankKeeperMock) GetSupply(ctx sdk.Context, denom string) sdk.Coin {
+	if m.GetSupplyFn == nil {
+		panic("not expected to be called")
+	}
+	return m.GetSupplyFn(ctx, denom)
+}
+
+func (m bankKeeperMock) IterateAccountBalances(ctx sdk.Context, addr sdk.AccAddress, cb func(sdk.Coin) bool) {
+	if m.IterateAccountBalancesFn == nil {
+		panic("not expected to be called")
+	}
+	m.IterateAccountBalancesFn(ctx, addr, cb)
+}
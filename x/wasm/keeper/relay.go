@@ -25,12 +25,16 @@ func (k Keeper) OnOpenChannel(
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-open-channel")
 
-	_, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
 	}
+	if contractInfo.Terminated {
+		return sdkerrors.Wrap(types.ErrContractTerminated, contractAddr.String())
+	}
 
 	env := types.NewEnv(ctx, contractAddr)
+	resetQueryCache(ctx)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
@@ -60,8 +64,12 @@ func (k Keeper) OnConnectChannel(
 	if err != nil {
 		return err
 	}
+	if contractInfo.Terminated {
+		return sdkerrors.Wrap(types.ErrContractTerminated, contractAddr.String())
+	}
 
 	env := types.NewEnv(ctx, contractAddr)
+	resetQueryCache(ctx)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
@@ -91,8 +99,12 @@ func (k Keeper) OnCloseChannel(
 	if err != nil {
 		return err
 	}
+	if contractInfo.Terminated {
+		return sdkerrors.Wrap(types.ErrContractTerminated, contractAddr.String())
+	}
 
 	params := types.NewEnv(ctx, contractAddr)
+	resetQueryCache(ctx)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
@@ -121,8 +133,12 @@ func (k Keeper) OnRecvPacket(
 	if err != nil {
 		return nil, err
 	}
+	if contractInfo.Terminated {
+		return nil, sdkerrors.Wrap(types.ErrContractTerminated, contractAddr.String())
+	}
 
 	env := types.NewEnv(ctx, contractAddr)
+	resetQueryCache(ctx)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
@@ -152,8 +168,12 @@ func (k Keeper) OnAckPacket(
 	if err != nil {
 		return err
 	}
+	if contractInfo.Terminated {
+		return sdkerrors.Wrap(types.ErrContractTerminated, contractAddr.String())
+	}
 
 	env := types.NewEnv(ctx, contractAddr)
+	resetQueryCache(ctx)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
@@ -179,8 +199,12 @@ func (k Keeper) OnTimeoutPacket(
 	if err != nil {
 		return err
 	}
+	if contractInfo.Terminated {
+		return sdkerrors.Wrap(types.ErrContractTerminated, contractAddr.String())
+	}
 
 	env := types.NewEnv(ctx, contractAddr)
+	resetQueryCache(ctx)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	gas := k.runtimeGasForContract(ctx)
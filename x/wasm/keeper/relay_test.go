@@ -9,6 +9,7 @@ import (
 	wasmvm "github.com/CosmWasm/wasmvm"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -701,3 +702,71 @@ func stripTypes(events sdk.Events) []string {
 	}
 	return r
 }
+
+func TestTrackInFlightPackets(t *testing.T) {
+	messenger := &wasmtesting.MockMessageHandler{
+		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+			return []sdk.Event{sdk.NewEvent(channeltypes.EventTypeSendPacket,
+				sdk.NewAttribute(channeltypes.AttributeKeySrcPort, "transfer"),
+				sdk.NewAttribute(channeltypes.AttributeKeySrcChannel, "channel-0"),
+				sdk.NewAttribute(channeltypes.AttributeKeySequence, "7"),
+			)}, nil, nil
+		},
+	}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithMessageHandler(messenger))
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	var contractMsg wasmvmtypes.CosmosMsg
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		return &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{{ReplyOn: wasmvmtypes.ReplyNever, Msg: contractMsg}}}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	q := Querier(keepers.WasmKeeper)
+	queryPackets := func(ctx sdk.Context) []types.InFlightPacket {
+		t.Helper()
+		rsp, err := q.InFlightPackets(sdk.WrapSDKContext(ctx), &types.QueryInFlightPacketsRequest{Address: example.Contract.String()})
+		require.NoError(t, err)
+		return rsp.Packets
+	}
+
+	specs := map[string]struct {
+		srcMsg     wasmvmtypes.CosmosMsg
+		expPackets []types.InFlightPacket
+	}{
+		"ibc transfer": {
+			srcMsg: wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{Transfer: &wasmvmtypes.TransferMsg{
+				ChannelID: "channel-0",
+				ToAddress: "myRecipient",
+				Amount:    wasmvmtypes.NewCoin(1, "denom"),
+				Timeout:   wasmvmtypes.IBCTimeout{Timestamp: 1},
+			}}},
+			expPackets: []types.InFlightPacket{{SourcePort: "transfer", SourceChannel: "channel-0", Sequence: 7}},
+		},
+		"raw ibc packet": {
+			srcMsg: wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &wasmvmtypes.SendPacketMsg{
+				ChannelID: "channel-0",
+				Data:      []byte("myData"),
+				Timeout:   wasmvmtypes.IBCTimeout{Timestamp: 1},
+			}}},
+			expPackets: []types.InFlightPacket{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			contractMsg = spec.srcMsg
+
+			// when
+			_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, spec.expPackets, queryPackets(ctx))
+
+			// and when acknowledged
+			keepers.WasmKeeper.RemoveInFlightPacket(ctx, "transfer", "channel-0", 7)
+			// then
+			assert.Empty(t, queryPackets(ctx))
+		})
+	}
+}
@@ -649,6 +649,192 @@ func TestQueryStakingPlugin(t *testing.T) {
 	require.Equal(t, origReward, finalReward)
 }
 
+func TestChainQuerierDelegationRewards(t *testing.T) {
+	initInfo := initializeStaking(t)
+	ctx, valAddr, contractAddr := initInfo.ctx, initInfo.valAddr, initInfo.contractAddr
+	stakingKeeper, distKeeper := initInfo.stakingKeeper, initInfo.distKeeper
+
+	funds := sdk.NewCoins(sdk.NewInt64Coin("stake", 200000))
+	bob := createFakeFundedAccount(t, ctx, initInfo.accKeeper, initInfo.bankKeeper, funds)
+	bondBz, err := json.Marshal(StakingHandleMsg{Bond: &struct{}{}})
+	require.NoError(t, err)
+	_, err = initInfo.contractKeeper.Execute(ctx, contractAddr, bob, bondBz, funds)
+	require.NoError(t, err)
+
+	// rewards accrue over some blocks
+	for i := 0; i < 3; i++ {
+		ctx = nextBlock(ctx, stakingKeeper)
+		// we get 1/6, our share should be 40k minus 10% commission = 36k per block
+		setValidatorRewards(ctx, stakingKeeper, distKeeper, valAddr, "240000")
+	}
+	origReward := distKeeper.GetValidatorCurrentRewards(ctx, valAddr)
+
+	q := ChainQuerier(initInfo.wasmKeeper, nil)
+	query := wasmtypes.ChainQuery{DelegationRewards: &wasmtypes.DelegationRewardsQuery{Validator: valAddr.String()}}
+	specs := map[string]struct {
+		caller     sdk.AccAddress
+		expRewards []wasmtypes.DecCoin
+	}{
+		"delegation with rewards": {
+			caller:     contractAddr,
+			expRewards: []wasmtypes.DecCoin{{Denom: "stake", Amount: "108000.000000000000000000"}},
+		},
+		"no delegation": {
+			caller:     bob,
+			expRewards: []wasmtypes.DecCoin{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			raw, err := q(ctx, spec.caller, &query)
+			require.NoError(t, err)
+			var res wasmtypes.DelegationRewardsResponse
+			mustParse(t, raw, &res)
+			assert.Equal(t, spec.expRewards, res.Rewards)
+		})
+	}
+
+	// invalid validator address
+	_, err = q(ctx, contractAddr, &wasmtypes.ChainQuery{DelegationRewards: &wasmtypes.DelegationRewardsQuery{Validator: "invalid"}})
+	require.Error(t, err)
+
+	// ensure rewards did not change when querying (neither amount nor period)
+	require.Equal(t, origReward, distKeeper.GetValidatorCurrentRewards(ctx, valAddr))
+}
+
+func TestChainQuerierSupplyAndStakingPool(t *testing.T) {
+	initInfo := initializeStaking(t)
+	ctx, contractAddr := initInfo.ctx, initInfo.contractAddr
+	stakingKeeper, bankKeeper := initInfo.stakingKeeper, initInfo.bankKeeper
+
+	funds := sdk.NewCoins(sdk.NewInt64Coin("stake", 200000))
+	bob := createFakeFundedAccount(t, ctx, initInfo.accKeeper, bankKeeper, funds)
+	bondBz, err := json.Marshal(StakingHandleMsg{Bond: &struct{}{}})
+	require.NoError(t, err)
+	_, err = initInfo.contractKeeper.Execute(ctx, contractAddr, bob, bondBz, funds)
+	require.NoError(t, err)
+
+	q := ChainQuerier(initInfo.wasmKeeper, nil)
+
+	// total supply matches the bank keeper
+	raw, err := q(ctx, contractAddr, &wasmtypes.ChainQuery{TotalSupply: &wasmtypes.TotalSupplyQuery{Denom: "stake"}})
+	require.NoError(t, err)
+	var supplyRes wasmtypes.TotalSupplyResponse
+	mustParse(t, raw, &supplyRes)
+	expSupply := bankKeeper.GetSupply(ctx, "stake")
+	assert.Equal(t, wasmvmtypes.Coin{Denom: "stake", Amount: expSupply.Amount.String()}, supplyRes.Amount)
+
+	// unknown denoms have no supply
+	raw, err = q(ctx, contractAddr, &wasmtypes.ChainQuery{TotalSupply: &wasmtypes.TotalSupplyQuery{Denom: "unknown"}})
+	require.NoError(t, err)
+	mustParse(t, raw, &supplyRes)
+	assert.Equal(t, wasmvmtypes.Coin{Denom: "unknown", Amount: "0"}, supplyRes.Amount)
+
+	// staking pool matches the staking keeper
+	raw, err = q(ctx, contractAddr, &wasmtypes.ChainQuery{StakingPool: &wasmtypes.StakingPoolQuery{}})
+	require.NoError(t, err)
+	var poolRes wasmtypes.StakingPoolResponse
+	mustParse(t, raw, &poolRes)
+	expPool, err := stakingkeeper.Querier{Keeper: stakingKeeper}.Pool(sdk.WrapSDKContext(ctx), &stakingtypes.QueryPoolRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, wasmvmtypes.Coin{Denom: "stake", Amount: expPool.Pool.BondedTokens.String()}, poolRes.BondedTokens)
+	assert.Equal(t, wasmvmtypes.Coin{Denom: "stake", Amount: expPool.Pool.NotBondedTokens.String()}, poolRes.NotBondedTokens)
+	assert.Equal(t, stakingKeeper.TotalBondedTokens(ctx).String(), poolRes.BondedTokens.Amount)
+}
+
+func TestChainQuerierValidator(t *testing.T) {
+	initInfo := initializeStaking(t)
+	ctx, bondedVal := initInfo.ctx, initInfo.valAddr
+	stakingKeeper, accKeeper, bankKeeper := initInfo.stakingKeeper, initInfo.accKeeper, initInfo.bankKeeper
+
+	jailedVal := addValidator(t, ctx, stakingKeeper, accKeeper, bankKeeper, sdk.NewInt64Coin("stake", 1000000))
+	ctx = nextBlock(ctx, stakingKeeper)
+	v, found := stakingKeeper.GetValidator(ctx, jailedVal)
+	require.True(t, found)
+	consAddr, err := v.GetConsAddr()
+	require.NoError(t, err)
+	stakingKeeper.Jail(ctx, consAddr)
+	ctx = nextBlock(ctx, stakingKeeper)
+
+	unbondedVal := addValidator(t, ctx, stakingKeeper, accKeeper, bankKeeper, sdk.NewInt64Coin("stake", 1000000))
+
+	q := ChainQuerier(initInfo.wasmKeeper, nil)
+	specs := map[string]struct {
+		address string
+		exp     *wasmtypes.ValidatorInfo
+		expErr  bool
+	}{
+		"bonded": {
+			address: bondedVal.String(),
+			exp:     &wasmtypes.ValidatorInfo{Address: bondedVal.String(), Commission: "0.100000000000000000", Status: "BOND_STATUS_BONDED"},
+		},
+		"unbonded": {
+			address: unbondedVal.String(),
+			exp:     &wasmtypes.ValidatorInfo{Address: unbondedVal.String(), Commission: "0.100000000000000000", Status: "BOND_STATUS_UNBONDED"},
+		},
+		"jailed": {
+			address: jailedVal.String(),
+			exp:     &wasmtypes.ValidatorInfo{Address: jailedVal.String(), Commission: "0.100000000000000000", Jailed: true, Status: "BOND_STATUS_UNBONDING"},
+		},
+		"unknown": {
+			address: sdk.ValAddress(RandomAccountAddress(t)).String(),
+		},
+		"invalid address": {
+			address: "invalid",
+			expErr:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			raw, err := q(ctx, initInfo.contractAddr, &wasmtypes.ChainQuery{Validator: &wasmtypes.ValidatorQuery{Address: spec.address}})
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var res wasmtypes.ValidatorResponse
+			mustParse(t, raw, &res)
+			assert.Equal(t, spec.exp, res.Validator)
+		})
+	}
+}
+
+func TestChainQuerierActiveValidatorSet(t *testing.T) {
+	initInfo := initializeStaking(t)
+	ctx := initInfo.ctx
+	stakingKeeper, accKeeper, bankKeeper := initInfo.stakingKeeper, initInfo.accKeeper, initInfo.bankKeeper
+	q := ChainQuerier(initInfo.wasmKeeper, nil)
+
+	assertActiveSet := func(t *testing.T, ctx sdk.Context, expCount uint32) {
+		t.Helper()
+		raw, err := q(ctx, initInfo.contractAddr, &wasmtypes.ChainQuery{ActiveValidatorSet: &wasmtypes.ActiveValidatorSetQuery{}})
+		require.NoError(t, err)
+		var res wasmtypes.ActiveValidatorSetResponse
+		mustParse(t, raw, &res)
+		assert.Equal(t, expCount, res.BondedCount)
+		assert.Equal(t, uint32(len(stakingKeeper.GetLastValidators(ctx))), res.BondedCount)
+		assert.Equal(t, stakingKeeper.MaxValidators(ctx), res.MaxValidators)
+	}
+	assertActiveSet(t, ctx, 1)
+
+	// when a validator joins
+	newVal := addValidator(t, ctx, stakingKeeper, accKeeper, bankKeeper, sdk.NewInt64Coin("stake", 1000000))
+	// then it is counted once the set was committed
+	assertActiveSet(t, ctx, 1)
+	ctx = nextBlock(ctx, stakingKeeper)
+	assertActiveSet(t, ctx, 2)
+
+	// when the validator leaves
+	v, found := stakingKeeper.GetValidator(ctx, newVal)
+	require.True(t, found)
+	consAddr, err := v.GetConsAddr()
+	require.NoError(t, err)
+	stakingKeeper.Jail(ctx, consAddr)
+	ctx = nextBlock(ctx, stakingKeeper)
+	// then
+	assertActiveSet(t, ctx, 1)
+}
+
 // adds a few validators and returns a list of validators that are registered
 func addValidator(t *testing.T, ctx sdk.Context, stakingKeeper stakingkeeper.Keeper, accountKeeper authkeeper.AccountKeeper, bankKeeper bankkeeper.Keeper, value sdk.Coin) sdk.ValAddress {
 	owner := createFakeFundedAccount(t, ctx, accountKeeper, bankKeeper, sdk.Coins{value})
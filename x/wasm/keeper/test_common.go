@@ -21,6 +21,7 @@ import (
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	"github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	authzmodule "github.com/cosmos/cosmos-sdk/x/authz/module"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
@@ -34,6 +35,7 @@ import (
 	distributionkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
 	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	"github.com/cosmos/cosmos-sdk/x/evidence"
+	feegrantmodule "github.com/cosmos/cosmos-sdk/x/feegrant/module"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	govkeeper "github.com/cosmos/cosmos-sdk/x/gov/keeper"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
@@ -80,11 +82,13 @@ type TestingT interface {
 
 var ModuleBasics = module.NewBasicManager(
 	auth.AppModuleBasic{},
+	authzmodule.AppModuleBasic{},
 	bank.AppModuleBasic{},
 	capability.AppModuleBasic{},
 	staking.AppModuleBasic{},
 	mint.AppModuleBasic{},
 	distribution.AppModuleBasic{},
+	feegrantmodule.AppModuleBasic{},
 	gov.NewAppModuleBasic(
 		paramsclient.ProposalHandler, distrclient.ProposalHandler, upgradeclient.ProposalHandler,
 	),
@@ -184,17 +188,17 @@ func createTestInput(
 	keyCapabilityTransient := storetypes.NewMemoryStoreKey(capabilitytypes.MemStoreKey)
 
 	ms := store.NewCommitMultiStore(db)
-	ms.MountStoreWithDB(keyWasm, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyBank, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyDistro, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
-	ms.MountStoreWithDB(keyGov, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyCapability, sdk.StoreTypeIAVL, db)
-	ms.MountStoreWithDB(keyCapabilityTransient, sdk.StoreTypeMemory, db)
+	ms.MountStoreWithDB(keyWasm, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyBank, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyDistro, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, nil)
+	ms.MountStoreWithDB(keyGov, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyCapability, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyCapabilityTransient, sdk.StoreTypeMemory, nil)
 	require.NoError(t, ms.LoadLatestVersion())
 
 	ctx := sdk.NewContext(ms, tmproto.Header{
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"encoding/json"
+	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	fuzz "github.com/google/gofuzz"
@@ -10,7 +11,7 @@ import (
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
-var ModelFuzzers = []interface{}{FuzzAddr, FuzzAddrString, FuzzAbsoluteTxPosition, FuzzContractInfo, FuzzStateModel, FuzzAccessType, FuzzAccessConfig, FuzzContractCodeHistory}
+var ModelFuzzers = []interface{}{FuzzAddr, FuzzAddrString, FuzzAbsoluteTxPosition, FuzzContractInfo, FuzzStateModel, FuzzAccessType, FuzzAccessConfig, FuzzContractCodeHistory, FuzzParams, FuzzInFlightPacket}
 
 func FuzzAddr(m *sdk.AccAddress, c fuzz.Continue) {
 	*m = make([]byte, 20)
@@ -72,3 +73,23 @@ func FuzzAccessConfig(m *types.AccessConfig, c fuzz.Continue) {
 	FuzzAddr(&add, c)
 	*m = m.Permission.With(add)
 }
+
+// FuzzParams fuzzes the params and fixes the values that are constrained by the param validation
+func FuzzParams(m *types.Params, c fuzz.Continue) {
+	c.FuzzNoCustom(m)
+	FuzzAccessConfig(&m.CodeUploadAccess, c)
+	FuzzAccessType(&m.InstantiateDefaultPermission, c)
+	m.CodeChecksumAllowlist = make([][]byte, c.Intn(3))
+	for i := range m.CodeChecksumAllowlist {
+		m.CodeChecksumAllowlist[i] = make([]byte, types.ChecksumLength)
+		c.Read(m.CodeChecksumAllowlist[i])
+	}
+	m.MaxGasPerTxPercent %= 101
+	m.MaxGasRefundPercent %= 101
+}
+
+func FuzzInFlightPacket(m *types.InFlightPacket, c fuzz.Continue) {
+	m.SourcePort = "transfer"
+	m.SourceChannel = fmt.Sprintf("channel-%d", c.Intn(10))
+	m.Sequence = c.RandUint64() | 1
+}
@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// setFundsTimelock moves the funds from the depositor into the module account where they are held until they are
+// released to the contract with releaseTimelockedFunds. The funds can be released from the unlock height and
+// unlock time (unix nanoseconds) on. Zero disables the restriction but at least one must be set.
+// The depositor must have authorized the transfer. Returns the id of the lock.
+func (k Keeper) setFundsTimelock(ctx sdk.Context, contractAddr, depositor sdk.AccAddress, amount sdk.Coins, unlockHeight int64, unlockTime uint64) (uint64, error) {
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return 0, sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	lock := types.TimelockedFunds{
+		ID:           k.PeekAutoIncrementID(ctx, types.KeyLastTimelockID),
+		Depositor:    depositor.String(),
+		Amount:       amount,
+		UnlockHeight: unlockHeight,
+		UnlockTime:   unlockTime,
+	}
+	if err := lock.ValidateBasic(); err != nil {
+		return 0, err
+	}
+	if err := k.bankKeeper.IsSendEnabledCoins(ctx, amount...); err != nil {
+		return 0, err
+	}
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, depositor, types.ModuleName, amount); err != nil {
+		return 0, sdkerrors.Wrap(err, "lock funds")
+	}
+	lock.ID = k.autoIncrementID(ctx, types.KeyLastTimelockID)
+	k.storeTimelockedFunds(ctx, contractAddr, lock)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeTimelockFunds,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyLockID, strconv.FormatUint(lock.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyDepositor, lock.Depositor),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+	))
+	return lock.ID, nil
+}
+
+// releaseTimelockedFunds sends the timelocked funds from the module account to the contract. Fails with
+// ErrFundsLocked before the unlock height or time of the lock is reached.
+func (k Keeper) releaseTimelockedFunds(ctx sdk.Context, contractAddr sdk.AccAddress, lockID uint64) error {
+	lock, found := k.GetTimelockedFunds(ctx, contractAddr, lockID)
+	if !found {
+		return sdkerrors.Wrap(types.ErrNotFound, "timelocked funds")
+	}
+	if !lock.IsUnlocked(ctx.BlockHeight(), ctx.BlockTime()) {
+		return sdkerrors.Wrapf(types.ErrFundsLocked, "unlock height: %d, unlock time: %d", lock.UnlockHeight, lock.UnlockTime)
+	}
+	ctx.KVStore(k.storeKey).Delete(types.GetTimelockedFundsKey(contractAddr, lockID))
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, contractAddr, lock.Amount); err != nil {
+		return sdkerrors.Wrap(err, "release funds")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeReleaseTimelockedFunds,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyLockID, strconv.FormatUint(lockID, 10)),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, lock.Amount.String()),
+	))
+	return nil
+}
+
+// GetTimelockedFunds returns the timelocked funds of the contract with the given lock id
+func (k Keeper) GetTimelockedFunds(ctx sdk.Context, contractAddr sdk.AccAddress, lockID uint64) (types.TimelockedFunds, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetTimelockedFundsKey(contractAddr, lockID))
+	if bz == nil {
+		return types.TimelockedFunds{}, false
+	}
+	var lock types.TimelockedFunds
+	k.cdc.MustUnmarshal(bz, &lock)
+	return lock, true
+}
+
+// IterateTimelockedFunds iterates over the pending timelocked funds of the contract ordered by lock id
+func (k Keeper) IterateTimelockedFunds(ctx sdk.Context, contractAddr sdk.AccAddress, cb func(types.TimelockedFunds) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetTimelockedFundsPrefix(contractAddr))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var lock types.TimelockedFunds
+		k.cdc.MustUnmarshal(iter.Value(), &lock)
+		if cb(lock) {
+			return
+		}
+	}
+}
+
+func (k Keeper) storeTimelockedFunds(ctx sdk.Context, contractAddr sdk.AccAddress, lock types.TimelockedFunds) {
+	ctx.KVStore(k.storeKey).Set(types.GetTimelockedFundsKey(contractAddr, lock.ID), k.cdc.MustMarshal(&lock))
+}
+
+func (k Keeper) importTimelockedFunds(ctx sdk.Context, contractAddr sdk.AccAddress, locks []types.TimelockedFunds) error {
+	store := ctx.KVStore(k.storeKey)
+	for _, lock := range locks {
+		if store.Has(types.GetTimelockedFundsKey(contractAddr, lock.ID)) {
+			return sdkerrors.Wrapf(types.ErrDuplicate, "timelocked funds: %d", lock.ID)
+		}
+		k.storeTimelockedFunds(ctx, contractAddr, lock)
+	}
+	return nil
+}
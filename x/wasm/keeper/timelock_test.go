@@ -0,0 +1,140 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestTimelockedFunds(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	k := keepers.WasmKeeper
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	depositor := createFakeFundedAccount(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, deposit.Add(deposit...))
+
+	blockTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx = ctx.WithBlockHeight(10).WithBlockTime(blockTime)
+
+	// when
+	heightLockID, err := k.setFundsTimelock(ctx, example.Contract, depositor, deposit, 20, 0)
+	require.NoError(t, err)
+	timeLockID, err := k.setFundsTimelock(ctx, example.Contract, depositor, deposit, 0, uint64(blockTime.Add(time.Hour).UnixNano()))
+	require.NoError(t, err)
+
+	// then funds are held by the module account
+	assert.Equal(t, uint64(1), heightLockID)
+	assert.Equal(t, uint64(2), timeLockID)
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, depositor).IsZero())
+	assert.Equal(t, deposit.Add(deposit...), keepers.BankKeeper.GetAllBalances(ctx, moduleAddr))
+
+	// and the locks are pending
+	q := Querier(k)
+	rsp, err := q.TimelockedFunds(sdk.WrapSDKContext(ctx), &types.QueryTimelockedFundsRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	require.Len(t, rsp.Locks, 2)
+	assert.Equal(t, types.TimelockedFunds{ID: 1, Depositor: depositor.String(), Amount: deposit, UnlockHeight: 20}, rsp.Locks[0])
+
+	// when released before unlock
+	err = k.releaseTimelockedFunds(ctx.WithBlockHeight(19), example.Contract, heightLockID)
+	assert.True(t, types.ErrFundsLocked.Is(err), err)
+	err = k.releaseTimelockedFunds(ctx.WithBlockHeight(100).WithBlockTime(blockTime.Add(time.Hour-time.Nanosecond)), example.Contract, timeLockID)
+	assert.True(t, types.ErrFundsLocked.Is(err), err)
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, example.Contract).IsZero())
+
+	// when released after unlock
+	err = k.releaseTimelockedFunds(ctx.WithBlockHeight(20), example.Contract, heightLockID)
+	require.NoError(t, err)
+	assert.Equal(t, deposit, keepers.BankKeeper.GetAllBalances(ctx, example.Contract))
+	err = k.releaseTimelockedFunds(ctx.WithBlockTime(blockTime.Add(time.Hour)), example.Contract, timeLockID)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, deposit.Add(deposit...), keepers.BankKeeper.GetAllBalances(ctx, example.Contract))
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, moduleAddr).IsZero())
+	rsp, err = q.TimelockedFunds(sdk.WrapSDKContext(ctx), &types.QueryTimelockedFundsRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	assert.Empty(t, rsp.Locks)
+
+	// and can not be released twice
+	err = k.releaseTimelockedFunds(ctx.WithBlockHeight(20), example.Contract, heightLockID)
+	assert.True(t, types.ErrNotFound.Is(err), err)
+}
+
+func TestExecuteWithTimelockedFunds(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	var mock wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mock)
+	var capturedFunds []wasmvmtypes.Coin
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+		capturedFunds = info.Funds
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	msgServer := NewMsgServerImpl(keepers.ContractKeeper)
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	depositor := createFakeFundedAccount(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, deposit)
+	ctx = ctx.WithBlockHeight(10)
+
+	// when executed with a timelock
+	rsp, err := msgServer.ExecuteContract(sdk.WrapSDKContext(ctx), &types.MsgExecuteContract{
+		Sender:   depositor.String(),
+		Contract: example.Contract.String(),
+		Msg:      []byte(`{}`),
+		Funds:    deposit,
+		Timelock: &types.FundsTimelock{UnlockHeight: 20},
+	})
+
+	// then the funds are held by the module account instead of being sent with the execution
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), rsp.TimelockID)
+	assert.Empty(t, capturedFunds)
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, depositor).IsZero())
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, example.Contract).IsZero())
+	assert.Equal(t, deposit, keepers.BankKeeper.GetAllBalances(ctx, moduleAddr))
+	lock, found := keepers.WasmKeeper.GetTimelockedFunds(ctx, example.Contract, rsp.TimelockID)
+	require.True(t, found)
+	assert.Equal(t, depositor.String(), lock.Depositor)
+
+	// when released before unlock
+	releaseMsg := &types.MsgReleaseTimelockedFunds{
+		Sender:   RandomBech32AccountAddress(t),
+		Contract: example.Contract.String(),
+		LockID:   rsp.TimelockID,
+	}
+	_, err = msgServer.ReleaseTimelockedFunds(sdk.WrapSDKContext(ctx.WithBlockHeight(19)), releaseMsg)
+	assert.True(t, types.ErrFundsLocked.Is(err), err)
+
+	// when released by anybody after unlock
+	_, err = msgServer.ReleaseTimelockedFunds(sdk.WrapSDKContext(ctx.WithBlockHeight(20)), releaseMsg)
+
+	// then the contract receives the funds
+	require.NoError(t, err)
+	assert.Equal(t, deposit, keepers.BankKeeper.GetAllBalances(ctx, example.Contract))
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, moduleAddr).IsZero())
+}
+
+func TestSetFundsTimelockRejectsUnknownContract(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	depositor := createFakeFundedAccount(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, deposit)
+
+	_, err := keepers.WasmKeeper.setFundsTimelock(ctx, RandomAccountAddress(t), depositor, deposit, 1, 0)
+	assert.True(t, types.ErrNotFound.Is(err), err)
+	assert.Equal(t, deposit, keepers.BankKeeper.GetAllBalances(ctx, depositor))
+}
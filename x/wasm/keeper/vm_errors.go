@@ -0,0 +1,91 @@
+package keeper
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// maxVMPanicMsgLen is the max length of a panic message that is returned in an error
+const maxVMPanicMsgLen = 256
+
+// vmPanicError is returned when a wasmVM call panicked for any other reason than out of gas
+type vmPanicError struct {
+	msg string
+}
+
+func (e vmPanicError) Error() string {
+	return "vm panic: " + e.msg
+}
+
+// vmPanicMarkers are contained in the errors returned by the wasmVM when the contract trapped, for example on a
+// panic, or when the VM itself panicked
+var vmPanicMarkers = []string{"Wasmer runtime error:", "Caught panic"}
+
+// callVM executes the given wasmVM call. A contract or VM panic reported by the wasmVM is returned as
+// vmPanicError so that it can not be confused with out of gas or an error returned by the contract.
+// Go panics are not recovered: out of gas is handled by the gas meter owner and any other panic aborts
+// the call like everywhere else in the keeper.
+func callVM(fn func() error) error {
+	err := fn()
+	if err == nil {
+		return nil
+	}
+	for _, m := range vmPanicMarkers {
+		if strings.Contains(err.Error(), m) {
+			return vmPanicError{msg: sanitizeVMPanicMsg(err.Error())}
+		}
+	}
+	return err
+}
+
+// wrapVMError classifies an error returned from a wasmVM call:
+// - out of gas in the VM panics with the SDK out of gas error for the gas meter handling
+// - panics are wrapped into the given error type
+// - errors returned by the contract are wrapped into a types.StructuredError of the given error type with the
+// contract's error message as data, so that a contract that dispatched the call as submessage gets both in the reply
+func wrapVMError(err error, errType *sdkerrors.Error) error {
+	var oog wasmvmtypes.OutOfGasError
+	if errors.As(err, &oog) {
+		panic(sdk.ErrorOutOfGas{Descriptor: "Wasmer function execution"})
+	}
+	var panicErr vmPanicError
+	if errors.As(err, &panicErr) {
+		return sdkerrors.Wrap(errType, err.Error())
+	}
+	return sdkerrors.Wrap(types.NewStructuredError(errType, []byte(err.Error())), err.Error())
+}
+
+// wrapQueryVMError classifies an error returned from a wasmVM query so that clients can tell the failure
+// categories apart:
+// - out of gas in the VM panics with the SDK out of gas error which the query entry points return as sdkerrors.ErrOutOfGas
+// - panics are wrapped into types.ErrVMFailure
+// - errors returned by the contract are wrapped into types.ErrQueryFailed with the contract's error message
+func wrapQueryVMError(err error) error {
+	var panicErr vmPanicError
+	if errors.As(err, &panicErr) {
+		return sdkerrors.Wrap(types.ErrVMFailure, err.Error())
+	}
+	return wrapVMError(err, types.ErrQueryFailed)
+}
+
+// sanitizeVMPanicMsg removes non printable characters from the panic message and truncates it to
+// a max length so that it can be returned to clients.
+func sanitizeVMPanicMsg(msg string) string {
+	msg = strings.Map(func(r rune) rune {
+		if unicode.IsPrint(r) {
+			return r
+		}
+		return -1
+	}, msg)
+	if len(msg) > maxVMPanicMsgLen {
+		return msg[:maxVMPanicMsgLen] + "..."
+	}
+	return msg
+}
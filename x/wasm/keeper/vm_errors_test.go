@@ -0,0 +1,192 @@
+package keeper
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestExecuteVMErrorClassification(t *testing.T) {
+	specs := map[string]struct {
+		doInContract func() error
+		expErr       error
+		expErrMsg    string
+		expReplyData []byte
+		expPanic     interface{}
+	}{
+		"contract error": {
+			doInContract: func() error {
+				return errors.New("my contract error")
+			},
+			expErr:       types.ErrExecuteFailed,
+			expErrMsg:    "my contract error",
+			expReplyData: []byte("my contract error"),
+		},
+		"vm panic": {
+			doInContract: func() error {
+				return errors.New("Error calling the VM: Wasmer runtime error: RuntimeError: unreachable\x00\n")
+			},
+			expErr:    types.ErrExecuteFailed,
+			expErrMsg: "vm panic: Error calling the VM: Wasmer runtime error: RuntimeError: unreachable: execute wasm contract failed",
+		},
+		"go panic": {
+			doInContract: func() error {
+				panic("my panic")
+			},
+			expPanic: "my panic",
+		},
+		"vm out of gas": {
+			doInContract: func() error {
+				return wasmvmtypes.OutOfGasError{}
+			},
+			expPanic: sdk.ErrorOutOfGas{Descriptor: "Wasmer function execution"},
+		},
+		"sdk out of gas": {
+			doInContract: func() error {
+				panic(sdk.ErrorOutOfGas{Descriptor: "testing"})
+			},
+			expPanic: sdk.ErrorOutOfGas{Descriptor: "testing"},
+		},
+	}
+	entryPoints := map[string]func(ctx sdk.Context, keepers TestKeepers, example ExampleContractInstance) error{
+		"execute": func(ctx sdk.Context, keepers TestKeepers, example ExampleContractInstance) error {
+			_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+			return err
+		},
+		"reply": func(ctx sdk.Context, keepers TestKeepers, example ExampleContractInstance) error {
+			_, err := keepers.WasmKeeper.reply(ctx, example.Contract, wasmvmtypes.Reply{})
+			return err
+		},
+	}
+	for name, spec := range specs {
+		for entryPoint, call := range entryPoints {
+			t.Run(entryPoint+" "+name, func(t *testing.T) {
+				ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+				var mock wasmtesting.MockWasmer
+				wasmtesting.MakeInstantiable(&mock)
+				example := SeedNewContractInstance(t, ctx, keepers, &mock)
+				mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+					return nil, 0, spec.doInContract()
+				}
+				mock.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+					return nil, 0, spec.doInContract()
+				}
+				if spec.expPanic != nil {
+					require.PanicsWithValue(t, spec.expPanic, func() {
+						_ = call(ctx, keepers, example)
+					})
+					return
+				}
+				// when
+				err := call(ctx, keepers, example)
+				// then
+				require.True(t, errors.Is(err, spec.expErr), "got %+v", err)
+				assert.Contains(t, err.Error(), spec.expErrMsg)
+				var structured *types.StructuredError
+				if spec.expReplyData == nil {
+					assert.False(t, errors.As(err, &structured))
+					return
+				}
+				require.True(t, errors.As(err, &structured))
+				assert.Equal(t, types.ReplyError{
+					Codespace: types.DefaultCodespace,
+					Code:      types.ErrExecuteFailed.ABCICode(),
+					Data:      spec.expReplyData,
+					Message:   err.Error(),
+				}, structured.ReplyError(err.Error()))
+			})
+		}
+	}
+}
+
+func TestQueryVMErrorClassification(t *testing.T) {
+	specs := map[string]struct {
+		doInContract func() error
+		expErr       *sdkerrors.Error
+		expErrMsg    string
+	}{
+		"contract error": {
+			doInContract: func() error {
+				return errors.New("my contract error")
+			},
+			expErr:    types.ErrQueryFailed,
+			expErrMsg: "my contract error",
+		},
+		"vm panic": {
+			doInContract: func() error {
+				return errors.New("Error calling the VM: Caught panic")
+			},
+			expErr:    types.ErrVMFailure,
+			expErrMsg: "vm panic: Error calling the VM: Caught panic",
+		},
+
+		"vm out of gas": {
+			doInContract: func() error {
+				return wasmvmtypes.OutOfGasError{}
+			},
+			expErr:    sdkerrors.ErrOutOfGas,
+			expErrMsg: "Wasmer function execution",
+		},
+		"sdk out of gas": {
+			doInContract: func() error {
+				panic(sdk.ErrorOutOfGas{Descriptor: "testing"})
+			},
+			expErr:    sdkerrors.ErrOutOfGas,
+			expErrMsg: "testing",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			var mock wasmtesting.MockWasmer
+			wasmtesting.MakeInstantiable(&mock)
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) ([]byte, uint64, error) {
+				return nil, 0, spec.doInContract()
+			}
+			// when
+			_, err := keepers.WasmKeeper.QuerySmartWithGasLimit(ctx, example.Contract, []byte(`{}`), 1_000_000)
+			// then
+			require.True(t, spec.expErr.Is(err), "got %+v", err)
+			assert.Contains(t, err.Error(), spec.expErrMsg)
+			codespace, code, _ := sdkerrors.ABCIInfo(err, false)
+			assert.Equal(t, spec.expErr.Codespace(), codespace)
+			assert.Equal(t, spec.expErr.ABCICode(), code)
+		})
+	}
+}
+
+func TestSanitizeVMPanicMsg(t *testing.T) {
+	specs := map[string]struct {
+		src string
+		exp string
+	}{
+		"printable": {
+			src: "my panic",
+			exp: "my panic",
+		},
+		"non printable removed": {
+			src: "my\x00 panic\n",
+			exp: "my panic",
+		},
+		"truncated": {
+			src: strings.Repeat("a", maxVMPanicMsgLen+1),
+			exp: strings.Repeat("a", maxVMPanicMsgLen) + "...",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, sanitizeVMPanicMsg(spec.src))
+		})
+	}
+}
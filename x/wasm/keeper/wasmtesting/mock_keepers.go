@@ -3,6 +3,7 @@ package wasmtesting
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	connectiontypes "github.com/cosmos/ibc-go/v2/modules/core/03-connection/types"
 	channeltypes "github.com/cosmos/ibc-go/v2/modules/core/04-channel/types"
 	ibcexported "github.com/cosmos/ibc-go/v2/modules/core/exported"
 
@@ -71,6 +72,25 @@ func MockChannelKeeperIterator(s []channeltypes.IdentifiedChannel) func(ctx sdk.
 	}
 }
 
+type MockConnectionKeeper struct {
+	GetConnectionFn            func(ctx sdk.Context, connectionID string) (connectiontypes.ConnectionEnd, bool)
+	GetClientConnectionPathsFn func(ctx sdk.Context, clientID string) ([]string, bool)
+}
+
+func (m MockConnectionKeeper) GetConnection(ctx sdk.Context, connectionID string) (connectiontypes.ConnectionEnd, bool) {
+	if m.GetConnectionFn == nil {
+		panic("not supposed to be called!")
+	}
+	return m.GetConnectionFn(ctx, connectionID)
+}
+
+func (m MockConnectionKeeper) GetClientConnectionPaths(ctx sdk.Context, clientID string) ([]string, bool) {
+	if m.GetClientConnectionPathsFn == nil {
+		panic("not supposed to be called!")
+	}
+	return m.GetClientConnectionPathsFn(ctx, clientID)
+}
+
 type MockCapabilityKeeper struct {
 	GetCapabilityFn          func(ctx sdk.Context, name string) (*capabilitytypes.Capability, bool)
 	ClaimCapabilityFn        func(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error
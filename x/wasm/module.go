@@ -34,9 +34,12 @@ var (
 
 // Module init related flags
 const (
-	flagWasmMemoryCacheSize    = "wasm.memory_cache_size"
-	flagWasmQueryGasLimit      = "wasm.query_gas_limit"
-	flagWasmSimulationGasLimit = "wasm.simulation_gas_limit"
+	flagWasmMemoryCacheSize      = "wasm.memory_cache_size"
+	flagWasmContractMemoryLimit  = "wasm.contract_memory_limit"
+	flagWasmQueryGasLimit        = "wasm.query_gas_limit"
+	flagWasmCheckTxQueryGasLimit = "wasm.check_tx_query_gas_limit"
+	flagWasmSimulationGasLimit   = "wasm.simulation_gas_limit"
+	flagWasmPinnedCodeBudget     = "wasm.pinned_code_memory_budget"
 )
 
 // AppModuleBasic defines the basic application module used by the wasm module.
@@ -117,6 +120,28 @@ func NewAppModule(cdc codec.Codec, keeper *Keeper, validatorSetSource keeper.Val
 func (am AppModule) RegisterServices(cfg module.Configurator) {
 	types.RegisterMsgServer(cfg.MsgServer(), keeper.NewMsgServerImpl(keeper.NewDefaultPermissionKeeper(am.keeper)))
 	types.RegisterQueryServer(cfg.QueryServer(), NewQuerier(am.keeper))
+
+	m := keeper.NewMigrator(*am.keeper)
+	err := cfg.RegisterMigration(types.ModuleName, 1, m.Migrate1to2)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 2, m.Migrate2to3)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 3, m.Migrate3to4)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 4, m.Migrate4to5)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 5, m.Migrate5to6)
+	if err != nil {
+		panic(err)
+	}
 }
 
 func (am AppModule) LegacyQuerierHandler(amino *codec.LegacyAmino) sdk.Querier {
@@ -155,8 +180,12 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 	return cdc.MustMarshalJSON(gs)
 }
 
-// BeginBlock returns the begin blocker for the wasm module.
-func (am AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) {}
+// BeginBlock returns the begin blocker for the wasm module. It updates the average block time and deletes expired
+// code hash commitments.
+func (am AppModule) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) {
+	am.keeper.UpdateBlockTimeAverage(ctx)
+	am.keeper.PruneExpiredCodeCommitments(ctx)
+}
 
 // EndBlock returns the end blocker for the wasm module. It returns no validator
 // updates.
@@ -194,8 +223,11 @@ func (am AppModule) WeightedOperations(simState module.SimulationState) []simtyp
 func AddModuleInitFlags(startCmd *cobra.Command) {
 	defaults := DefaultWasmConfig()
 	startCmd.Flags().Uint32(flagWasmMemoryCacheSize, defaults.MemoryCacheSize, "Sets the size in MiB (NOT bytes) of an in-memory cache for Wasm modules. Set to 0 to disable.")
+	startCmd.Flags().Uint32(flagWasmContractMemoryLimit, defaults.ContractMemoryLimit, "Sets the memory limit in MiB (NOT bytes) of each Wasm contract instance. Should not be lower than on other nodes in the network.")
 	startCmd.Flags().Uint64(flagWasmQueryGasLimit, defaults.SmartQueryGasLimit, "Set the max gas that can be spent on executing a query with a Wasm contract")
+	startCmd.Flags().Uint64(flagWasmCheckTxQueryGasLimit, defaults.SmartQueryCheckTxGasLimit, "Set the max gas that can be spent on executing a query with a Wasm contract sent by a client")
 	startCmd.Flags().String(flagWasmSimulationGasLimit, "", "Set the max gas that can be spent when executing a simulation TX")
+	startCmd.Flags().Uint32(flagWasmPinnedCodeBudget, defaults.PinnedCodeMemoryBudget, "Sets the max size in MiB (NOT bytes) of the Wasm codes pinned in memory. Set to 0 to disable.")
 }
 
 // ReadWasmConfig reads the wasm specifig configuration
@@ -207,11 +239,26 @@ func ReadWasmConfig(opts servertypes.AppOptions) (types.WasmConfig, error) {
 			return cfg, err
 		}
 	}
+	if v := opts.Get(flagWasmContractMemoryLimit); v != nil {
+		if cfg.ContractMemoryLimit, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
 	if v := opts.Get(flagWasmQueryGasLimit); v != nil {
 		if cfg.SmartQueryGasLimit, err = cast.ToUint64E(v); err != nil {
 			return cfg, err
 		}
 	}
+	if v := opts.Get(flagWasmCheckTxQueryGasLimit); v != nil {
+		if cfg.SmartQueryCheckTxGasLimit, err = cast.ToUint64E(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmPinnedCodeBudget); v != nil {
+		if cfg.PinnedCodeMemoryBudget, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
 	if v := opts.Get(flagWasmSimulationGasLimit); v != nil {
 		if raw, ok := v.(string); ok && raw != "" {
 			limit, err := cast.ToUint64E(v) // non empty string set
@@ -227,8 +274,8 @@ func ReadWasmConfig(opts servertypes.AppOptions) (types.WasmConfig, error) {
 			return cfg, err
 		}
 	}
-	return cfg, nil
+	return cfg, cfg.ValidateBasic()
 }
 
 // ConsensusVersion implements AppModule/ConsensusVersion.
-func (AppModule) ConsensusVersion() uint64 { return 1 }
+func (AppModule) ConsensusVersion() uint64 { return 6 }
@@ -301,6 +301,7 @@ func TestHandleExecute(t *testing.T) {
 	assertAttribute(t, "recipient", bob.String(), res.Events[5].Attributes[0])
 	assertAttribute(t, "sender", contractBech32Addr, res.Events[5].Attributes[1])
 	assertAttribute(t, "amount", "105000denom", res.Events[5].Attributes[2])
+	assertAttribute(t, "_contract_address", contractBech32Addr, res.Events[5].Attributes[3])
 	// finally, standard x/wasm tag
 
 	// ensure bob now exists and got both payments released
@@ -397,16 +398,30 @@ func TestHandleExecuteEscrow(t *testing.T) {
 func TestReadWasmConfig(t *testing.T) {
 	defaults := DefaultWasmConfig()
 	specs := map[string]struct {
-		src AppOptionsMock
-		exp types.WasmConfig
+		src    AppOptionsMock
+		exp    types.WasmConfig
+		expErr bool
 	}{
 		"set query gas limit via opts": {
 			src: AppOptionsMock{
 				"wasm.query_gas_limit": 1,
 			},
 			exp: types.WasmConfig{
-				SmartQueryGasLimit: 1,
-				MemoryCacheSize:    defaults.MemoryCacheSize,
+				SmartQueryGasLimit:        1,
+				SmartQueryCheckTxGasLimit: defaults.SmartQueryCheckTxGasLimit,
+				MemoryCacheSize:           defaults.MemoryCacheSize,
+				ContractMemoryLimit:       defaults.ContractMemoryLimit,
+			},
+		},
+		"set check tx query gas limit via opts": {
+			src: AppOptionsMock{
+				"wasm.check_tx_query_gas_limit": 2,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit:        defaults.SmartQueryGasLimit,
+				SmartQueryCheckTxGasLimit: 2,
+				MemoryCacheSize:           defaults.MemoryCacheSize,
+				ContractMemoryLimit:       defaults.ContractMemoryLimit,
 			},
 		},
 		"set cache via opts": {
@@ -414,18 +429,51 @@ func TestReadWasmConfig(t *testing.T) {
 				"wasm.memory_cache_size": 2,
 			},
 			exp: types.WasmConfig{
-				MemoryCacheSize:    2,
-				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:           2,
+				SmartQueryGasLimit:        defaults.SmartQueryGasLimit,
+				SmartQueryCheckTxGasLimit: defaults.SmartQueryCheckTxGasLimit,
+				ContractMemoryLimit:       defaults.ContractMemoryLimit,
+			},
+		},
+		"set contract memory limit via opts": {
+			src: AppOptionsMock{
+				"wasm.contract_memory_limit": 64,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit:        defaults.SmartQueryGasLimit,
+				SmartQueryCheckTxGasLimit: defaults.SmartQueryCheckTxGasLimit,
+				MemoryCacheSize:           defaults.MemoryCacheSize,
+				ContractMemoryLimit:       64,
 			},
 		},
+		"set pinned code memory budget via opts": {
+			src: AppOptionsMock{
+				"wasm.pinned_code_memory_budget": 512,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit:        defaults.SmartQueryGasLimit,
+				SmartQueryCheckTxGasLimit: defaults.SmartQueryCheckTxGasLimit,
+				MemoryCacheSize:           defaults.MemoryCacheSize,
+				ContractMemoryLimit:       defaults.ContractMemoryLimit,
+				PinnedCodeMemoryBudget:    512,
+			},
+		},
+		"contract memory limit below min": {
+			src: AppOptionsMock{
+				"wasm.contract_memory_limit": types.MinContractMemoryLimit - 1,
+			},
+			expErr: true,
+		},
 		"set debug via opts": {
 			src: AppOptionsMock{
 				"trace": true,
 			},
 			exp: types.WasmConfig{
-				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
-				MemoryCacheSize:    defaults.MemoryCacheSize,
-				ContractDebugMode:  true,
+				SmartQueryGasLimit:        defaults.SmartQueryGasLimit,
+				SmartQueryCheckTxGasLimit: defaults.SmartQueryCheckTxGasLimit,
+				MemoryCacheSize:           defaults.MemoryCacheSize,
+				ContractMemoryLimit:       defaults.ContractMemoryLimit,
+				ContractDebugMode:         true,
 			},
 		},
 		"all defaults when no options set": {
@@ -435,6 +483,10 @@ func TestReadWasmConfig(t *testing.T) {
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
 			got, err := ReadWasmConfig(spec.src)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
 			require.NoError(t, err)
 			assert.Equal(t, spec.exp, got)
 		})
@@ -33,6 +33,76 @@ func ParamChanges(r *rand.Rand, cdc codec.Codec) []simtypes.ParamChange {
 				return fmt.Sprintf(`"%d"`, params.MaxWasmCodeSize)
 			},
 		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMaxMsgSize),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf(`"%d"`, params.MaxMsgSize)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyInstanceCost),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf(`"%d"`, params.InstanceCost)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyCompileCost),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf(`"%d"`, params.CompileCost)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMaxContractResponseDataSize),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf(`"%d"`, params.MaxContractResponseDataSize)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyQueryCacheEnabled),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%t", params.QueryCacheEnabled)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMaxGasPerTxPercent),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%d", params.MaxGasPerTxPercent)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMaxCodeCount),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf(`"%d"`, params.MaxCodeCount)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMaxGasRefundPercent),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%d", params.MaxGasRefundPercent)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyAllowContractInstantiation),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%t", params.AllowContractInstantiation)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMaxReplyGas),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf(`"%d"`, params.MaxReplyGas)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMaxIteratorItems),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf(`"%d"`, params.MaxIteratorItems)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyBlockTimeAverageWindow),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%d", params.BlockTimeAverageWindow)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyDeduplicateCodeUploads),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%t", params.DeduplicateCodeUploads)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyCodeCommitmentWindow),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%d", params.CodeCommitmentWindow)
+			},
+		),
 	}
 }
 
@@ -44,5 +114,18 @@ func RandomParams(r *rand.Rand) types.Params {
 		CodeUploadAccess:             accessConfig,
 		InstantiateDefaultPermission: accessConfig.Permission,
 		MaxWasmCodeSize:              uint64(simtypes.RandIntBetween(r, 1, 600) * 1024),
+		MaxMsgSize:                   uint64(simtypes.RandIntBetween(r, 64, 1024) * 1024),
+		InstanceCost:                 uint64(simtypes.RandIntBetween(r, 1, 100) * 1000),
+		CompileCost:                  uint64(simtypes.RandIntBetween(r, 1, 10)),
+		MaxContractResponseDataSize:  uint64(simtypes.RandIntBetween(r, 64, 1024) * 1024),
+		QueryCacheEnabled:            r.Intn(2) == 0,
+		MaxGasPerTxPercent:           uint32(simtypes.RandIntBetween(r, 50, 101)),
+		MaxGasRefundPercent:          uint32(simtypes.RandIntBetween(r, 0, 51)),
+		AllowContractInstantiation:   r.Intn(2) == 0,
+		MaxReplyGas:                  uint64(simtypes.RandIntBetween(r, 0, 2) * 10_000_000),
+		MaxIteratorItems:             uint64(simtypes.RandIntBetween(r, 0, 2) * 1000),
+		BlockTimeAverageWindow:       uint32(simtypes.RandIntBetween(r, 0, 1000)),
+		DeduplicateCodeUploads:       r.Intn(2) == 0,
+		CodeCommitmentWindow:         uint32(simtypes.RandIntBetween(r, 0, 100)),
 	}
 }
@@ -0,0 +1,52 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	_ authtypes.AccountI       = (*ContractAccount)(nil)
+	_ authtypes.GenesisAccount = (*ContractAccount)(nil)
+)
+
+// NewContractAccount constructor
+func NewContractAccount(ba *authtypes.BaseAccount, codeID uint64) *ContractAccount {
+	return &ContractAccount{
+		BaseAccount: ba,
+		CodeID:      codeID,
+	}
+}
+
+// IsContractAccount returns true when the given account is a contract account
+func IsContractAccount(acc authtypes.AccountI) bool {
+	_, ok := acc.(*ContractAccount)
+	return ok
+}
+
+type contractAccountPretty struct {
+	Address       sdk.AccAddress `json:"address" yaml:"address"`
+	AccountNumber uint64         `json:"account_number" yaml:"account_number"`
+	Sequence      uint64         `json:"sequence" yaml:"sequence"`
+	CodeID        uint64         `json:"code_id" yaml:"code_id"`
+}
+
+func (acc ContractAccount) String() string {
+	out, _ := acc.MarshalYAML()
+	return out.(string)
+}
+
+// MarshalYAML returns the YAML representation of a contract account.
+func (acc ContractAccount) MarshalYAML() (interface{}, error) {
+	bs, err := yaml.Marshal(contractAccountPretty{
+		Address:       acc.GetAddress(),
+		AccountNumber: acc.AccountNumber,
+		Sequence:      acc.Sequence,
+		CodeID:        acc.CodeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return string(bs), nil
+}
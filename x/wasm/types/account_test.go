@@ -0,0 +1,32 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractAccountMarshalInterface(t *testing.T) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	authtypes.RegisterInterfaces(interfaceRegistry)
+	RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	addr := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+	src := NewContractAccount(authtypes.NewBaseAccount(addr, nil, 1, 0), 2)
+
+	bz, err := cdc.MarshalInterface(src)
+	require.NoError(t, err)
+
+	var got authtypes.AccountI
+	require.NoError(t, cdc.UnmarshalInterface(bz, &got))
+	assert.Equal(t, src, got)
+	assert.True(t, IsContractAccount(got))
+	assert.False(t, IsContractAccount(authtypes.NewBaseAccountWithAddress(addr)))
+}
@@ -9,6 +9,16 @@ type contextKey int
 const (
 	// private type creates an interface key for Context that cannot be accessed by any other package
 	contextKeyTXCount contextKey = iota
+	contextKeyCallStack
+	contextKeyReentrancyGuard
+	contextKeyMigrationCursor
+	contextKeySubMsgGasUsed
+	contextKeyQueryCache
+	contextKeyExecuteMemo
+	contextKeyCodeSource
+	contextKeyGasRefundTracker
+	contextKeyContractQuery
+	contextKeyExternalQuery
 )
 
 // WithTXCounter stores a transaction counter value in the context
@@ -22,3 +32,66 @@ func TXCounter(ctx sdk.Context) (uint32, bool) {
 	val, ok := ctx.Value(contextKeyTXCount).(uint32)
 	return val, ok
 }
+
+// WithSubMsgGasUsed stores the gas consumed by a submessage in the context that is used to process the reply
+func WithSubMsgGasUsed(ctx sdk.Context, gasUsed uint64) sdk.Context {
+	return ctx.WithValue(contextKeySubMsgGasUsed, gasUsed)
+}
+
+// SubMsgGasUsed returns the gas consumed by the submessage and found bool from the context.
+// The result will be (0, false) outside of a reply.
+func SubMsgGasUsed(ctx sdk.Context) (uint64, bool) {
+	val, ok := ctx.Value(contextKeySubMsgGasUsed).(uint64)
+	return val, ok
+}
+
+// WithExecuteMemo stores the memo of a contract execution in the context that is recorded in the execute event
+func WithExecuteMemo(ctx sdk.Context, memo string) sdk.Context {
+	return ctx.WithValue(contextKeyExecuteMemo, memo)
+}
+
+// ExecuteMemo returns the memo of the contract execution from the context. The result is empty when no memo was set.
+func ExecuteMemo(ctx sdk.Context) string {
+	memo, _ := ctx.Value(contextKeyExecuteMemo).(string)
+	return memo
+}
+
+type codeSource struct {
+	source, builder string
+}
+
+// WithCodeSource stores the source URL and the builder of a wasm code in the context that are persisted with the
+// code info on upload
+func WithCodeSource(ctx sdk.Context, source, builder string) sdk.Context {
+	return ctx.WithValue(contextKeyCodeSource, codeSource{source: source, builder: builder})
+}
+
+// CodeSource returns the source URL and the builder of a wasm code from the context. The results are empty when
+// not set.
+func CodeSource(ctx sdk.Context) (source, builder string) {
+	s, _ := ctx.Value(contextKeyCodeSource).(codeSource)
+	return s.source, s.builder
+}
+
+// WithQueryFromContract marks the context as the context of a query that was sent by a contract
+func WithQueryFromContract(ctx sdk.Context) sdk.Context {
+	return ctx.WithValue(contextKeyContractQuery, true)
+}
+
+// IsQueryFromContract returns true when the query in the context was sent by a contract
+func IsQueryFromContract(ctx sdk.Context) bool {
+	ok, _ := ctx.Value(contextKeyContractQuery).(bool)
+	return ok
+}
+
+// WithExternalQuery marks the context as the context of a query that was sent by a client, like a wallet,
+// and not by a contract. Such queries can never be part of the consensus state.
+func WithExternalQuery(ctx sdk.Context) sdk.Context {
+	return ctx.WithValue(contextKeyExternalQuery, true)
+}
+
+// IsExternalQuery returns true when the query in the context was sent by a client
+func IsExternalQuery(ctx sdk.Context) bool {
+	ok, _ := ctx.Value(contextKeyExternalQuery).(bool)
+	return ok
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WithContractOnCallStack returns a new context with the given contract address added to the stack of
+// contracts that are currently executing.
+func WithContractOnCallStack(ctx sdk.Context, contractAddr sdk.AccAddress) sdk.Context {
+	parent, _ := ctx.Value(contextKeyCallStack).([]string)
+	// copy to not modify the stack of the parent context
+	stack := make([]string, len(parent), len(parent)+1)
+	copy(stack, parent)
+	return ctx.WithValue(contextKeyCallStack, append(stack, contractAddr.String()))
+}
+
+// IsContractOnCallStack returns true when the given contract is currently executing in the context.
+func IsContractOnCallStack(ctx sdk.Context, contractAddr sdk.AccAddress) bool {
+	stack, _ := ctx.Value(contextKeyCallStack).([]string)
+	addr := contractAddr.String()
+	for _, v := range stack {
+		if v == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// IsContractExecuting returns true when any contract is currently executing in the context.
+func IsContractExecuting(ctx sdk.Context) bool {
+	stack, _ := ctx.Value(contextKeyCallStack).([]string)
+	return len(stack) != 0
+}
+
+// WithReentrancyGuard returns a new context where contract calls made with it, or with any
+// context derived from it, are rejected when they target a contract that is already executing.
+func WithReentrancyGuard(ctx sdk.Context) sdk.Context {
+	return ctx.WithValue(contextKeyReentrancyGuard, true)
+}
+
+// IsReentrancyGuardEnabled returns true when the re-entrancy guard was enabled for the context.
+func IsReentrancyGuardEnabled(ctx sdk.Context) bool {
+	enabled, _ := ctx.Value(contextKeyReentrancyGuard).(bool)
+	return enabled
+}
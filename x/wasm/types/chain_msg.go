@@ -0,0 +1,130 @@
+package types
+
+import (
+	"encoding/json"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ChainMsg is sent by a contract as custom message in the ChainNamespace to use chain features that are not
+// covered by the CosmWasm standard messages. Exactly one field must be set.
+type ChainMsg struct {
+	MultiSend *MultiSendMsg `json:"multi_send,omitempty"`
+	Terminate *TerminateMsg `json:"terminate,omitempty"`
+	GasHint   *GasHintMsg   `json:"gas_hint,omitempty"`
+	Authz     *AuthzMsg     `json:"authz,omitempty"`
+	Feegrant  *FeegrantMsg  `json:"feegrant,omitempty"`
+}
+
+// IsEmpty returns true when no chain message variant is set
+func (m ChainMsg) IsEmpty() bool {
+	return m == ChainMsg{}
+}
+
+// DecodeChainMsg decodes a custom message that is sent in the ChainNamespace. It returns false when the message is
+// not in the namespace and belongs to the chain's custom encoder.
+func DecodeChainMsg(bz []byte) (*ChainMsg, bool, error) {
+	payload, ok := chainNamespacePayload(bz)
+	if !ok {
+		return nil, false, nil
+	}
+	var m ChainMsg
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return nil, true, sdkerrors.Wrap(ErrInvalidMsg, err.Error())
+	}
+	return &m, true, nil
+}
+
+// MultiSendMsg sends tokens from the contract to multiple recipients with a single bank multi send.
+// The amount must equal the sum of all output amounts.
+type MultiSendMsg struct {
+	// Amount is the total amount sent by the contract
+	Amount []wasmvmtypes.Coin `json:"amount"`
+	// Outputs are the recipients with the amounts that they receive
+	Outputs []MultiSendOutput `json:"outputs"`
+}
+
+// TerminateMsg disables the sending contract permanently. Execute and sudo calls to the contract are rejected
+// afterwards while queries are still served. Other messages of the same response, like a final funds transfer,
+// are still dispatched. The termination is irreversible.
+type TerminateMsg struct{}
+
+// GasHintMsg reports the gas that the contract expects a submessage of the same response to consume. The hint is
+// emitted as an event for observability only and has no effect on gas limits or execution. Operators can correlate
+// it with the gas used reported by the reply event of the submessage with the same id.
+type GasHintMsg struct {
+	// MsgID is the id of the submessage the hint refers to
+	MsgID uint64 `json:"msg_id"`
+	// ExpectedGas is the amount of gas the contract expects the submessage to consume
+	ExpectedGas uint64 `json:"expected_gas"`
+}
+
+// AuthzMsg grants or revokes an authorization with the contract as granter in the x/authz module.
+// Exactly one field must be set.
+type AuthzMsg struct {
+	Grant  *AuthzGrantMsg  `json:"grant,omitempty"`
+	Revoke *AuthzRevokeMsg `json:"revoke,omitempty"`
+}
+
+// AuthzGrantMsg grants the grantee the authorization to execute messages on behalf of the contract until the
+// expiration. An existing grant for the same message type is overwritten.
+type AuthzGrantMsg struct {
+	// Grantee is the bech32 encoded address that receives the authorization
+	Grantee string `json:"grantee"`
+	// Authorization is the protobuf encoded authorization, for example a GenericAuthorization
+	Authorization AuthzAuthorization `json:"authorization"`
+	// Expiration is the time in unix nanoseconds when the grant expires. It must be after the current block time,
+	// not 0 and not exceed max int64.
+	Expiration uint64 `json:"expiration"`
+}
+
+// AuthzAuthorization is a protobuf encoded x/authz authorization with its type url,
+// like "/cosmos.authz.v1beta1.GenericAuthorization"
+type AuthzAuthorization struct {
+	TypeURL string `json:"type_url"`
+	Value   []byte `json:"value"`
+}
+
+// AuthzRevokeMsg revokes the authorization of the grantee for the message type
+type AuthzRevokeMsg struct {
+	// Grantee is the bech32 encoded address of the authorization to revoke
+	Grantee string `json:"grantee"`
+	// MsgTypeURL is the type url of the message of the authorization, like "/cosmos.gov.v1beta1.MsgVote"
+	MsgTypeURL string `json:"msg_type_url"`
+}
+
+// FeegrantMsg grants or revokes a fee allowance with the contract as granter in the x/feegrant module so that the
+// contract can pay the fees of its users. Exactly one field must be set.
+type FeegrantMsg struct {
+	GrantAllowance  *FeegrantGrantAllowanceMsg  `json:"grant_allowance,omitempty"`
+	RevokeAllowance *FeegrantRevokeAllowanceMsg `json:"revoke_allowance,omitempty"`
+}
+
+// FeegrantGrantAllowanceMsg grants the grantee an allowance to pay fees from the contract account
+type FeegrantGrantAllowanceMsg struct {
+	// Grantee is the bech32 encoded address that receives the allowance
+	Grantee string `json:"grantee"`
+	// Allowance is the protobuf encoded fee allowance, for example a BasicAllowance
+	Allowance FeegrantAllowance `json:"allowance"`
+}
+
+// FeegrantAllowance is a protobuf encoded x/feegrant allowance with its type url,
+// like "/cosmos.feegrant.v1beta1.BasicAllowance"
+type FeegrantAllowance struct {
+	TypeURL string `json:"type_url"`
+	Value   []byte `json:"value"`
+}
+
+// FeegrantRevokeAllowanceMsg revokes the fee allowance of the grantee
+type FeegrantRevokeAllowanceMsg struct {
+	// Grantee is the bech32 encoded address of the allowance to revoke
+	Grantee string `json:"grantee"`
+}
+
+// MultiSendOutput is a recipient of a MultiSendMsg
+type MultiSendOutput struct {
+	// ToAddress is the bech32 encoded recipient address
+	ToAddress string             `json:"to_address"`
+	Amount    []wasmvmtypes.Coin `json:"amount"`
+}
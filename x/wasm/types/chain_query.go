@@ -0,0 +1,410 @@
+package types
+
+import (
+	"encoding/json"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ChainNamespace is the only top level key of a custom query or message that is reserved for the chain queries
+// and messages, for example `{"wasmd":{"module_account":{"name":"gov"}}}`. The key is matched case sensitive.
+// Custom payloads with any other key are passed to the chain's custom querier or encoder unchanged.
+const ChainNamespace = "wasmd"
+
+// chainNamespacePayload returns the json under the chain namespace key. It returns false when the custom payload
+// is not an object with the namespace as its only key.
+func chainNamespacePayload(bz []byte) (json.RawMessage, bool) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &envelope); err != nil || len(envelope) != 1 {
+		return nil, false
+	}
+	payload, ok := envelope[ChainNamespace]
+	return payload, ok
+}
+
+// ChainQuery is sent by a contract as custom query in the ChainNamespace to read chain data that is not covered
+// by the CosmWasm standard queries. Exactly one field must be set.
+type ChainQuery struct {
+	ModuleAccount        *ModuleAccountQuery        `json:"module_account,omitempty"`
+	MigrationStateBatch  *MigrationStateBatchQuery  `json:"migration_state_batch,omitempty"`
+	IsContract           *IsContractQuery           `json:"is_contract,omitempty"`
+	Secp256k1Verify      *SignatureVerifyQuery      `json:"secp256k1_verify,omitempty"`
+	Ed25519Verify        *SignatureVerifyQuery      `json:"ed25519_verify,omitempty"`
+	ContractBalance      *ContractBalanceQuery      `json:"contract_balance,omitempty"`
+	ValidateAddress      *ValidateAddressQuery      `json:"validate_address,omitempty"`
+	Randomness           *RandomnessQuery           `json:"randomness,omitempty"`
+	MinGasPrices         *MinGasPricesQuery         `json:"min_gas_prices,omitempty"`
+	DelegationRewards    *DelegationRewardsQuery    `json:"delegation_rewards,omitempty"`
+	TrySmart             *TrySmartQuery             `json:"try_smart,omitempty"`
+	Sha256               *HashQuery                 `json:"sha256,omitempty"`
+	Ripemd160            *HashQuery                 `json:"ripemd160,omitempty"`
+	Keccak256            *HashQuery                 `json:"keccak256,omitempty"`
+	TotalSupply          *TotalSupplyQuery          `json:"total_supply,omitempty"`
+	StakingPool          *StakingPoolQuery          `json:"staking_pool,omitempty"`
+	CodeHistory          *CodeHistoryQuery          `json:"code_history,omitempty"`
+	IBCClientConnections *IBCClientConnectionsQuery `json:"ibc_client_connections,omitempty"`
+	ContractAdmin        *ContractAdminQuery        `json:"contract_admin,omitempty"`
+	TxPosition           *TxPositionQuery           `json:"tx_position,omitempty"`
+	AverageBlockTime     *AverageBlockTimeQuery     `json:"average_block_time,omitempty"`
+	GovParams            *GovParamsQuery            `json:"gov_params,omitempty"`
+	Validator            *ValidatorQuery            `json:"validator,omitempty"`
+	ActiveValidatorSet   *ActiveValidatorSetQuery   `json:"active_validator_set,omitempty"`
+	ModuleParams         *ModuleParamsQuery         `json:"module_params,omitempty"`
+}
+
+// IsEmpty returns true when no chain query variant is set
+func (q ChainQuery) IsEmpty() bool {
+	return q == ChainQuery{}
+}
+
+// DecodeChainQuery decodes a custom query that is sent in the ChainNamespace. It returns false when the query is
+// not in the namespace and belongs to the chain's custom querier.
+func DecodeChainQuery(bz []byte) (*ChainQuery, bool, error) {
+	payload, ok := chainNamespacePayload(bz)
+	if !ok {
+		return nil, false, nil
+	}
+	var q ChainQuery
+	if err := json.Unmarshal(payload, &q); err != nil {
+		return nil, true, sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	return &q, true, nil
+}
+
+// ModuleAccountQuery requests the address of a module account by the module name
+type ModuleAccountQuery struct {
+	Name string `json:"name"`
+}
+
+// ModuleAccountResponse is the response to a ModuleAccountQuery
+type ModuleAccountResponse struct {
+	// Address is the bech32 encoded module account address
+	Address string `json:"address"`
+}
+
+// IsContractQuery requests if the given address belongs to a contract instance
+type IsContractQuery struct {
+	// Address is the bech32 encoded address to check
+	Address string `json:"address"`
+}
+
+// IsContractResponse is the response to an IsContractQuery
+type IsContractResponse struct {
+	IsContract bool `json:"is_contract"`
+}
+
+// ValidateAddressQuery requests if the given address is a valid account address with the bech32 prefix of this chain.
+// Unlike the other queries that take an address, an invalid address is not an error but reported in the response.
+type ValidateAddressQuery struct {
+	Address string `json:"address"`
+}
+
+// ValidateAddressResponse is the response to a ValidateAddressQuery
+type ValidateAddressResponse struct {
+	Valid bool `json:"valid"`
+	// IsContract is true when the address belongs to a contract instance
+	IsContract bool `json:"is_contract"`
+	// IsModuleAccount is true when the address belongs to a module account
+	IsModuleAccount bool `json:"is_module_account"`
+}
+
+// RandomnessQuery requests a seed that is derived from the block hash, the block height and the calling contract
+// address. The seed is deterministic so that all validators return the same value for the same block and contract.
+// It is known to the block proposer in advance and can be computed by anybody once the block hash is public, so it
+// must not be used where unpredictability is security critical. The query is rejected in CheckTx where no stable
+// block hash exists.
+type RandomnessQuery struct{}
+
+// RandomnessResponse is the response to a RandomnessQuery
+type RandomnessResponse struct {
+	// Seed is the sha256 hash of the block hash, the big endian block height and the contract address
+	Seed []byte `json:"seed"`
+}
+
+// MinGasPricesQuery requests the gas prices that the chain agreed on in consensus, for example via a fee market
+// module. The node local minimum gas prices are not returned as they differ between nodes. By default the bond
+// denom with a zero price is returned; chains must wire their own source of gas prices.
+type MinGasPricesQuery struct{}
+
+// MinGasPricesResponse is the response to a MinGasPricesQuery
+type MinGasPricesResponse struct {
+	GasPrices []DecCoin `json:"gas_prices"`
+}
+
+// DecCoin is a coin with a decimal amount
+type DecCoin struct {
+	Denom string `json:"denom"`
+	// Amount is the decimal string representation, for example "0.025000000000000000"
+	Amount string `json:"amount"`
+}
+
+// DelegationRewardsQuery requests the pending staking rewards of the calling contract's delegation to the
+// validator. The rewards are not withdrawn.
+type DelegationRewardsQuery struct {
+	// Validator is the bech32 encoded validator operator address
+	Validator string `json:"validator"`
+}
+
+// DelegationRewardsResponse is the response to a DelegationRewardsQuery
+type DelegationRewardsResponse struct {
+	// Rewards are empty when the contract has no delegation to the validator
+	Rewards []DecCoin `json:"rewards"`
+}
+
+// TrySmartQuery is a smart query to another contract that returns a failure of the queried contract as data
+// instead of failing the query of the caller. This allows the caller to handle broken contracts gracefully.
+// Running out of gas is not recoverable and still aborts the caller.
+type TrySmartQuery struct {
+	// ContractAddr is the bech32 encoded address of the contract to query
+	ContractAddr string `json:"contract_addr"`
+	// Msg is the json encoded query message
+	Msg []byte `json:"msg"`
+}
+
+// TrySmartResponse is the response to a TrySmartQuery. Either Data or Error is set.
+type TrySmartResponse struct {
+	// Data is the result of the queried contract
+	Data []byte `json:"data,omitempty"`
+	// Error is set when the queried contract failed
+	Error *QueryError `json:"error,omitempty"`
+}
+
+// QueryError describes a failed query with the ABCI error code
+type QueryError struct {
+	Codespace string `json:"codespace"`
+	Code      uint32 `json:"code"`
+	Message   string `json:"message"`
+}
+
+// HashQuery requests the digest of the data with the hash function of the query variant. Hashing is deterministic:
+// the same data always results in the same digest on all nodes. The gas charged is set by the gas register and
+// depends on the data length only.
+type HashQuery struct {
+	Data []byte `json:"data"`
+}
+
+// HashResponse is the response to a HashQuery
+type HashResponse struct {
+	// Digest is the 32 byte sha256 or keccak256, or 20 byte ripemd160 hash of the data
+	Digest []byte `json:"digest"`
+}
+
+// TotalSupplyQuery requests the total supply of a denom at the current height
+type TotalSupplyQuery struct {
+	Denom string `json:"denom"`
+}
+
+// TotalSupplyResponse is the response to a TotalSupplyQuery
+type TotalSupplyResponse struct {
+	// Amount is zero when the denom has no supply
+	Amount wasmvmtypes.Coin `json:"amount"`
+}
+
+// StakingPoolQuery requests the bonded and not bonded tokens of the staking module at the current height.
+// Together with the TotalSupplyQuery of the bond denom, contracts can compute the bonded ratio.
+type StakingPoolQuery struct{}
+
+// StakingPoolResponse is the response to a StakingPoolQuery. Both amounts are in the bond denom.
+type StakingPoolResponse struct {
+	BondedTokens    wasmvmtypes.Coin `json:"bonded_tokens"`
+	NotBondedTokens wasmvmtypes.Coin `json:"not_bonded_tokens"`
+}
+
+// ValidatorQuery requests the commission and status of a validator. Unlike the CosmWasm standard validator query,
+// the validator does not need to be in the bonded set and jailed validators are returned.
+type ValidatorQuery struct {
+	// Address is the bech32 encoded validator operator address
+	Address string `json:"address"`
+}
+
+// ValidatorResponse is the response to a ValidatorQuery
+type ValidatorResponse struct {
+	// Validator is nil when the validator does not exist
+	Validator *ValidatorInfo `json:"validator,omitempty"`
+}
+
+// ValidatorInfo is the commission and status of a validator
+type ValidatorInfo struct {
+	// Address is the bech32 encoded validator operator address
+	Address string `json:"address"`
+	// Commission is the current commission rate as decimal string, for example "0.100000000000000000"
+	Commission string `json:"commission"`
+	Jailed     bool   `json:"jailed"`
+	// Status is the bond status name: "BOND_STATUS_BONDED", "BOND_STATUS_UNBONDING" or "BOND_STATUS_UNBONDED"
+	Status string `json:"status"`
+}
+
+// ActiveValidatorSetQuery requests the size of the active validator set and its max size from the staking params.
+// The set is the one that was committed by the staking module in the end block of the previous block.
+type ActiveValidatorSetQuery struct{}
+
+// ActiveValidatorSetResponse is the response to an ActiveValidatorSetQuery
+type ActiveValidatorSetResponse struct {
+	// BondedCount is the number of validators in the active set
+	BondedCount uint32 `json:"bonded_count"`
+	// MaxValidators is the max number of validators in the active set
+	MaxValidators uint32 `json:"max_validators"`
+}
+
+// CodeHistoryQuery requests the code history of the calling contract. A contract can compare the height of the last
+// migrate entry with the current block height to detect that it was just migrated and run one time upgrade logic.
+type CodeHistoryQuery struct{}
+
+// CodeHistoryResponse is the response to a CodeHistoryQuery
+type CodeHistoryResponse struct {
+	// Entries are in chronological order, starting with the instantiation
+	Entries []CodeHistoryEntry `json:"entries"`
+}
+
+// CodeHistoryEntry is an operation that set the code of a contract
+type CodeHistoryEntry struct {
+	// Operation is one of "init", "migrate" or "genesis"
+	Operation string `json:"operation"`
+	CodeID    uint64 `json:"code_id"`
+	// Height is the block height of the operation
+	Height uint64 `json:"height"`
+}
+
+const (
+	// DefaultIBCConnectionsLimit is the number of connections returned for an ibc client connections query without limit
+	DefaultIBCConnectionsLimit = 100
+	// MaxIBCConnectionsLimit is the max number of connections returned for a single ibc client connections query
+	MaxIBCConnectionsLimit = 500
+)
+
+// IBCClientConnectionsQuery requests the connections of an IBC light client with their states. Contracts can use
+// it to discover the connections to a counterparty chain. Unknown clients return an empty list.
+type IBCClientConnectionsQuery struct {
+	ClientID string `json:"client_id"`
+	// StartAfter is the exclusive connection id to continue a previous query with
+	StartAfter string `json:"start_after,omitempty"`
+	// Limit is the max number of connections returned. Defaults to DefaultIBCConnectionsLimit.
+	Limit uint32 `json:"limit,omitempty"`
+}
+
+// IBCClientConnectionsResponse is the response to an IBCClientConnectionsQuery
+type IBCClientConnectionsResponse struct {
+	Connections []IBCConnection `json:"connections"`
+	// NextKey is the start after value to query the next page with. Empty when all connections were returned.
+	NextKey string `json:"next_key,omitempty"`
+}
+
+// IBCConnection is the id and state of an IBC connection
+type IBCConnection struct {
+	ID string `json:"id"`
+	// State is the connection state name, like "STATE_OPEN"
+	State string `json:"state"`
+}
+
+// GovParamsQuery requests the current deposit, voting and tally params of the gov module. Contracts can use them
+// to compute the timeline of a proposal.
+type GovParamsQuery struct{}
+
+// GovParamsResponse is the response to a GovParamsQuery. Periods are in nanoseconds and the tally params are
+// decimal strings.
+type GovParamsResponse struct {
+	MinDeposit       wasmvmtypes.Coins `json:"min_deposit"`
+	MaxDepositPeriod uint64            `json:"max_deposit_period"`
+	VotingPeriod     uint64            `json:"voting_period"`
+	Quorum           string            `json:"quorum"`
+	Threshold        string            `json:"threshold"`
+	VetoThreshold    string            `json:"veto_threshold"`
+}
+
+// ModuleParamsQuery requests the current params of the wasm module, like the max message size, so that contracts
+// can adjust to the limits of the chain. The response is the protobuf json encoded Params with all fields set.
+// Unsigned 64 bit integers are encoded as strings.
+type ModuleParamsQuery struct{}
+
+// AverageBlockTimeQuery requests the moving average of the block time that is updated by the module in each begin
+// block. Contracts can use it to convert between heights and durations.
+type AverageBlockTimeQuery struct{}
+
+// AverageBlockTimeResponse is the response to an AverageBlockTimeQuery
+type AverageBlockTimeResponse struct {
+	// Nanos is the average block time in nanoseconds. Zero when not enough blocks were tracked.
+	Nanos uint64 `json:"nanos"`
+}
+
+// TxPositionQuery requests the position of the current transaction in the block. The position is set by the tx
+// counter ante handler and is deterministic within a block. It is not available in queries and simulations.
+type TxPositionQuery struct{}
+
+// TxPositionResponse is the response to a TxPositionQuery
+type TxPositionResponse struct {
+	Height uint64 `json:"height"`
+	// TxIndex is the zero based index of the transaction in the block
+	TxIndex uint32 `json:"tx_index"`
+}
+
+// ContractAdminQuery requests the admin of a contract. Registry contracts can use it to verify that they control a
+// contract before they send a migrate or update admin message that would fail otherwise.
+type ContractAdminQuery struct {
+	ContractAddr string `json:"contract_addr"`
+}
+
+// ContractAdminResponse is the response to a ContractAdminQuery
+type ContractAdminResponse struct {
+	// Admin is empty when the contract has no admin
+	Admin string `json:"admin,omitempty"`
+	// IsAdmin is true when the calling contract is the admin
+	IsAdmin bool `json:"is_admin"`
+}
+
+// ContractBalanceQuery requests all balances of the calling contract. It is a cheaper alternative to the
+// bank all balances query as the balances are read with a single iteration and charged with a fixed gas cost
+// per denom. The address does not need to be encoded by the contract and decoded by the chain either.
+type ContractBalanceQuery struct{}
+
+// ContractBalanceResponse is the response to a ContractBalanceQuery
+type ContractBalanceResponse struct {
+	Amount wasmvmtypes.Coins `json:"amount"`
+}
+
+// supported signature algorithms of the signature verification queries
+const (
+	SignatureAlgorithmSecp256k1 = "secp256k1"
+	SignatureAlgorithmEd25519   = "ed25519"
+)
+
+// SignatureVerifyQuery requests the verification of a signature over the message. Secp256k1 signatures are
+// verified over the sha256 hash of the message and must be in the 64 byte r||s format with a low s value, as
+// for Cosmos SDK transactions. The gas charged per verification is set by the gas register and does not depend
+// on the result.
+type SignatureVerifyQuery struct {
+	Message   []byte `json:"message"`
+	Signature []byte `json:"signature"`
+	// PublicKey is the compressed secp256k1 (33 bytes) or ed25519 (32 bytes) public key
+	PublicKey []byte `json:"public_key"`
+}
+
+// SignatureVerifyResponse is the response to a SignatureVerifyQuery
+type SignatureVerifyResponse struct {
+	Verifies bool `json:"verifies"`
+}
+
+// MigrationStateBatchQuery requests a batch of the calling contract's own state in key order.
+// It is only supported while the contract is executing its migrate entrypoint.
+type MigrationStateBatchQuery struct {
+	// StartAfter is the exclusive key to start the iteration after. Ignored when Resume is set.
+	StartAfter []byte `json:"start_after,omitempty"`
+	// Resume continues the iteration after the last batch of this or a previous, unfinished migration.
+	// Without stored progress the iteration starts with the first key.
+	Resume bool `json:"resume,omitempty"`
+	// Limit is the max number of models returned. Defaults to DefaultStateBatchLimit.
+	Limit uint32 `json:"limit,omitempty"`
+}
+
+// MigrationStateBatchResponse is the response to a MigrationStateBatchQuery
+type MigrationStateBatchResponse struct {
+	Models []StateModel `json:"models"`
+	// NextKey is the exclusive key to continue the iteration with. Empty when all state was read.
+	NextKey []byte `json:"next_key,omitempty"`
+}
+
+// StateModel is a raw key/value pair of contract state
+type StateModel struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
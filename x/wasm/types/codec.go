@@ -6,6 +6,7 @@ import (
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/msgservice"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
@@ -17,6 +18,12 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) { //nolint:staticcheck
 	cdc.RegisterConcrete(&MsgMigrateContract{}, "wasm/MsgMigrateContract", nil)
 	cdc.RegisterConcrete(&MsgUpdateAdmin{}, "wasm/MsgUpdateAdmin", nil)
 	cdc.RegisterConcrete(&MsgClearAdmin{}, "wasm/MsgClearAdmin", nil)
+	cdc.RegisterConcrete(&MsgCommitCodeHash{}, "wasm/MsgCommitCodeHash", nil)
+	cdc.RegisterConcrete(&MsgRevealCode{}, "wasm/MsgRevealCode", nil)
+	cdc.RegisterConcrete(&MsgReleaseTimelockedFunds{}, "wasm/MsgReleaseTimelockedFunds", nil)
+	cdc.RegisterConcrete(&MsgUpdateExecutePermission{}, "wasm/MsgUpdateExecutePermission", nil)
+	cdc.RegisterConcrete(&MsgUpdateMigratePermission{}, "wasm/MsgUpdateMigratePermission", nil)
+	cdc.RegisterConcrete(&MsgUpdateAcceptedDenoms{}, "wasm/MsgUpdateAcceptedDenoms", nil)
 	cdc.RegisterConcrete(&PinCodesProposal{}, "wasm/PinCodesProposal", nil)
 	cdc.RegisterConcrete(&UnpinCodesProposal{}, "wasm/UnpinCodesProposal", nil)
 
@@ -25,6 +32,11 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) { //nolint:staticcheck
 	cdc.RegisterConcrete(&MigrateContractProposal{}, "wasm/MigrateContractProposal", nil)
 	cdc.RegisterConcrete(&UpdateAdminProposal{}, "wasm/UpdateAdminProposal", nil)
 	cdc.RegisterConcrete(&ClearAdminProposal{}, "wasm/ClearAdminProposal", nil)
+	cdc.RegisterConcrete(&BatchStoreCodeProposal{}, "wasm/BatchStoreCodeProposal", nil)
+	cdc.RegisterConcrete(&RemoveCodeProposal{}, "wasm/RemoveCodeProposal", nil)
+	cdc.RegisterConcrete(&ReserveContractAddressProposal{}, "wasm/ReserveContractAddressProposal", nil)
+
+	cdc.RegisterConcrete(&ContractAccount{}, "wasm/ContractAccount", nil)
 }
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
@@ -36,6 +48,12 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&MsgMigrateContract{},
 		&MsgUpdateAdmin{},
 		&MsgClearAdmin{},
+		&MsgCommitCodeHash{},
+		&MsgRevealCode{},
+		&MsgReleaseTimelockedFunds{},
+		&MsgUpdateExecutePermission{},
+		&MsgUpdateMigratePermission{},
+		&MsgUpdateAcceptedDenoms{},
 		&MsgIBCCloseChannel{},
 		&MsgIBCSend{},
 	)
@@ -48,6 +66,18 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&ClearAdminProposal{},
 		&PinCodesProposal{},
 		&UnpinCodesProposal{},
+		&BatchStoreCodeProposal{},
+		&RemoveCodeProposal{},
+		&ReserveContractAddressProposal{},
+	)
+
+	registry.RegisterImplementations(
+		(*authtypes.AccountI)(nil),
+		&ContractAccount{},
+	)
+	registry.RegisterImplementations(
+		(*authtypes.GenesisAccount)(nil),
+		&ContractAccount{},
 	)
 
 	registry.RegisterInterface("ContractInfoExtension", (*ContractInfoExtension)(nil))
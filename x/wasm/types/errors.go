@@ -69,4 +69,25 @@ var (
 
 	// ErrInvalidEvent error if an attribute/event from the contract is invalid
 	ErrInvalidEvent = sdkErrors.Register(DefaultCodespace, 21, "invalid event")
+
+	// ErrReentrancy error when a contract that is already executing in the current call stack is called again
+	ErrReentrancy = sdkErrors.Register(DefaultCodespace, 22, "contract re-entrancy")
+
+	// ErrContractPaused error when a paused contract is executed
+	ErrContractPaused = sdkErrors.Register(DefaultCodespace, 23, "contract paused")
+
+	// ErrChecksumNotAllowed error when a code is instantiated that is not on the checksum allowlist
+	ErrChecksumNotAllowed = sdkErrors.Register(DefaultCodespace, 24, "code checksum not allowlisted")
+
+	// ErrContractTerminated error when a contract is executed that has terminated itself
+	ErrContractTerminated = sdkErrors.Register(DefaultCodespace, 25, "contract terminated")
+
+	// ErrRateLimited error when a contract is executed more often than the execute rate limiter allows
+	ErrRateLimited = sdkErrors.Register(DefaultCodespace, 26, "execute rate limit exceeded")
+
+	// ErrVMFailure error when a contract call failed in the wasm vm for other reasons than a contract error or out of gas
+	ErrVMFailure = sdkErrors.Register(DefaultCodespace, 27, "wasm vm failure")
+
+	// ErrFundsLocked error when timelocked funds are released before the unlock height or time
+	ErrFundsLocked = sdkErrors.Register(DefaultCodespace, 28, "funds locked")
 )
@@ -12,17 +12,62 @@ const (
 	EventTypeMigrate           = "migrate"
 	EventTypePinCode           = "pin_code"
 	EventTypeUnpinCode         = "unpin_code"
+	EventTypeRemoveCode        = "remove_code"
+	EventTypeTerminate         = "terminate"
 	EventTypeSudo              = "sudo"
 	EventTypeReply             = "reply"
 	EventTypeGovContractResult = "gov_contract_result"
+	EventTypeAdminAction       = "admin_action"
+	EventTypeIBCSendPacket     = "ibc_send_packet"
+
+	EventTypeReserveContractAddress = "reserve_contract_address"
+	EventTypeGasBreakdown           = "gas_breakdown"
+	EventTypeGasHint                = "gas_hint"
+	EventTypeTimelockFunds          = "timelock_funds"
+	EventTypeReleaseTimelockedFunds = "release_timelocked_funds"
+	EventTypeDispatchedMsg          = "dispatched_msg"
+	EventTypeCommitCodeHash         = "commit_code_hash"
+)
+
+// admin actions that are reported with the EventTypeAdminAction audit event
+const (
+	AdminActionMigrate     = "migrate"
+	AdminActionUpdateAdmin = "update_admin"
+	AdminActionClearAdmin  = "clear_admin"
+	AdminActionPause       = "pause"
+	AdminActionUnpause     = "unpause"
+
+	AdminActionUpdateMigratePermission = "update_migrate_permission"
+	AdminActionUpdateExecutePermission = "update_execute_permission"
+	AdminActionUpdateAcceptedDenoms    = "update_accepted_denoms"
 )
 
 // event attributes returned from contract execution
 const (
 	AttributeReservedPrefix = "_"
 
-	AttributeKeyContractAddr  = "_contract_address"
-	AttributeKeyCodeID        = "code_id"
-	AttributeKeyResultDataHex = "result"
-	AttributeKeyFeature       = "feature"
+	AttributeKeyContractAddr   = "_contract_address"
+	AttributeKeyCodeID         = "code_id"
+	AttributeKeyCodeIDs        = "code_ids"
+	AttributeKeyResultDataHex  = "result"
+	AttributeKeyFeature        = "feature"
+	AttributeKeyAdmin          = "admin"
+	AttributeKeyCreator        = "creator"
+	AttributeKeyLabel          = "label"
+	AttributeKeyAction         = "action"
+	AttributeKeySubMsgID       = "msg_id"
+	AttributeKeyGasUsed        = "gas_used"
+	AttributeKeyMemo           = "memo"
+	AttributeKeySrcChecksum    = "src_checksum"
+	AttributeKeyDestChecksum   = "dest_checksum"
+	AttributeKeyVMGas          = "vm_gas"
+	AttributeKeyHostGas        = "host_gas"
+	AttributeKeyContractOrigin = "_contract_origin"
+	AttributeKeyExpectedGas    = "expected_gas"
+	AttributeKeyLockID         = "lock_id"
+	AttributeKeyDepositor      = "depositor"
+	AttributeKeyMsgType        = "msg_type"
+	AttributeKeyDataLength     = "data_length"
+	AttributeKeyChecksum       = "checksum"
+	AttributeKeyExpiryHeight   = "expiry_height"
 )
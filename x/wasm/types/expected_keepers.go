@@ -17,6 +17,8 @@ import (
 type BankViewKeeper interface {
 	GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
 	GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin
+	IterateAccountBalances(ctx sdk.Context, addr sdk.AccAddress, cb func(sdk.Coin) bool)
+	GetSupply(ctx sdk.Context, denom string) sdk.Coin
 }
 
 // Burner is a subset of the sdk bank keeper methods
@@ -35,12 +37,15 @@ type BankKeeper interface {
 
 	BlockedAddr(addr sdk.AccAddress) bool
 	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
 }
 
 // AccountKeeper defines a subset of methods implemented by the cosmos-sdk account keeper
 type AccountKeeper interface {
 	// Return a new account with the next account number and the specified address. Does not save the new account to the store.
 	NewAccountWithAddress(ctx sdk.Context, addr sdk.AccAddress) authtypes.AccountI
+	// Return a new account with the next account number. Does not save the new account to the store.
+	NewAccount(ctx sdk.Context, acc authtypes.AccountI) authtypes.AccountI
 	// Retrieve an account from the store.
 	GetAccount(ctx sdk.Context, addr sdk.AccAddress) authtypes.AccountI
 	// Set an account in the store.
@@ -60,6 +65,10 @@ type StakingKeeper interface {
 	GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator stakingtypes.Validator, found bool)
 	// GetBondedValidatorsByPower get the current group of bonded validators sorted by power-rank
 	GetBondedValidatorsByPower(ctx sdk.Context) []stakingtypes.Validator
+	// IterateLastValidatorPowers iterate over the last validator set index
+	IterateLastValidatorPowers(ctx sdk.Context, handler func(operator sdk.ValAddress, power int64) (stop bool))
+	// MaxValidators - Maximum number of validators
+	MaxValidators(ctx sdk.Context) (res uint32)
 	// GetAllDelegatorDelegations return all delegations for a delegator
 	GetAllDelegatorDelegations(ctx sdk.Context, delegator sdk.AccAddress) []stakingtypes.Delegation
 	// GetDelegation return a specific delegation
@@ -88,6 +97,7 @@ type ClientKeeper interface {
 // ConnectionKeeper defines the expected IBC connection keeper
 type ConnectionKeeper interface {
 	GetConnection(ctx sdk.Context, connectionID string) (connection connectiontypes.ConnectionEnd, found bool)
+	GetClientConnectionPaths(ctx sdk.Context, clientID string) ([]string, bool)
 }
 
 // PortKeeper defines the expected IBC port keeper
@@ -10,16 +10,24 @@ import (
 type ViewKeeper interface {
 	GetContractHistory(ctx sdk.Context, contractAddr sdk.AccAddress) []ContractCodeHistoryEntry
 	QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error)
+	QuerySmartWithGasLimit(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte, gasLimit uint64) ([]byte, error)
 	QueryRaw(ctx sdk.Context, contractAddress sdk.AccAddress, key []byte) []byte
 	HasContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) bool
 	GetContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) *ContractInfo
 	IterateContractInfo(ctx sdk.Context, cb func(sdk.AccAddress, ContractInfo) bool)
 	IterateContractsByCode(ctx sdk.Context, codeID uint64, cb func(address sdk.AccAddress) bool)
+	IterateContractsByCreator(ctx sdk.Context, creator sdk.AccAddress, cb func(address sdk.AccAddress) bool)
 	GetContractState(ctx sdk.Context, contractAddress sdk.AccAddress) sdk.Iterator
 	GetCodeInfo(ctx sdk.Context, codeID uint64) *CodeInfo
 	IterateCodeInfos(ctx sdk.Context, cb func(uint64, CodeInfo) bool)
 	GetByteCode(ctx sdk.Context, codeID uint64) ([]byte, error)
 	IsPinnedCode(ctx sdk.Context, codeID uint64) bool
+	GetCodeInstanceCount(ctx sdk.Context, codeID uint64) uint64
+	GetCapabilities() []string
+	GetParams(ctx sdk.Context) Params
+	SimulateExecute(ctx sdk.Context, gasLimit sdk.Gas, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, sdk.Gas, sdk.Events, error)
+	PreviewExecuteEvents(ctx sdk.Context, gasLimit sdk.Gas, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) (sdk.Events, error)
+	SimulateInstantiate(ctx sdk.Context, gasLimit sdk.Gas, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins) (sdk.AccAddress, []byte, sdk.Gas, sdk.Events, error)
 }
 
 // ContractOpsKeeper contains mutable operations on a contract.
@@ -27,12 +35,30 @@ type ContractOpsKeeper interface {
 	// Create uploads and compiles a WASM contract, returning a short identifier for the contract
 	Create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *AccessConfig) (codeID uint64, err error)
 
+	// CommitCodeHash records the checksum and size of a wasm code that is stored with RevealCode within the code
+	// commitment window. Returns the last height in which the code can be revealed.
+	CommitCodeHash(ctx sdk.Context, creator sdk.AccAddress, checksum []byte, codeSize uint64, instantiateAccess *AccessConfig) (int64, error)
+
+	// RevealCode stores a wasm code that matches a code hash commitment of the creator
+	RevealCode(ctx sdk.Context, creator sdk.AccAddress, checksum []byte, wasmCode []byte) (codeID uint64, err error)
+
 	// Instantiate creates an instance of a WASM contract
 	Instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins) (sdk.AccAddress, []byte, error)
 
+	// InstantiateGovManaged creates an instance of a WASM contract with the governance module account as admin. Only
+	// governance proposals can migrate or modify the contract.
+	InstantiateGovManaged(ctx sdk.Context, codeID uint64, creator sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins) (sdk.AccAddress, []byte, error)
+
 	// Execute executes the contract instance
 	Execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error)
 
+	// SetFundsTimelock moves the funds from the depositor into the module account where they are held until they are
+	// released to the contract from the unlock height and time (unix nanoseconds) on. Returns the id of the lock.
+	SetFundsTimelock(ctx sdk.Context, contractAddress, depositor sdk.AccAddress, amount sdk.Coins, unlockHeight int64, unlockTime uint64) (uint64, error)
+
+	// ReleaseTimelockedFunds sends the timelocked funds to the contract once the unlock height and time are reached
+	ReleaseTimelockedFunds(ctx sdk.Context, contractAddress sdk.AccAddress, lockID uint64) error
+
 	// Migrate allows to upgrade a contract to a new code with data migration.
 	Migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte) ([]byte, error)
 
@@ -40,6 +66,7 @@ type ContractOpsKeeper interface {
 	UpdateContractAdmin(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newAdmin sdk.AccAddress) error
 
 	// ClearContractAdmin sets the admin value on the ContractInfo to nil, to disable further migrations/ updates.
+	// A migrate permission is removed as well.
 	ClearContractAdmin(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress) error
 
 	// PinCode pins the wasm contract in wasmvm cache
@@ -48,10 +75,39 @@ type ContractOpsKeeper interface {
 	// UnpinCode removes the wasm contract from wasmvm cache
 	UnpinCode(ctx sdk.Context, codeID uint64) error
 
+	// RemoveCode deletes a code that is not referenced by any contract instance anymore
+	RemoveCode(ctx sdk.Context, codeID uint64) error
+
+	// ReserveContractAddress reserves the contract address for the next instantiation of the code with the given
+	// checksum by the creator
+	ReserveContractAddress(ctx sdk.Context, contractAddress, creator sdk.AccAddress, codeHash []byte) error
+
+	// SetContractPaused pauses or unpauses the execution of a contract. A paused contract rejects execute and sudo calls
+	// but can still be queried.
+	SetContractPaused(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, paused bool) error
+
+	// SetContractMigratePermission restricts who can migrate a contract. When set, it overrides the admin's ability to
+	// migrate. A nil permission restores the default where the admin can migrate.
+	SetContractMigratePermission(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, permission *AccessConfig) error
+
+	// SetContractExecutePermission restricts who can execute a contract. A nil permission restores the default where
+	// everybody can execute.
+	SetContractExecutePermission(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, permission *AccessConfig) error
+
+	// SetContractAcceptedDenoms restricts the denoms that can be sent as funds with an execute call. An empty list
+	// restores the default where all denoms are accepted.
+	SetContractAcceptedDenoms(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, denoms []string) error
+
 	// SetContractInfoExtension updates the extension point data that is stored with the contract info
 	SetContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra ContractInfoExtension) error
 }
 
+// InFlightPacketKeeper tracks the IBC transfer packets sent by contracts
+type InFlightPacketKeeper interface {
+	// RemoveInFlightPacket deletes the record of a packet sent by a contract when it was acknowledged or timed out
+	RemoveInFlightPacket(ctx sdk.Context, portID, channelID string, sequence uint64)
+}
+
 // IBCContractKeeper IBC lifecycle event handler
 type IBCContractKeeper interface {
 	OnOpenChannel(
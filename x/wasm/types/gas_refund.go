@@ -0,0 +1,46 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GasRefundTracker counts the contract state entries that were deleted within a single contract message so that
+// a bounded gas refund can be granted at the end of the message.
+type GasRefundTracker struct {
+	deletedKeys uint64
+}
+
+// NewGasRefundTracker constructor
+func NewGasRefundTracker() *GasRefundTracker {
+	return &GasRefundTracker{}
+}
+
+// AddDeletedKey counts a deleted contract state entry
+func (t *GasRefundTracker) AddDeletedKey() {
+	t.deletedKeys++
+}
+
+// DeletedKeys returns the number of deleted contract state entries. The value can be used as a checkpoint for
+// RevertTo.
+func (t *GasRefundTracker) DeletedKeys() uint64 {
+	return t.deletedKeys
+}
+
+// RevertTo drops all deletions counted after the given checkpoint. This is used when the state changes of a
+// submessage are discarded.
+func (t *GasRefundTracker) RevertTo(checkpoint uint64) {
+	if checkpoint < t.deletedKeys {
+		t.deletedKeys = checkpoint
+	}
+}
+
+// WithGasRefundTracker returns a new context with the gas refund tracker of the contract message.
+func WithGasRefundTracker(ctx sdk.Context, tracker *GasRefundTracker) sdk.Context {
+	return ctx.WithValue(contextKeyGasRefundTracker, tracker)
+}
+
+// GasRefundTrackerFromContext returns the gas refund tracker of the contract message or nil when not set.
+func GasRefundTrackerFromContext(ctx sdk.Context) *GasRefundTracker {
+	tracker, _ := ctx.Value(contextKeyGasRefundTracker).(*GasRefundTracker)
+	return tracker
+}
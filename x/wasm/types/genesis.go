@@ -1,8 +1,11 @@
 package types
 
 import (
+	"crypto/sha256"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	host "github.com/cosmos/ibc-go/v2/modules/core/24-host"
 )
 
 func (s Sequence) ValidateBasic() error {
@@ -36,6 +39,66 @@ func (s GenesisState) ValidateBasic() error {
 			return sdkerrors.Wrapf(err, "gen message: %d", i)
 		}
 	}
+	for i := range s.CodeCommitments {
+		if err := s.CodeCommitments[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "code commitment: %d", i)
+		}
+	}
+	for i := range s.ReservedContractAddresses {
+		if err := s.ReservedContractAddresses[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "reserved contract address: %d", i)
+		}
+	}
+	if s.AverageBlockTime < 0 {
+		return sdkerrors.Wrap(ErrInvalid, "average block time")
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation of the code commitment
+func (c CodeCommitment) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(c.Creator); err != nil {
+		return sdkerrors.Wrap(err, "creator")
+	}
+	if len(c.Checksum) != sha256.Size {
+		return sdkerrors.Wrapf(ErrInvalid, "checksum must be %d bytes", sha256.Size)
+	}
+	if c.CodeSize == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "code size")
+	}
+	if c.InstantiatePermission != nil {
+		if err := c.InstantiatePermission.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, "instantiate permission")
+		}
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation of the reserved contract address
+func (r ReservedContractAddress) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(r.ContractAddress); err != nil {
+		return sdkerrors.Wrap(err, "contract address")
+	}
+	if _, err := sdk.AccAddressFromBech32(r.Creator); err != nil {
+		return sdkerrors.Wrap(err, "creator")
+	}
+	if len(r.CodeHash) != ChecksumLength {
+		return sdkerrors.Wrapf(ErrInvalid, "code hash must be %d bytes", ChecksumLength)
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation of the in-flight packet
+func (p InFlightPacket) ValidateBasic() error {
+	if err := host.PortIdentifierValidator(p.SourcePort); err != nil {
+		return sdkerrors.Wrap(err, "source port")
+	}
+	if err := host.ChannelIdentifierValidator(p.SourceChannel); err != nil {
+		return sdkerrors.Wrap(err, "source channel")
+	}
+	if p.Sequence == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "sequence")
+	}
 	return nil
 }
 
@@ -68,6 +131,24 @@ func (c Contract) ValidateBasic() error {
 			return sdkerrors.Wrapf(err, "contract state %d", i)
 		}
 	}
+	for i := range c.TimelockedFunds {
+		if err := c.TimelockedFunds[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "timelocked funds %d", i)
+		}
+	}
+	for i := range c.InFlightPackets {
+		if err := c.InFlightPackets[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "in-flight packet %d", i)
+		}
+	}
+	if c.ReinitMsg != nil {
+		if len(c.ContractState) != 0 {
+			return sdkerrors.Wrap(ErrInvalid, "contract state and reinit msg are mutually exclusive")
+		}
+		if err := c.ReinitMsg.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, "reinit msg")
+		}
+	}
 	return nil
 }
 
@@ -30,6 +30,15 @@ type GenesisState struct {
 	Contracts []Contract             `protobuf:"bytes,3,rep,name=contracts,proto3" json:"contracts,omitempty"`
 	Sequences []Sequence             `protobuf:"bytes,4,rep,name=sequences,proto3" json:"sequences,omitempty"`
 	GenMsgs   []GenesisState_GenMsgs `protobuf:"bytes,5,rep,name=gen_msgs,json=genMsgs,proto3" json:"gen_msgs,omitempty"`
+	// CodeCommitments are the code hash commitments that were not revealed, yet
+	CodeCommitments []CodeCommitment `protobuf:"bytes,6,rep,name=code_commitments,json=codeCommitments,proto3" json:"code_commitments,omitempty"`
+	// ReservedContractAddresses are the contract addresses reserved by
+	// governance that were not instantiated into, yet
+	ReservedContractAddresses []ReservedContractAddress `protobuf:"bytes,7,rep,name=reserved_contract_addresses,json=reservedContractAddresses,proto3" json:"reserved_contract_addresses,omitempty"`
+	// AverageBlockTime is the moving average of the block time in nanoseconds.
+	// The time of the last block is not exported so that the downtime of a
+	// chain upgrade is not tracked as block time.
+	AverageBlockTime int64 `protobuf:"varint,8,opt,name=average_block_time,json=averageBlockTime,proto3" json:"average_block_time,omitempty"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -100,6 +109,27 @@ func (m *GenesisState) GetGenMsgs() []GenesisState_GenMsgs {
 	return nil
 }
 
+func (m *GenesisState) GetCodeCommitments() []CodeCommitment {
+	if m != nil {
+		return m.CodeCommitments
+	}
+	return nil
+}
+
+func (m *GenesisState) GetReservedContractAddresses() []ReservedContractAddress {
+	if m != nil {
+		return m.ReservedContractAddresses
+	}
+	return nil
+}
+
+func (m *GenesisState) GetAverageBlockTime() int64 {
+	if m != nil {
+		return m.AverageBlockTime
+	}
+	return 0
+}
+
 // GenMsgs define the messages that can be executed during genesis phase in
 // order. The intention is to have more human readable data that is auditable.
 type GenesisState_GenMsgs struct {
@@ -277,6 +307,19 @@ type Contract struct {
 	ContractAddress string       `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
 	ContractInfo    ContractInfo `protobuf:"bytes,2,opt,name=contract_info,json=contractInfo,proto3" json:"contract_info"`
 	ContractState   []Model      `protobuf:"bytes,3,rep,name=contract_state,json=contractState,proto3" json:"contract_state"`
+	// ReinitMsg when set, the contract is initialized by calling its
+	// instantiate entrypoint with this message instead of importing the
+	// contract state. Can not be combined with contract state.
+	ReinitMsg RawContractMessage `protobuf:"bytes,4,opt,name=reinit_msg,json=reinitMsg,proto3,casttype=RawContractMessage" json:"reinit_msg,omitempty"`
+	// TimelockedFunds are the funds held by the module until they are released
+	// to the contract
+	TimelockedFunds []TimelockedFunds `protobuf:"bytes,5,rep,name=timelocked_funds,json=timelockedFunds,proto3" json:"timelocked_funds,omitempty"`
+	// MigrationProgress is the state iteration position of an unfinished
+	// contract migration that is resumed with the next migrate call
+	MigrationProgress []byte `protobuf:"bytes,6,opt,name=migration_progress,json=migrationProgress,proto3" json:"migration_progress,omitempty"`
+	// InFlightPackets are the IBC transfer packets sent by the contract that
+	// were neither acknowledged nor timed out, yet
+	InFlightPackets []InFlightPacket `protobuf:"bytes,7,rep,name=in_flight_packets,json=inFlightPackets,proto3" json:"in_flight_packets,omitempty"`
 }
 
 func (m *Contract) Reset()         { *m = Contract{} }
@@ -333,6 +376,34 @@ func (m *Contract) GetContractState() []Model {
 	return nil
 }
 
+func (m *Contract) GetReinitMsg() RawContractMessage {
+	if m != nil {
+		return m.ReinitMsg
+	}
+	return nil
+}
+
+func (m *Contract) GetTimelockedFunds() []TimelockedFunds {
+	if m != nil {
+		return m.TimelockedFunds
+	}
+	return nil
+}
+
+func (m *Contract) GetMigrationProgress() []byte {
+	if m != nil {
+		return m.MigrationProgress
+	}
+	return nil
+}
+
+func (m *Contract) GetInFlightPackets() []InFlightPacket {
+	if m != nil {
+		return m.InFlightPackets
+	}
+	return nil
+}
+
 // Sequence key and value of an id generation counter
 type Sequence struct {
 	IDKey []byte `protobuf:"bytes,1,opt,name=id_key,json=idKey,proto3" json:"id_key,omitempty"`
@@ -397,48 +468,63 @@ func init() {
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/genesis.proto", fileDescriptor_2ab3f539b23472a6) }
 
 var fileDescriptor_2ab3f539b23472a6 = []byte{
-	// 646 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0xcf, 0x6e, 0xd3, 0x4e,
-	0x10, 0xc7, 0xe3, 0x26, 0x4e, 0x93, 0x69, 0x7e, 0xbf, 0x56, 0xdb, 0xaa, 0x35, 0x06, 0x9c, 0x28,
-	0xa0, 0x2a, 0x48, 0x28, 0x51, 0x8b, 0xc4, 0x0d, 0x01, 0x6e, 0x2b, 0x6a, 0x55, 0x95, 0xc0, 0x15,
-	0x42, 0x42, 0xaa, 0x22, 0xd7, 0xde, 0x1a, 0x8b, 0xda, 0x1b, 0xb2, 0x9b, 0x52, 0x9f, 0x79, 0x01,
-	0x1e, 0x01, 0x5e, 0x06, 0xf5, 0xd8, 0x23, 0xa7, 0x08, 0xa5, 0x37, 0x9e, 0x02, 0xed, 0x1f, 0xbb,
-	0x06, 0xa7, 0x17, 0x2b, 0x3b, 0xf3, 0x9d, 0xcf, 0xfc, 0xc9, 0xec, 0x82, 0xe5, 0x13, 0x1a, 0x7f,
-	0xf6, 0x68, 0x3c, 0x10, 0x9f, 0xf3, 0xad, 0x41, 0x88, 0x13, 0x4c, 0x23, 0xda, 0x1f, 0x8d, 0x09,
-	0x23, 0x68, 0x25, 0xf3, 0xf7, 0xc5, 0xe7, 0x7c, 0xcb, 0x5c, 0x0b, 0x49, 0x48, 0x84, 0x73, 0xc0,
-	0x7f, 0x49, 0x9d, 0x79, 0xaf, 0xc4, 0x61, 0xe9, 0x08, 0x2b, 0x8a, 0x79, 0xa7, 0xec, 0xbd, 0x90,
-	0xae, 0xee, 0x37, 0x1d, 0x5a, 0xaf, 0x64, 0xca, 0x23, 0xe6, 0x31, 0x8c, 0x9e, 0x42, 0x7d, 0xe4,
-	0x8d, 0xbd, 0x98, 0x1a, 0x5a, 0x47, 0xeb, 0x2d, 0x6d, 0x1b, 0xfd, 0x7f, 0x4b, 0xe8, 0xbf, 0x16,
-	0x7e, 0xbb, 0x76, 0x39, 0x6d, 0x57, 0x5c, 0xa5, 0x46, 0x7b, 0xa0, 0xfb, 0x24, 0xc0, 0xd4, 0x58,
-	0xe8, 0x54, 0x7b, 0x4b, 0xdb, 0xeb, 0xe5, 0xb0, 0x1d, 0x12, 0x60, 0x7b, 0x83, 0x07, 0xfd, 0x9e,
-	0xb6, 0x97, 0x85, 0xf8, 0x31, 0x89, 0x23, 0x86, 0xe3, 0x11, 0x4b, 0x5d, 0x19, 0x8d, 0xde, 0x42,
-	0xd3, 0x27, 0x09, 0x1b, 0x7b, 0x3e, 0xa3, 0x46, 0x55, 0xa0, 0xcc, 0x79, 0x28, 0x29, 0xb1, 0xef,
-	0x2a, 0xdc, 0x6a, 0x1e, 0x54, 0x40, 0xde, 0x90, 0x38, 0x96, 0xe2, 0x4f, 0x13, 0x9c, 0xf8, 0x98,
-	0x1a, 0xb5, 0xdb, 0xb0, 0x47, 0x4a, 0x72, 0x83, 0xcd, 0x83, 0x8a, 0xd8, 0xdc, 0x88, 0x8e, 0xa1,
-	0x11, 0xe2, 0x64, 0x18, 0xd3, 0x90, 0x1a, 0xba, 0xa0, 0x6e, 0x96, 0xa9, 0xc5, 0xf1, 0xf2, 0xc3,
-	0x21, 0x0d, 0xa9, 0x6d, 0xaa, 0x0c, 0x28, 0x8b, 0x2f, 0x24, 0x58, 0x0c, 0xa5, 0xc8, 0xfc, 0xb2,
-	0x00, 0x8b, 0x2a, 0x00, 0x3d, 0x07, 0xa0, 0x8c, 0x8c, 0xf1, 0x90, 0xcf, 0x49, 0xfd, 0x37, 0x56,
-	0x39, 0xd9, 0x21, 0x0d, 0x8f, 0xb8, 0x8c, 0x0f, 0x7b, 0xbf, 0xe2, 0x36, 0x69, 0x76, 0x40, 0xc7,
-	0xb0, 0x16, 0x25, 0x94, 0x79, 0x09, 0x8b, 0x3c, 0xc6, 0x31, 0x72, 0x36, 0xc6, 0x82, 0x40, 0xf5,
-	0xe6, 0xa2, 0x9c, 0x9b, 0x80, 0x6c, 0xe4, 0xfb, 0x15, 0x77, 0x35, 0x2a, 0x9b, 0xd1, 0x1b, 0x58,
-	0xc1, 0x17, 0xd8, 0x9f, 0x14, 0xd1, 0x55, 0x81, 0x7e, 0x38, 0x17, 0xbd, 0x27, 0xc5, 0x05, 0xec,
-	0x32, 0xfe, 0xdb, 0x64, 0xeb, 0x50, 0xa5, 0x93, 0xb8, 0xfb, 0x5d, 0x83, 0x9a, 0xe8, 0xe0, 0x01,
-	0x2c, 0xf2, 0xe6, 0x87, 0x51, 0x20, 0xfa, 0xaf, 0xd9, 0x30, 0x9b, 0xb6, 0xeb, 0xdc, 0xe5, 0xec,
-	0xba, 0x75, 0xee, 0x72, 0x02, 0xf4, 0x8c, 0x2f, 0x10, 0x17, 0x25, 0xa7, 0x44, 0xf5, 0x66, 0xce,
-	0xdf, 0x45, 0x27, 0x39, 0x25, 0x6a, 0x89, 0x1b, 0xbe, 0x3a, 0xa3, 0xfb, 0x00, 0x22, 0xfc, 0x24,
-	0x65, 0x98, 0x8a, 0x06, 0x5a, 0xae, 0x00, 0xda, 0xdc, 0x80, 0xd6, 0xa1, 0x3e, 0x8a, 0x92, 0x04,
-	0x07, 0x46, 0xad, 0xa3, 0xf5, 0x1a, 0xae, 0x3a, 0x75, 0x7f, 0x68, 0xd0, 0xc8, 0x47, 0xf1, 0x08,
-	0x56, 0xb2, 0x11, 0x0c, 0xbd, 0x20, 0x18, 0x63, 0x2a, 0x2f, 0x53, 0xd3, 0x5d, 0xce, 0xec, 0x2f,
-	0xa5, 0x19, 0x39, 0xf0, 0x5f, 0x2e, 0x2d, 0x54, 0x6c, 0xdd, 0xbe, 0xf2, 0x85, 0xaa, 0x5b, 0x7e,
-	0xc1, 0x86, 0x76, 0xe1, 0xff, 0x1c, 0x45, 0xf9, 0xae, 0xa9, 0xeb, 0xb3, 0x31, 0x67, 0xfc, 0x24,
-	0xc0, 0x67, 0x0a, 0x92, 0xe7, 0x17, 0xfb, 0xd9, 0xb5, 0xa1, 0x91, 0xdd, 0x02, 0xd4, 0x81, 0x7a,
-	0x14, 0x0c, 0x3f, 0xe2, 0x54, 0x54, 0xdf, 0xb2, 0x9b, 0xb3, 0x69, 0x5b, 0x77, 0x76, 0x0f, 0x70,
-	0xea, 0xea, 0x51, 0x70, 0x80, 0x53, 0xb4, 0x06, 0xfa, 0xb9, 0x77, 0x36, 0xc1, 0xa2, 0xec, 0x9a,
-	0x2b, 0x0f, 0xf6, 0x8b, 0xcb, 0x99, 0xa5, 0x5d, 0xcd, 0x2c, 0xed, 0xd7, 0xcc, 0xd2, 0xbe, 0x5e,
-	0x5b, 0x95, 0xab, 0x6b, 0xab, 0xf2, 0xf3, 0xda, 0xaa, 0xbc, 0xdf, 0x0c, 0x23, 0xf6, 0x61, 0x72,
-	0xd2, 0xf7, 0x49, 0x3c, 0xd8, 0x21, 0x34, 0x7e, 0x97, 0xbd, 0x49, 0xc1, 0xe0, 0x42, 0xbe, 0x4d,
-	0xe2, 0xd9, 0x3a, 0xa9, 0x8b, 0xc7, 0xe9, 0xc9, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x7c, 0x05,
-	0x87, 0xde, 0x1f, 0x05, 0x00, 0x00,
+	// 890 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x95, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0xc7, 0x25, 0xeb, 0x7b, 0xa2, 0xd6, 0xca, 0xc6, 0x70, 0x18, 0xb9, 0xa5, 0x54, 0xa5, 0x0d,
+	0x14, 0x20, 0x91, 0x90, 0x14, 0xed, 0xad, 0x68, 0x4b, 0x3b, 0x69, 0x84, 0xc0, 0x80, 0x4b, 0xa7,
+	0x28, 0x50, 0x20, 0x20, 0x68, 0x72, 0xcc, 0x2c, 0x6c, 0x72, 0x15, 0xee, 0x4a, 0xb1, 0xce, 0x7d,
+	0x81, 0xf6, 0xd6, 0x6b, 0xdf, 0x26, 0xc7, 0x1c, 0x8b, 0x1e, 0x84, 0x42, 0xbe, 0xf5, 0x11, 0x7a,
+	0x2a, 0x76, 0xb9, 0xa4, 0x29, 0x53, 0xc9, 0x85, 0xd2, 0xee, 0xfc, 0xe7, 0x37, 0x1f, 0x9c, 0x5d,
+	0x82, 0xe9, 0x31, 0x1e, 0xbe, 0x71, 0x79, 0x38, 0x56, 0x8f, 0xf9, 0xa3, 0x71, 0x80, 0x11, 0x72,
+	0xca, 0x47, 0xd3, 0x98, 0x09, 0x46, 0x3a, 0xa9, 0x7d, 0xa4, 0x1e, 0xf3, 0x47, 0xdd, 0x9d, 0x80,
+	0x05, 0x4c, 0x19, 0xc7, 0xf2, 0x5f, 0xa2, 0xeb, 0x7e, 0x52, 0xe0, 0x88, 0xc5, 0x14, 0x35, 0xa5,
+	0x7b, 0xa7, 0x68, 0xbd, 0x48, 0x4c, 0x83, 0xbf, 0x1b, 0xd0, 0xfe, 0x21, 0x09, 0x79, 0x2c, 0x5c,
+	0x81, 0xe4, 0x6b, 0xa8, 0x4f, 0xdd, 0xd8, 0x0d, 0xb9, 0x51, 0xee, 0x97, 0x87, 0x37, 0x1e, 0x1b,
+	0xa3, 0xeb, 0x29, 0x8c, 0x8e, 0x94, 0xdd, 0xaa, 0xbe, 0x5d, 0xf6, 0x4a, 0xb6, 0x56, 0x93, 0x27,
+	0x50, 0xf3, 0x98, 0x8f, 0xdc, 0xd8, 0xea, 0x57, 0x86, 0x37, 0x1e, 0xef, 0x16, 0xdd, 0xf6, 0x99,
+	0x8f, 0xd6, 0x6d, 0xe9, 0xf4, 0xef, 0xb2, 0xb7, 0xad, 0xc4, 0x0f, 0x58, 0x48, 0x05, 0x86, 0x53,
+	0xb1, 0xb0, 0x13, 0x6f, 0xf2, 0x13, 0xb4, 0x3c, 0x16, 0x89, 0xd8, 0xf5, 0x04, 0x37, 0x2a, 0x0a,
+	0xd5, 0xdd, 0x84, 0x4a, 0x24, 0xd6, 0x9e, 0xc6, 0xdd, 0xca, 0x9c, 0x72, 0xc8, 0x2b, 0x92, 0xc4,
+	0x72, 0x7c, 0x3d, 0xc3, 0xc8, 0x43, 0x6e, 0x54, 0xdf, 0x87, 0x3d, 0xd6, 0x92, 0x2b, 0x6c, 0xe6,
+	0x94, 0xc7, 0x66, 0x9b, 0xe4, 0x25, 0x34, 0x03, 0x8c, 0x9c, 0x90, 0x07, 0xdc, 0xa8, 0x29, 0xea,
+	0xbd, 0x22, 0x35, 0xdf, 0x5e, 0xb9, 0x38, 0xe4, 0x01, 0xb7, 0xba, 0x3a, 0x02, 0x49, 0xfd, 0x73,
+	0x01, 0x1a, 0x41, 0x22, 0x22, 0x0c, 0x3a, 0xb2, 0x2b, 0x8e, 0xc7, 0xc2, 0x90, 0x8a, 0x10, 0x23,
+	0xc1, 0x8d, 0xba, 0x0a, 0xd3, 0xdf, 0xdc, 0xde, 0xfd, 0x4c, 0x68, 0x0d, 0x74, 0x80, 0xee, 0x75,
+	0x42, 0x2e, 0xd0, 0xb6, 0xb7, 0xe6, 0xc3, 0xc9, 0x1f, 0x65, 0xd8, 0x8b, 0x91, 0x63, 0x3c, 0x47,
+	0xdf, 0x49, 0xbb, 0xe7, 0xb8, 0xbe, 0x1f, 0x23, 0xe7, 0xc8, 0x8d, 0x86, 0x0a, 0x7e, 0xbf, 0x18,
+	0xdc, 0xd6, 0x4e, 0xe9, 0x8b, 0xf9, 0x3e, 0x71, 0xb1, 0x1e, 0xea, 0x2c, 0xbe, 0xf8, 0x00, 0x35,
+	0x97, 0xd0, 0x9d, 0x78, 0x33, 0x07, 0x39, 0x79, 0x00, 0xc4, 0x9d, 0x63, 0xec, 0x06, 0xe8, 0x9c,
+	0x9c, 0x33, 0xef, 0xcc, 0x11, 0x34, 0x44, 0xa3, 0xd9, 0x2f, 0x0f, 0x2b, 0x76, 0x47, 0x5b, 0x2c,
+	0x69, 0x78, 0x41, 0x43, 0xec, 0xfe, 0xba, 0x05, 0x0d, 0xdd, 0x6a, 0xf2, 0x2d, 0x00, 0x17, 0x2c,
+	0x96, 0x4d, 0xf0, 0x51, 0x4f, 0xb5, 0x59, 0x2c, 0xe1, 0x90, 0x07, 0xc7, 0x52, 0x26, 0xfb, 0xf8,
+	0xac, 0x64, 0xb7, 0x78, 0xba, 0x20, 0x2f, 0x61, 0x87, 0x46, 0x5c, 0xb8, 0x91, 0xa0, 0xae, 0xc0,
+	0xac, 0x02, 0x63, 0x4b, 0xa1, 0x86, 0x1b, 0x51, 0x93, 0x2b, 0x87, 0xb4, 0x96, 0x67, 0x25, 0xfb,
+	0x16, 0x2d, 0x6e, 0x93, 0x1f, 0xa1, 0x83, 0x17, 0xe8, 0xcd, 0xf2, 0xe8, 0x8a, 0x42, 0x7f, 0xbe,
+	0x11, 0xfd, 0x24, 0x11, 0xe7, 0xb0, 0xdb, 0xb8, 0xbe, 0x65, 0xd5, 0xa0, 0xc2, 0x67, 0xe1, 0xe0,
+	0xcf, 0x32, 0x54, 0x55, 0x05, 0x77, 0xa1, 0xa1, 0xc6, 0x80, 0xfa, 0xaa, 0xfe, 0xaa, 0x05, 0xab,
+	0x65, 0xaf, 0x2e, 0x4d, 0x93, 0x03, 0xbb, 0x2e, 0x4d, 0x13, 0x9f, 0x7c, 0x03, 0xad, 0x44, 0x14,
+	0x9d, 0x32, 0x5d, 0x5b, 0x77, 0xf3, 0x98, 0x4d, 0xa2, 0x53, 0xa6, 0x8f, 0x7f, 0xd3, 0xd3, 0x6b,
+	0xf2, 0x29, 0x80, 0x72, 0x3f, 0x59, 0x08, 0xe4, 0xaa, 0x80, 0xb6, 0xad, 0x80, 0x96, 0xdc, 0x20,
+	0xbb, 0x50, 0x9f, 0xd2, 0x28, 0x42, 0xdf, 0xa8, 0xf6, 0xcb, 0xc3, 0xa6, 0xad, 0x57, 0x83, 0xdf,
+	0xab, 0xd0, 0xcc, 0x5a, 0x71, 0x5f, 0x0e, 0xfc, 0xfa, 0x7c, 0xa8, 0x84, 0x5b, 0xf6, 0x76, 0xba,
+	0xaf, 0x27, 0x82, 0x4c, 0xe0, 0xa3, 0x4c, 0x9a, 0xcb, 0xd8, 0x7c, 0xff, 0x65, 0x91, 0xcb, 0xba,
+	0xed, 0xe5, 0xf6, 0xc8, 0x01, 0x7c, 0x9c, 0xa1, 0xb8, 0x3c, 0xa5, 0xfa, 0xe2, 0xb9, 0xbd, 0xa1,
+	0xfd, 0xcc, 0xc7, 0x73, 0x0d, 0xc9, 0xe2, 0x27, 0x17, 0xe7, 0x57, 0x00, 0x31, 0xd2, 0x88, 0x0a,
+	0x79, 0x9c, 0x55, 0x91, 0x6d, 0x6b, 0xf7, 0xbf, 0x65, 0x8f, 0xd8, 0xee, 0x9b, 0x34, 0x85, 0x43,
+	0xe4, 0xdc, 0x0d, 0xd0, 0x6e, 0x25, 0xca, 0x43, 0x1e, 0x90, 0x29, 0x74, 0xe4, 0x24, 0xcb, 0xc9,
+	0x45, 0xdf, 0x39, 0x9d, 0x45, 0x7e, 0x7a, 0x95, 0x7c, 0x56, 0x0c, 0xff, 0x22, 0x53, 0x3e, 0x95,
+	0xc2, 0xab, 0x43, 0x7e, 0x1d, 0x91, 0x3f, 0xe4, 0x62, 0xdd, 0x89, 0x3c, 0x04, 0x12, 0xd2, 0x20,
+	0x76, 0x05, 0x65, 0x91, 0x33, 0x8d, 0x59, 0xa0, 0xda, 0x5c, 0x57, 0x2f, 0xec, 0x66, 0x66, 0x39,
+	0xd2, 0x06, 0xf2, 0x1a, 0x6e, 0xd2, 0xc8, 0x39, 0x3d, 0xa7, 0xc1, 0x2b, 0xe1, 0x4c, 0x5d, 0xef,
+	0x0c, 0x45, 0x7a, 0x11, 0x6c, 0xb8, 0x85, 0x26, 0xd1, 0x53, 0xa5, 0x3c, 0x52, 0x42, 0xeb, 0xae,
+	0x4e, 0x70, 0xaf, 0x80, 0xc8, 0x67, 0x48, 0xd7, 0x9c, 0xf8, 0xc0, 0x82, 0x66, 0x7a, 0x15, 0x93,
+	0x3e, 0xd4, 0xa9, 0xef, 0x9c, 0xe1, 0x42, 0x0d, 0x42, 0xdb, 0x6a, 0xad, 0x96, 0xbd, 0xda, 0xe4,
+	0xe0, 0x39, 0x2e, 0xec, 0x1a, 0xf5, 0x9f, 0xe3, 0x82, 0xec, 0x40, 0x6d, 0xee, 0x9e, 0xcf, 0x50,
+	0x4d, 0x40, 0xd5, 0x4e, 0x16, 0xd6, 0x77, 0x6f, 0x57, 0x66, 0xf9, 0xdd, 0xca, 0x2c, 0xff, 0xb3,
+	0x32, 0xcb, 0xbf, 0x5d, 0x9a, 0xa5, 0x77, 0x97, 0x66, 0xe9, 0xaf, 0x4b, 0xb3, 0xf4, 0xcb, 0xbd,
+	0x80, 0x8a, 0x57, 0xb3, 0x93, 0x91, 0xc7, 0xc2, 0xf1, 0x3e, 0xe3, 0xe1, 0xcf, 0xe9, 0x87, 0xd1,
+	0x1f, 0x5f, 0xa8, 0xdf, 0xe4, 0xdb, 0x79, 0x52, 0x57, 0x5f, 0xc8, 0x2f, 0xff, 0x0f, 0x00, 0x00,
+	0xff, 0xff, 0x63, 0x47, 0xf7, 0xe4, 0xa4, 0x07, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -461,6 +547,39 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AverageBlockTime != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.AverageBlockTime))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.ReservedContractAddresses) > 0 {
+		for iNdEx := len(m.ReservedContractAddresses) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ReservedContractAddresses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.CodeCommitments) > 0 {
+		for iNdEx := len(m.CodeCommitments) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CodeCommitments[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.GenMsgs) > 0 {
 		for iNdEx := len(m.GenMsgs) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -700,6 +819,48 @@ func (m *Contract) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.InFlightPackets) > 0 {
+		for iNdEx := len(m.InFlightPackets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.InFlightPackets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.MigrationProgress) > 0 {
+		i -= len(m.MigrationProgress)
+		copy(dAtA[i:], m.MigrationProgress)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.MigrationProgress)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.TimelockedFunds) > 0 {
+		for iNdEx := len(m.TimelockedFunds) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TimelockedFunds[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.ReinitMsg) > 0 {
+		i -= len(m.ReinitMsg)
+		copy(dAtA[i:], m.ReinitMsg)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.ReinitMsg)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.ContractState) > 0 {
 		for iNdEx := len(m.ContractState) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -812,6 +973,21 @@ func (m *GenesisState) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if len(m.CodeCommitments) > 0 {
+		for _, e := range m.CodeCommitments {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.ReservedContractAddresses) > 0 {
+		for _, e := range m.ReservedContractAddresses {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if m.AverageBlockTime != 0 {
+		n += 1 + sovGenesis(uint64(m.AverageBlockTime))
+	}
 	return n
 }
 
@@ -902,6 +1078,26 @@ func (m *Contract) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	l = len(m.ReinitMsg)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if len(m.TimelockedFunds) > 0 {
+		for _, e := range m.TimelockedFunds {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	l = len(m.MigrationProgress)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if len(m.InFlightPackets) > 0 {
+		for _, e := range m.InFlightPackets {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1125,6 +1321,93 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeCommitments", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeCommitments = append(m.CodeCommitments, CodeCommitment{})
+			if err := m.CodeCommitments[len(m.CodeCommitments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReservedContractAddresses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReservedContractAddresses = append(m.ReservedContractAddresses, ReservedContractAddress{})
+			if err := m.ReservedContractAddresses[len(m.ReservedContractAddresses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AverageBlockTime", wireType)
+			}
+			m.AverageBlockTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AverageBlockTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1585,6 +1868,142 @@ func (m *Contract) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReinitMsg", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReinitMsg = append(m.ReinitMsg[:0], dAtA[iNdEx:postIndex]...)
+			if m.ReinitMsg == nil {
+				m.ReinitMsg = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimelockedFunds", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TimelockedFunds = append(m.TimelockedFunds, TimelockedFunds{})
+			if err := m.TimelockedFunds[len(m.TimelockedFunds)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MigrationProgress", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MigrationProgress = append(m.MigrationProgress[:0], dAtA[iNdEx:postIndex]...)
+			if m.MigrationProgress == nil {
+				m.MigrationProgress = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InFlightPackets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InFlightPackets = append(m.InFlightPackets, InFlightPacket{})
+			if err := m.InFlightPackets[len(m.InFlightPackets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -21,6 +21,18 @@ func TestValidateGenesisState(t *testing.T) {
 			},
 			expError: true,
 		},
+		"params instantiate default permission unspecified": {
+			srcMutator: func(s *GenesisState) {
+				s.Params.InstantiateDefaultPermission = AccessTypeUnspecified
+			},
+			expError: true,
+		},
+		"params instantiate default permission unknown": {
+			srcMutator: func(s *GenesisState) {
+				s.Params.InstantiateDefaultPermission = 99
+			},
+			expError: true,
+		},
 		"codeinfo invalid": {
 			srcMutator: func(s *GenesisState) {
 				s.Codes[0].CodeInfo.CodeHash = nil
@@ -157,6 +169,36 @@ func TestContractValidateBasic(t *testing.T) {
 			},
 			expError: true,
 		},
+		"in-flight packet": {
+			srcMutator: func(c *Contract) {
+				c.InFlightPackets = []InFlightPacket{{SourcePort: "transfer", SourceChannel: "channel-0", Sequence: 1}}
+			},
+		},
+		"in-flight packet invalid": {
+			srcMutator: func(c *Contract) {
+				c.InFlightPackets = []InFlightPacket{{SourcePort: "transfer", SourceChannel: "channel-0"}}
+			},
+			expError: true,
+		},
+		"reinit msg without state": {
+			srcMutator: func(c *Contract) {
+				c.ContractState = nil
+				c.ReinitMsg = []byte(`{"foo":"bar"}`)
+			},
+		},
+		"reinit msg with state": {
+			srcMutator: func(c *Contract) {
+				c.ReinitMsg = []byte(`{"foo":"bar"}`)
+			},
+			expError: true,
+		},
+		"reinit msg invalid": {
+			srcMutator: func(c *Contract) {
+				c.ContractState = nil
+				c.ReinitMsg = []byte(`not a json`)
+			},
+			expError: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
 )
 
 const (
@@ -31,9 +32,23 @@ var (
 	ContractByCodeIDAndCreatedSecondaryIndexPrefix = []byte{0x06}
 	PinnedCodeIndexPrefix                          = []byte{0x07}
 	TXCounterPrefix                                = []byte{0x08}
+	ContractsByCreatorPrefix                       = []byte{0x09}
+	MigrationProgressPrefix                        = []byte{0x0a}
+	CodeInstanceCountPrefix                        = []byte{0x0b}
+	InFlightPacketPrefix                           = []byte{0x0c}
+	InFlightPacketSenderPrefix                     = []byte{0x0d}
+	ReservedContractAddressPrefix                  = []byte{0x0e}
+	ReservedContractAddressByCreatorPrefix         = []byte{0x0f}
+	BlockTimeAveragePrefix                         = []byte{0x10}
+	CodeIDByChecksumPrefix                         = []byte{0x11}
+	TimelockedFundsPrefix                          = []byte{0x12}
+	CodeCommitmentPrefix                           = []byte{0x13}
+	CodeCountKey                                   = []byte{0x14}
+	CodeCommitmentExpiryPrefix                     = []byte{0x15}
 
 	KeyLastCodeID     = append(SequenceKeyPrefix, []byte("lastCodeId")...)
 	KeyLastInstanceID = append(SequenceKeyPrefix, []byte("lastContractId")...)
+	KeyLastTimelockID = append(SequenceKeyPrefix, []byte("lastTimelockId")...)
 )
 
 // GetCodeKey constructs the key for retreiving the ID for the WASM code
@@ -42,11 +57,50 @@ func GetCodeKey(codeID uint64) []byte {
 	return append(CodeKeyPrefix, contractIDBz...)
 }
 
+// GetCodeInstanceCountKey returns the key for the number of contract instances of a code
+func GetCodeInstanceCountKey(codeID uint64) []byte {
+	return append(CodeInstanceCountPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetCodeIDByChecksumKey returns the key for the code id index of a wasm code checksum: `<prefix><checksum>`
+func GetCodeIDByChecksumKey(checksum []byte) []byte {
+	return append(CodeIDByChecksumPrefix, checksum...)
+}
+
+// GetCodeCommitmentKey returns the key for the code hash commitment of a wasm code by the creator:
+// `<prefix><creatorAddrLen><creatorAddr><checksum>`
+func GetCodeCommitmentKey(creator sdk.AccAddress, checksum []byte) []byte {
+	bz := address.MustLengthPrefix(creator)
+	prefixLen := len(CodeCommitmentPrefix)
+	r := make([]byte, prefixLen+len(bz)+len(checksum))
+	copy(r[0:], CodeCommitmentPrefix)
+	copy(r[prefixLen:], bz)
+	copy(r[prefixLen+len(bz):], checksum)
+	return r
+}
+
+// GetCodeCommitmentExpiryKey returns the key for the expiry queue of code hash commitments:
+// `<prefix><expiryHeight><creatorAddrLen><creatorAddr><checksum>`
+func GetCodeCommitmentExpiryKey(expiryHeight int64, creator sdk.AccAddress, checksum []byte) []byte {
+	commitmentKey := GetCodeCommitmentKey(creator, checksum)[len(CodeCommitmentPrefix):]
+	prefixLen := len(CodeCommitmentExpiryPrefix)
+	r := make([]byte, prefixLen+8+len(commitmentKey))
+	copy(r[0:], CodeCommitmentExpiryPrefix)
+	copy(r[prefixLen:], sdk.Uint64ToBigEndian(uint64(expiryHeight)))
+	copy(r[prefixLen+8:], commitmentKey)
+	return r
+}
+
 // GetContractAddressKey returns the key for the WASM contract instance
 func GetContractAddressKey(addr sdk.AccAddress) []byte {
 	return append(ContractKeyPrefix, addr...)
 }
 
+// GetMigrationProgressKey returns the key for the stored state iteration position of an unfinished contract migration
+func GetMigrationProgressKey(addr sdk.AccAddress) []byte {
+	return append(MigrationProgressPrefix, addr...)
+}
+
 // GetContractStorePrefix returns the store prefix for the WASM contract instance
 func GetContractStorePrefix(addr sdk.AccAddress) []byte {
 	return append(ContractStorePrefix, addr...)
@@ -74,6 +128,28 @@ func GetContractByCodeIDSecondaryIndexPrefix(codeID uint64) []byte {
 	return r
 }
 
+// GetContractByCreatorSecondaryIndexKey returns the key for the secondary index:
+// `<prefix><creatorAddrLen><creatorAddr><created><contractAddr>`
+func GetContractByCreatorSecondaryIndexKey(creator sdk.AccAddress, created *AbsoluteTxPosition, contractAddr sdk.AccAddress) []byte {
+	prefix := GetContractsByCreatorPrefix(creator)
+	prefixLen := len(prefix)
+	r := make([]byte, prefixLen+AbsoluteTxPositionLen+len(contractAddr.Bytes()))
+	copy(r[0:], prefix)
+	copy(r[prefixLen:], created.Bytes())
+	copy(r[prefixLen+AbsoluteTxPositionLen:], contractAddr)
+	return r
+}
+
+// GetContractsByCreatorPrefix returns the prefix for the creator secondary index: `<prefix><creatorAddrLen><creatorAddr>`
+func GetContractsByCreatorPrefix(creator sdk.AccAddress) []byte {
+	bz := address.MustLengthPrefix(creator)
+	prefixLen := len(ContractsByCreatorPrefix)
+	r := make([]byte, prefixLen+len(bz))
+	copy(r[0:], ContractsByCreatorPrefix)
+	copy(r[prefixLen:], bz)
+	return r
+}
+
 // GetContractCodeHistoryElementKey returns the key a contract code history entry: `<prefix><contractAddr><position>`
 func GetContractCodeHistoryElementKey(contractAddr sdk.AccAddress, pos uint64) []byte {
 	prefix := GetContractCodeHistoryElementPrefix(contractAddr)
@@ -93,6 +169,82 @@ func GetContractCodeHistoryElementPrefix(contractAddr sdk.AccAddress) []byte {
 	return r
 }
 
+// GetInFlightPacketsPrefix returns the key prefix for the in-flight packets of a contract: `<prefix><contractAddrLen><contractAddr>`
+func GetInFlightPacketsPrefix(contractAddr sdk.AccAddress) []byte {
+	bz := address.MustLengthPrefix(contractAddr)
+	prefixLen := len(InFlightPacketPrefix)
+	r := make([]byte, prefixLen+len(bz))
+	copy(r[0:], InFlightPacketPrefix)
+	copy(r[prefixLen:], bz)
+	return r
+}
+
+// GetInFlightPacketKey returns the key for an in-flight packet of a contract:
+// `<prefix><contractAddrLen><contractAddr><portLen><port><channelLen><channel><sequence>`
+func GetInFlightPacketKey(contractAddr sdk.AccAddress, portID, channelID string, sequence uint64) []byte {
+	return append(GetInFlightPacketsPrefix(contractAddr), packetIdentifier(portID, channelID, sequence)...)
+}
+
+// GetInFlightPacketSenderKey returns the key for the contract that sent an in-flight packet:
+// `<prefix><portLen><port><channelLen><channel><sequence>`
+func GetInFlightPacketSenderKey(portID, channelID string, sequence uint64) []byte {
+	id := packetIdentifier(portID, channelID, sequence)
+	prefixLen := len(InFlightPacketSenderPrefix)
+	r := make([]byte, prefixLen+len(id))
+	copy(r[0:], InFlightPacketSenderPrefix)
+	copy(r[prefixLen:], id)
+	return r
+}
+
+// packetIdentifier returns the unique identifier of a packet on this chain: `<portLen><port><channelLen><channel><sequence>`
+func packetIdentifier(portID, channelID string, sequence uint64) []byte {
+	r := make([]byte, 0, 2+len(portID)+len(channelID)+8)
+	r = append(r, byte(len(portID)))
+	r = append(r, portID...)
+	r = append(r, byte(len(channelID)))
+	r = append(r, channelID...)
+	return append(r, sdk.Uint64ToBigEndian(sequence)...)
+}
+
+// GetTimelockedFundsPrefix returns the key prefix for the timelocked funds of a contract:
+// `<prefix><contractAddrLen><contractAddr>`
+func GetTimelockedFundsPrefix(contractAddr sdk.AccAddress) []byte {
+	bz := address.MustLengthPrefix(contractAddr)
+	prefixLen := len(TimelockedFundsPrefix)
+	r := make([]byte, prefixLen+len(bz))
+	copy(r[0:], TimelockedFundsPrefix)
+	copy(r[prefixLen:], bz)
+	return r
+}
+
+// GetTimelockedFundsKey returns the key for timelocked funds of a contract: `<prefix><contractAddrLen><contractAddr><lockID>`
+func GetTimelockedFundsKey(contractAddr sdk.AccAddress, lockID uint64) []byte {
+	return append(GetTimelockedFundsPrefix(contractAddr), sdk.Uint64ToBigEndian(lockID)...)
+}
+
+// GetReservedContractAddressKey returns the key for a contract address reserved by governance: `<prefix><contractAddr>`
+func GetReservedContractAddressKey(contractAddr sdk.AccAddress) []byte {
+	return append(ReservedContractAddressPrefix, contractAddr...)
+}
+
+// GetReservedContractAddressByCreatorPrefix returns the prefix for the creator and code index of reserved contract
+// addresses: `<prefix><creatorAddrLen><creatorAddr><codeHash>`
+func GetReservedContractAddressByCreatorPrefix(creator sdk.AccAddress, codeHash []byte) []byte {
+	bz := address.MustLengthPrefix(creator)
+	prefixLen := len(ReservedContractAddressByCreatorPrefix)
+	r := make([]byte, prefixLen+len(bz)+len(codeHash))
+	copy(r[0:], ReservedContractAddressByCreatorPrefix)
+	copy(r[prefixLen:], bz)
+	copy(r[prefixLen+len(bz):], codeHash)
+	return r
+}
+
+// GetReservedContractAddressByCreatorKey returns the key for the creator and code index of a reserved contract address:
+// `<prefix><creatorAddrLen><creatorAddr><codeHash><contractAddr>`
+func GetReservedContractAddressByCreatorKey(creator sdk.AccAddress, codeHash []byte, contractAddr sdk.AccAddress) []byte {
+	return append(GetReservedContractAddressByCreatorPrefix(creator, codeHash), contractAddr...)
+}
+
 // GetPinnedCodeIndexPrefix returns the key prefix for a code id pinned into the wasmvm cache
 func GetPinnedCodeIndexPrefix(codeID uint64) []byte {
 	prefixLen := len(PinnedCodeIndexPrefix)
@@ -43,3 +43,21 @@ func TestGetContractByCreatedSecondaryIndexKey(t *testing.T) {
 	}
 	assert.Equal(t, exp, got)
 }
+
+func TestGetContractByCreatorSecondaryIndexKey(t *testing.T) {
+	creatorAddr := bytes.Repeat([]byte{5}, address.Len)
+	contractAddr := bytes.Repeat([]byte{4}, address.Len)
+	pos := &AbsoluteTxPosition{2 + 1<<(8*7), 3 + 1<<(8*7)}
+	got := GetContractByCreatorSecondaryIndexKey(creatorAddr, pos, contractAddr)
+	exp := []byte{9, // prefix
+		byte(address.Len), // creator address length
+	}
+	exp = append(exp, creatorAddr...)
+	exp = append(exp,
+		1, 0, 0, 0, 0, 0, 0, 2, // height
+		1, 0, 0, 0, 0, 0, 0, 3, // index
+	)
+	exp = append(exp, contractAddr...)
+	assert.Len(t, got, 2+address.Len+AbsoluteTxPositionLen+address.Len)
+	assert.Equal(t, exp, got)
+}
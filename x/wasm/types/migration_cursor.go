@@ -0,0 +1,53 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DefaultStateBatchLimit is the number of state models returned for a migration state batch query without limit
+	DefaultStateBatchLimit = 100
+	// MaxStateBatchLimit is the max number of state models returned for a single migration state batch query
+	MaxStateBatchLimit = 1000
+)
+
+// MigrationCursor tracks how far a contract has iterated over its own state while it is migrating.
+// The position is persisted after a successful migration so that a large state migration can be split
+// into multiple migrate calls that resume where the previous one stopped.
+type MigrationCursor struct {
+	contract sdk.AccAddress
+	position []byte
+	updated  bool
+}
+
+// NewMigrationCursor constructor
+func NewMigrationCursor(contractAddr sdk.AccAddress) *MigrationCursor {
+	return &MigrationCursor{contract: contractAddr}
+}
+
+// Contract returns the address of the migrating contract
+func (c *MigrationCursor) Contract() sdk.AccAddress {
+	return c.contract
+}
+
+// Update sets the key to continue the iteration with. A nil key marks the iteration as completed.
+func (c *MigrationCursor) Update(next []byte) {
+	c.position = next
+	c.updated = true
+}
+
+// Position returns the key to continue the iteration with and true when it was updated
+func (c *MigrationCursor) Position() ([]byte, bool) {
+	return c.position, c.updated
+}
+
+// WithMigrationCursor returns a new context with the cursor for the migrating contract.
+func WithMigrationCursor(ctx sdk.Context, cursor *MigrationCursor) sdk.Context {
+	return ctx.WithValue(contextKeyMigrationCursor, cursor)
+}
+
+// MigrationCursorFromContext returns the cursor of the migrating contract or nil when not set.
+func MigrationCursorFromContext(ctx sdk.Context) *MigrationCursor {
+	cursor, _ := ctx.Value(contextKeyMigrationCursor).(*MigrationCursor)
+	return cursor
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
@@ -17,11 +18,38 @@ const (
 	DefaultParamspace = ModuleName
 	// DefaultMaxWasmCodeSize limit max bytes read to prevent gzip bombs
 	DefaultMaxWasmCodeSize = 600 * 1024 * 2
+	// DefaultMaxMsgSize limit max bytes of a json message passed to a contract
+	DefaultMaxMsgSize = 1024 * 1024
+	// DefaultInstanceCost is how much SDK gas is charged each time a wasm instance is loaded
+	DefaultInstanceCost uint64 = 60_000
+	// DefaultCompileCost is how much SDK gas is charged *per byte* for compiling wasm code
+	DefaultCompileCost uint64 = 3
+	// DefaultMaxContractResponseDataSize limit max bytes of the data returned in a contract response
+	DefaultMaxContractResponseDataSize = 256 * 1024
+	// DefaultBlockTimeAverageWindow is the number of blocks of the block time moving average
+	DefaultBlockTimeAverageWindow = 100
+	// ChecksumLength is the length of a wasm code checksum (sha256)
+	ChecksumLength = 32
 )
 
 var ParamStoreKeyUploadAccess = []byte("uploadAccess")
 var ParamStoreKeyInstantiateAccess = []byte("instantiateAccess")
 var ParamStoreKeyMaxWasmCodeSize = []byte("maxWasmCodeSize")
+var ParamStoreKeyMaxMsgSize = []byte("maxMsgSize")
+var ParamStoreKeyInstanceCost = []byte("instanceCost")
+var ParamStoreKeyCompileCost = []byte("compileCost")
+var ParamStoreKeyCodeChecksumAllowlist = []byte("codeChecksumAllowlist")
+var ParamStoreKeyMaxContractResponseDataSize = []byte("maxContractResponseDataSize")
+var ParamStoreKeyQueryCacheEnabled = []byte("queryCacheEnabled")
+var ParamStoreKeyMaxGasPerTxPercent = []byte("maxGasPerTxPercent")
+var ParamStoreKeyMaxCodeCount = []byte("maxCodeCount")
+var ParamStoreKeyMaxGasRefundPercent = []byte("maxGasRefundPercent")
+var ParamStoreKeyAllowContractInstantiation = []byte("allowContractInstantiation")
+var ParamStoreKeyMaxReplyGas = []byte("maxReplyGas")
+var ParamStoreKeyMaxIteratorItems = []byte("maxIteratorItems")
+var ParamStoreKeyBlockTimeAverageWindow = []byte("blockTimeAverageWindow")
+var ParamStoreKeyDeduplicateCodeUploads = []byte("deduplicateCodeUploads")
+var ParamStoreKeyCodeCommitmentWindow = []byte("codeCommitmentWindow")
 
 var AllAccessTypes = []AccessType{
 	AccessTypeNobody,
@@ -99,6 +127,12 @@ func DefaultParams() Params {
 		CodeUploadAccess:             AllowEverybody,
 		InstantiateDefaultPermission: AccessTypeEverybody,
 		MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+		MaxMsgSize:                   DefaultMaxMsgSize,
+		InstanceCost:                 DefaultInstanceCost,
+		CompileCost:                  DefaultCompileCost,
+		MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
+		AllowContractInstantiation:   true,
+		BlockTimeAverageWindow:       DefaultBlockTimeAverageWindow,
 	}
 }
 
@@ -116,6 +150,21 @@ func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 		paramtypes.NewParamSetPair(ParamStoreKeyUploadAccess, &p.CodeUploadAccess, validateAccessConfig),
 		paramtypes.NewParamSetPair(ParamStoreKeyInstantiateAccess, &p.InstantiateDefaultPermission, validateAccessType),
 		paramtypes.NewParamSetPair(ParamStoreKeyMaxWasmCodeSize, &p.MaxWasmCodeSize, validateMaxWasmCodeSize),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxMsgSize, &p.MaxMsgSize, validateMaxMsgSize),
+		paramtypes.NewParamSetPair(ParamStoreKeyInstanceCost, &p.InstanceCost, validateGasCost),
+		paramtypes.NewParamSetPair(ParamStoreKeyCompileCost, &p.CompileCost, validateGasCost),
+		paramtypes.NewParamSetPair(ParamStoreKeyCodeChecksumAllowlist, &p.CodeChecksumAllowlist, validateChecksumAllowlist),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxContractResponseDataSize, &p.MaxContractResponseDataSize, validateMaxContractResponseDataSize),
+		paramtypes.NewParamSetPair(ParamStoreKeyQueryCacheEnabled, &p.QueryCacheEnabled, validateBool),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxGasPerTxPercent, &p.MaxGasPerTxPercent, validatePercent),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxCodeCount, &p.MaxCodeCount, validateUint64),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxGasRefundPercent, &p.MaxGasRefundPercent, validatePercent),
+		paramtypes.NewParamSetPair(ParamStoreKeyAllowContractInstantiation, &p.AllowContractInstantiation, validateBool),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxReplyGas, &p.MaxReplyGas, validateUint64),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxIteratorItems, &p.MaxIteratorItems, validateUint64),
+		paramtypes.NewParamSetPair(ParamStoreKeyBlockTimeAverageWindow, &p.BlockTimeAverageWindow, validateUint32),
+		paramtypes.NewParamSetPair(ParamStoreKeyDeduplicateCodeUploads, &p.DeduplicateCodeUploads, validateBool),
+		paramtypes.NewParamSetPair(ParamStoreKeyCodeCommitmentWindow, &p.CodeCommitmentWindow, validateUint32),
 	}
 }
 
@@ -130,6 +179,42 @@ func (p Params) ValidateBasic() error {
 	if err := validateMaxWasmCodeSize(p.MaxWasmCodeSize); err != nil {
 		return errors.Wrap(err, "max wasm code size")
 	}
+	if err := validateMaxMsgSize(p.MaxMsgSize); err != nil {
+		return errors.Wrap(err, "max msg size")
+	}
+	if err := validateGasCost(p.InstanceCost); err != nil {
+		return errors.Wrap(err, "instance cost")
+	}
+	if err := validateGasCost(p.CompileCost); err != nil {
+		return errors.Wrap(err, "compile cost")
+	}
+	if err := validateChecksumAllowlist(p.CodeChecksumAllowlist); err != nil {
+		return errors.Wrap(err, "code checksum allowlist")
+	}
+	if err := validateMaxContractResponseDataSize(p.MaxContractResponseDataSize); err != nil {
+		return errors.Wrap(err, "max contract response data size")
+	}
+	if err := validatePercent(p.MaxGasPerTxPercent); err != nil {
+		return errors.Wrap(err, "max gas per tx percent")
+	}
+	if err := validateUint64(p.MaxCodeCount); err != nil {
+		return errors.Wrap(err, "max code count")
+	}
+	if err := validatePercent(p.MaxGasRefundPercent); err != nil {
+		return errors.Wrap(err, "max gas refund percent")
+	}
+	if err := validateUint64(p.MaxReplyGas); err != nil {
+		return errors.Wrap(err, "max reply gas")
+	}
+	if err := validateUint64(p.MaxIteratorItems); err != nil {
+		return errors.Wrap(err, "max iterator items")
+	}
+	if err := validateUint32(p.BlockTimeAverageWindow); err != nil {
+		return errors.Wrap(err, "block time average window")
+	}
+	if err := validateUint32(p.CodeCommitmentWindow); err != nil {
+		return errors.Wrap(err, "code commitment window")
+	}
 	return nil
 }
 
@@ -168,6 +253,102 @@ func validateMaxWasmCodeSize(i interface{}) error {
 	return nil
 }
 
+func validateMaxMsgSize(i interface{}) error {
+	a, ok := i.(uint64)
+	if !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	if a == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "must be greater 0")
+	}
+	return nil
+}
+
+func validateMaxContractResponseDataSize(i interface{}) error {
+	a, ok := i.(uint64)
+	if !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	if a == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "must be greater 0")
+	}
+	return nil
+}
+
+func validatePercent(i interface{}) error {
+	a, ok := i.(uint32)
+	if !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	if a > 100 {
+		return sdkerrors.Wrap(ErrInvalid, "must not be greater 100")
+	}
+	return nil
+}
+
+func validateUint64(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	return nil
+}
+
+func validateUint32(i interface{}) error {
+	if _, ok := i.(uint32); !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	return nil
+}
+
+func validateBool(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	return nil
+}
+
+func validateGasCost(i interface{}) error {
+	a, ok := i.(uint64)
+	if !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	if a == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "must be greater 0")
+	}
+	return nil
+}
+
+func validateChecksumAllowlist(i interface{}) error {
+	a, ok := i.([][]byte)
+	if !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	unique := make(map[string]struct{}, len(a))
+	for _, checksum := range a {
+		if len(checksum) != ChecksumLength {
+			return sdkerrors.Wrapf(ErrInvalid, "checksum length: %d", len(checksum))
+		}
+		if _, exists := unique[string(checksum)]; exists {
+			return sdkerrors.Wrapf(ErrDuplicate, "checksum: %X", checksum)
+		}
+		unique[string(checksum)] = struct{}{}
+	}
+	return nil
+}
+
+// IsChecksumAllowed returns true when the code checksum allowlist is empty or contains the given checksum
+func (p Params) IsChecksumAllowed(checksum []byte) bool {
+	if len(p.CodeChecksumAllowlist) == 0 {
+		return true
+	}
+	for _, c := range p.CodeChecksumAllowlist {
+		if bytes.Equal(c, checksum) {
+			return true
+		}
+	}
+	return false
+}
+
 func (a AccessConfig) ValidateBasic() error {
 	switch a.Permission {
 	case AccessTypeUnspecified:
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
 	"testing"
 
@@ -17,6 +18,21 @@ func TestValidateParams(t *testing.T) {
 		anyAddress     sdk.AccAddress = make([]byte, address.Len)
 		invalidAddress                = "invalid address"
 	)
+	withChecksumAllowlist := func(checksums ...[]byte) Params {
+		p := DefaultParams()
+		p.CodeChecksumAllowlist = checksums
+		return p
+	}
+	withMaxGasPerTxPercent := func(percent uint32) Params {
+		p := DefaultParams()
+		p.MaxGasPerTxPercent = percent
+		return p
+	}
+	withMaxGasRefundPercent := func(percent uint32) Params {
+		p := DefaultParams()
+		p.MaxGasRefundPercent = percent
+		return p
+	}
 
 	specs := map[string]struct {
 		src    Params
@@ -30,6 +46,10 @@ func TestValidateParams(t *testing.T) {
 				CodeUploadAccess:             AllowNobody,
 				InstantiateDefaultPermission: AccessTypeNobody,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 		},
 		"all good with everybody": {
@@ -37,6 +57,10 @@ func TestValidateParams(t *testing.T) {
 				CodeUploadAccess:             AllowEverybody,
 				InstantiateDefaultPermission: AccessTypeEverybody,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 		},
 		"all good with only address": {
@@ -44,12 +68,20 @@ func TestValidateParams(t *testing.T) {
 				CodeUploadAccess:             AccessTypeOnlyAddress.With(anyAddress),
 				InstantiateDefaultPermission: AccessTypeOnlyAddress,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 		},
 		"reject empty type in instantiate permission": {
 			src: Params{
-				CodeUploadAccess: AllowNobody,
-				MaxWasmCodeSize:  DefaultMaxWasmCodeSize,
+				CodeUploadAccess:            AllowNobody,
+				MaxWasmCodeSize:             DefaultMaxWasmCodeSize,
+				MaxMsgSize:                  DefaultMaxMsgSize,
+				InstanceCost:                DefaultInstanceCost,
+				CompileCost:                 DefaultCompileCost,
+				MaxContractResponseDataSize: DefaultMaxContractResponseDataSize,
 			},
 			expErr: true,
 		},
@@ -58,6 +90,10 @@ func TestValidateParams(t *testing.T) {
 				CodeUploadAccess:             AllowNobody,
 				InstantiateDefaultPermission: 1111,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 			expErr: true,
 		},
@@ -66,6 +102,10 @@ func TestValidateParams(t *testing.T) {
 				CodeUploadAccess:             AccessConfig{Permission: AccessTypeOnlyAddress, Address: invalidAddress},
 				InstantiateDefaultPermission: AccessTypeOnlyAddress,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 			expErr: true,
 		},
@@ -74,6 +114,10 @@ func TestValidateParams(t *testing.T) {
 				CodeUploadAccess:             AccessConfig{Permission: AccessTypeEverybody, Address: anyAddress.String()},
 				InstantiateDefaultPermission: AccessTypeOnlyAddress,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 			expErr: true,
 		},
@@ -82,6 +126,10 @@ func TestValidateParams(t *testing.T) {
 				CodeUploadAccess:             AccessConfig{Permission: AccessTypeNobody, Address: anyAddress.String()},
 				InstantiateDefaultPermission: AccessTypeOnlyAddress,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 			expErr: true,
 		},
@@ -89,6 +137,10 @@ func TestValidateParams(t *testing.T) {
 			src: Params{
 				InstantiateDefaultPermission: AccessTypeOnlyAddress,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 			expErr: true,
 		},
@@ -97,6 +149,10 @@ func TestValidateParams(t *testing.T) {
 				CodeUploadAccess:             AccessConfig{Permission: AccessTypeUnspecified},
 				InstantiateDefaultPermission: AccessTypeOnlyAddress,
 				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 			expErr: true,
 		},
@@ -104,9 +160,82 @@ func TestValidateParams(t *testing.T) {
 			src: Params{
 				CodeUploadAccess:             AllowNobody,
 				InstantiateDefaultPermission: AccessTypeNobody,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
+			},
+			expErr: true,
+		},
+		"reject empty max msg size": {
+			src: Params{
+				CodeUploadAccess:             AllowNobody,
+				InstantiateDefaultPermission: AccessTypeNobody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
+			},
+			expErr: true,
+		},
+		"reject empty instance cost": {
+			src: Params{
+				CodeUploadAccess:             AllowNobody,
+				InstantiateDefaultPermission: AccessTypeNobody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				CompileCost:                  DefaultCompileCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
+			},
+			expErr: true,
+		},
+		"reject empty compile cost": {
+			src: Params{
+				CodeUploadAccess:             AllowNobody,
+				InstantiateDefaultPermission: AccessTypeNobody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				MaxContractResponseDataSize:  DefaultMaxContractResponseDataSize,
 			},
 			expErr: true,
 		},
+		"reject empty max contract response data size": {
+			src: Params{
+				CodeUploadAccess:             AllowNobody,
+				InstantiateDefaultPermission: AccessTypeNobody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxMsgSize:                   DefaultMaxMsgSize,
+				InstanceCost:                 DefaultInstanceCost,
+				CompileCost:                  DefaultCompileCost,
+			},
+			expErr: true,
+		},
+		"all good with checksum allowlist": {
+			src: withChecksumAllowlist(bytes.Repeat([]byte{1}, ChecksumLength), bytes.Repeat([]byte{2}, ChecksumLength)),
+		},
+		"reject invalid checksum length in allowlist": {
+			src:    withChecksumAllowlist(bytes.Repeat([]byte{1}, ChecksumLength-1)),
+			expErr: true,
+		},
+		"reject duplicate checksum in allowlist": {
+			src:    withChecksumAllowlist(bytes.Repeat([]byte{1}, ChecksumLength), bytes.Repeat([]byte{1}, ChecksumLength)),
+			expErr: true,
+		},
+		"all good with max gas per tx percent": {
+			src: withMaxGasPerTxPercent(100),
+		},
+		"reject max gas per tx percent above 100": {
+			src:    withMaxGasPerTxPercent(101),
+			expErr: true,
+		},
+		"all good with max gas refund percent": {
+			src: withMaxGasRefundPercent(100),
+		},
+		"reject max gas refund percent above 100": {
+			src:    withMaxGasRefundPercent(101),
+			expErr: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -169,7 +298,21 @@ func TestParamsUnmarshalJson(t *testing.T) {
 		"defaults": {
 			src: `{"code_upload_access": {"permission": "Everybody"},
 				"instantiate_default_permission": "Everybody",
-				"max_wasm_code_size": 1228800}`,
+				"max_wasm_code_size": 1228800,
+				"max_msg_size": 1048576,
+				"instance_cost": 60000,
+				"compile_cost": 3,
+				"max_contract_response_data_size": 262144,
+				"query_cache_enabled": false,
+				"max_gas_per_tx_percent": 0,
+				"max_code_count": 0,
+				"max_gas_refund_percent": 0,
+				"allow_contract_instantiation": true,
+				"max_reply_gas": 0,
+				"max_iterator_items": 0,
+				"block_time_average_window": 100,
+				"deduplicate_code_uploads": false,
+				"code_commitment_window": 0}`,
 			exp: DefaultParams(),
 		},
 	}
@@ -13,13 +13,16 @@ import (
 type ProposalType string
 
 const (
-	ProposalTypeStoreCode           ProposalType = "StoreCode"
-	ProposalTypeInstantiateContract ProposalType = "InstantiateContract"
-	ProposalTypeMigrateContract     ProposalType = "MigrateContract"
-	ProposalTypeUpdateAdmin         ProposalType = "UpdateAdmin"
-	ProposalTypeClearAdmin          ProposalType = "ClearAdmin"
-	ProposalTypePinCodes            ProposalType = "PinCodes"
-	ProposalTypeUnpinCodes          ProposalType = "UnpinCodes"
+	ProposalTypeStoreCode              ProposalType = "StoreCode"
+	ProposalTypeInstantiateContract    ProposalType = "InstantiateContract"
+	ProposalTypeMigrateContract        ProposalType = "MigrateContract"
+	ProposalTypeUpdateAdmin            ProposalType = "UpdateAdmin"
+	ProposalTypeClearAdmin             ProposalType = "ClearAdmin"
+	ProposalTypePinCodes               ProposalType = "PinCodes"
+	ProposalTypeUnpinCodes             ProposalType = "UnpinCodes"
+	ProposalTypeBatchStoreCode         ProposalType = "BatchStoreCode"
+	ProposalTypeRemoveCode             ProposalType = "RemoveCode"
+	ProposalTypeReserveContractAddress ProposalType = "ReserveContractAddress"
 )
 
 // DisableAllProposals contains no wasm gov types.
@@ -34,6 +37,9 @@ var EnableAllProposals = []ProposalType{
 	ProposalTypeClearAdmin,
 	ProposalTypePinCodes,
 	ProposalTypeUnpinCodes,
+	ProposalTypeBatchStoreCode,
+	ProposalTypeRemoveCode,
+	ProposalTypeReserveContractAddress,
 }
 
 // ConvertToProposals maps each key to a ProposalType and returns a typed list.
@@ -62,6 +68,9 @@ func init() { // register new content types with the sdk
 	govtypes.RegisterProposalType(string(ProposalTypeClearAdmin))
 	govtypes.RegisterProposalType(string(ProposalTypePinCodes))
 	govtypes.RegisterProposalType(string(ProposalTypeUnpinCodes))
+	govtypes.RegisterProposalType(string(ProposalTypeBatchStoreCode))
+	govtypes.RegisterProposalType(string(ProposalTypeRemoveCode))
+	govtypes.RegisterProposalType(string(ProposalTypeReserveContractAddress))
 	govtypes.RegisterProposalTypeCodec(&StoreCodeProposal{}, "wasm/StoreCodeProposal")
 	govtypes.RegisterProposalTypeCodec(&InstantiateContractProposal{}, "wasm/InstantiateContractProposal")
 	govtypes.RegisterProposalTypeCodec(&MigrateContractProposal{}, "wasm/MigrateContractProposal")
@@ -69,6 +78,9 @@ func init() { // register new content types with the sdk
 	govtypes.RegisterProposalTypeCodec(&ClearAdminProposal{}, "wasm/ClearAdminProposal")
 	govtypes.RegisterProposalTypeCodec(&PinCodesProposal{}, "wasm/PinCodesProposal")
 	govtypes.RegisterProposalTypeCodec(&UnpinCodesProposal{}, "wasm/UnpinCodesProposal")
+	govtypes.RegisterProposalTypeCodec(&BatchStoreCodeProposal{}, "wasm/BatchStoreCodeProposal")
+	govtypes.RegisterProposalTypeCodec(&RemoveCodeProposal{}, "wasm/RemoveCodeProposal")
+	govtypes.RegisterProposalTypeCodec(&ReserveContractAddressProposal{}, "wasm/ReserveContractAddressProposal")
 }
 
 // ProposalRoute returns the routing key of a parameter change proposal.
@@ -409,6 +421,98 @@ func (p UnpinCodesProposal) String() string {
 `, p.Title, p.Description, p.CodeIDs)
 }
 
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p BatchStoreCodeProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *BatchStoreCodeProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p BatchStoreCodeProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p BatchStoreCodeProposal) ProposalType() string { return string(ProposalTypeBatchStoreCode) }
+
+// ValidateBasic validates the proposal
+func (p BatchStoreCodeProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	if _, err := sdk.AccAddressFromBech32(p.RunAs); err != nil {
+		return sdkerrors.Wrap(err, "run as")
+	}
+	if len(p.Codes) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "codes")
+	}
+	var totalSize int
+	for i, c := range p.Codes {
+		if err := c.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "code %d", i)
+		}
+		totalSize += len(c.WASMByteCode)
+	}
+	if totalSize > MaxBatchWasmSize {
+		return sdkerrors.Wrapf(ErrLimit, "total code size cannot be longer than %d bytes", MaxBatchWasmSize)
+	}
+	return nil
+}
+
+// String implements the Stringer interface.
+func (p BatchStoreCodeProposal) String() string {
+	return fmt.Sprintf(`Batch Store Code Proposal:
+  Title:       %s
+  Description: %s
+  Run as:      %s
+  Codes:       %d
+`, p.Title, p.Description, p.RunAs, len(p.Codes))
+}
+
+// MarshalYAML pretty prints the wasm byte codes
+func (p BatchStoreCodeProposal) MarshalYAML() (interface{}, error) {
+	type entry struct {
+		WASMByteCode          string        `yaml:"wasm_byte_code"`
+		InstantiatePermission *AccessConfig `yaml:"instantiate_permission"`
+	}
+	codes := make([]entry, len(p.Codes))
+	for i, c := range p.Codes {
+		codes[i] = entry{
+			WASMByteCode:          base64.StdEncoding.EncodeToString(c.WASMByteCode),
+			InstantiatePermission: c.InstantiatePermission,
+		}
+	}
+	return struct {
+		Title       string  `yaml:"title"`
+		Description string  `yaml:"description"`
+		RunAs       string  `yaml:"run_as"`
+		Codes       []entry `yaml:"codes"`
+	}{
+		Title:       p.Title,
+		Description: p.Description,
+		RunAs:       p.RunAs,
+		Codes:       codes,
+	}, nil
+}
+
+// ValidateBasic validates the code entry
+func (e StoreCodeEntry) ValidateBasic() error {
+	if err := validateWasmCode(e.WASMByteCode); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "code bytes %s", err.Error())
+	}
+	if e.InstantiatePermission != nil {
+		if err := e.InstantiatePermission.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, "instantiate permission")
+		}
+	}
+	return nil
+}
+
+// String implements the Stringer interface.
+func (e StoreCodeEntry) String() string {
+	return fmt.Sprintf(`Store Code Entry:
+  WasmCode:    %X
+`, e.WASMByteCode)
+}
+
 func validateProposalCommons(title, description string) error {
 	if strings.TrimSpace(title) != title {
 		return sdkerrors.Wrap(govtypes.ErrInvalidProposalContent, "proposal title must not start/end with white spaces")
@@ -430,3 +534,77 @@ func validateProposalCommons(title, description string) error {
 	}
 	return nil
 }
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p RemoveCodeProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *RemoveCodeProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p RemoveCodeProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p RemoveCodeProposal) ProposalType() string { return string(ProposalTypeRemoveCode) }
+
+// ValidateBasic validates the proposal
+func (p RemoveCodeProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	if p.CodeID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "code id")
+	}
+	return nil
+}
+
+// String implements the Stringer interface.
+func (p RemoveCodeProposal) String() string {
+	return fmt.Sprintf(`Remove Wasm Code Proposal:
+  Title:       %s
+  Description: %s
+  Code id:     %d
+`, p.Title, p.Description, p.CodeID)
+}
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p ReserveContractAddressProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *ReserveContractAddressProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p ReserveContractAddressProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p ReserveContractAddressProposal) ProposalType() string {
+	return string(ProposalTypeReserveContractAddress)
+}
+
+// ValidateBasic validates the proposal
+func (p ReserveContractAddressProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	if _, err := sdk.AccAddressFromBech32(p.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if _, err := sdk.AccAddressFromBech32(p.Creator); err != nil {
+		return sdkerrors.Wrap(err, "creator")
+	}
+	if len(p.CodeHash) != ChecksumLength {
+		return sdkerrors.Wrapf(ErrInvalid, "code hash must be %d bytes", ChecksumLength)
+	}
+	return nil
+}
+
+// String implements the Stringer interface.
+func (p ReserveContractAddressProposal) String() string {
+	return fmt.Sprintf(`Reserve Contract Address Proposal:
+  Title:       %s
+  Description: %s
+  Contract:    %s
+  Creator:     %s
+  Code hash:   %X
+`, p.Title, p.Description, p.Contract, p.Creator, p.CodeHash)
+}
@@ -162,6 +162,80 @@ func TestValidateStoreCodeProposal(t *testing.T) {
 	}
 }
 
+func TestValidateBatchStoreCodeProposal(t *testing.T) {
+	var anyAddress sdk.AccAddress = bytes.Repeat([]byte{0x0}, address.Len)
+
+	specs := map[string]struct {
+		src    *BatchStoreCodeProposal
+		expErr bool
+	}{
+		"all good": {
+			src: BatchStoreCodeProposalFixture(),
+		},
+		"with instantiate permission": {
+			src: BatchStoreCodeProposalFixture(func(p *BatchStoreCodeProposal) {
+				accessConfig := AccessTypeOnlyAddress.With(anyAddress)
+				p.Codes[0].InstantiatePermission = &accessConfig
+			}),
+		},
+		"base data missing": {
+			src: BatchStoreCodeProposalFixture(func(p *BatchStoreCodeProposal) {
+				p.Title = ""
+			}),
+			expErr: true,
+		},
+		"run_as invalid": {
+			src: BatchStoreCodeProposalFixture(func(p *BatchStoreCodeProposal) {
+				p.RunAs = "invalid address"
+			}),
+			expErr: true,
+		},
+		"codes missing": {
+			src: BatchStoreCodeProposalFixture(func(p *BatchStoreCodeProposal) {
+				p.Codes = nil
+			}),
+			expErr: true,
+		},
+		"empty wasm code": {
+			src: BatchStoreCodeProposalFixture(func(p *BatchStoreCodeProposal) {
+				p.Codes[1].WASMByteCode = nil
+			}),
+			expErr: true,
+		},
+		"wasm code too big": {
+			src: BatchStoreCodeProposalFixture(func(p *BatchStoreCodeProposal) {
+				p.Codes[1].WASMByteCode = bytes.Repeat([]byte{0x0}, MaxWasmSize+1)
+			}),
+			expErr: true,
+		},
+		"total size too big": {
+			src: BatchStoreCodeProposalFixture(func(p *BatchStoreCodeProposal) {
+				p.Codes = make([]StoreCodeEntry, MaxBatchWasmSize/MaxWasmSize+1)
+				for i := range p.Codes {
+					p.Codes[i].WASMByteCode = bytes.Repeat([]byte{0x0}, MaxWasmSize)
+				}
+			}),
+			expErr: true,
+		},
+		"with invalid instantiate permission": {
+			src: BatchStoreCodeProposalFixture(func(p *BatchStoreCodeProposal) {
+				p.Codes[0].InstantiatePermission = &AccessConfig{}
+			}),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateInstantiateContractProposal(t *testing.T) {
 	var (
 		invalidAddress = "invalid address"
@@ -428,6 +502,102 @@ func TestValidateClearAdminProposal(t *testing.T) {
 	}
 }
 
+func TestValidateRemoveCodeProposal(t *testing.T) {
+	specs := map[string]struct {
+		src    *RemoveCodeProposal
+		expErr bool
+	}{
+		"all good": {
+			src: RemoveCodeProposalFixture(),
+		},
+		"base data missing": {
+			src: RemoveCodeProposalFixture(func(p *RemoveCodeProposal) {
+				p.Title = ""
+			}),
+			expErr: true,
+		},
+		"code id missing": {
+			src: RemoveCodeProposalFixture(func(p *RemoveCodeProposal) {
+				p.CodeID = 0
+			}),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateReserveContractAddressProposal(t *testing.T) {
+	specs := map[string]struct {
+		src    *ReserveContractAddressProposal
+		expErr bool
+	}{
+		"all good": {
+			src: ReserveContractAddressProposalFixture(),
+		},
+		"base data missing": {
+			src: ReserveContractAddressProposalFixture(func(p *ReserveContractAddressProposal) {
+				p.Title = ""
+			}),
+			expErr: true,
+		},
+		"contract missing": {
+			src: ReserveContractAddressProposalFixture(func(p *ReserveContractAddressProposal) {
+				p.Contract = ""
+			}),
+			expErr: true,
+		},
+		"contract invalid": {
+			src: ReserveContractAddressProposalFixture(func(p *ReserveContractAddressProposal) {
+				p.Contract = "invalid address"
+			}),
+			expErr: true,
+		},
+		"creator missing": {
+			src: ReserveContractAddressProposalFixture(func(p *ReserveContractAddressProposal) {
+				p.Creator = ""
+			}),
+			expErr: true,
+		},
+		"creator invalid": {
+			src: ReserveContractAddressProposalFixture(func(p *ReserveContractAddressProposal) {
+				p.Creator = "invalid address"
+			}),
+			expErr: true,
+		},
+		"code hash missing": {
+			src: ReserveContractAddressProposalFixture(func(p *ReserveContractAddressProposal) {
+				p.CodeHash = nil
+			}),
+			expErr: true,
+		},
+		"code hash invalid": {
+			src: ReserveContractAddressProposalFixture(func(p *ReserveContractAddressProposal) {
+				p.CodeHash = []byte{0x1}
+			}),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestProposalStrings(t *testing.T) {
 	specs := map[string]struct {
 		src govtypes.Content
@@ -535,6 +705,14 @@ func TestProposalStrings(t *testing.T) {
   Title:       Foo
   Description: Bar
   Codes:       [3 2 1]
+`,
+		},
+		"remove code": {
+			src: RemoveCodeProposalFixture(),
+			exp: `Remove Wasm Code Proposal:
+  Title:       Foo
+  Description: Bar
+  Code id:     1
 `,
 		},
 	}
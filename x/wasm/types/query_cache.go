@@ -0,0 +1,71 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// QueryCache stores the results of smart queries to contracts within a single transaction so that repeated
+// identical queries are not executed again. The gas consumed by the original query is stored with the result
+// to charge the same amount for every cache hit.
+// As query results can depend on any state, the whole cache is reset whenever state may have been modified:
+// when a contract is called in a way that can modify state, on every write to a contract store and after every
+// message dispatched by a contract.
+type QueryCache struct {
+	entries  map[string]map[string]queryCacheEntry
+	enabled  bool
+	resolved bool
+}
+
+type queryCacheEntry struct {
+	result  []byte
+	gasUsed sdk.Gas
+}
+
+// NewQueryCache constructor
+func NewQueryCache() *QueryCache {
+	return &QueryCache{entries: make(map[string]map[string]queryCacheEntry)}
+}
+
+// IsEnabled returns true when the cache should be used. The resolve function is called only once per cache
+// to read the setting.
+func (c *QueryCache) IsEnabled(resolve func() bool) bool {
+	if !c.resolved {
+		c.enabled, c.resolved = resolve(), true
+	}
+	return c.enabled
+}
+
+// Get returns the cached result and the gas consumed by the original query
+func (c *QueryCache) Get(contract sdk.AccAddress, req []byte) ([]byte, sdk.Gas, bool) {
+	e, ok := c.entries[string(contract)][string(req)]
+	if !ok {
+		return nil, 0, false
+	}
+	return append([]byte{}, e.result...), e.gasUsed, true
+}
+
+// Set stores a query result with the gas consumed to compute it
+func (c *QueryCache) Set(contract sdk.AccAddress, req []byte, result []byte, gasUsed sdk.Gas) {
+	byContract, ok := c.entries[string(contract)]
+	if !ok {
+		byContract = make(map[string]queryCacheEntry)
+		c.entries[string(contract)] = byContract
+	}
+	byContract[string(req)] = queryCacheEntry{result: append([]byte{}, result...), gasUsed: gasUsed}
+}
+
+// Reset drops all cached results
+func (c *QueryCache) Reset() {
+	c.entries = make(map[string]map[string]queryCacheEntry)
+}
+
+// WithQueryCache returns a new context with the query cache of the transaction.
+func WithQueryCache(ctx sdk.Context, cache *QueryCache) sdk.Context {
+	return ctx.WithValue(contextKeyQueryCache, cache)
+}
+
+// QueryCacheFromContext returns the query cache of the transaction or nil when not set.
+func QueryCacheFromContext(ctx sdk.Context) *QueryCache {
+	cache, _ := ctx.Value(contextKeyQueryCache).(*QueryCache)
+	return cache
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryCache(t *testing.T) {
+	contractA := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+	contractB := sdk.AccAddress(bytes.Repeat([]byte{2}, 20))
+	cache := NewQueryCache()
+
+	_, _, found := cache.Get(contractA, []byte(`{}`))
+	assert.False(t, found)
+
+	cache.Set(contractA, []byte(`{}`), []byte(`"a"`), 100)
+	cache.Set(contractB, []byte(`{}`), []byte(`"b"`), 200)
+
+	got, gasUsed, found := cache.Get(contractA, []byte(`{}`))
+	require.True(t, found)
+	assert.Equal(t, []byte(`"a"`), got)
+	assert.Equal(t, sdk.Gas(100), gasUsed)
+	_, _, found = cache.Get(contractA, []byte(`{"other":{}}`))
+	assert.False(t, found)
+
+	// when reset
+	cache.Reset()
+	// then all entries are dropped
+	_, _, found = cache.Get(contractA, []byte(`{}`))
+	assert.False(t, found)
+	_, _, found = cache.Get(contractB, []byte(`{}`))
+	assert.False(t, found)
+}
+
+func TestQueryCacheIsEnabled(t *testing.T) {
+	cache := NewQueryCache()
+	var calls int
+	resolve := func() bool {
+		calls++
+		return true
+	}
+	assert.True(t, cache.IsEnabled(resolve))
+	assert.True(t, cache.IsEnabled(resolve))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// StructuredError is a registered error with additional data for the reply of a failed submessage. Modules opt in to
+// structured replies by returning or wrapping a StructuredError in their message handlers. The reply of a failed
+// submessage carries the json encoded ReplyError then, instead of the plain error string.
+// The wasm module returns errors of contracts as StructuredError with the contract's error message as data.
+type StructuredError struct {
+	err  *sdkerrors.Error
+	data []byte
+}
+
+// NewStructuredError constructor. The data is optional.
+func NewStructuredError(err *sdkerrors.Error, data []byte) *StructuredError {
+	return &StructuredError{err: err, data: data}
+}
+
+func (e *StructuredError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the registered error so that it is matched by sdkerrors Is and reported by sdkerrors.ABCIInfo
+func (e *StructuredError) Cause() error {
+	return e.err
+}
+
+// Unwrap implements the built-in errors.Unwrap
+func (e *StructuredError) Unwrap() error {
+	return e.err
+}
+
+// ReplyError returns the structured form of the error with the given message
+func (e *StructuredError) ReplyError(msg string) ReplyError {
+	return ReplyError{
+		Codespace: e.err.Codespace(),
+		Code:      e.err.ABCICode(),
+		Data:      e.data,
+		Message:   msg,
+	}
+}
+
+// ReplyError is the json encoded error of a failed submessage reply when the failure was caused by a StructuredError
+type ReplyError struct {
+	Codespace string `json:"codespace"`
+	Code      uint32 `json:"code"`
+	Data      []byte `json:"data,omitempty"`
+	// Message is the full error string, as it is returned for errors without structured form
+	Message string `json:"message"`
+}
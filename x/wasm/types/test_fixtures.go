@@ -198,6 +198,23 @@ func StoreCodeProposalFixture(mutators ...func(*StoreCodeProposal)) *StoreCodePr
 	return p
 }
 
+func BatchStoreCodeProposalFixture(mutators ...func(*BatchStoreCodeProposal)) *BatchStoreCodeProposal {
+	const anyAddress = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	p := &BatchStoreCodeProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		RunAs:       anyAddress,
+		Codes: []StoreCodeEntry{
+			{WASMByteCode: []byte{0x0}},
+			{WASMByteCode: []byte{0x1}},
+		},
+	}
+	for _, m := range mutators {
+		m(p)
+	}
+	return p
+}
+
 func InstantiateContractProposalFixture(mutators ...func(p *InstantiateContractProposal)) *InstantiateContractProposal {
 	var (
 		anyValidAddress sdk.AccAddress = bytes.Repeat([]byte{0x1}, address.Len)
@@ -295,3 +312,33 @@ func ClearAdminProposalFixture(mutators ...func(p *ClearAdminProposal)) *ClearAd
 	}
 	return p
 }
+
+func RemoveCodeProposalFixture(mutators ...func(p *RemoveCodeProposal)) *RemoveCodeProposal {
+	p := &RemoveCodeProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		CodeID:      1,
+	}
+	for _, m := range mutators {
+		m(p)
+	}
+	return p
+}
+
+func ReserveContractAddressProposalFixture(mutators ...func(p *ReserveContractAddressProposal)) *ReserveContractAddressProposal {
+	const (
+		contractAddr = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhuc53mp6"
+		creatorAddr  = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	)
+	p := &ReserveContractAddressProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contract:    contractAddr,
+		Creator:     creatorAddr,
+		CodeHash:    bytes.Repeat([]byte{0x1}, ChecksumLength),
+	}
+	for _, m := range mutators {
+		m(p)
+	}
+	return p
+}
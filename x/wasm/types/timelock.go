@@ -0,0 +1,33 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ValidateBasic performs basic validation of the timelocked funds
+func (l TimelockedFunds) ValidateBasic() error {
+	if l.ID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "id")
+	}
+	if _, err := sdk.AccAddressFromBech32(l.Depositor); err != nil {
+		return sdkerrors.Wrap(err, "depositor")
+	}
+	if !l.Amount.IsValid() || l.Amount.IsZero() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount")
+	}
+	if l.UnlockHeight < 0 {
+		return sdkerrors.Wrap(ErrInvalid, "unlock height")
+	}
+	if l.UnlockHeight == 0 && l.UnlockTime == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "unlock height or time")
+	}
+	return nil
+}
+
+// IsUnlocked returns true when the funds can be released at the given block height and time
+func (l TimelockedFunds) IsUnlocked(height int64, blockTime time.Time) bool {
+	return height >= l.UnlockHeight && uint64(blockTime.UnixNano()) >= l.UnlockTime
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"strings"
@@ -63,6 +64,12 @@ func (msg MsgStoreCode) ValidateBasic() error {
 			return sdkerrors.Wrap(err, "instantiate permission")
 		}
 	}
+	if err := ValidateCodeSource(msg.Source); err != nil {
+		return sdkerrors.Wrap(err, "source")
+	}
+	if err := ValidateCodeBuilder(msg.Builder); err != nil {
+		return sdkerrors.Wrap(err, "builder")
+	}
 	return nil
 }
 
@@ -106,6 +113,9 @@ func (msg MsgInstantiateContract) ValidateBasic() error {
 	}
 
 	if len(msg.Admin) != 0 {
+		if msg.GovManaged {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "admin must be empty for gov managed contracts")
+		}
 		if _, err := sdk.AccAddressFromBech32(msg.Admin); err != nil {
 			return sdkerrors.Wrap(err, "admin")
 		}
@@ -151,6 +161,17 @@ func (msg MsgExecuteContract) ValidateBasic() error {
 	if err := msg.Msg.ValidateBasic(); err != nil {
 		return sdkerrors.Wrap(err, "payload msg")
 	}
+	if len(msg.Memo) > MaxExecuteMemoSize {
+		return sdkerrors.Wrapf(ErrLimit, "memo cannot be longer than %d characters", MaxExecuteMemoSize)
+	}
+	if msg.Timelock != nil {
+		if msg.Funds.IsZero() {
+			return sdkerrors.Wrap(ErrEmpty, "timelocked funds")
+		}
+		if err := msg.Timelock.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, "timelock")
+		}
+	}
 	return nil
 }
 
@@ -278,6 +299,224 @@ func (msg MsgClearAdmin) GetSigners() []sdk.AccAddress {
 
 }
 
+func (msg MsgCommitCodeHash) Route() string {
+	return RouterKey
+}
+
+func (msg MsgCommitCodeHash) Type() string {
+	return "commit-code-hash"
+}
+
+func (msg MsgCommitCodeHash) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if len(msg.Checksum) != sha256.Size {
+		return sdkerrors.Wrapf(ErrInvalid, "checksum must be %d bytes", sha256.Size)
+	}
+	if msg.CodeSize == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "code size")
+	}
+	if msg.InstantiatePermission != nil {
+		if err := msg.InstantiatePermission.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, "instantiate permission")
+		}
+	}
+	return nil
+}
+
+func (msg MsgCommitCodeHash) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgCommitCodeHash) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgRevealCode) Route() string {
+	return RouterKey
+}
+
+func (msg MsgRevealCode) Type() string {
+	return "reveal-code"
+}
+
+func (msg MsgRevealCode) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if len(msg.Checksum) != sha256.Size {
+		return sdkerrors.Wrapf(ErrInvalid, "checksum must be %d bytes", sha256.Size)
+	}
+	if err := validateWasmCode(msg.WASMByteCode); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "code bytes %s", err.Error())
+	}
+	return nil
+}
+
+func (msg MsgRevealCode) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgRevealCode) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+// ValidateBasic performs basic validation of the timelock
+func (t FundsTimelock) ValidateBasic() error {
+	if t.UnlockHeight < 0 {
+		return sdkerrors.Wrap(ErrInvalid, "unlock height")
+	}
+	if t.UnlockHeight == 0 && t.UnlockTime == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "unlock height or time")
+	}
+	return nil
+}
+
+func (msg MsgReleaseTimelockedFunds) Route() string {
+	return RouterKey
+}
+
+func (msg MsgReleaseTimelockedFunds) Type() string {
+	return "release-timelocked-funds"
+}
+
+func (msg MsgReleaseTimelockedFunds) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if msg.LockID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "lock id")
+	}
+	return nil
+}
+
+func (msg MsgReleaseTimelockedFunds) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgReleaseTimelockedFunds) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgUpdateExecutePermission) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateExecutePermission) Type() string {
+	return "update-execute-permission"
+}
+
+func (msg MsgUpdateExecutePermission) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if msg.ExecutePermission != nil {
+		if err := msg.ExecutePermission.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, "execute permission")
+		}
+	}
+	return nil
+}
+
+func (msg MsgUpdateExecutePermission) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateExecutePermission) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgUpdateMigratePermission) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateMigratePermission) Type() string {
+	return "update-migrate-permission"
+}
+
+func (msg MsgUpdateMigratePermission) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if msg.MigratePermission != nil {
+		if err := ValidateMigratePermission(*msg.MigratePermission); err != nil {
+			return sdkerrors.Wrap(err, "migrate permission")
+		}
+	}
+	return nil
+}
+
+func (msg MsgUpdateMigratePermission) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateMigratePermission) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgUpdateAcceptedDenoms) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateAcceptedDenoms) Type() string {
+	return "update-accepted-denoms"
+}
+
+func (msg MsgUpdateAcceptedDenoms) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if err := ValidateAcceptedDenoms(msg.Denoms); err != nil {
+		return sdkerrors.Wrap(err, "denoms")
+	}
+	return nil
+}
+
+func (msg MsgUpdateAcceptedDenoms) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateAcceptedDenoms) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
 func (msg MsgIBCSend) Route() string {
 	return RouterKey
 }
@@ -52,9 +52,43 @@ func TestStoreCodeValidation(t *testing.T) {
 			msg: MsgStoreCode{
 				Sender:       goodAddress,
 				WASMByteCode: []byte("foo"),
+				Source:       "https://github.com/CosmWasm/cosmwasm/tree/v1.0.0/contracts/hackatom",
+				Builder:      "cosmwasm/rust-optimizer:0.12.4",
 			},
 			valid: true,
 		},
+		"source not an url": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Source:       "github.com/CosmWasm/cosmwasm",
+			},
+			valid: false,
+		},
+		"source with unsupported scheme": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Source:       "ftp://example.com/cosmwasm",
+			},
+			valid: false,
+		},
+		"source exceeds limit": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Source:       "https://example.com/" + strings.Repeat("a", MaxCodeSourceSize),
+			},
+			valid: false,
+		},
+		"builder exceeds limit": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Builder:      strings.Repeat("a", MaxCodeBuilderSize+1),
+			},
+			valid: false,
+		},
 		"invalid InstantiatePermission": {
 			msg: MsgStoreCode{
 				Sender:                goodAddress,
@@ -77,6 +111,96 @@ func TestStoreCodeValidation(t *testing.T) {
 	}
 }
 
+func TestCommitCodeHashValidation(t *testing.T) {
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	checksum := bytes.Repeat([]byte{1}, 32)
+
+	cases := map[string]struct {
+		msg   MsgCommitCodeHash
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgCommitCodeHash{},
+			valid: false,
+		},
+		"correct minimal": {
+			msg:   MsgCommitCodeHash{Sender: goodAddress, Checksum: checksum, CodeSize: 1},
+			valid: true,
+		},
+		"correct maximal": {
+			msg:   MsgCommitCodeHash{Sender: goodAddress, Checksum: checksum, CodeSize: 1, InstantiatePermission: &AllowNobody},
+			valid: true,
+		},
+		"bad sender": {
+			msg:   MsgCommitCodeHash{Sender: "invalid", Checksum: checksum, CodeSize: 1},
+			valid: false,
+		},
+		"checksum too short": {
+			msg:   MsgCommitCodeHash{Sender: goodAddress, Checksum: checksum[1:], CodeSize: 1},
+			valid: false,
+		},
+		"zero code size": {
+			msg:   MsgCommitCodeHash{Sender: goodAddress, Checksum: checksum},
+			valid: false,
+		},
+		"invalid InstantiatePermission": {
+			msg:   MsgCommitCodeHash{Sender: goodAddress, Checksum: checksum, CodeSize: 1, InstantiatePermission: &AccessConfig{Permission: AccessTypeOnlyAddress, Address: "invalid"}},
+			valid: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRevealCodeValidation(t *testing.T) {
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	checksum := bytes.Repeat([]byte{1}, 32)
+
+	cases := map[string]struct {
+		msg   MsgRevealCode
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgRevealCode{},
+			valid: false,
+		},
+		"correct": {
+			msg:   MsgRevealCode{Sender: goodAddress, Checksum: checksum, WASMByteCode: []byte("foo")},
+			valid: true,
+		},
+		"bad sender": {
+			msg:   MsgRevealCode{Sender: "invalid", Checksum: checksum, WASMByteCode: []byte("foo")},
+			valid: false,
+		},
+		"missing checksum": {
+			msg:   MsgRevealCode{Sender: goodAddress, WASMByteCode: []byte("foo")},
+			valid: false,
+		},
+		"missing code": {
+			msg:   MsgRevealCode{Sender: goodAddress, Checksum: checksum},
+			valid: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestInstantiateContractValidation(t *testing.T) {
 	bad, err := sdk.AccAddressFromHex("012345")
 	require.NoError(t, err)
@@ -142,6 +266,27 @@ func TestInstantiateContractValidation(t *testing.T) {
 			},
 			valid: true,
 		},
+		"gov managed": {
+			msg: MsgInstantiateContract{
+				Sender:     goodAddress,
+				CodeID:     firstCodeID,
+				Label:      "foo",
+				Msg:        []byte("{}"),
+				GovManaged: true,
+			},
+			valid: true,
+		},
+		"gov managed with admin": {
+			msg: MsgInstantiateContract{
+				Sender:     goodAddress,
+				Admin:      goodAddress,
+				CodeID:     firstCodeID,
+				Label:      "foo",
+				Msg:        []byte("{}"),
+				GovManaged: true,
+			},
+			valid: false,
+		},
 		"negative funds": {
 			msg: MsgInstantiateContract{
 				Sender: goodAddress,
@@ -213,9 +358,57 @@ func TestExecuteContractValidation(t *testing.T) {
 				Contract: goodAddress,
 				Msg:      []byte(`{"some": "data"}`),
 				Funds:    sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdk.NewInt(200)}},
+				Memo:     "my memo",
 			},
 			valid: true,
 		},
+		"memo max length": {
+			msg: MsgExecuteContract{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte("{}"),
+				Memo:     strings.Repeat("a", MaxExecuteMemoSize),
+			},
+			valid: true,
+		},
+		"memo too long": {
+			msg: MsgExecuteContract{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte("{}"),
+				Memo:     strings.Repeat("a", MaxExecuteMemoSize+1),
+			},
+			valid: false,
+		},
+		"with timelock": {
+			msg: MsgExecuteContract{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte("{}"),
+				Funds:    sdk.Coins{sdk.NewInt64Coin("foobar", 200)},
+				Timelock: &FundsTimelock{UnlockHeight: 1},
+			},
+			valid: true,
+		},
+		"timelock without funds": {
+			msg: MsgExecuteContract{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte("{}"),
+				Timelock: &FundsTimelock{UnlockHeight: 1},
+			},
+			valid: false,
+		},
+		"timelock without unlock height or time": {
+			msg: MsgExecuteContract{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte("{}"),
+				Funds:    sdk.Coins{sdk.NewInt64Coin("foobar", 200)},
+				Timelock: &FundsTimelock{},
+			},
+			valid: false,
+		},
 		"bad sender": {
 			msg: MsgExecuteContract{
 				Sender:   badAddress,
@@ -293,6 +486,188 @@ func TestExecuteContractValidation(t *testing.T) {
 	}
 }
 
+func TestReleaseTimelockedFundsValidation(t *testing.T) {
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	cases := map[string]struct {
+		msg   MsgReleaseTimelockedFunds
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgReleaseTimelockedFunds{},
+			valid: false,
+		},
+		"correct": {
+			msg:   MsgReleaseTimelockedFunds{Sender: goodAddress, Contract: goodAddress, LockID: 1},
+			valid: true,
+		},
+		"bad sender": {
+			msg:   MsgReleaseTimelockedFunds{Sender: "invalid", Contract: goodAddress, LockID: 1},
+			valid: false,
+		},
+		"bad contract": {
+			msg:   MsgReleaseTimelockedFunds{Sender: goodAddress, Contract: "invalid", LockID: 1},
+			valid: false,
+		},
+		"missing lock id": {
+			msg:   MsgReleaseTimelockedFunds{Sender: goodAddress, Contract: goodAddress},
+			valid: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateExecutePermissionValidation(t *testing.T) {
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	onlyAddress := AccessTypeOnlyAddress.With(sdk.AccAddress(make([]byte, 20)))
+
+	cases := map[string]struct {
+		msg   MsgUpdateExecutePermission
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgUpdateExecutePermission{},
+			valid: false,
+		},
+		"correct": {
+			msg:   MsgUpdateExecutePermission{Sender: goodAddress, Contract: goodAddress, ExecutePermission: &onlyAddress},
+			valid: true,
+		},
+		"default permission": {
+			msg:   MsgUpdateExecutePermission{Sender: goodAddress, Contract: goodAddress},
+			valid: true,
+		},
+		"bad sender": {
+			msg:   MsgUpdateExecutePermission{Sender: "invalid", Contract: goodAddress},
+			valid: false,
+		},
+		"bad contract": {
+			msg:   MsgUpdateExecutePermission{Sender: goodAddress, Contract: "invalid"},
+			valid: false,
+		},
+		"bad permission": {
+			msg:   MsgUpdateExecutePermission{Sender: goodAddress, Contract: goodAddress, ExecutePermission: &AccessConfig{Permission: AccessTypeOnlyAddress}},
+			valid: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateMigratePermissionValidation(t *testing.T) {
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	onlyAddress := AccessTypeOnlyAddress.With(sdk.AccAddress(make([]byte, 20)))
+
+	cases := map[string]struct {
+		msg   MsgUpdateMigratePermission
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgUpdateMigratePermission{},
+			valid: false,
+		},
+		"correct": {
+			msg:   MsgUpdateMigratePermission{Sender: goodAddress, Contract: goodAddress, MigratePermission: &onlyAddress},
+			valid: true,
+		},
+		"default permission": {
+			msg:   MsgUpdateMigratePermission{Sender: goodAddress, Contract: goodAddress},
+			valid: true,
+		},
+		"bad sender": {
+			msg:   MsgUpdateMigratePermission{Sender: "invalid", Contract: goodAddress},
+			valid: false,
+		},
+		"bad contract": {
+			msg:   MsgUpdateMigratePermission{Sender: goodAddress, Contract: "invalid"},
+			valid: false,
+		},
+		"everybody not allowed": {
+			msg:   MsgUpdateMigratePermission{Sender: goodAddress, Contract: goodAddress, MigratePermission: &AllowEverybody},
+			valid: false,
+		},
+		"bad permission": {
+			msg:   MsgUpdateMigratePermission{Sender: goodAddress, Contract: goodAddress, MigratePermission: &AccessConfig{Permission: AccessTypeOnlyAddress}},
+			valid: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateAcceptedDenomsValidation(t *testing.T) {
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	cases := map[string]struct {
+		msg   MsgUpdateAcceptedDenoms
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgUpdateAcceptedDenoms{},
+			valid: false,
+		},
+		"correct": {
+			msg:   MsgUpdateAcceptedDenoms{Sender: goodAddress, Contract: goodAddress, Denoms: []string{"stake"}},
+			valid: true,
+		},
+		"all denoms": {
+			msg:   MsgUpdateAcceptedDenoms{Sender: goodAddress, Contract: goodAddress},
+			valid: true,
+		},
+		"bad sender": {
+			msg:   MsgUpdateAcceptedDenoms{Sender: "invalid", Contract: goodAddress},
+			valid: false,
+		},
+		"bad contract": {
+			msg:   MsgUpdateAcceptedDenoms{Sender: goodAddress, Contract: "invalid"},
+			valid: false,
+		},
+		"bad denom": {
+			msg:   MsgUpdateAcceptedDenoms{Sender: goodAddress, Contract: goodAddress, Denoms: []string{"!"}},
+			valid: false,
+		},
+		"duplicate denom": {
+			msg:   MsgUpdateAcceptedDenoms{Sender: goodAddress, Contract: goodAddress, Denoms: []string{"stake", "stake"}},
+			valid: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestMsgUpdateAdministrator(t *testing.T) {
 	bad, err := sdk.AccAddressFromHex("012345")
 	require.NoError(t, err)
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -12,11 +13,16 @@ import (
 )
 
 const (
-	defaultMemoryCacheSize    uint32 = 100 // in MiB
-	defaultSmartQueryGasLimit uint64 = 3_000_000
-	defaultContractDebugMode         = false
+	defaultMemoryCacheSize           uint32 = 100 // in MiB
+	defaultContractMemoryLimit       uint32 = 32  // in MiB
+	defaultSmartQueryGasLimit        uint64 = 3_000_000
+	defaultSmartQueryCheckTxGasLimit uint64 = 1_000_000
+	defaultContractDebugMode                = false
 )
 
+// MinContractMemoryLimit is the lowest memory limit in MiB that can be configured for a contract instance
+const MinContractMemoryLimit uint32 = 16
+
 func (m Model) ValidateBasic() error {
 	if len(m.Key) == 0 {
 		return sdkerrors.Wrap(ErrEmpty, "key")
@@ -34,6 +40,12 @@ func (c CodeInfo) ValidateBasic() error {
 	if err := c.InstantiateConfig.ValidateBasic(); err != nil {
 		return sdkerrors.Wrap(err, "instantiate config")
 	}
+	if err := ValidateCodeSource(c.Source); err != nil {
+		return sdkerrors.Wrap(err, "source")
+	}
+	if err := ValidateCodeBuilder(c.Builder); err != nil {
+		return sdkerrors.Wrap(err, "builder")
+	}
 	return nil
 }
 
@@ -86,6 +98,19 @@ func (c *ContractInfo) ValidateBasic() error {
 	if err := validateLabel(c.Label); err != nil {
 		return sdkerrors.Wrap(err, "label")
 	}
+	if c.MigratePermission != nil {
+		if err := ValidateMigratePermission(*c.MigratePermission); err != nil {
+			return sdkerrors.Wrap(err, "migrate permission")
+		}
+	}
+	if c.ExecutePermission != nil {
+		if err := c.ExecutePermission.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, "execute permission")
+		}
+	}
+	if err := ValidateAcceptedDenoms(c.AcceptedDenoms); err != nil {
+		return sdkerrors.Wrap(err, "accepted denoms")
+	}
 	if c.Extension == nil {
 		return nil
 	}
@@ -100,6 +125,33 @@ func (c *ContractInfo) ValidateBasic() error {
 	return nil
 }
 
+// ValidateMigratePermission validates the access config that restricts contract migrations. Everybody is not
+// supported as it would allow anyone to replace the contract code.
+func ValidateMigratePermission(c AccessConfig) error {
+	if err := c.ValidateBasic(); err != nil {
+		return err
+	}
+	if c.Permission == AccessTypeEverybody {
+		return sdkerrors.Wrap(ErrInvalid, "everybody not allowed")
+	}
+	return nil
+}
+
+// ValidateAcceptedDenoms validates the denoms that a contract accepts as funds. An empty list accepts all denoms.
+func ValidateAcceptedDenoms(denoms []string) error {
+	seen := make(map[string]struct{}, len(denoms))
+	for _, d := range denoms {
+		if err := sdk.ValidateDenom(d); err != nil {
+			return err
+		}
+		if _, ok := seen[d]; ok {
+			return sdkerrors.Wrapf(ErrDuplicate, "denom %q", d)
+		}
+		seen[d] = struct{}{}
+	}
+	return nil
+}
+
 // SetExtension set new extension data. Calls `ValidateBasic() error` on non nil values when method is implemented by
 // the extension.
 func (c *ContractInfo) SetExtension(ext ContractInfoExtension) error {
@@ -187,6 +239,26 @@ func (c *ContractInfo) AdminAddr() sdk.AccAddress {
 	return admin
 }
 
+// AssertAcceptsFunds returns an error when the contract restricts the accepted denoms and a coin is not accepted
+func (c *ContractInfo) AssertAcceptsFunds(coins sdk.Coins) error {
+	if len(c.AcceptedDenoms) == 0 {
+		return nil
+	}
+	for _, coin := range coins {
+		accepted := false
+		for _, d := range c.AcceptedDenoms {
+			if coin.Denom == d {
+				accepted = true
+				break
+			}
+		}
+		if !accepted {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "denom %q not accepted by contract, accepted: %s", coin.Denom, strings.Join(c.AcceptedDenoms, ","))
+		}
+	}
+	return nil
+}
+
 // ContractInfoExtension defines the extension point for custom data to be stored with a contract info
 type ContractInfoExtension interface {
 	proto.Message
@@ -301,17 +373,38 @@ type WasmConfig struct {
 	SimulationGasLimit *uint64
 	// SimulationGasLimit is the max gas to be used in a smart query contract call
 	SmartQueryGasLimit uint64
-	// MemoryCacheSize in MiB not bytes
+	// SmartQueryCheckTxGasLimit is the max gas to be used in a smart query contract call
+	// that is sent by a client, like wallet simulations, and not by a contract. It is capped by SmartQueryGasLimit.
+	SmartQueryCheckTxGasLimit uint64
+	// MemoryCacheSize in MiB not bytes. The cache keeps compiled modules of recently used, unpinned contracts
+	// in memory in addition to the pinned ones. Set to 0 to disable.
 	MemoryCacheSize uint32
+	// ContractMemoryLimit is the memory limit of each contract instance in MiB not bytes. Each running instance can
+	// allocate up to this limit, so the peak memory usage grows with the depth of nested contract calls.
+	// Executions that need more memory fail, so nodes should not run with a lower limit than the rest of the network.
+	ContractMemoryLimit uint32
 	// ContractDebugMode log what contract print
 	ContractDebugMode bool
+	// PinnedCodeMemoryBudget in MiB not bytes. Limits the total size of the codes that are pinned in memory on this
+	// node. The least recently used pinned codes are evicted from memory when exceeded. Set to 0 to disable.
+	PinnedCodeMemoryBudget uint32
+}
+
+// ValidateBasic performs basic validation of the config values
+func (c WasmConfig) ValidateBasic() error {
+	if c.ContractMemoryLimit < MinContractMemoryLimit {
+		return sdkerrors.Wrapf(ErrInvalid, "contract memory limit must be at least %d MiB", MinContractMemoryLimit)
+	}
+	return nil
 }
 
 // DefaultWasmConfig returns the default settings for WasmConfig
 func DefaultWasmConfig() WasmConfig {
 	return WasmConfig{
-		SmartQueryGasLimit: defaultSmartQueryGasLimit,
-		MemoryCacheSize:    defaultMemoryCacheSize,
-		ContractDebugMode:  defaultContractDebugMode,
+		SmartQueryGasLimit:        defaultSmartQueryGasLimit,
+		SmartQueryCheckTxGasLimit: defaultSmartQueryCheckTxGasLimit,
+		MemoryCacheSize:           defaultMemoryCacheSize,
+		ContractMemoryLimit:       defaultContractMemoryLimit,
+		ContractDebugMode:         defaultContractDebugMode,
 	}
 }
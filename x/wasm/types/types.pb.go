@@ -7,6 +7,9 @@ import (
 	bytes "bytes"
 	fmt "fmt"
 	types "github.com/cosmos/cosmos-sdk/codec/types"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types2 "github.com/cosmos/cosmos-sdk/types"
+	types1 "github.com/cosmos/cosmos-sdk/x/auth/types"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/regen-network/cosmos-proto"
@@ -177,6 +180,56 @@ type Params struct {
 	CodeUploadAccess             AccessConfig `protobuf:"bytes,1,opt,name=code_upload_access,json=codeUploadAccess,proto3" json:"code_upload_access" yaml:"code_upload_access"`
 	InstantiateDefaultPermission AccessType   `protobuf:"varint,2,opt,name=instantiate_default_permission,json=instantiateDefaultPermission,proto3,enum=cosmwasm.wasm.v1.AccessType" json:"instantiate_default_permission,omitempty" yaml:"instantiate_default_permission"`
 	MaxWasmCodeSize              uint64       `protobuf:"varint,3,opt,name=max_wasm_code_size,json=maxWasmCodeSize,proto3" json:"max_wasm_code_size,omitempty" yaml:"max_wasm_code_size"`
+	MaxMsgSize                   uint64       `protobuf:"varint,4,opt,name=max_msg_size,json=maxMsgSize,proto3" json:"max_msg_size,omitempty" yaml:"max_msg_size"`
+	// InstanceCost is the SDK gas charged each time a wasm instance is loaded
+	// for a contract that is not pinned
+	InstanceCost uint64 `protobuf:"varint,5,opt,name=instance_cost,json=instanceCost,proto3" json:"instance_cost,omitempty" yaml:"instance_cost"`
+	// CompileCost is the SDK gas charged per byte for compiling wasm code
+	CompileCost uint64 `protobuf:"varint,6,opt,name=compile_cost,json=compileCost,proto3" json:"compile_cost,omitempty" yaml:"compile_cost"`
+	// CodeChecksumAllowlist contains the checksums of the wasm codes that can be
+	// instantiated or migrated to. Any code can be used when empty.
+	CodeChecksumAllowlist [][]byte `protobuf:"bytes,7,rep,name=code_checksum_allowlist,json=codeChecksumAllowlist,proto3" json:"code_checksum_allowlist,omitempty" yaml:"code_checksum_allowlist"`
+	// MaxContractResponseDataSize is the max number of bytes of the data field in
+	// a contract response
+	MaxContractResponseDataSize uint64 `protobuf:"varint,8,opt,name=max_contract_response_data_size,json=maxContractResponseDataSize,proto3" json:"max_contract_response_data_size,omitempty" yaml:"max_contract_response_data_size"`
+	// QueryCacheEnabled turns on the caching of contract to contract smart query
+	// results within a single transaction
+	QueryCacheEnabled bool `protobuf:"varint,9,opt,name=query_cache_enabled,json=queryCacheEnabled,proto3" json:"query_cache_enabled,omitempty" yaml:"query_cache_enabled"`
+	// MaxGasPerTxPercent is the max share of the block gas limit in percent
+	// that a transaction can consume. It applies to all transactions as
+	// contracts can be called via messages of other modules. Zero disables the
+	// limit.
+	MaxGasPerTxPercent uint32 `protobuf:"varint,10,opt,name=max_gas_per_tx_percent,json=maxGasPerTxPercent,proto3" json:"max_gas_per_tx_percent,omitempty" yaml:"max_gas_per_tx_percent"`
+	// MaxCodeCount is the max number of codes that can be uploaded to the
+	// chain. Zero disables the limit.
+	MaxCodeCount uint64 `protobuf:"varint,11,opt,name=max_code_count,json=maxCodeCount,proto3" json:"max_code_count,omitempty" yaml:"max_code_count"`
+	// MaxGasRefundPercent is the max share of the gas used by a contract call in
+	// percent that is refunded for deleting contract state. Zero disables the
+	// refund.
+	MaxGasRefundPercent uint32 `protobuf:"varint,12,opt,name=max_gas_refund_percent,json=maxGasRefundPercent,proto3" json:"max_gas_refund_percent,omitempty" yaml:"max_gas_refund_percent"`
+	// AllowContractInstantiation controls if contracts can instantiate other
+	// contracts. Instantiations by accounts that are not contracts are not
+	// affected.
+	AllowContractInstantiation bool `protobuf:"varint,13,opt,name=allow_contract_instantiation,json=allowContractInstantiation,proto3" json:"allow_contract_instantiation,omitempty" yaml:"allow_contract_instantiation"`
+	// MaxReplyGas is the max gas that a single reply call of a contract can
+	// consume. A reply that exceeds it fails with out of gas. Zero disables the
+	// limit.
+	MaxReplyGas uint64 `protobuf:"varint,14,opt,name=max_reply_gas,json=maxReplyGas,proto3" json:"max_reply_gas,omitempty" yaml:"max_reply_gas"`
+	// MaxIteratorItems is the max number of entries that a single iterator over
+	// the contract state returns before it signals exhaustion. Zero disables the
+	// limit.
+	MaxIteratorItems uint64 `protobuf:"varint,15,opt,name=max_iterator_items,json=maxIteratorItems,proto3" json:"max_iterator_items,omitempty" yaml:"max_iterator_items"`
+	// BlockTimeAverageWindow is the number of blocks of the moving average of
+	// the block time that contracts can query. Zero disables the tracking.
+	BlockTimeAverageWindow uint32 `protobuf:"varint,16,opt,name=block_time_average_window,json=blockTimeAverageWindow,proto3" json:"block_time_average_window,omitempty" yaml:"block_time_average_window"`
+	// DeduplicateCodeUploads when set, an upload of a wasm code that is stored
+	// already with the same instantiate permission and source returns the
+	// existing code id instead of creating a new one.
+	DeduplicateCodeUploads bool `protobuf:"varint,17,opt,name=deduplicate_code_uploads,json=deduplicateCodeUploads,proto3" json:"deduplicate_code_uploads,omitempty" yaml:"deduplicate_code_uploads"`
+	// CodeCommitmentWindow is the number of blocks after a code hash commitment
+	// in which the code can be revealed. Zero disables the commit and reveal
+	// upload.
+	CodeCommitmentWindow uint32 `protobuf:"varint,18,opt,name=code_commitment_window,json=codeCommitmentWindow,proto3" json:"code_commitment_window,omitempty" yaml:"code_commitment_window"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
@@ -219,6 +272,10 @@ type CodeInfo struct {
 	Creator string `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
 	// InstantiateConfig access control to apply on contract creation, optional
 	InstantiateConfig AccessConfig `protobuf:"bytes,5,opt,name=instantiate_config,json=instantiateConfig,proto3" json:"instantiate_config"`
+	// Source is the URL of the source code repository, optional
+	Source string `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	// Builder is the docker image that was used to build the code, optional
+	Builder string `protobuf:"bytes,7,opt,name=builder,proto3" json:"builder,omitempty"`
 }
 
 func (m *CodeInfo) Reset()         { *m = CodeInfo{} }
@@ -264,14 +321,33 @@ type ContractInfo struct {
 	Admin string `protobuf:"bytes,3,opt,name=admin,proto3" json:"admin,omitempty"`
 	// Label is optional metadata to be stored with a contract instance.
 	Label string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
-	// Created Tx position when the contract was instantiated.
-	// This data should kept internal and not be exposed via query results. Just
-	// use for sorting
+	// Created Tx position when the contract was instantiated. Contracts that were
+	// instantiated before the position was tracked have a zero position.
 	Created   *AbsoluteTxPosition `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
 	IBCPortID string              `protobuf:"bytes,6,opt,name=ibc_port_id,json=ibcPortId,proto3" json:"ibc_port_id,omitempty"`
 	// Extension is an extension point to store custom metadata within the
 	// persistence model.
 	Extension *types.Any `protobuf:"bytes,7,opt,name=extension,proto3" json:"extension,omitempty"`
+	// Paused is set when execute and sudo calls to the contract are disabled by
+	// the admin or governance. Queries are still supported.
+	Paused bool `protobuf:"varint,8,opt,name=paused,proto3" json:"paused,omitempty"`
+	// Terminated is set when the contract disabled itself permanently with the
+	// terminate chain message. Execute and sudo calls are rejected, queries are
+	// still supported.
+	Terminated bool `protobuf:"varint,9,opt,name=terminated,proto3" json:"terminated,omitempty"`
+	// MigratePermission optionally restricts who can migrate the contract. When
+	// set it overrides the admin's ability to migrate. When not set, the admin
+	// can migrate the contract.
+	MigratePermission *AccessConfig `protobuf:"bytes,10,opt,name=migrate_permission,json=migratePermission,proto3" json:"migrate_permission,omitempty"`
+	// ExecutePermission optionally restricts who can execute the contract. When
+	// not set, everybody can execute the contract.
+	ExecutePermission *AccessConfig `protobuf:"bytes,11,opt,name=execute_permission,json=executePermission,proto3" json:"execute_permission,omitempty"`
+	// GovManaged contracts have the governance module account as admin. Admin
+	// operations and migrations are only accepted from governance proposals.
+	GovManaged bool `protobuf:"varint,12,opt,name=gov_managed,json=govManaged,proto3" json:"gov_managed,omitempty"`
+	// AcceptedDenoms optionally restricts the denoms that can be sent as funds
+	// with an execute call. When empty, all denoms are accepted.
+	AcceptedDenoms []string `protobuf:"bytes,13,rep,name=accepted_denoms,json=acceptedDenoms,proto3" json:"accepted_denoms,omitempty"`
 }
 
 func (m *ContractInfo) Reset()         { *m = ContractInfo{} }
@@ -434,6 +510,236 @@ func (m *Model) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Model proto.InternalMessageInfo
 
+// ContractAccount is the account type of a contract instance. It extends the
+// BaseAccount with the code ID so that contract accounts can be identified
+// without a wasm state lookup.
+type ContractAccount struct {
+	*types1.BaseAccount `protobuf:"bytes,1,opt,name=base_account,json=baseAccount,proto3,embedded=base_account" json:"base_account,omitempty" yaml:"base_account"`
+	// CodeID is the reference to the stored Wasm code of the contract
+	CodeID uint64 `protobuf:"varint,2,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+}
+
+func (m *ContractAccount) Reset()      { *m = ContractAccount{} }
+func (*ContractAccount) ProtoMessage() {}
+func (*ContractAccount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{8}
+}
+func (m *ContractAccount) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContractAccount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContractAccount.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ContractAccount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractAccount.Merge(m, src)
+}
+func (m *ContractAccount) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContractAccount) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractAccount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractAccount proto.InternalMessageInfo
+
+// InFlightPacket is an IBC transfer packet sent by a contract that was neither
+// acknowledged nor timed out, yet
+type InFlightPacket struct {
+	// SourcePort is the port the packet was sent on
+	SourcePort string `protobuf:"bytes,1,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
+	// SourceChannel is the channel the packet was sent on
+	SourceChannel string `protobuf:"bytes,2,opt,name=source_channel,json=sourceChannel,proto3" json:"source_channel,omitempty"`
+	// Sequence is the packet sequence number on the channel
+	Sequence uint64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (m *InFlightPacket) Reset()         { *m = InFlightPacket{} }
+func (m *InFlightPacket) String() string { return proto.CompactTextString(m) }
+func (*InFlightPacket) ProtoMessage()    {}
+func (*InFlightPacket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{9}
+}
+func (m *InFlightPacket) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InFlightPacket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InFlightPacket.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InFlightPacket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InFlightPacket.Merge(m, src)
+}
+func (m *InFlightPacket) XXX_Size() int {
+	return m.Size()
+}
+func (m *InFlightPacket) XXX_DiscardUnknown() {
+	xxx_messageInfo_InFlightPacket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InFlightPacket proto.InternalMessageInfo
+
+// TimelockedFunds are funds sent to a contract that are held by the wasm module
+// until they are released to the contract after the unlock height and time
+type TimelockedFunds struct {
+	// ID is the unique identifier of the lock
+	ID uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Depositor is the address that sent the funds
+	Depositor string `protobuf:"bytes,2,opt,name=depositor,proto3" json:"depositor,omitempty"`
+	// Amount is the locked amount
+	Amount github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,3,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+	// UnlockHeight is the block height from which the funds can be released.
+	// Zero means no height restriction.
+	UnlockHeight int64 `protobuf:"varint,4,opt,name=unlock_height,json=unlockHeight,proto3" json:"unlock_height,omitempty"`
+	// UnlockTime is the block time in unix nanoseconds from which the funds can
+	// be released. Zero means no time restriction.
+	UnlockTime uint64 `protobuf:"varint,5,opt,name=unlock_time,json=unlockTime,proto3" json:"unlock_time,omitempty"`
+}
+
+func (m *TimelockedFunds) Reset()         { *m = TimelockedFunds{} }
+func (m *TimelockedFunds) String() string { return proto.CompactTextString(m) }
+func (*TimelockedFunds) ProtoMessage()    {}
+func (*TimelockedFunds) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{10}
+}
+func (m *TimelockedFunds) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimelockedFunds) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimelockedFunds.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TimelockedFunds) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimelockedFunds.Merge(m, src)
+}
+func (m *TimelockedFunds) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimelockedFunds) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimelockedFunds.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimelockedFunds proto.InternalMessageInfo
+
+// CodeCommitment is the checksum and size of a wasm code that was committed to
+// before the code is revealed
+type CodeCommitment struct {
+	// Creator is the address that committed to the code and becomes the creator
+	// of the stored code
+	Creator string `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Checksum is the sha256 hash of the uncompressed wasm code
+	Checksum []byte `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// CodeSize is the size of the uncompressed wasm code in bytes
+	CodeSize uint64 `protobuf:"varint,3,opt,name=code_size,json=codeSize,proto3" json:"code_size,omitempty"`
+	// ExpiryHeight is the last block height in which the code can be revealed
+	ExpiryHeight int64 `protobuf:"varint,4,opt,name=expiry_height,json=expiryHeight,proto3" json:"expiry_height,omitempty"`
+	// InstantiatePermission access control to apply on contract creation,
+	// optional
+	InstantiatePermission *AccessConfig `protobuf:"bytes,5,opt,name=instantiate_permission,json=instantiatePermission,proto3" json:"instantiate_permission,omitempty"`
+}
+
+func (m *CodeCommitment) Reset()         { *m = CodeCommitment{} }
+func (m *CodeCommitment) String() string { return proto.CompactTextString(m) }
+func (*CodeCommitment) ProtoMessage()    {}
+func (*CodeCommitment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{11}
+}
+func (m *CodeCommitment) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CodeCommitment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CodeCommitment.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CodeCommitment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeCommitment.Merge(m, src)
+}
+func (m *CodeCommitment) XXX_Size() int {
+	return m.Size()
+}
+func (m *CodeCommitment) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeCommitment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeCommitment proto.InternalMessageInfo
+
+// ReservedContractAddress is a contract address reserved by governance for the
+// next instantiation of a code by a creator
+type ReservedContractAddress struct {
+	// ContractAddress is the reserved address
+	ContractAddress string `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	// Creator is the only account that can instantiate into the reserved address
+	Creator string `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
+	// CodeHash is the checksum of the wasm code that can be instantiated into
+	// the reserved address
+	CodeHash []byte `protobuf:"bytes,3,opt,name=code_hash,json=codeHash,proto3" json:"code_hash,omitempty"`
+}
+
+func (m *ReservedContractAddress) Reset()         { *m = ReservedContractAddress{} }
+func (m *ReservedContractAddress) String() string { return proto.CompactTextString(m) }
+func (*ReservedContractAddress) ProtoMessage()    {}
+func (*ReservedContractAddress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{12}
+}
+func (m *ReservedContractAddress) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReservedContractAddress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReservedContractAddress.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReservedContractAddress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReservedContractAddress.Merge(m, src)
+}
+func (m *ReservedContractAddress) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReservedContractAddress) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReservedContractAddress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReservedContractAddress proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterEnum("cosmwasm.wasm.v1.AccessType", AccessType_name, AccessType_value)
 	proto.RegisterEnum("cosmwasm.wasm.v1.ContractCodeHistoryOperationType", ContractCodeHistoryOperationType_name, ContractCodeHistoryOperationType_value)
@@ -445,85 +751,154 @@ func init() {
 	proto.RegisterType((*ContractCodeHistoryEntry)(nil), "cosmwasm.wasm.v1.ContractCodeHistoryEntry")
 	proto.RegisterType((*AbsoluteTxPosition)(nil), "cosmwasm.wasm.v1.AbsoluteTxPosition")
 	proto.RegisterType((*Model)(nil), "cosmwasm.wasm.v1.Model")
+	proto.RegisterType((*ContractAccount)(nil), "cosmwasm.wasm.v1.ContractAccount")
+	proto.RegisterType((*InFlightPacket)(nil), "cosmwasm.wasm.v1.InFlightPacket")
+	proto.RegisterType((*TimelockedFunds)(nil), "cosmwasm.wasm.v1.TimelockedFunds")
+	proto.RegisterType((*CodeCommitment)(nil), "cosmwasm.wasm.v1.CodeCommitment")
+	proto.RegisterType((*ReservedContractAddress)(nil), "cosmwasm.wasm.v1.ReservedContractAddress")
 }
 
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/types.proto", fileDescriptor_e6155d98fa173e02) }
 
 var fileDescriptor_e6155d98fa173e02 = []byte{
-	// 1164 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcb, 0x6f, 0x1b, 0xc5,
-	0x1f, 0xf7, 0xda, 0xce, 0xc3, 0xd3, 0xfc, 0x5a, 0x77, 0x7e, 0x09, 0x75, 0x4c, 0xb1, 0xdd, 0xa5,
-	0x40, 0xfa, 0xb2, 0x69, 0x40, 0x80, 0x7a, 0xa8, 0xe4, 0xc7, 0xd2, 0x6c, 0x44, 0x6c, 0x6b, 0xec,
-	0x52, 0x05, 0xa9, 0x5a, 0x8d, 0x77, 0x27, 0xce, 0xa8, 0xbb, 0x3b, 0xd6, 0xce, 0x38, 0xf5, 0xf6,
-	0x2f, 0x40, 0x91, 0x90, 0xb8, 0xc1, 0x25, 0x12, 0x02, 0x84, 0xfa, 0x07, 0x70, 0xe5, 0x5e, 0x71,
-	0xea, 0x11, 0x09, 0xc9, 0x82, 0xf4, 0x02, 0xd7, 0x1c, 0xcb, 0x05, 0xed, 0x4c, 0x2c, 0xaf, 0x9a,
-	0xb6, 0x31, 0x97, 0xd5, 0x7c, 0x1f, 0x9f, 0xcf, 0xf7, 0x35, 0xf3, 0xd5, 0x82, 0x8b, 0x36, 0xe3,
-	0xde, 0x43, 0xcc, 0xbd, 0x8a, 0xfc, 0xec, 0xdd, 0xac, 0x88, 0x70, 0x40, 0x78, 0x79, 0x10, 0x30,
-	0xc1, 0x60, 0x76, 0x62, 0x2d, 0xcb, 0xcf, 0xde, 0xcd, 0xfc, 0x6a, 0xa4, 0x61, 0xdc, 0x92, 0xf6,
-	0x8a, 0x12, 0x94, 0x73, 0x7e, 0xb9, 0xcf, 0xfa, 0x4c, 0xe9, 0xa3, 0xd3, 0xb1, 0x76, 0xb5, 0xcf,
-	0x58, 0xdf, 0x25, 0x15, 0x29, 0xf5, 0x86, 0x3b, 0x15, 0xec, 0x87, 0xca, 0xa4, 0xdf, 0x07, 0xe7,
-	0xaa, 0xb6, 0x4d, 0x38, 0xef, 0x86, 0x03, 0xd2, 0xc6, 0x01, 0xf6, 0x60, 0x03, 0xcc, 0xed, 0x61,
-	0x77, 0x48, 0x72, 0x5a, 0x49, 0x5b, 0x3b, 0xbb, 0x7e, 0xb1, 0xfc, 0x62, 0x02, 0xe5, 0x29, 0xa2,
-	0x96, 0x3d, 0x1a, 0x17, 0x97, 0x42, 0xec, 0xb9, 0xb7, 0x74, 0x09, 0xd2, 0x91, 0x02, 0xdf, 0x4a,
-	0x7f, 0xfb, 0x5d, 0x51, 0xd3, 0xbf, 0xd1, 0xc0, 0x92, 0xf2, 0xae, 0x33, 0x7f, 0x87, 0xf6, 0x61,
-	0x07, 0x80, 0x01, 0x09, 0x3c, 0xca, 0x39, 0x65, 0xfe, 0x4c, 0x11, 0x56, 0x8e, 0xc6, 0xc5, 0xf3,
-	0x2a, 0xc2, 0x14, 0xa9, 0xa3, 0x18, 0x0d, 0xbc, 0x0e, 0x16, 0xb0, 0xe3, 0x04, 0x84, 0xf3, 0x5c,
-	0xb2, 0xa4, 0xad, 0x65, 0x6a, 0xf0, 0x68, 0x5c, 0x3c, 0xab, 0x30, 0xc7, 0x06, 0x1d, 0x4d, 0x5c,
-	0x8e, 0x33, 0xfb, 0x3d, 0x09, 0xe6, 0x65, 0xbd, 0x1c, 0x32, 0x00, 0x6d, 0xe6, 0x10, 0x6b, 0x38,
-	0x70, 0x19, 0x76, 0x2c, 0x2c, 0x63, 0xcb, 0xdc, 0xce, 0xac, 0x17, 0x5e, 0x95, 0x9b, 0xaa, 0xa7,
-	0x76, 0xe9, 0xc9, 0xb8, 0x98, 0x38, 0x1a, 0x17, 0x57, 0x55, 0xb4, 0x93, 0x3c, 0x3a, 0xca, 0x46,
-	0xca, 0xbb, 0x52, 0xa7, 0xa0, 0xf0, 0x2b, 0x0d, 0x14, 0xa8, 0xcf, 0x05, 0xf6, 0x05, 0xc5, 0x82,
-	0x58, 0x0e, 0xd9, 0xc1, 0x43, 0x57, 0x58, 0xb1, 0xce, 0x24, 0x67, 0xe8, 0xcc, 0x95, 0xa3, 0x71,
-	0xf1, 0x1d, 0x15, 0xf7, 0xf5, 0x6c, 0x3a, 0xba, 0x18, 0x73, 0x68, 0x28, 0x7b, 0x7b, 0xda, 0xbf,
-	0x4d, 0x00, 0x3d, 0x3c, 0xb2, 0xa2, 0x10, 0x96, 0xac, 0x80, 0xd3, 0x47, 0x24, 0x97, 0x2a, 0x69,
-	0x6b, 0xe9, 0xda, 0x5b, 0xd3, 0xe2, 0x4e, 0xfa, 0xe8, 0xe8, 0x9c, 0x87, 0x47, 0xf7, 0x30, 0xf7,
-	0xea, 0xcc, 0x21, 0x1d, 0xfa, 0x48, 0xcd, 0x3d, 0xa1, 0x7f, 0xaf, 0x81, 0xc5, 0x48, 0x65, 0xfa,
-	0x3b, 0x0c, 0xbe, 0x09, 0x32, 0x12, 0xb1, 0x8b, 0xf9, 0xae, 0x6c, 0xeb, 0x12, 0x5a, 0x8c, 0x14,
-	0x1b, 0x98, 0xef, 0xc2, 0x1c, 0x58, 0xb0, 0x03, 0x82, 0x05, 0x0b, 0xd4, 0xec, 0xd0, 0x44, 0x84,
-	0x1d, 0x00, 0xe3, 0x65, 0xd9, 0xb2, 0xe1, 0xb9, 0xb9, 0x99, 0xc6, 0x92, 0x8e, 0xc6, 0x82, 0xce,
-	0xc7, 0xf0, 0xca, 0xb0, 0x99, 0x5e, 0x4c, 0x65, 0xd3, 0x9b, 0xe9, 0xc5, 0x74, 0x76, 0x4e, 0xff,
-	0x25, 0x09, 0x96, 0xea, 0xcc, 0x17, 0x01, 0xb6, 0x85, 0x4c, 0xf4, 0x6d, 0xb0, 0x20, 0x13, 0xa5,
-	0x8e, 0x4c, 0x33, 0x5d, 0x03, 0x87, 0xe3, 0xe2, 0xbc, 0xac, 0xa3, 0x81, 0xe6, 0x23, 0x93, 0xe9,
-	0xbc, 0x26, 0xe1, 0x65, 0x30, 0x87, 0x1d, 0x8f, 0xfa, 0xb2, 0x73, 0x19, 0xa4, 0x84, 0x48, 0xeb,
-	0xe2, 0x1e, 0x71, 0x73, 0x69, 0xa5, 0x95, 0x02, 0xbc, 0x7d, 0xcc, 0x42, 0x9c, 0xe3, 0x8a, 0x2e,
-	0xbf, 0xa4, 0xa2, 0x1e, 0x67, 0xee, 0x50, 0x90, 0xee, 0xa8, 0xcd, 0x38, 0x15, 0x94, 0xf9, 0x68,
-	0x02, 0x82, 0x37, 0xc0, 0x19, 0xda, 0xb3, 0xad, 0x01, 0x0b, 0x44, 0x94, 0xee, 0xbc, 0xbc, 0xf6,
-	0xff, 0x3b, 0x1c, 0x17, 0x33, 0x66, 0xad, 0xde, 0x66, 0x81, 0x30, 0x1b, 0x28, 0x43, 0x7b, 0xb6,
-	0x3c, 0x3a, 0x70, 0x0b, 0x64, 0xc8, 0x48, 0x10, 0x5f, 0xde, 0xad, 0x05, 0x19, 0x70, 0xb9, 0xac,
-	0xb6, 0x42, 0x79, 0xb2, 0x15, 0xca, 0x55, 0x3f, 0xac, 0xad, 0xfe, 0xfa, 0xf3, 0x8d, 0x95, 0x78,
-	0x53, 0x8c, 0x09, 0x0c, 0x4d, 0x19, 0x6e, 0xa5, 0xff, 0x8a, 0x9e, 0xd0, 0x3f, 0x1a, 0xc8, 0x4d,
-	0x5c, 0xa3, 0x26, 0x6d, 0x50, 0x2e, 0x58, 0x10, 0x1a, 0xbe, 0x08, 0x42, 0xd8, 0x06, 0x19, 0x36,
-	0x20, 0x01, 0x16, 0xd3, 0x77, 0xbe, 0x7e, 0xb2, 0xc4, 0x97, 0xc0, 0x5b, 0x13, 0x54, 0x74, 0xc7,
-	0xd1, 0x94, 0x24, 0x3e, 0x9d, 0xe4, 0x2b, 0xa7, 0x73, 0x1b, 0x2c, 0x0c, 0x07, 0x8e, 0xec, 0x6b,
-	0xea, 0xbf, 0xf4, 0xf5, 0x18, 0x04, 0xd7, 0x40, 0xca, 0xe3, 0x7d, 0x39, 0xab, 0xa5, 0xda, 0x1b,
-	0xcf, 0xc7, 0x45, 0x88, 0xf0, 0xc3, 0x49, 0x96, 0x5b, 0x84, 0x73, 0xdc, 0x27, 0x28, 0x72, 0xd1,
-	0x11, 0x80, 0x27, 0x89, 0xe0, 0x25, 0xb0, 0xd4, 0x73, 0x99, 0xfd, 0xc0, 0xda, 0x25, 0xb4, 0xbf,
-	0x2b, 0xd4, 0x3d, 0x42, 0x67, 0xa4, 0x6e, 0x43, 0xaa, 0xe0, 0x2a, 0x58, 0x14, 0x23, 0x8b, 0xfa,
-	0x0e, 0x19, 0xa9, 0x42, 0xd0, 0x82, 0x18, 0x99, 0x91, 0xa8, 0x53, 0x30, 0xb7, 0xc5, 0x1c, 0xe2,
-	0xc2, 0x4d, 0x90, 0x7a, 0x40, 0x42, 0xf5, 0x58, 0x6a, 0x9f, 0x3c, 0x1f, 0x17, 0x3f, 0xec, 0x53,
-	0xb1, 0x3b, 0xec, 0x95, 0x6d, 0xe6, 0x55, 0x04, 0xf1, 0x9d, 0xe8, 0xf1, 0xfa, 0x22, 0x7e, 0x74,
-	0x69, 0x8f, 0x57, 0x7a, 0xa1, 0x20, 0xbc, 0xbc, 0x41, 0x46, 0xb5, 0xe8, 0x80, 0x22, 0x92, 0xe8,
-	0x02, 0xaa, 0x7d, 0x9e, 0x94, 0x4f, 0x4f, 0x09, 0x57, 0xff, 0xd6, 0x00, 0x98, 0xee, 0x12, 0xf8,
-	0x11, 0xb8, 0x50, 0xad, 0xd7, 0x8d, 0x4e, 0xc7, 0xea, 0x6e, 0xb7, 0x0d, 0xeb, 0x6e, 0xb3, 0xd3,
-	0x36, 0xea, 0xe6, 0xa7, 0xa6, 0xd1, 0xc8, 0x26, 0xf2, 0xab, 0xfb, 0x07, 0xa5, 0x95, 0xa9, 0xf3,
-	0x5d, 0x9f, 0x0f, 0x88, 0x4d, 0x77, 0x28, 0x71, 0xe0, 0x75, 0x00, 0xe3, 0xb8, 0x66, 0xab, 0xd6,
-	0x6a, 0x6c, 0x67, 0xb5, 0xfc, 0xf2, 0xfe, 0x41, 0x29, 0x3b, 0x85, 0x34, 0x59, 0x8f, 0x39, 0x21,
-	0xfc, 0x18, 0xe4, 0xe2, 0xde, 0xad, 0xe6, 0x67, 0xdb, 0x56, 0xb5, 0xd1, 0x40, 0x46, 0xa7, 0x93,
-	0x4d, 0xbe, 0x18, 0xa6, 0xe5, 0xbb, 0x61, 0x55, 0xed, 0x6c, 0xb8, 0x0e, 0x56, 0xe2, 0x40, 0xe3,
-	0x73, 0x03, 0x6d, 0xcb, 0x48, 0xa9, 0xfc, 0x85, 0xfd, 0x83, 0xd2, 0xff, 0xa7, 0x28, 0x63, 0x8f,
-	0x04, 0x61, 0x14, 0x2c, 0xbf, 0xf8, 0xe5, 0x0f, 0x85, 0xc4, 0xe3, 0x1f, 0x0b, 0x89, 0xab, 0x3f,
-	0xa5, 0x40, 0xe9, 0xb4, 0x9b, 0x06, 0x09, 0x78, 0xbf, 0xde, 0x6a, 0x76, 0x51, 0xb5, 0xde, 0xb5,
-	0xea, 0xad, 0x86, 0x61, 0x6d, 0x98, 0x9d, 0x6e, 0x0b, 0x6d, 0x5b, 0xad, 0xb6, 0x81, 0xaa, 0x5d,
-	0xb3, 0xd5, 0x7c, 0x59, 0x6b, 0x2a, 0xfb, 0x07, 0xa5, 0x6b, 0xa7, 0x71, 0xc7, 0x1b, 0x76, 0x0f,
-	0x5c, 0x99, 0x29, 0x8c, 0xd9, 0x34, 0xbb, 0x59, 0x2d, 0xbf, 0xb6, 0x7f, 0x50, 0xba, 0x7c, 0x1a,
-	0xbf, 0xe9, 0x53, 0x01, 0xef, 0x83, 0xeb, 0x33, 0x11, 0x6f, 0x99, 0x77, 0x50, 0xb5, 0x6b, 0x64,
-	0x93, 0xf9, 0x6b, 0xfb, 0x07, 0xa5, 0xf7, 0x4e, 0xe3, 0xde, 0xa2, 0xfd, 0x00, 0x0b, 0x32, 0x33,
-	0xfd, 0x1d, 0xa3, 0x69, 0x74, 0xcc, 0x4e, 0x36, 0x35, 0x1b, 0xfd, 0x1d, 0xe2, 0x13, 0x4e, 0x79,
-	0x3e, 0x1d, 0x0d, 0xab, 0xb6, 0xf1, 0xe4, 0xcf, 0x42, 0xe2, 0xf1, 0x61, 0x41, 0x7b, 0x72, 0x58,
-	0xd0, 0x9e, 0x1e, 0x16, 0xb4, 0x3f, 0x0e, 0x0b, 0xda, 0xd7, 0xcf, 0x0a, 0x89, 0xa7, 0xcf, 0x0a,
-	0x89, 0xdf, 0x9e, 0x15, 0x12, 0x5f, 0xbc, 0x1b, 0x7b, 0x07, 0x75, 0xc6, 0xbd, 0x7b, 0x93, 0xdf,
-	0x26, 0xa7, 0x32, 0x52, 0xbf, 0x4f, 0xf2, 0xdf, 0xa9, 0x37, 0x2f, 0xb7, 0xda, 0x07, 0xff, 0x06,
-	0x00, 0x00, 0xff, 0xff, 0x18, 0x23, 0xa5, 0x9f, 0x5c, 0x09, 0x00, 0x00,
+	// 2190 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x18, 0x4b, 0x6f, 0x1b, 0xc7,
+	0x59, 0x2b, 0x52, 0x12, 0x39, 0xa2, 0x24, 0x7a, 0x2c, 0xc9, 0x14, 0xe3, 0x70, 0x99, 0x75, 0x12,
+	0xcb, 0x8e, 0x2d, 0xc6, 0x6e, 0xd1, 0x87, 0xd1, 0xa6, 0x10, 0x1f, 0xb6, 0xe8, 0x56, 0x12, 0x31,
+	0xa4, 0x63, 0x38, 0x80, 0xb1, 0x1d, 0xee, 0x8e, 0xa8, 0x85, 0xf7, 0xc1, 0xec, 0xec, 0xca, 0x64,
+	0xfa, 0x07, 0x02, 0x01, 0x05, 0x7a, 0x6b, 0x2f, 0x02, 0x0c, 0xb4, 0x28, 0x82, 0x1e, 0x7a, 0x28,
+	0xf2, 0x23, 0x8c, 0x9e, 0x8c, 0xa2, 0x87, 0x5e, 0xba, 0x69, 0xe5, 0x4b, 0x7a, 0xe5, 0x31, 0xbd,
+	0x14, 0x33, 0xb3, 0xcb, 0x5d, 0xbd, 0x6c, 0xf6, 0x22, 0xee, 0xf7, 0x7e, 0xcc, 0x37, 0xdf, 0xf7,
+	0x8d, 0xc0, 0x55, 0xcd, 0xa1, 0xd6, 0x73, 0x4c, 0xad, 0x0a, 0xff, 0x73, 0x70, 0xa7, 0xe2, 0x0d,
+	0xfb, 0x84, 0x6e, 0xf4, 0x5d, 0xc7, 0x73, 0x60, 0x3e, 0xa2, 0x6e, 0xf0, 0x3f, 0x07, 0x77, 0x8a,
+	0x6b, 0x0c, 0xe3, 0x50, 0x95, 0xd3, 0x2b, 0x02, 0x10, 0xcc, 0xc5, 0x92, 0x80, 0x2a, 0xd8, 0xf7,
+	0xf6, 0x2b, 0x07, 0x77, 0xba, 0xc4, 0xc3, 0x77, 0x38, 0x70, 0x8a, 0xde, 0xc5, 0x94, 0x8c, 0xe9,
+	0x9a, 0x63, 0xd8, 0x21, 0x7d, 0xb9, 0xe7, 0xf4, 0x1c, 0xa1, 0x97, 0x7d, 0x85, 0xd8, 0xb5, 0x9e,
+	0xe3, 0xf4, 0x4c, 0x52, 0xe1, 0x50, 0xd7, 0xdf, 0xab, 0x60, 0x7b, 0x28, 0x48, 0xca, 0x53, 0xb0,
+	0xb4, 0xa9, 0x69, 0x84, 0xd2, 0xce, 0xb0, 0x4f, 0x5a, 0xd8, 0xc5, 0x16, 0xac, 0x83, 0x99, 0x03,
+	0x6c, 0xfa, 0xa4, 0x20, 0x95, 0xa5, 0xf5, 0xc5, 0xbb, 0x57, 0x37, 0x4e, 0x07, 0xb0, 0x11, 0x4b,
+	0x54, 0xf3, 0xa3, 0x40, 0xce, 0x0d, 0xb1, 0x65, 0xde, 0x53, 0xb8, 0x90, 0x82, 0x84, 0xf0, 0xbd,
+	0xf4, 0xef, 0x5e, 0xc8, 0x92, 0xf2, 0x5b, 0x09, 0xe4, 0x04, 0x77, 0xcd, 0xb1, 0xf7, 0x8c, 0x1e,
+	0x6c, 0x03, 0xd0, 0x27, 0xae, 0x65, 0x50, 0x6a, 0x38, 0xf6, 0x44, 0x16, 0x56, 0x46, 0x81, 0x7c,
+	0x49, 0x58, 0x88, 0x25, 0x15, 0x94, 0x50, 0x03, 0x6f, 0x81, 0x39, 0xac, 0xeb, 0x2e, 0xa1, 0xb4,
+	0x30, 0x5d, 0x96, 0xd6, 0xb3, 0x55, 0x38, 0x0a, 0xe4, 0x45, 0x21, 0x13, 0x12, 0x14, 0x14, 0xb1,
+	0x84, 0x9e, 0xfd, 0x39, 0x07, 0x66, 0x79, 0xbc, 0x14, 0x3a, 0x00, 0x6a, 0x8e, 0x4e, 0x54, 0xbf,
+	0x6f, 0x3a, 0x58, 0x57, 0x31, 0xb7, 0xcd, 0x7d, 0x9b, 0xbf, 0x5b, 0xba, 0xc8, 0x37, 0x11, 0x4f,
+	0xf5, 0xbd, 0x97, 0x81, 0x3c, 0x35, 0x0a, 0xe4, 0x35, 0x61, 0xed, 0xac, 0x1e, 0x05, 0xe5, 0x19,
+	0xf2, 0x11, 0xc7, 0x09, 0x51, 0xf8, 0x6b, 0x09, 0x94, 0x0c, 0x9b, 0x7a, 0xd8, 0xf6, 0x0c, 0xec,
+	0x11, 0x55, 0x27, 0x7b, 0xd8, 0x37, 0x3d, 0x35, 0x91, 0x99, 0xe9, 0x09, 0x32, 0x73, 0x63, 0x14,
+	0xc8, 0x1f, 0x08, 0xbb, 0x6f, 0xd6, 0xa6, 0xa0, 0xab, 0x09, 0x86, 0xba, 0xa0, 0xb7, 0xe2, 0xfc,
+	0x3d, 0x04, 0xd0, 0xc2, 0x03, 0x95, 0x99, 0x50, 0x79, 0x04, 0xd4, 0xf8, 0x82, 0x14, 0x52, 0x65,
+	0x69, 0x3d, 0x5d, 0x7d, 0x37, 0x0e, 0xee, 0x2c, 0x8f, 0x82, 0x96, 0x2c, 0x3c, 0x78, 0x8c, 0xa9,
+	0x55, 0x73, 0x74, 0xd2, 0x36, 0xbe, 0x20, 0xf0, 0xc7, 0x20, 0xc7, 0xf8, 0x2c, 0xda, 0x13, 0x5a,
+	0xd2, 0x5c, 0xcb, 0x95, 0x51, 0x20, 0x5f, 0x8e, 0xb5, 0x44, 0x54, 0x05, 0x01, 0x0b, 0x0f, 0xb6,
+	0x69, 0x8f, 0x8b, 0xfe, 0x14, 0x2c, 0x08, 0x37, 0x35, 0xa2, 0x6a, 0x0e, 0xf5, 0x0a, 0x33, 0x5c,
+	0xb6, 0x30, 0x0a, 0xe4, 0xe5, 0x64, 0x98, 0x21, 0x59, 0x41, 0xb9, 0x08, 0xae, 0x39, 0xd4, 0x83,
+	0xf7, 0x40, 0x4e, 0x73, 0xac, 0xbe, 0x61, 0x86, 0xd2, 0xb3, 0xa7, 0x2d, 0x27, 0xa9, 0x0a, 0x9a,
+	0x0f, 0x41, 0x2e, 0xfb, 0x19, 0xb8, 0xc2, 0x83, 0xd2, 0xf6, 0x89, 0xf6, 0x8c, 0xfa, 0x96, 0x8a,
+	0x4d, 0xd3, 0x79, 0x6e, 0x1a, 0xd4, 0x2b, 0xcc, 0x95, 0x53, 0xeb, 0xb9, 0xaa, 0x32, 0x0a, 0xe4,
+	0x52, 0xe2, 0x8c, 0xcf, 0x32, 0x2a, 0x68, 0x85, 0x51, 0x6a, 0x21, 0x61, 0x33, 0xc2, 0xc3, 0x3e,
+	0x90, 0x59, 0xcc, 0x9a, 0x63, 0x7b, 0x2e, 0xd6, 0x3c, 0xd5, 0x25, 0xb4, 0xef, 0xd8, 0x94, 0xa8,
+	0x3a, 0xf6, 0xb0, 0x48, 0x52, 0x86, 0xbb, 0x7a, 0x73, 0x14, 0xc8, 0x1f, 0xc6, 0x49, 0x7a, 0x83,
+	0x80, 0x82, 0xde, 0xb1, 0xf0, 0xa0, 0x16, 0x32, 0xa0, 0x90, 0x5e, 0xc7, 0x1e, 0xe6, 0x89, 0xdc,
+	0x01, 0x97, 0x3f, 0xf7, 0x89, 0x3b, 0x54, 0x35, 0xac, 0xed, 0x13, 0x95, 0xd8, 0xb8, 0x6b, 0x12,
+	0xbd, 0x90, 0x2d, 0x4b, 0xeb, 0x99, 0x6a, 0x69, 0x14, 0xc8, 0x45, 0x61, 0xe5, 0x1c, 0x26, 0x05,
+	0x5d, 0xe2, 0xd8, 0x1a, 0x43, 0x36, 0x04, 0x0e, 0x3e, 0x02, 0xab, 0xcc, 0xa1, 0x1e, 0xa6, 0xac,
+	0xa8, 0x54, 0x6f, 0xc0, 0x7e, 0x34, 0x62, 0x7b, 0x05, 0x50, 0x96, 0xd6, 0x17, 0xaa, 0xef, 0x8d,
+	0x02, 0xf9, 0xdd, 0xd8, 0xf1, 0xb3, 0x7c, 0x0a, 0x62, 0x05, 0xf6, 0x00, 0xd3, 0x16, 0x71, 0x3b,
+	0x83, 0x96, 0x40, 0xc2, 0x9f, 0x81, 0x45, 0x11, 0x27, 0xcb, 0xa7, 0xe3, 0xdb, 0x5e, 0x61, 0x9e,
+	0xe7, 0x61, 0x6d, 0x14, 0xc8, 0x2b, 0xc9, 0x3c, 0x44, 0x74, 0x05, 0xe5, 0x78, 0xd8, 0x3a, 0xa9,
+	0x31, 0x10, 0x7e, 0x1a, 0xfb, 0xe5, 0x92, 0x3d, 0xdf, 0xd6, 0xc7, 0x7e, 0xe5, 0x2e, 0xf2, 0xeb,
+	0x24, 0x9f, 0x82, 0x2e, 0x0b, 0xbf, 0x10, 0x47, 0x47, 0x8e, 0x19, 0xe0, 0x2a, 0x3f, 0xd6, 0xf8,
+	0x08, 0xe2, 0xeb, 0xc3, 0x2e, 0xe7, 0x02, 0x4f, 0xe4, 0xf5, 0x51, 0x20, 0x5f, 0x13, 0xda, 0xdf,
+	0xc4, 0xad, 0xa0, 0x22, 0x27, 0x47, 0xa7, 0xd5, 0x4c, 0x12, 0xe1, 0x4f, 0xc0, 0x02, 0x73, 0xcd,
+	0x25, 0x7d, 0x73, 0xc8, 0x1c, 0x2c, 0x2c, 0x9e, 0xae, 0xf9, 0x13, 0x64, 0x05, 0xcd, 0x5b, 0x78,
+	0x80, 0x18, 0xf8, 0x00, 0x53, 0xf8, 0x73, 0x71, 0x71, 0x0d, 0x8f, 0xb8, 0xd8, 0x73, 0x5c, 0xf6,
+	0x61, 0xd1, 0xc2, 0xd2, 0x79, 0x17, 0xf7, 0x24, 0x8f, 0x82, 0xf2, 0x16, 0x1e, 0x34, 0x43, 0x5c,
+	0x93, 0xa1, 0xa0, 0x0a, 0xd6, 0xba, 0xa6, 0xa3, 0x3d, 0x53, 0x3d, 0xc3, 0x22, 0x2a, 0x3e, 0x20,
+	0x2e, 0xee, 0x11, 0xf5, 0xb9, 0x61, 0xeb, 0xce, 0xf3, 0x42, 0x9e, 0x27, 0xf4, 0xfd, 0x51, 0x20,
+	0x97, 0x85, 0xce, 0x0b, 0x59, 0x15, 0xb4, 0xca, 0x69, 0x1d, 0xc3, 0x22, 0x9b, 0x82, 0xf2, 0x98,
+	0x13, 0xe0, 0x53, 0x50, 0xd0, 0x89, 0xee, 0xf7, 0x4d, 0x43, 0x63, 0x7d, 0x2a, 0xd1, 0x2b, 0x69,
+	0xe1, 0x12, 0x4f, 0xe9, 0xb5, 0x51, 0x20, 0xcb, 0x42, 0xff, 0x45, 0x9c, 0x0a, 0x5a, 0x4d, 0x90,
+	0x6a, 0xe3, 0xd6, 0x4a, 0xe1, 0x63, 0xb0, 0x1a, 0x96, 0x8a, 0x65, 0x19, 0x9e, 0x45, 0x6c, 0x2f,
+	0x72, 0x1e, 0x9e, 0xae, 0x86, 0xf3, 0xf9, 0x14, 0xb4, 0xcc, 0x6f, 0xf0, 0x18, 0x2f, 0xfc, 0xe6,
+	0x03, 0x63, 0x4a, 0xf9, 0xbb, 0x04, 0x32, 0xcc, 0x5c, 0xd3, 0xde, 0x73, 0xe0, 0x3b, 0x20, 0xcb,
+	0x75, 0xec, 0x63, 0xba, 0xcf, 0x27, 0x45, 0x0e, 0x65, 0x18, 0x62, 0x0b, 0xd3, 0x7d, 0x58, 0x00,
+	0x73, 0x9a, 0x4b, 0x58, 0x62, 0xc5, 0x38, 0x42, 0x11, 0x08, 0xdb, 0x00, 0x26, 0x3b, 0xb5, 0xc6,
+	0x67, 0x48, 0x61, 0x66, 0xa2, 0x49, 0x93, 0x66, 0x93, 0x06, 0x5d, 0x4a, 0xc8, 0x0b, 0x02, 0x5c,
+	0x05, 0xb3, 0xd4, 0xf1, 0x5d, 0x8d, 0xf0, 0x8e, 0x97, 0x45, 0x21, 0xc4, 0xdc, 0xe8, 0xfa, 0x86,
+	0xa9, 0x13, 0xb7, 0x30, 0x27, 0xdc, 0x08, 0xc1, 0x87, 0xe9, 0x4c, 0x2a, 0x9f, 0x7e, 0x98, 0xce,
+	0xa4, 0xf3, 0x33, 0xca, 0x3f, 0xd3, 0x20, 0x17, 0x97, 0xe6, 0x9e, 0x03, 0xaf, 0x81, 0x39, 0x1e,
+	0x9a, 0xa1, 0xf3, 0xc0, 0xd2, 0x55, 0x70, 0x1c, 0xc8, 0xb3, 0x3c, 0xf2, 0x3a, 0x9a, 0x65, 0xa4,
+	0xa6, 0xfe, 0x86, 0x10, 0x97, 0xc1, 0x0c, 0xd6, 0x2d, 0xc3, 0xe6, 0xe3, 0x23, 0x8b, 0x04, 0xc0,
+	0xb0, 0x26, 0xee, 0x12, 0x93, 0x8f, 0x83, 0x2c, 0x12, 0x00, 0xfc, 0x24, 0xd4, 0x42, 0xf4, 0x30,
+	0x07, 0xef, 0x9f, 0x93, 0x83, 0x2e, 0x75, 0x4c, 0xdf, 0x23, 0x9d, 0x41, 0xcb, 0xa1, 0x06, 0xbb,
+	0x33, 0x28, 0x12, 0x82, 0xb7, 0xc1, 0xbc, 0xd1, 0xd5, 0xd4, 0xbe, 0xe3, 0x7a, 0xcc, 0x5d, 0x1e,
+	0x7e, 0x75, 0xe1, 0x38, 0x90, 0xb3, 0xcd, 0x6a, 0xad, 0xe5, 0xb8, 0x5e, 0xb3, 0x8e, 0xb2, 0x46,
+	0x57, 0xe3, 0x9f, 0x3a, 0xdc, 0x06, 0x59, 0x32, 0xf0, 0x88, 0xcd, 0x07, 0xec, 0x1c, 0x37, 0xb8,
+	0xbc, 0x21, 0x56, 0xa3, 0x8d, 0x68, 0x35, 0xda, 0xd8, 0xb4, 0x87, 0xd5, 0xb5, 0xbf, 0x7e, 0x7d,
+	0x7b, 0x25, 0x99, 0x94, 0x46, 0x24, 0x86, 0x62, 0x0d, 0x2c, 0xef, 0x7d, 0xec, 0x53, 0xa2, 0xf3,
+	0xf6, 0x9d, 0x41, 0x21, 0x04, 0x4b, 0x00, 0x78, 0x6c, 0xb6, 0xda, 0xd8, 0x8b, 0x9a, 0x2e, 0x4a,
+	0x60, 0xe0, 0x36, 0x80, 0x96, 0xd1, 0x73, 0x59, 0x01, 0x24, 0x06, 0x3e, 0x98, 0xa4, 0x08, 0xd0,
+	0xa5, 0x50, 0x32, 0x31, 0xbc, 0xb7, 0x01, 0x24, 0x03, 0xa2, 0xf9, 0x27, 0xd5, 0xcd, 0x4f, 0xa6,
+	0x2e, 0x94, 0x4c, 0xa8, 0x93, 0xc1, 0x7c, 0xcf, 0x39, 0x50, 0x2d, 0x6c, 0xe3, 0x1e, 0xd1, 0x79,
+	0x23, 0xcd, 0x20, 0xd0, 0x73, 0x0e, 0xb6, 0x05, 0x06, 0x5e, 0x07, 0x4b, 0x6c, 0xb3, 0xe9, 0x7b,
+	0x44, 0x57, 0x75, 0x62, 0x3b, 0x16, 0x2d, 0x2c, 0x94, 0x53, 0xeb, 0x59, 0xb4, 0x18, 0xa1, 0xeb,
+	0x1c, 0x7b, 0x2f, 0xfd, 0x2d, 0xdb, 0xb3, 0xfe, 0x2b, 0x81, 0x42, 0x94, 0x4a, 0x56, 0x44, 0x5b,
+	0x06, 0xf5, 0x1c, 0x77, 0xd8, 0xb0, 0x3d, 0x77, 0x08, 0x5b, 0x20, 0xeb, 0xf4, 0x59, 0x0f, 0x8a,
+	0x97, 0xc1, 0xbb, 0x67, 0x5d, 0x3e, 0x47, 0x7c, 0x37, 0x92, 0x62, 0x8b, 0x10, 0x8a, 0x95, 0x24,
+	0xab, 0x77, 0xfa, 0xc2, 0xea, 0xfd, 0x04, 0xcc, 0xf9, 0x7d, 0x9d, 0x1f, 0x4f, 0xea, 0xff, 0xa9,
+	0xbb, 0x50, 0x08, 0xae, 0x83, 0x94, 0x45, 0x7b, 0xbc, 0x96, 0x73, 0xd5, 0xd5, 0xef, 0x02, 0x19,
+	0x22, 0x3c, 0xee, 0xef, 0xdb, 0x84, 0x52, 0xdc, 0x23, 0x88, 0xb1, 0x28, 0x08, 0xc0, 0xb3, 0x8a,
+	0xe0, 0x7b, 0x20, 0x27, 0xda, 0xe7, 0x3e, 0x31, 0x7a, 0xfb, 0x9e, 0xb8, 0x67, 0x68, 0x9e, 0xe3,
+	0xb6, 0x38, 0x0a, 0xae, 0x81, 0x8c, 0x37, 0x50, 0x0d, 0x5b, 0x27, 0x03, 0x11, 0x08, 0x9a, 0xf3,
+	0x06, 0x4d, 0x06, 0x2a, 0x06, 0x98, 0xd9, 0x76, 0x74, 0x62, 0xc2, 0x87, 0x20, 0xf5, 0x8c, 0x0c,
+	0x45, 0xfb, 0xa9, 0xfe, 0xe8, 0xbb, 0x40, 0xfe, 0x7e, 0xcf, 0xf0, 0xf6, 0xfd, 0xee, 0x86, 0xe6,
+	0x58, 0x15, 0x8f, 0xd8, 0x3a, 0xaf, 0x39, 0x2f, 0xf9, 0x69, 0x1a, 0x5d, 0x5a, 0xe9, 0x0e, 0x3d,
+	0x42, 0x37, 0xb6, 0xc8, 0xa0, 0xca, 0x3e, 0x10, 0x53, 0xc2, 0x2e, 0xa8, 0x58, 0xfa, 0xa7, 0x79,
+	0x33, 0x13, 0x80, 0xf2, 0x17, 0x09, 0x2c, 0x45, 0x71, 0x6d, 0x6a, 0x7c, 0x06, 0xc3, 0x5f, 0x82,
+	0x1c, 0x7b, 0x7d, 0xa8, 0x58, 0xc0, 0xe1, 0x9e, 0x5c, 0xde, 0x08, 0xdf, 0x31, 0xfc, 0xb1, 0x12,
+	0xbe, 0x4c, 0x36, 0xaa, 0x98, 0x92, 0x50, 0xae, 0xfa, 0xce, 0xab, 0x40, 0x96, 0xe2, 0x65, 0x2c,
+	0xa9, 0x43, 0x41, 0xf3, 0xdd, 0x98, 0x73, 0xa2, 0x33, 0xbc, 0x57, 0xf8, 0xf2, 0x85, 0x3c, 0xc5,
+	0x1a, 0xf3, 0xb7, 0x2f, 0xe4, 0xa9, 0xbf, 0x7d, 0x7d, 0x3b, 0x13, 0x4a, 0x37, 0x15, 0x0f, 0x2c,
+	0x36, 0xed, 0xfb, 0x26, 0x4b, 0x63, 0x0b, 0x6b, 0xcf, 0x88, 0xc7, 0x6a, 0x5a, 0xf4, 0x44, 0xde,
+	0x2a, 0xb8, 0xc7, 0x59, 0x04, 0x04, 0x8a, 0xf5, 0x06, 0xf8, 0x01, 0x58, 0x0c, 0x19, 0xb4, 0x7d,
+	0x6c, 0xdb, 0xc4, 0x0c, 0xbb, 0xda, 0x82, 0xc0, 0xd6, 0x04, 0x12, 0x16, 0x41, 0x86, 0x92, 0xcf,
+	0x7d, 0x62, 0x6b, 0xe1, 0x76, 0x8c, 0xc6, 0xb0, 0x32, 0x92, 0xc0, 0x12, 0x1b, 0x79, 0xec, 0x0c,
+	0x89, 0x7e, 0xdf, 0xb7, 0x75, 0x0a, 0x57, 0xc1, 0xf4, 0xb8, 0x8b, 0xce, 0x1e, 0x07, 0xf2, 0x74,
+	0xb3, 0x8e, 0xa6, 0x0d, 0x1d, 0x5e, 0x05, 0x59, 0x9d, 0xf4, 0x59, 0x35, 0x8c, 0xfb, 0x67, 0x8c,
+	0x80, 0x1a, 0x98, 0xc5, 0x16, 0x4f, 0x6d, 0xaa, 0x9c, 0x5a, 0x9f, 0xbf, 0xbb, 0x16, 0xa5, 0x96,
+	0xe5, 0x68, 0x9c, 0xda, 0x9a, 0x63, 0xd8, 0xd5, 0x8f, 0xd9, 0x4c, 0xf8, 0xd3, 0x37, 0xf2, 0x7a,
+	0xe2, 0xe0, 0xc3, 0x17, 0xa2, 0xf8, 0xb9, 0x4d, 0xf5, 0x67, 0xe1, 0x6b, 0x94, 0x09, 0x50, 0x14,
+	0xaa, 0x86, 0xd7, 0xc0, 0x82, 0x6f, 0x27, 0x6b, 0x90, 0x15, 0x73, 0x0a, 0xe5, 0x7c, 0x3b, 0x51,
+	0x84, 0x32, 0x98, 0xf7, 0xed, 0xf1, 0x9c, 0x17, 0xeb, 0x38, 0x02, 0x02, 0xc5, 0x62, 0x55, 0xbe,
+	0x91, 0xc0, 0x62, 0xed, 0xc4, 0xc8, 0x4c, 0x4e, 0x06, 0xe9, 0xe4, 0x64, 0x28, 0x82, 0x4c, 0xb4,
+	0x35, 0x87, 0x55, 0x36, 0x86, 0xc7, 0xf3, 0x34, 0x7e, 0x78, 0x88, 0x79, 0xca, 0xd7, 0xd9, 0x6b,
+	0x60, 0x81, 0x0c, 0xfa, 0x86, 0x3b, 0x3c, 0xe5, 0xab, 0x40, 0x86, 0xbe, 0x3e, 0x02, 0xab, 0xc9,
+	0xd1, 0x9a, 0x68, 0x85, 0x13, 0x8d, 0x57, 0xb4, 0x92, 0x90, 0x8e, 0xdb, 0xa1, 0xf2, 0x2b, 0x70,
+	0x05, 0x11, 0x4a, 0xdc, 0x03, 0xa2, 0x8f, 0x2f, 0x82, 0x78, 0x47, 0xc2, 0x1b, 0x20, 0x3f, 0xde,
+	0xf8, 0xa2, 0xe7, 0xa7, 0x08, 0x79, 0x49, 0x3b, 0xc5, 0x7a, 0xf1, 0xb8, 0x3c, 0xb1, 0x48, 0xa4,
+	0x4e, 0x2e, 0x12, 0x37, 0xff, 0x23, 0x01, 0x10, 0xbf, 0xf7, 0xe0, 0x0f, 0xc0, 0x95, 0xcd, 0x5a,
+	0xad, 0xd1, 0x6e, 0xab, 0x9d, 0x27, 0xad, 0x86, 0xfa, 0x68, 0xa7, 0xdd, 0x6a, 0xd4, 0x9a, 0xf7,
+	0x9b, 0x8d, 0x7a, 0x7e, 0xaa, 0xb8, 0x76, 0x78, 0x54, 0x5e, 0x89, 0x99, 0x1f, 0xd9, 0xb4, 0x4f,
+	0x34, 0x63, 0xcf, 0x20, 0x3a, 0xbc, 0x05, 0x60, 0x52, 0x6e, 0x67, 0xb7, 0xba, 0x5b, 0x7f, 0x92,
+	0x97, 0x8a, 0xcb, 0x87, 0x47, 0xe5, 0x7c, 0x2c, 0xb2, 0xe3, 0x74, 0x1d, 0x7d, 0x08, 0x7f, 0x08,
+	0x0a, 0x49, 0xee, 0xdd, 0x9d, 0x5f, 0x3c, 0x51, 0x37, 0xeb, 0x75, 0xd4, 0x68, 0xb7, 0xf3, 0xd3,
+	0xa7, 0xcd, 0xec, 0xda, 0xe6, 0x30, 0x0a, 0xf2, 0x2e, 0x58, 0x49, 0x0a, 0x36, 0x3e, 0x6d, 0xa0,
+	0x27, 0xdc, 0x52, 0xaa, 0x78, 0xe5, 0xf0, 0xa8, 0x7c, 0x39, 0x96, 0x6a, 0x1c, 0x10, 0x77, 0xc8,
+	0x8c, 0x15, 0x33, 0x5f, 0xfe, 0xbe, 0x34, 0xf5, 0xd5, 0x1f, 0x4a, 0x53, 0x37, 0xff, 0x98, 0x02,
+	0xe5, 0xb7, 0x35, 0x7a, 0x48, 0xc0, 0xc7, 0xb5, 0xdd, 0x9d, 0x0e, 0xda, 0xac, 0x75, 0xd4, 0xda,
+	0x6e, 0xbd, 0xa1, 0x6e, 0x35, 0xdb, 0x9d, 0x5d, 0xf4, 0x44, 0xdd, 0x6d, 0x35, 0xd0, 0x66, 0xa7,
+	0xb9, 0xbb, 0x73, 0x5e, 0x6a, 0x2a, 0x87, 0x47, 0xe5, 0x8f, 0xde, 0xa6, 0x3b, 0x99, 0xb0, 0xc7,
+	0xe0, 0xc6, 0x44, 0x66, 0x9a, 0x3b, 0xcd, 0x4e, 0x5e, 0x2a, 0xae, 0x1f, 0x1e, 0x95, 0xdf, 0x7f,
+	0x9b, 0xfe, 0xa6, 0x6d, 0x78, 0xf0, 0x29, 0xb8, 0x35, 0x91, 0xe2, 0xed, 0xe6, 0x03, 0xb4, 0xd9,
+	0x69, 0xe4, 0xa7, 0x8b, 0x1f, 0x1d, 0x1e, 0x95, 0xaf, 0xbf, 0x4d, 0xf7, 0xb6, 0x58, 0x0a, 0x26,
+	0x56, 0xff, 0xa0, 0xb1, 0xd3, 0x68, 0x37, 0xdb, 0xf9, 0xd4, 0x64, 0xea, 0x1f, 0x10, 0x9b, 0x50,
+	0x83, 0x16, 0xd3, 0xec, 0xb0, 0xaa, 0x5b, 0x2f, 0xff, 0x5d, 0x9a, 0xfa, 0xea, 0xb8, 0x24, 0xbd,
+	0x3c, 0x2e, 0x49, 0xaf, 0x8e, 0x4b, 0xd2, 0xbf, 0x8e, 0x4b, 0xd2, 0x6f, 0x5e, 0x97, 0xa6, 0x5e,
+	0xbd, 0x2e, 0x4d, 0xfd, 0xe3, 0x75, 0x69, 0xea, 0xb3, 0x0f, 0x13, 0xdd, 0xa8, 0xe6, 0x50, 0xeb,
+	0x71, 0xf4, 0xaf, 0x31, 0xbd, 0x32, 0xe0, 0xbf, 0xa2, 0x23, 0x75, 0x67, 0xf9, 0xd2, 0xf5, 0xbd,
+	0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x29, 0x98, 0x0d, 0x81, 0x40, 0x13, 0x00, 0x00,
 }
 
 func (this *AccessTypeParam) Equal(that interface{}) bool {
@@ -605,6 +980,56 @@ func (this *Params) Equal(that interface{}) bool {
 	if this.MaxWasmCodeSize != that1.MaxWasmCodeSize {
 		return false
 	}
+	if this.MaxMsgSize != that1.MaxMsgSize {
+		return false
+	}
+	if this.InstanceCost != that1.InstanceCost {
+		return false
+	}
+	if this.CompileCost != that1.CompileCost {
+		return false
+	}
+	if len(this.CodeChecksumAllowlist) != len(that1.CodeChecksumAllowlist) {
+		return false
+	}
+	for i := range this.CodeChecksumAllowlist {
+		if !bytes.Equal(this.CodeChecksumAllowlist[i], that1.CodeChecksumAllowlist[i]) {
+			return false
+		}
+	}
+	if this.MaxContractResponseDataSize != that1.MaxContractResponseDataSize {
+		return false
+	}
+	if this.QueryCacheEnabled != that1.QueryCacheEnabled {
+		return false
+	}
+	if this.MaxGasPerTxPercent != that1.MaxGasPerTxPercent {
+		return false
+	}
+	if this.MaxCodeCount != that1.MaxCodeCount {
+		return false
+	}
+	if this.MaxGasRefundPercent != that1.MaxGasRefundPercent {
+		return false
+	}
+	if this.AllowContractInstantiation != that1.AllowContractInstantiation {
+		return false
+	}
+	if this.MaxReplyGas != that1.MaxReplyGas {
+		return false
+	}
+	if this.MaxIteratorItems != that1.MaxIteratorItems {
+		return false
+	}
+	if this.BlockTimeAverageWindow != that1.BlockTimeAverageWindow {
+		return false
+	}
+	if this.DeduplicateCodeUploads != that1.DeduplicateCodeUploads {
+		return false
+	}
+	if this.CodeCommitmentWindow != that1.CodeCommitmentWindow {
+		return false
+	}
 	return true
 }
 func (this *CodeInfo) Equal(that interface{}) bool {
@@ -635,6 +1060,12 @@ func (this *CodeInfo) Equal(that interface{}) bool {
 	if !this.InstantiateConfig.Equal(&that1.InstantiateConfig) {
 		return false
 	}
+	if this.Source != that1.Source {
+		return false
+	}
+	if this.Builder != that1.Builder {
+		return false
+	}
 	return true
 }
 func (this *ContractInfo) Equal(that interface{}) bool {
@@ -677,6 +1108,29 @@ func (this *ContractInfo) Equal(that interface{}) bool {
 	if !this.Extension.Equal(that1.Extension) {
 		return false
 	}
+	if this.Paused != that1.Paused {
+		return false
+	}
+	if this.Terminated != that1.Terminated {
+		return false
+	}
+	if !this.MigratePermission.Equal(that1.MigratePermission) {
+		return false
+	}
+	if !this.ExecutePermission.Equal(that1.ExecutePermission) {
+		return false
+	}
+	if this.GovManaged != that1.GovManaged {
+		return false
+	}
+	if len(this.AcceptedDenoms) != len(that1.AcceptedDenoms) {
+		return false
+	}
+	for i := range this.AcceptedDenoms {
+		if this.AcceptedDenoms[i] != that1.AcceptedDenoms[i] {
+			return false
+		}
+	}
 	return true
 }
 func (this *ContractCodeHistoryEntry) Equal(that interface{}) bool {
@@ -766,93 +1220,330 @@ func (this *Model) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (m *AccessTypeParam) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *InFlightPacket) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *AccessTypeParam) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *AccessTypeParam) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Value != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Value))
-		i--
-		dAtA[i] = 0x8
+	that1, ok := that.(*InFlightPacket)
+	if !ok {
+		that2, ok := that.(InFlightPacket)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *AccessConfig) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *AccessConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *AccessConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Address) > 0 {
-		i -= len(m.Address)
-		copy(dAtA[i:], m.Address)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Address)))
-		i--
-		dAtA[i] = 0x12
+	if this.SourcePort != that1.SourcePort {
+		return false
 	}
-	if m.Permission != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Permission))
-		i--
-		dAtA[i] = 0x8
+	if this.SourceChannel != that1.SourceChannel {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *Params) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Sequence != that1.Sequence {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Params) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return true
 }
-
-func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.MaxWasmCodeSize != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.MaxWasmCodeSize))
-		i--
-		dAtA[i] = 0x18
+func (this *TimelockedFunds) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TimelockedFunds)
+	if !ok {
+		that2, ok := that.(TimelockedFunds)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	if this.Depositor != that1.Depositor {
+		return false
+	}
+	if len(this.Amount) != len(that1.Amount) {
+		return false
+	}
+	for i := range this.Amount {
+		if !this.Amount[i].Equal(&that1.Amount[i]) {
+			return false
+		}
+	}
+	if this.UnlockHeight != that1.UnlockHeight {
+		return false
+	}
+	if this.UnlockTime != that1.UnlockTime {
+		return false
+	}
+	return true
+}
+func (this *CodeCommitment) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CodeCommitment)
+	if !ok {
+		that2, ok := that.(CodeCommitment)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Creator != that1.Creator {
+		return false
+	}
+	if !bytes.Equal(this.Checksum, that1.Checksum) {
+		return false
+	}
+	if this.CodeSize != that1.CodeSize {
+		return false
+	}
+	if this.ExpiryHeight != that1.ExpiryHeight {
+		return false
+	}
+	if !this.InstantiatePermission.Equal(that1.InstantiatePermission) {
+		return false
+	}
+	return true
+}
+func (this *ReservedContractAddress) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ReservedContractAddress)
+	if !ok {
+		that2, ok := that.(ReservedContractAddress)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ContractAddress != that1.ContractAddress {
+		return false
+	}
+	if this.Creator != that1.Creator {
+		return false
+	}
+	if !bytes.Equal(this.CodeHash, that1.CodeHash) {
+		return false
+	}
+	return true
+}
+func (m *AccessTypeParam) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccessTypeParam) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccessTypeParam) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Value))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AccessConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccessConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccessConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Permission != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Permission))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CodeCommitmentWindow != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeCommitmentWindow))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.DeduplicateCodeUploads {
+		i--
+		if m.DeduplicateCodeUploads {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.BlockTimeAverageWindow != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BlockTimeAverageWindow))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.MaxIteratorItems != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxIteratorItems))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.MaxReplyGas != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxReplyGas))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.AllowContractInstantiation {
+		i--
+		if m.AllowContractInstantiation {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.MaxGasRefundPercent != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxGasRefundPercent))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.MaxCodeCount != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxCodeCount))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.MaxGasPerTxPercent != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxGasPerTxPercent))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.QueryCacheEnabled {
+		i--
+		if m.QueryCacheEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.MaxContractResponseDataSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxContractResponseDataSize))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.CodeChecksumAllowlist) > 0 {
+		for iNdEx := len(m.CodeChecksumAllowlist) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CodeChecksumAllowlist[iNdEx])
+			copy(dAtA[i:], m.CodeChecksumAllowlist[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.CodeChecksumAllowlist[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.CompileCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CompileCost))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.InstanceCost != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.InstanceCost))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MaxMsgSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxMsgSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxWasmCodeSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxWasmCodeSize))
+		i--
+		dAtA[i] = 0x18
 	}
 	if m.InstantiateDefaultPermission != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.InstantiateDefaultPermission))
@@ -892,6 +1583,20 @@ func (m *CodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Builder) > 0 {
+		i -= len(m.Builder)
+		copy(dAtA[i:], m.Builder)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Builder)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x32
+	}
 	{
 		size, err := m.InstantiateConfig.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -939,6 +1644,69 @@ func (m *ContractInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AcceptedDenoms) > 0 {
+		for iNdEx := len(m.AcceptedDenoms) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AcceptedDenoms[iNdEx])
+			copy(dAtA[i:], m.AcceptedDenoms[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.AcceptedDenoms[iNdEx])))
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if m.GovManaged {
+		i--
+		if m.GovManaged {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.ExecutePermission != nil {
+		{
+			size, err := m.ExecutePermission.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.MigratePermission != nil {
+		{
+			size, err := m.MigratePermission.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.Terminated {
+		i--
+		if m.Terminated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Paused {
+		i--
+		if m.Paused {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
 	if m.Extension != nil {
 		{
 			size, err := m.Extension.MarshalToSizedBuffer(dAtA[:i])
@@ -1121,9 +1889,253 @@ func (m *Model) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
+func (m *ContractAccount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractAccount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractAccount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CodeID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.BaseAccount != nil {
+		{
+			size, err := m.BaseAccount.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InFlightPacket) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InFlightPacket) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InFlightPacket) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Sequence != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.SourceChannel) > 0 {
+		i -= len(m.SourceChannel)
+		copy(dAtA[i:], m.SourceChannel)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.SourceChannel)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SourcePort) > 0 {
+		i -= len(m.SourcePort)
+		copy(dAtA[i:], m.SourcePort)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.SourcePort)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TimelockedFunds) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimelockedFunds) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimelockedFunds) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.UnlockTime != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.UnlockTime))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.UnlockHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.UnlockHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Amount) > 0 {
+		for iNdEx := len(m.Amount) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Amount[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Depositor) > 0 {
+		i -= len(m.Depositor)
+		copy(dAtA[i:], m.Depositor)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Depositor)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CodeCommitment) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CodeCommitment) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CodeCommitment) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.InstantiatePermission != nil {
+		{
+			size, err := m.InstantiatePermission.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.ExpiryHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ExpiryHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.CodeSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Checksum) > 0 {
+		i -= len(m.Checksum)
+		copy(dAtA[i:], m.Checksum)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Checksum)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Creator) > 0 {
+		i -= len(m.Creator)
+		copy(dAtA[i:], m.Creator)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Creator)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReservedContractAddress) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReservedContractAddress) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReservedContractAddress) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.CodeHash) > 0 {
+		i -= len(m.CodeHash)
+		copy(dAtA[i:], m.CodeHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.CodeHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Creator) > 0 {
+		i -= len(m.Creator)
+		copy(dAtA[i:], m.Creator)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Creator)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ContractAddress) > 0 {
+		i -= len(m.ContractAddress)
+		copy(dAtA[i:], m.ContractAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ContractAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
 		v >>= 7
@@ -1174,6 +2186,54 @@ func (m *Params) Size() (n int) {
 	if m.MaxWasmCodeSize != 0 {
 		n += 1 + sovTypes(uint64(m.MaxWasmCodeSize))
 	}
+	if m.MaxMsgSize != 0 {
+		n += 1 + sovTypes(uint64(m.MaxMsgSize))
+	}
+	if m.InstanceCost != 0 {
+		n += 1 + sovTypes(uint64(m.InstanceCost))
+	}
+	if m.CompileCost != 0 {
+		n += 1 + sovTypes(uint64(m.CompileCost))
+	}
+	if len(m.CodeChecksumAllowlist) > 0 {
+		for _, b := range m.CodeChecksumAllowlist {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.MaxContractResponseDataSize != 0 {
+		n += 1 + sovTypes(uint64(m.MaxContractResponseDataSize))
+	}
+	if m.QueryCacheEnabled {
+		n += 2
+	}
+	if m.MaxGasPerTxPercent != 0 {
+		n += 1 + sovTypes(uint64(m.MaxGasPerTxPercent))
+	}
+	if m.MaxCodeCount != 0 {
+		n += 1 + sovTypes(uint64(m.MaxCodeCount))
+	}
+	if m.MaxGasRefundPercent != 0 {
+		n += 1 + sovTypes(uint64(m.MaxGasRefundPercent))
+	}
+	if m.AllowContractInstantiation {
+		n += 2
+	}
+	if m.MaxReplyGas != 0 {
+		n += 1 + sovTypes(uint64(m.MaxReplyGas))
+	}
+	if m.MaxIteratorItems != 0 {
+		n += 1 + sovTypes(uint64(m.MaxIteratorItems))
+	}
+	if m.BlockTimeAverageWindow != 0 {
+		n += 2 + sovTypes(uint64(m.BlockTimeAverageWindow))
+	}
+	if m.DeduplicateCodeUploads {
+		n += 3
+	}
+	if m.CodeCommitmentWindow != 0 {
+		n += 2 + sovTypes(uint64(m.CodeCommitmentWindow))
+	}
 	return n
 }
 
@@ -1193,6 +2253,14 @@ func (m *CodeInfo) Size() (n int) {
 	}
 	l = m.InstantiateConfig.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Builder)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1229,6 +2297,29 @@ func (m *ContractInfo) Size() (n int) {
 		l = m.Extension.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Paused {
+		n += 2
+	}
+	if m.Terminated {
+		n += 2
+	}
+	if m.MigratePermission != nil {
+		l = m.MigratePermission.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.ExecutePermission != nil {
+		l = m.ExecutePermission.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.GovManaged {
+		n += 2
+	}
+	if len(m.AcceptedDenoms) > 0 {
+		for _, s := range m.AcceptedDenoms {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1287,26 +2378,138 @@ func (m *Model) Size() (n int) {
 	return n
 }
 
-func sovTypes(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *ContractAccount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BaseAccount != nil {
+		l = m.BaseAccount.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.CodeID != 0 {
+		n += 1 + sovTypes(uint64(m.CodeID))
+	}
+	return n
 }
-func sozTypes(x uint64) (n int) {
-	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *InFlightPacket) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SourcePort)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.SourceChannel)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovTypes(uint64(m.Sequence))
+	}
+	return n
 }
-func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
+
+func (m *TimelockedFunds) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovTypes(uint64(m.ID))
+	}
+	l = len(m.Depositor)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Amount) > 0 {
+		for _, e := range m.Amount {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.UnlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.UnlockHeight))
+	}
+	if m.UnlockTime != 0 {
+		n += 1 + sovTypes(uint64(m.UnlockTime))
+	}
+	return n
+}
+
+func (m *CodeCommitment) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Creator)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Checksum)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.CodeSize != 0 {
+		n += 1 + sovTypes(uint64(m.CodeSize))
+	}
+	if m.ExpiryHeight != 0 {
+		n += 1 + sovTypes(uint64(m.ExpiryHeight))
+	}
+	if m.InstantiatePermission != nil {
+		l = m.InstantiatePermission.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ReservedContractAddress) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContractAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Creator)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.CodeHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
 			iNdEx++
 			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
@@ -1440,7 +2643,1233 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Address = string(dAtA[iNdEx:postIndex])
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeUploadAccess", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CodeUploadAccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateDefaultPermission", wireType)
+			}
+			m.InstantiateDefaultPermission = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InstantiateDefaultPermission |= AccessType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxWasmCodeSize", wireType)
+			}
+			m.MaxWasmCodeSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxWasmCodeSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMsgSize", wireType)
+			}
+			m.MaxMsgSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMsgSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceCost", wireType)
+			}
+			m.InstanceCost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InstanceCost |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompileCost", wireType)
+			}
+			m.CompileCost = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompileCost |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeChecksumAllowlist", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeChecksumAllowlist = append(m.CodeChecksumAllowlist, make([]byte, postIndex-iNdEx))
+			copy(m.CodeChecksumAllowlist[len(m.CodeChecksumAllowlist)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxContractResponseDataSize", wireType)
+			}
+			m.MaxContractResponseDataSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxContractResponseDataSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueryCacheEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.QueryCacheEnabled = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGasPerTxPercent", wireType)
+			}
+			m.MaxGasPerTxPercent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGasPerTxPercent |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCodeCount", wireType)
+			}
+			m.MaxCodeCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCodeCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGasRefundPercent", wireType)
+			}
+			m.MaxGasRefundPercent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGasRefundPercent |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowContractInstantiation", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllowContractInstantiation = bool(v != 0)
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxReplyGas", wireType)
+			}
+			m.MaxReplyGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxReplyGas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxIteratorItems", wireType)
+			}
+			m.MaxIteratorItems = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxIteratorItems |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockTimeAverageWindow", wireType)
+			}
+			m.BlockTimeAverageWindow = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockTimeAverageWindow |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeduplicateCodeUploads", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DeduplicateCodeUploads = bool(v != 0)
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeCommitmentWindow", wireType)
+			}
+			m.CodeCommitmentWindow = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeCommitmentWindow |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CodeInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CodeInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CodeInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeHash = append(m.CodeHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.CodeHash == nil {
+				m.CodeHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Creator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateConfig", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.InstantiateConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Builder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Builder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContractInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Creator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Admin = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Label = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Created == nil {
+				m.Created = &AbsoluteTxPosition{}
+			}
+			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IBCPortID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IBCPortID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Extension == nil {
+				m.Extension = &types.Any{}
+			}
+			if err := m.Extension.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Paused", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Paused = bool(v != 0)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Terminated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Terminated = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MigratePermission", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MigratePermission == nil {
+				m.MigratePermission = &AccessConfig{}
+			}
+			if err := m.MigratePermission.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutePermission", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExecutePermission == nil {
+				m.ExecutePermission = &AccessConfig{}
+			}
+			if err := m.ExecutePermission.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GovManaged", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.GovManaged = bool(v != 0)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AcceptedDenoms", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AcceptedDenoms = append(m.AcceptedDenoms, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractCodeHistoryEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractCodeHistoryEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			m.Operation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Operation |= ContractCodeHistoryOperationType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Updated == nil {
+				m.Updated = &AbsoluteTxPosition{}
+			}
+			if err := m.Updated.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
+			if m.Msg == nil {
+				m.Msg = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1463,7 +3892,7 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Params) Unmarshal(dAtA []byte) error {
+func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1486,50 +3915,17 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+			return fmt.Errorf("proto: AbsoluteTxPosition: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AbsoluteTxPosition: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeUploadAccess", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.CodeUploadAccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateDefaultPermission", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
 			}
-			m.InstantiateDefaultPermission = 0
+			m.BlockHeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1539,16 +3935,16 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.InstantiateDefaultPermission |= AccessType(b&0x7F) << shift
+				m.BlockHeight |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxWasmCodeSize", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TxIndex", wireType)
 			}
-			m.MaxWasmCodeSize = 0
+			m.TxIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1558,7 +3954,7 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxWasmCodeSize |= uint64(b&0x7F) << shift
+				m.TxIndex |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1584,7 +3980,7 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CodeInfo) Unmarshal(dAtA []byte) error {
+func (m *Model) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1607,15 +4003,15 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CodeInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: Model: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CodeInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Model: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1642,48 +4038,16 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CodeHash = append(m.CodeHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.CodeHash == nil {
-				m.CodeHash = []byte{}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Creator = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateConfig", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1693,23 +4057,24 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.InstantiateConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -1733,7 +4098,7 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ContractInfo) Unmarshal(dAtA []byte) error {
+func (m *ContractAccount) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1756,36 +4121,17 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContractInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: ContractAccount: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContractInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ContractAccount: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
-			}
-			m.CodeID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CodeID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseAccount", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1795,29 +4141,33 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Creator = string(dAtA[iNdEx:postIndex])
+			if m.BaseAccount == nil {
+				m.BaseAccount = &types1.BaseAccount{}
+			}
+			if err := m.BaseAccount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
 			}
-			var stringLen uint64
+			m.CodeID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1827,27 +4177,64 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.CodeID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Admin = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InFlightPacket) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InFlightPacket: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InFlightPacket: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SourcePort", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1872,50 +4259,14 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Label = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Created == nil {
-				m.Created = &AbsoluteTxPosition{}
-			}
-			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.SourcePort = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IBCPortID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceChannel", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1943,13 +4294,13 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IBCPortID = string(dAtA[iNdEx:postIndex])
+			m.SourceChannel = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
 			}
-			var msglen int
+			m.Sequence = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1959,28 +4310,11 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Sequence |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Extension == nil {
-				m.Extension = &types.Any{}
-			}
-			if err := m.Extension.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2002,7 +4336,7 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
+func (m *TimelockedFunds) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2025,17 +4359,17 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContractCodeHistoryEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: TimelockedFunds: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContractCodeHistoryEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TimelockedFunds: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			m.Operation = 0
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2045,16 +4379,16 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Operation |= ContractCodeHistoryOperationType(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Depositor", wireType)
 			}
-			m.CodeID = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2064,14 +4398,27 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CodeID |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Depositor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2098,18 +4445,16 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Updated == nil {
-				m.Updated = &AbsoluteTxPosition{}
-			}
-			if err := m.Updated.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Amount = append(m.Amount, types2.Coin{})
+			if err := m.Amount[len(m.Amount)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnlockHeight", wireType)
 			}
-			var byteLen int
+			m.UnlockHeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2119,26 +4464,30 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.UnlockHeight |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnlockTime", wireType)
 			}
-			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
-			if m.Msg == nil {
-				m.Msg = []byte{}
+			m.UnlockTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UnlockTime |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2160,7 +4509,7 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
+func (m *CodeCommitment) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2183,17 +4532,17 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AbsoluteTxPosition: wiretype end group for non-group")
+			return fmt.Errorf("proto: CodeCommitment: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AbsoluteTxPosition: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CodeCommitment: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
 			}
-			m.BlockHeight = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2203,16 +4552,63 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BlockHeight |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Creator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Checksum = append(m.Checksum[:0], dAtA[iNdEx:postIndex]...)
+			if m.Checksum == nil {
+				m.Checksum = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TxIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeSize", wireType)
 			}
-			m.TxIndex = 0
+			m.CodeSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2222,11 +4618,66 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TxIndex |= uint64(b&0x7F) << shift
+				m.CodeSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiryHeight", wireType)
+			}
+			m.ExpiryHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpiryHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiatePermission", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InstantiatePermission == nil {
+				m.InstantiatePermission = &AccessConfig{}
+			}
+			if err := m.InstantiatePermission.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2248,7 +4699,7 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Model) Unmarshal(dAtA []byte) error {
+func (m *ReservedContractAddress) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2271,17 +4722,17 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Model: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReservedContractAddress: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Model: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReservedContractAddress: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractAddress", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2291,29 +4742,59 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
-			}
+			m.ContractAddress = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Creator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeHash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2340,9 +4821,9 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.CodeHash = append(m.CodeHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.CodeHash == nil {
+				m.CodeHash = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -52,6 +52,41 @@ func TestContractInfoValidateBasic(t *testing.T) {
 			srcMutator: func(c *ContractInfo) { c.Label = strings.Repeat("a", MaxLabelSize+1) },
 			expError:   true,
 		},
+		"migrate permission nobody": {
+			srcMutator: func(c *ContractInfo) { c.MigratePermission = &AllowNobody },
+		},
+		"migrate permission everybody": {
+			srcMutator: func(c *ContractInfo) { c.MigratePermission = &AllowEverybody },
+			expError:   true,
+		},
+		"migrate permission invalid": {
+			srcMutator: func(c *ContractInfo) { c.MigratePermission = &AccessConfig{} },
+			expError:   true,
+		},
+		"execute permission only address": {
+			srcMutator: func(c *ContractInfo) {
+				p := AccessTypeOnlyAddress.With(randBytes(address.Len))
+				c.ExecutePermission = &p
+			},
+		},
+		"execute permission everybody": {
+			srcMutator: func(c *ContractInfo) { c.ExecutePermission = &AllowEverybody },
+		},
+		"execute permission invalid": {
+			srcMutator: func(c *ContractInfo) { c.ExecutePermission = &AccessConfig{} },
+			expError:   true,
+		},
+		"accepted denoms": {
+			srcMutator: func(c *ContractInfo) { c.AcceptedDenoms = []string{"stake", "ibc/ABCD"} },
+		},
+		"accepted denoms invalid": {
+			srcMutator: func(c *ContractInfo) { c.AcceptedDenoms = []string{"1invalid"} },
+			expError:   true,
+		},
+		"accepted denoms duplicate": {
+			srcMutator: func(c *ContractInfo) { c.AcceptedDenoms = []string{"stake", "stake"} },
+			expError:   true,
+		},
 		"invalid extension": {
 			srcMutator: func(c *ContractInfo) {
 				// any protobuf type with ValidateBasic method
@@ -109,6 +144,20 @@ func TestCodeInfoValidateBasic(t *testing.T) {
 			srcMutator: func(c *CodeInfo) { c.InstantiateConfig = AccessConfig{} },
 			expError:   true,
 		},
+		"with source and builder": {
+			srcMutator: func(c *CodeInfo) {
+				c.Source = "https://github.com/CosmWasm/cosmwasm"
+				c.Builder = "cosmwasm/rust-optimizer:0.12.4"
+			},
+		},
+		"source invalid": {
+			srcMutator: func(c *CodeInfo) { c.Source = "not an url" },
+			expError:   true,
+		},
+		"builder exceeds limit": {
+			srcMutator: func(c *CodeInfo) { c.Builder = strings.Repeat("a", MaxCodeBuilderSize+1) },
+			expError:   true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
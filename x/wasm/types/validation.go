@@ -1,14 +1,28 @@
 package types
 
 import (
+	"net/url"
+
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 const (
 	MaxWasmSize = 500 * 1024
 
+	// MaxBatchWasmSize is the max total size of all wasm codes that can be stored with one proposal
+	MaxBatchWasmSize = 10 * MaxWasmSize
+
 	// MaxLabelSize is the longest label that can be used when Instantiating a contract
 	MaxLabelSize = 128
+
+	// MaxExecuteMemoSize is the longest memo that can be attached to a contract execution
+	MaxExecuteMemoSize = 256
+
+	// MaxCodeSourceSize is the longest source URL that can be stored with a code
+	MaxCodeSourceSize = 256
+
+	// MaxCodeBuilderSize is the longest builder that can be stored with a code
+	MaxCodeBuilderSize = 128
 )
 
 func validateWasmCode(s []byte) error {
@@ -30,3 +44,32 @@ func validateLabel(label string) error {
 	}
 	return nil
 }
+
+// ValidateCodeSource accepts an empty source or an absolute http(s) URL within the size limit
+func ValidateCodeSource(source string) error {
+	if source == "" {
+		return nil
+	}
+	if len(source) > MaxCodeSourceSize {
+		return sdkerrors.Wrapf(ErrLimit, "cannot be longer than %d characters", MaxCodeSourceSize)
+	}
+	u, err := url.ParseRequestURI(source)
+	if err != nil {
+		return sdkerrors.Wrap(ErrInvalid, "not a valid url")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return sdkerrors.Wrap(ErrInvalid, "url scheme must be http or https")
+	}
+	if u.Host == "" {
+		return sdkerrors.Wrap(ErrInvalid, "url host is required")
+	}
+	return nil
+}
+
+// ValidateCodeBuilder accepts an empty builder or one within the size limit
+func ValidateCodeBuilder(builder string) error {
+	if len(builder) > MaxCodeBuilderSize {
+		return sdkerrors.Wrapf(ErrLimit, "cannot be longer than %d characters", MaxCodeBuilderSize)
+	}
+	return nil
+}